@@ -1,9 +1,18 @@
 package auction
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
 	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
 	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	auctionTypes "github.com/skip-mev/block-sdk/v2/x/auction/types"
 )
 
 const (
@@ -11,21 +20,168 @@ const (
 	MsgAuctionBid   = "/sdk.auction.v1.MsgAuctionBid"
 )
 
+// BundledTxsMessage is implemented by messages that bundle other transactions, the bundled transactions are linked back to the message
+type BundledTxsMessage interface {
+	GetBundledTxHashes() []string
+}
+
+// BidPayout is a single transfer of the bid from the bidder, to the proposer rewards address or the auction escrow account
+type BidPayout struct {
+	Recipient string
+	Amount    sdk.Coin
+}
+
 // The Skip Block SDK MsgAuctionBid message allows for the creation of a bid to execute a set of Transactions at the top of a block.
 // It is part of the MEV Lane x/auction module (https://docs.skip.money/blocksdk/lanes/existing-lanes/mev).
-// Research is being done on how the application should handle this message in the TX message parser.
+// The bid is extracted from the bidder in the ante handler and split between the proposer and the escrow account.
+// Ante handler events are not part of the message log, the transfers of the bid are read from the tx events outside of the
+// message logs when none are in the message log. Only when neither has a transfer of the bid (the tx events of chains before
+// Cosmos SDK v0.50 cannot be told apart from the message events) the full bid is recorded as sent to the auction module
+// account, the default escrow account.
 type WrapperMsgAuctionBid struct {
 	txModule.Message
+	BlockSDKMsgAuctionBid *auctionTypes.MsgAuctionBid
+	Bidder                string
+	Bid                   sdk.Coin
+	BundledTxHashes       []string
+	Payouts               []BidPayout
+	Refunds               sdk.Coins
+	TxEvents              []txModule.LogMessageEvent
+}
+
+func (sf *WrapperMsgAuctionBid) SetTxEvents(txEvents []txModule.LogMessageEvent) {
+	sf.TxEvents = txEvents
 }
 
 func (sf *WrapperMsgAuctionBid) String() string {
-	return ""
+	var bid string
+	if !sf.Bid.IsNil() {
+		bid = sf.Bid.String()
+	}
+
+	return fmt.Sprintf("MsgAuctionBid: %s bid %s for bundle of %d txs (%s)",
+		sf.Bidder, bid, len(sf.BundledTxHashes), strings.Join(sf.BundledTxHashes, ", "))
 }
 
 func (sf *WrapperMsgAuctionBid) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.BlockSDKMsgAuctionBid = msg.(*auctionTypes.MsgAuctionBid)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.Bidder = sf.BlockSDKMsgAuctionBid.Bidder
+	sf.Bid = sf.BlockSDKMsgAuctionBid.Bid
+
+	// The auction module emits the hashes of the bundled txs, fall back to hashing the bundled tx bytes the same way
+	bidEvent := txModule.GetEventWithType(auctionTypes.EventTypeAuctionBid, log)
+	bundledTxs := txModule.GetLastValueForAttribute(auctionTypes.EventAttrBundledTxs, bidEvent)
+	if bundledTxs != "" {
+		for _, txHash := range strings.Split(bundledTxs, ",") {
+			sf.BundledTxHashes = append(sf.BundledTxHashes, strings.TrimSpace(txHash))
+		}
+	} else {
+		for _, bundledTx := range sf.BlockSDKMsgAuctionBid.Transactions {
+			hash := sha256.Sum256(bundledTx)
+			sf.BundledTxHashes = append(sf.BundledTxHashes, hex.EncodeToString(hash[:]))
+		}
+	}
+
+	// Payouts of the bid denom from the bidder and refunds to the bidder, if the bank events are part of the message log,
+	// otherwise the ante handler transfers of the tx events
+	transferFound, err := sf.addBidTransfers(log)
+	if err != nil {
+		return err
+	}
+	if !transferFound {
+		transferFound, err = sf.addBidTransfers(&txModule.LogMessage{Events: sf.TxEvents})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !transferFound && sf.Bid.IsPositive() {
+		escrowAddress, err := getModuleAddress(sf.Bidder, auctionTypes.ModuleName)
+		if err != nil {
+			return fmt.Errorf("error getting auction module address for MsgAuctionBid: %w", err)
+		}
+		sf.Payouts = []BidPayout{{Recipient: escrowAddress, Amount: sf.Bid}}
+	}
+
 	return nil
 }
 
+// addBidTransfers adds the transfers of the bid denom from and to the bidder in the events as payouts and refunds, the tx fee
+// paid to the fee collector in the ante handler is not part of the bid
+func (sf *WrapperMsgAuctionBid) addBidTransfers(log *txModule.LogMessage) (bool, error) {
+	feeCollectorAddress, err := getModuleAddress(sf.Bidder, authTypes.FeeCollectorName)
+	if err != nil {
+		return false, fmt.Errorf("error getting fee collector address for MsgAuctionBid: %w", err)
+	}
+
+	transferFound := false
+	for _, transfer := range txModule.SplitEventsOnAttribute("transfer", "recipient", log) {
+		coins, err := sdk.ParseCoinsNormalized(transfer[txModule.EventAttributeAmount])
+		if err != nil {
+			return false, fmt.Errorf("error parsing transfer amount for MsgAuctionBid: %w", err)
+		}
+
+		amount := coins.AmountOf(sf.Bid.Denom)
+		if !amount.IsPositive() {
+			continue
+		}
+
+		switch sf.Bidder {
+		case transfer["sender"]:
+			if transfer["recipient"] == "" || transfer["recipient"] == feeCollectorAddress {
+				continue
+			}
+			transferFound = true
+			sf.Payouts = append(sf.Payouts, BidPayout{Recipient: transfer["recipient"], Amount: sdk.NewCoin(sf.Bid.Denom, amount)})
+		case transfer["recipient"]:
+			transferFound = true
+			sf.Refunds = sf.Refunds.Add(sdk.NewCoin(sf.Bid.Denom, amount))
+		}
+	}
+
+	return transferFound, nil
+}
+
+// getModuleAddress returns the address of the module account with the address prefix of the bidder
+func getModuleAddress(bidder string, moduleName string) (string, error) {
+	prefix, _, err := bech32.DecodeAndConvert(bidder)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32.ConvertAndEncode(prefix, authTypes.NewModuleAddress(moduleName))
+}
+
 func (sf *WrapperMsgAuctionBid) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
-	return nil
+	relevantData := make([]parsingTypes.MessageRelevantInformation, 0, len(sf.Payouts)+len(sf.Refunds))
+	for _, payout := range sf.Payouts {
+		relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+			AmountSent:       payout.Amount.Amount.BigInt(),
+			DenominationSent: payout.Amount.Denom,
+			SenderAddress:    sf.Bidder,
+			ReceiverAddress:  payout.Recipient,
+		})
+	}
+
+	for _, refund := range sf.Refunds {
+		relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+			AmountReceived:       refund.Amount.BigInt(),
+			DenominationReceived: refund.Denom,
+			ReceiverAddress:      sf.Bidder,
+		})
+	}
+
+	return relevantData
+}
+
+func (sf *WrapperMsgAuctionBid) GetBundledTxHashes() []string {
+	return sf.BundledTxHashes
 }
@@ -0,0 +1,115 @@
+package auction
+
+import (
+	"testing"
+
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	auctionTypes "github.com/skip-mev/block-sdk/v2/x/auction/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// A bech32 address with the osmo prefix, the bidder must be decodable for the module account fallback
+var bidder = mustBech32("osmo", []byte("auction-test-bidder-"))
+
+func mustBech32(prefix string, bytes []byte) string {
+	address, err := bech32.ConvertAndEncode(prefix, bytes)
+	if err != nil {
+		panic(err)
+	}
+	return address
+}
+
+func auctionBidLog(events ...txModule.LogMessageEvent) *txModule.LogMessage {
+	return &txModule.LogMessage{
+		Events: append([]txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: MsgAuctionBid}}},
+		}, events...),
+	}
+}
+
+func transferEvent(sender, recipient, amount string) txModule.LogMessageEvent {
+	return txModule.LogMessageEvent{Type: "transfer", Attributes: []txModule.Attribute{
+		{Key: "recipient", Value: recipient},
+		{Key: "sender", Value: sender},
+		{Key: "amount", Value: amount},
+	}}
+}
+
+func newMsgAuctionBid() *auctionTypes.MsgAuctionBid {
+	return &auctionTypes.MsgAuctionBid{
+		Bidder:       bidder,
+		Bid:          sdk.NewInt64Coin("uosmo", 1000),
+		Transactions: [][]byte{[]byte("tx1")},
+	}
+}
+
+func TestAuctionBidPayoutsFromTransfers(t *testing.T) {
+	log := auctionBidLog(
+		transferEvent(bidder, "osmo1proposer", "200uosmo"),
+		transferEvent(bidder, "osmo1escrow", "800uosmo"),
+	)
+
+	wrapper := &WrapperMsgAuctionBid{}
+	err := wrapper.HandleMsg(MsgAuctionBid, newMsgAuctionBid(), log)
+	assert.Nil(t, err)
+	assert.Len(t, wrapper.BundledTxHashes, 1)
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 2)
+	assert.Equal(t, "osmo1proposer", relevantData[0].ReceiverAddress)
+	assert.Equal(t, int64(200), relevantData[0].AmountSent.Int64())
+	assert.Equal(t, "osmo1escrow", relevantData[1].ReceiverAddress)
+	assert.Equal(t, int64(800), relevantData[1].AmountSent.Int64())
+}
+
+func TestAuctionBidRefundOnlyDoesNotFallBack(t *testing.T) {
+	log := auctionBidLog(transferEvent("osmo1escrow", bidder, "1000uosmo"))
+
+	wrapper := &WrapperMsgAuctionBid{}
+	err := wrapper.HandleMsg(MsgAuctionBid, newMsgAuctionBid(), log)
+	assert.Nil(t, err)
+	assert.Empty(t, wrapper.Payouts)
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 1)
+	assert.Equal(t, bidder, relevantData[0].ReceiverAddress)
+	assert.Equal(t, int64(1000), relevantData[0].AmountReceived.Int64())
+}
+
+func TestAuctionBidPayoutsFromAnteHandlerEvents(t *testing.T) {
+	feeCollector := mustBech32("osmo", authTypes.NewModuleAddress(authTypes.FeeCollectorName))
+
+	// The ante handler deducts the fee and the bid before the message runs, the transfers are only in the tx events
+	wrapper := &WrapperMsgAuctionBid{}
+	wrapper.SetTxEvents([]txModule.LogMessageEvent{
+		transferEvent(bidder, feeCollector, "50uosmo"),
+		transferEvent(bidder, "osmo1proposer", "200uosmo"),
+		transferEvent(bidder, "osmo1escrow", "800uosmo"),
+	})
+	err := wrapper.HandleMsg(MsgAuctionBid, newMsgAuctionBid(), auctionBidLog())
+	assert.Nil(t, err)
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 2, "the fee is not part of the bid")
+	assert.Equal(t, "osmo1proposer", relevantData[0].ReceiverAddress)
+	assert.Equal(t, int64(200), relevantData[0].AmountSent.Int64())
+	assert.Equal(t, "osmo1escrow", relevantData[1].ReceiverAddress)
+	assert.Equal(t, int64(800), relevantData[1].AmountSent.Int64())
+}
+
+func TestAuctionBidFallbackToModuleAccount(t *testing.T) {
+	wrapper := &WrapperMsgAuctionBid{}
+	err := wrapper.HandleMsg(MsgAuctionBid, newMsgAuctionBid(), auctionBidLog())
+	assert.Nil(t, err)
+
+	moduleAddress := mustBech32("osmo", authTypes.NewModuleAddress(auctionTypes.ModuleName))
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 1)
+	assert.Equal(t, bidder, relevantData[0].SenderAddress)
+	assert.Equal(t, moduleAddress, relevantData[0].ReceiverAddress)
+	assert.Equal(t, int64(1000), relevantData[0].AmountSent.Int64())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv"
 	csvParsers "github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/gains"
 	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
 	"github.com/gin-gonic/gin"
 	swaggerfiles "github.com/swaggo/files"
@@ -151,6 +153,12 @@ func main() {
 	}
 
 	r.POST("/events.csv", GetTaxableEventsCSV)
+	r.POST("/form8949.csv", GetForm8949CSV)
+	r.POST("/schedule_d.csv", GetScheduleDCSV)
+	r.POST("/income_summary.csv", GetIncomeSummaryCSV)
+	r.POST("/cl_positions.json", GetCLPositionsJSON)
+	r.POST("/cl_positions.csv", GetCLPositionsCSV)
+	r.POST("/nft_transfers.json", GetNFTTransfersJSON)
 	err = r.Run(fmt.Sprintf(":%v", svcPort))
 	if err != nil {
 		config.Log.Fatal("Error starting server.", err)
@@ -235,6 +243,7 @@ type TaxableEventsCSVRequest struct {
 	StartDate *string `json:"startDate"` // can be null
 	EndDate   *string `json:"endDate"`   // can be null
 	Format    string  `json:"format"`
+	Aggregate string  `json:"aggregate"` // can be empty
 }
 
 var jsTimeFmt = "2006-01-02T15:04:05Z07:00"
@@ -244,7 +253,7 @@ var jsTimeFmt = "2006-01-02T15:04:05Z07:00"
 // @Param data body TaxableEventsCSVRequest true "The options for the POST body"
 // @Router /events.csv [post]
 func GetTaxableEventsCSV(c *gin.Context) {
-	addresses, format, startDate, endDate, err := ParseTaxableEventsBody(c)
+	addresses, format, aggregation, startDate, endDate, err := ParseTaxableEventsBody(c)
 	if err != nil {
 		return
 	}
@@ -271,7 +280,7 @@ func GetTaxableEventsCSV(c *gin.Context) {
 		return
 	}
 
-	accountRows, headers, addressRowsCount, err := csv.ParseForAddress(validAddresses, startDate, endDate, DB, format)
+	accountRows, headers, addressRowsCount, err := csv.ParseForAddressAggregated(validAddresses, startDate, endDate, DB, format, aggregation)
 	if err != nil {
 		// the error returned here has already been pushed to the context... I think.
 		config.Log.Errorf("Error getting valid addresses %v: %s", addresses, err)
@@ -299,6 +308,12 @@ func GetTaxableEventsCSV(c *gin.Context) {
 		return
 	}
 
+	if csv.IsTextFormat(format) {
+		buffer := csv.ToText(accountRows, headers)
+		c.Data(200, "text/plain", buffer.Bytes())
+		return
+	}
+
 	buffer, err := csv.ToCsv(accountRows, headers)
 	if err != nil {
 		config.Log.Error("Error generating CSV", err)
@@ -314,7 +329,7 @@ func GetTaxableEventsCSV(c *gin.Context) {
 // @Param data body TaxableEventsCSVRequest true "The options for the POST body"
 // @Router /events.json [post]
 func GetTaxableEventsJSON(c *gin.Context) {
-	addresses, format, startDate, endDate, err := ParseTaxableEventsBody(c)
+	addresses, format, aggregation, startDate, endDate, err := ParseTaxableEventsBody(c)
 	if err != nil {
 		return
 	}
@@ -341,7 +356,7 @@ func GetTaxableEventsJSON(c *gin.Context) {
 		return
 	}
 
-	accountRows, _, addressRowsCount, err := csv.ParseForAddress(validAddresses, startDate, endDate, DB, format)
+	accountRows, _, addressRowsCount, err := csv.ParseForAddressAggregated(validAddresses, startDate, endDate, DB, format, aggregation)
 	if err != nil {
 		// the error returned here has already been pushed to the context... I think.
 		config.Log.Errorf("Error getting rows for addresses: %v", validAddresses)
@@ -371,13 +386,308 @@ func GetTaxableEventsJSON(c *gin.Context) {
 	c.JSON(200, accountRows)
 }
 
-func ParseTaxableEventsBody(c *gin.Context) ([]string, string, *time.Time, *time.Time, error) {
+// @Accept json
+// @Produce text/csv
+// @Param data body TaxableEventsCSVRequest true "The options for the POST body, the format is the cost basis method of the gains formats"
+// @Router /form8949.csv [post]
+func GetForm8949CSV(c *gin.Context) {
+	getTaxReportCSV(c, csv.ReportForm8949)
+}
+
+// @Accept json
+// @Produce text/csv
+// @Param data body TaxableEventsCSVRequest true "The options for the POST body, the format is the cost basis method of the gains formats"
+// @Router /schedule_d.csv [post]
+func GetScheduleDCSV(c *gin.Context) {
+	getTaxReportCSV(c, csv.ReportScheduleD)
+}
+
+func getTaxReportCSV(c *gin.Context, report string) {
+	addresses, method, _, startDate, endDate, err := ParseTaxableEventsBody(c)
+	if err != nil {
+		return
+	}
+
+	if !slices.Contains(gains.ParserKeys, method) {
+		c.JSON(422, gin.H{"message": fmt.Sprintf("Unsupported format %s, supported values are %s", method, gains.ParserKeys)})
+		return
+	}
+
+	// We only want to process and return data on addresses we know are valid (in our DB)
+	validAddresses, validAddressDBObjects, err := GetValidAddresses(addresses)
+	if err != nil {
+		config.Log.Errorf("Error getting valid addresses: %v", addresses)
+		c.AbortWithError(500, errors.New("error getting rows for address")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	lines, err := csv.Form8949ForAddresses(validAddresses, startDate, endDate, DB, method)
+	if err != nil {
+		config.Log.Errorf("Error computing the realized gains of addresses %v: %s", validAddresses, err)
+		c.AbortWithError(500, errors.New("error getting rows for address")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	addressLinesCount := make(map[string]uint)
+	for _, line := range lines {
+		addressLinesCount[line.Address]++
+	}
+
+	for _, address := range validAddressDBObjects {
+		usage := AddressUsageCSV{
+			Address:       address,
+			AddressID:     address.ID,
+			RowsRetrieved: addressLinesCount[address.Address],
+			Timestamp:     time.Now(),
+		}
+
+		err = DB.Create(&usage).Error
+		if err != nil {
+			config.Log.Errorf("Error saving address usage: %v", err)
+		}
+	}
+
+	if len(lines) == 0 {
+		c.JSON(404, gin.H{"message": "No disposals for given address"})
+		return
+	}
+
+	rows, headers := csv.Form8949ToCsvRows(lines)
+	if report == csv.ReportScheduleD {
+		rows, headers = csv.ScheduleDToCsvRows(csv.ScheduleDTotals(lines))
+	}
+
+	buffer, err := csv.ToCsv(rows, headers)
+	if err != nil {
+		config.Log.Error("Error generating CSV", err)
+		c.AbortWithError(500, errors.New("error getting rows for address")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	c.Data(200, "text/csv", buffer.Bytes())
+}
+
+type IncomeSummaryRequest struct {
+	Addresses string  `json:"addresses"`
+	StartDate *string `json:"startDate"` // can be null
+	EndDate   *string `json:"endDate"`   // can be null
+	Fiat      string  `json:"fiat"`      // can be empty
+}
+
+// @Accept json
+// @Produce text/csv
+// @Param data body IncomeSummaryRequest true "The options for the POST body"
+// @Router /income_summary.csv [post]
+func GetIncomeSummaryCSV(c *gin.Context) {
+	var requestBody IncomeSummaryRequest
+	err := c.BindJSON(&requestBody)
+	if err != nil {
+		c.AbortWithError(500, errors.New("error processing request body")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	// We expect ISO 8601 dates in UTC
+	var startDate *time.Time
+	if requestBody.StartDate != nil {
+		startTime, err := time.Parse(jsTimeFmt, *requestBody.StartDate)
+		if err != nil {
+			c.AbortWithError(500, fmt.Errorf("invalid start time. Err %v", err)) // nolint:errcheck
+			return
+		}
+		startDate = &startTime
+	}
+
+	var endDate *time.Time
+	if requestBody.EndDate != nil {
+		endTime, err := time.Parse(jsTimeFmt, *requestBody.EndDate)
+		if err != nil {
+			c.AbortWithError(500, fmt.Errorf("invalid end time. Err %v", err)) // nolint:errcheck
+			return
+		}
+		endDate = &endTime
+	}
+
+	if requestBody.Addresses == "" {
+		c.JSON(422, gin.H{"message": "Address is required"})
+		return
+	}
+
+	addresses := strings.Split(strings.ReplaceAll(requestBody.Addresses, " ", ""), ",")
+
+	// We only want to process and return data on addresses we know are valid (in our DB)
+	validAddresses, _, err := GetValidAddresses(addresses)
+	if err != nil {
+		config.Log.Errorf("Error getting valid addresses %v: %s", addresses, err)
+		c.AbortWithError(500, errors.New("error getting rows for address")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	summary, err := csv.IncomeSummaryForAddresses(validAddresses, startDate, endDate, DB, requestBody.Fiat)
+	if err != nil {
+		config.Log.Errorf("Error summarizing the income of addresses %v: %s", validAddresses, err)
+		c.AbortWithError(500, errors.New("error getting rows for address")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	if len(summary) == 0 {
+		c.JSON(404, gin.H{"message": "No income for given address"})
+		return
+	}
+
+	rows, headers := csv.IncomeSummaryToCsvRows(summary)
+	buffer, err := csv.ToCsv(rows, headers)
+	if err != nil {
+		config.Log.Error("Error generating CSV", err)
+		c.AbortWithError(500, errors.New("error getting rows for address")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	c.Data(200, "text/csv", buffer.Bytes())
+}
+
+type CLPositionsRequest struct {
+	Addresses string `json:"addresses"`
+}
+
+// @Accept json
+// @Produce json
+// @Param data body CLPositionsRequest true "The options for the POST body"
+// @Router /cl_positions.json [post]
+func GetCLPositionsJSON(c *gin.Context) {
+	positions, ok := getCLPositions(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(200, positions)
+}
+
+// @Accept json
+// @Produce text/csv
+// @Param data body CLPositionsRequest true "The options for the POST body"
+// @Router /cl_positions.csv [post]
+func GetCLPositionsCSV(c *gin.Context) {
+	positions, ok := getCLPositions(c)
+	if !ok {
+		return
+	}
+
+	rows, headers := csv.CLPositionsToCsvRows(csv.UniqueCLPositions(positions))
+	buffer, err := csv.ToCsv(rows, headers)
+	if err != nil {
+		config.Log.Error("Error generating CSV", err)
+		c.AbortWithError(500, errors.New("error getting positions for address")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	c.Data(200, "text/csv", buffer.Bytes())
+}
+
+// getCLPositions returns the position summaries of the addresses of the request body, it writes the error response and
+// returns false when there are none
+func getCLPositions(c *gin.Context) (map[string][]dbTypes.CLPositionSummary, bool) {
+	var requestBody CLPositionsRequest
+	err := c.BindJSON(&requestBody)
+	if err != nil {
+		c.AbortWithError(500, errors.New("error processing request body")) // nolint:staticcheck,errcheck
+		return nil, false
+	}
+
+	if requestBody.Addresses == "" {
+		c.JSON(422, gin.H{"message": "Address is required"})
+		return nil, false
+	}
+
+	addresses := strings.Split(strings.ReplaceAll(requestBody.Addresses, " ", ""), ",")
+
+	// We only want to process and return data on addresses we know are valid (in our DB)
+	validAddresses, _, err := GetValidAddresses(addresses)
+	if err != nil {
+		config.Log.Errorf("Error getting valid addresses %v: %s", addresses, err)
+		c.AbortWithError(500, errors.New("error getting positions for address")) // nolint:staticcheck,errcheck
+		return nil, false
+	}
+
+	positions := make(map[string][]dbTypes.CLPositionSummary)
+	found := false
+	for _, address := range validAddresses {
+		summaries, err := dbTypes.GetCLPositionSummaries(address, DB)
+		if err != nil {
+			config.Log.Errorf("Error getting CL positions for address %v: %s", address, err)
+			c.AbortWithError(500, errors.New("error getting positions for address")) // nolint:staticcheck,errcheck
+			return nil, false
+		}
+		positions[address] = summaries
+		found = found || len(summaries) > 0
+	}
+
+	if !found {
+		c.JSON(404, gin.H{"message": "No concentrated liquidity positions for given address"})
+		return nil, false
+	}
+
+	return positions, true
+}
+
+type NFTTransfersRequest struct {
+	Addresses string `json:"addresses"`
+}
+
+// @Accept json
+// @Produce json
+// @Param data body NFTTransfersRequest true "The options for the POST body"
+// @Router /nft_transfers.json [post]
+func GetNFTTransfersJSON(c *gin.Context) {
+	var requestBody NFTTransfersRequest
+	err := c.BindJSON(&requestBody)
+	if err != nil {
+		c.AbortWithError(500, errors.New("error processing request body")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	if requestBody.Addresses == "" {
+		c.JSON(422, gin.H{"message": "Address is required"})
+		return
+	}
+
+	addresses := strings.Split(strings.ReplaceAll(requestBody.Addresses, " ", ""), ",")
+
+	// We only want to process and return data on addresses we know are valid (in our DB)
+	validAddresses, _, err := GetValidAddresses(addresses)
+	if err != nil {
+		config.Log.Errorf("Error getting valid addresses %v: %s", addresses, err)
+		c.AbortWithError(500, errors.New("error getting NFT transfers for address")) // nolint:staticcheck,errcheck
+		return
+	}
+
+	transfers := make(map[string][]dbTypes.NFTTransferSummary)
+	found := false
+	for _, address := range validAddresses {
+		summaries, err := dbTypes.GetNFTTransferSummaries(address, DB)
+		if err != nil {
+			config.Log.Errorf("Error getting NFT transfers for address %v: %s", address, err)
+			c.AbortWithError(500, errors.New("error getting NFT transfers for address")) // nolint:staticcheck,errcheck
+			return
+		}
+		transfers[address] = summaries
+		found = found || len(summaries) > 0
+	}
+
+	if !found {
+		c.JSON(404, gin.H{"message": "No NFT transfers for given address"})
+		return
+	}
+
+	c.JSON(200, transfers)
+}
+
+func ParseTaxableEventsBody(c *gin.Context) ([]string, string, string, *time.Time, *time.Time, error) {
 	var requestBody TaxableEventsCSVRequest
 	err := c.BindJSON(&requestBody)
 	if err != nil {
 		// the error returned here has already been pushed to the context... I think.
 		c.AbortWithError(500, errors.New("error processing request body")) // nolint:staticcheck,errcheck
-		return nil, "", nil, nil, err
+		return nil, "", "", nil, nil, err
 	}
 
 	// We expect ISO 8601 dates in UTC
@@ -386,7 +696,7 @@ func ParseTaxableEventsBody(c *gin.Context) ([]string, string, *time.Time, *time
 		startTime, err := time.Parse(jsTimeFmt, *requestBody.StartDate)
 		if err != nil {
 			c.AbortWithError(500, fmt.Errorf("invalid start time. Err %v", err)) // nolint:errcheck
-			return nil, "", nil, nil, err
+			return nil, "", "", nil, nil, err
 		}
 		startDate = &startTime
 	}
@@ -396,7 +706,7 @@ func ParseTaxableEventsBody(c *gin.Context) ([]string, string, *time.Time, *time
 		endTime, err := time.Parse(jsTimeFmt, *requestBody.EndDate)
 		if err != nil {
 			c.AbortWithError(500, fmt.Errorf("invalid end time. Err %v", err)) // nolint:errcheck
-			return nil, "", nil, nil, err
+			return nil, "", "", nil, nil, err
 		}
 		endDate = &endTime
 	}
@@ -404,7 +714,7 @@ func ParseTaxableEventsBody(c *gin.Context) ([]string, string, *time.Time, *time
 
 	if requestBody.Addresses == "" {
 		c.JSON(422, gin.H{"message": "Address is required"})
-		return nil, "", nil, nil, err
+		return nil, "", "", nil, nil, err
 	}
 
 	// parse addresses
@@ -418,13 +728,26 @@ func ParseTaxableEventsBody(c *gin.Context) ([]string, string, *time.Time, *time
 
 	if format == "" {
 		c.JSON(422, gin.H{"message": "Format is required"})
-		return nil, "", nil, nil, errors.New("format is required")
+		return nil, "", "", nil, nil, errors.New("format is required")
 	}
 
-	return addresses, format, startDate, endDate, nil
+	aggregation := requestBody.Aggregate
+	if aggregation != csv.AggregationNone && !slices.Contains(csv.Aggregations, aggregation) {
+		c.JSON(422, gin.H{"message": fmt.Sprintf("Unsupported aggregation %s, supported values are %s", aggregation, csv.Aggregations)})
+		return nil, "", "", nil, nil, errors.New("unsupported aggregation")
+	}
+
+	return addresses, format, aggregation, startDate, endDate, nil
 }
 
 func GetValidAddresses(addresses []string) ([]string, []dbTypes.Address, error) {
+	// 0x addresses are looked up by the Bech32 addresses of the same account
+	addresses, err := dbTypes.ResolveEVMAddresses(addresses, DB)
+	if err != nil {
+		config.Log.Errorf("Error resolving EVM addresses: %v", addresses)
+		return nil, nil, err
+	}
+
 	validAddresses, err := dbTypes.GetAddresses(addresses, DB)
 	if err != nil {
 		config.Log.Errorf("Error getting addresses: %v", addresses)
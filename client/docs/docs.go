@@ -19,6 +19,50 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/cl_positions.csv": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv"
+                ],
+                "parameters": [
+                    {
+                        "description": "The options for the POST body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CLPositionsRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/cl_positions.json": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "description": "The options for the POST body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CLPositionsRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
         "/events.csv": {
             "post": {
                 "consumes": [
@@ -63,19 +107,147 @@ const docTemplate = `{
                 "responses": {}
             }
         },
+        "/form8949.csv": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv"
+                ],
+                "parameters": [
+                    {
+                        "description": "The options for the POST body, the format is the cost basis method of the gains formats",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TaxableEventsCSVRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
         "/gcphealth": {
             "get": {
                 "responses": {}
             }
+        },
+        "/income_summary.csv": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv"
+                ],
+                "parameters": [
+                    {
+                        "description": "The options for the POST body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.IncomeSummaryRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/nft_transfers.json": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "description": "The options for the POST body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.NFTTransfersRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/schedule_d.csv": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv"
+                ],
+                "parameters": [
+                    {
+                        "description": "The options for the POST body, the format is the cost basis method of the gains formats",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TaxableEventsCSVRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
         }
     },
     "definitions": {
+        "main.CLPositionsRequest": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.IncomeSummaryRequest": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "description": "can be null",
+                    "type": "string"
+                },
+                "fiat": {
+                    "description": "can be empty",
+                    "type": "string"
+                },
+                "startDate": {
+                    "description": "can be null",
+                    "type": "string"
+                }
+            }
+        },
+        "main.NFTTransfersRequest": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "string"
+                }
+            }
+        },
         "main.TaxableEventsCSVRequest": {
             "type": "object",
             "properties": {
                 "addresses": {
                     "type": "string"
                 },
+                "aggregate": {
+                    "description": "can be empty",
+                    "type": "string"
+                },
                 "chain": {
                     "type": "string"
                 },
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	clPositionsConfig       config.CLPositionsConfig
+	clPositionsDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&clPositionsConfig.Log, clPositionsCmd)
+	config.SetupDatabaseFlags(&clPositionsConfig.Database, clPositionsCmd)
+	config.SetupCLPositionsSpecificFlags(&clPositionsConfig, clPositionsCmd)
+	rootCmd.AddCommand(clPositionsCmd)
+}
+
+var clPositionsCmd = &cobra.Command{
+	Use:   "cl-positions",
+	Short: "Summarizes the Osmosis concentrated liquidity positions of the addresses.",
+	Long: `Reports every concentrated liquidity position the addresses have owned or interacted with, with the pool, the
+	tick range, the current owner and the deposits, withdrawals, collected spread rewards, incentives and net result of the
+	position per denom.`,
+	PreRunE: setupCLPositions,
+	Run:     clPositions,
+}
+
+func setupCLPositions(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+
+	err := clPositionsConfig.Validate()
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousCLPositionsKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(clPositionsConfig.Log.Level, clPositionsConfig.Log.Path, clPositionsConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(clPositionsConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	clPositionsDbConnection = db
+
+	dbTypes.CacheDenoms(db)
+	dbTypes.CacheIBCDenoms(db)
+
+	return nil
+}
+
+func clPositions(cmd *cobra.Command, args []string) {
+	cfg := clPositionsConfig
+	db := clPositionsDbConnection
+
+	// 0x addresses are queried by the Bech32 addresses of the same account
+	addresses, err := dbTypes.ResolveEVMAddresses(cfg.Base.Addresses, db)
+	if err != nil {
+		config.Log.Fatal("Error resolving EVM addresses", err)
+	}
+
+	positions := make(map[string][]dbTypes.CLPositionSummary)
+	for _, address := range addresses {
+		summaries, err := dbTypes.GetCLPositionSummaries(address, db)
+		if err != nil {
+			config.Log.Fatal("Error getting the CL positions", err)
+		}
+		positions[address] = summaries
+	}
+
+	switch cfg.Base.Format {
+	case "json":
+		output, err := json.MarshalIndent(positions, "", "  ")
+		if err != nil {
+			config.Log.Fatal("Error generating JSON", err)
+		}
+		fmt.Println(string(output))
+	default:
+		csvRows, headers := csv.CLPositionsToCsvRows(csv.UniqueCLPositions(positions))
+		buffer, err := csv.ToCsv(csvRows, headers)
+		if err != nil {
+			config.Log.Fatal("Error generating CSV", err)
+		}
+		fmt.Println(buffer.String())
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/prices"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	derivePricesConfig       config.DerivePricesConfig
+	derivePricesDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&derivePricesConfig.Log, derivePricesCmd)
+	config.SetupDatabaseFlags(&derivePricesConfig.Database, derivePricesCmd)
+	config.SetupDerivePricesSpecificFlags(&derivePricesConfig, derivePricesCmd)
+	rootCmd.AddCommand(derivePricesCmd)
+}
+
+var derivePricesCmd = &cobra.Command{
+	Use:   "derive-prices",
+	Short: "Derives prices from the indexed Osmosis swaps for tokens without market data.",
+	Long: `Computes volume-weighted prices per denom pair and time bucket from the indexed Osmosis swaps and stores them in the
+	prices table with the osmosis-swaps source. The reference denoms are pegged to the fiat currency, the liquid denoms are
+	priced through their pairs with the reference denoms and each other, and every other denom through its highest volume pair
+	with a priced denom. Each price records the denoms it was chained through, the fiat volume and the number of swaps it was
+	derived from. Imported prices are preferred over derived prices when the exports are valued.`,
+	PreRunE: setupDerivePrices,
+	Run:     derivePrices,
+}
+
+func setupDerivePrices(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+
+	err := derivePricesConfig.Validate()
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousDerivePricesKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(derivePricesConfig.Log.Level, derivePricesConfig.Log.Path, derivePricesConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(derivePricesConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	derivePricesDbConnection = db
+
+	dbTypes.CacheDenoms(db)
+	dbTypes.CacheIBCDenoms(db)
+
+	return nil
+}
+
+func derivePrices(cmd *cobra.Command, args []string) {
+	cfg := derivePricesConfig
+	db := derivePricesDbConnection
+
+	var chain dbTypes.Chain
+	result := db.Where("chain_id = ?", cfg.Base.ChainID).Limit(1).Find(&chain)
+	if result.Error != nil {
+		config.Log.Fatalf("Error getting Chain model. Err: %v", result.Error)
+	}
+
+	if chain.ID == 0 {
+		config.Log.Fatalf("Chain %s is not indexed", cfg.Base.ChainID)
+	}
+
+	var denoms []dbTypes.Denom
+	result = db.Find(&denoms)
+	if result.Error != nil {
+		config.Log.Fatalf("Error getting the denoms. Err: %v", result.Error)
+	}
+
+	denomsByID := make(map[uint]dbTypes.Denom)
+	names := make(map[uint]string)
+	for _, denom := range denoms {
+		denomsByID[denom.ID] = denom
+		names[denom.ID] = denom.Symbol
+		if denom.Symbol == "" || denom.Symbol == "UNKNOWN" {
+			names[denom.ID] = denom.Base
+		}
+	}
+
+	references := getDenomIDs(db, cfg.Base.References)
+	liquid := getDenomIDs(db, cfg.Base.Liquid)
+	deriver := prices.NewSwapDeriver(cfg.Base.Bucket, cfg.Base.FiatCurrency, references, liquid, names)
+
+	lowestBlock, err := dbTypes.GetLowestIndexedBlock(db, chain.ID)
+	if err != nil {
+		config.Log.Fatalf("Error getting the lowest indexed block. Err: %v", err)
+	}
+
+	highestBlock := dbTypes.GetHighestIndexedBlock(db, chain.ID)
+	if highestBlock.ID == 0 {
+		config.Log.Fatalf("No block of chain %s indexed", chain.ChainID)
+	}
+
+	start := lowestBlock.TimeStamp
+	end := highestBlock.TimeStamp.Add(time.Nanosecond)
+	if cfg.Base.StartDate != "" {
+		start, _ = time.Parse("2006-01-02:15:04:05", cfg.Base.StartDate)
+	}
+	if cfg.Base.EndDate != "" {
+		end, _ = time.Parse("2006-01-02:15:04:05", cfg.Base.EndDate)
+	}
+
+	// The swaps are loaded a day at a time, the days start on a bucket boundary so no bucket is split
+	const window = 24 * time.Hour
+	total := 0
+	for windowStart := start.Truncate(cfg.Base.Bucket); windowStart.Before(end); windowStart = windowStart.Add(window) {
+		windowEnd := windowStart.Add(window)
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+		queryStart := windowStart
+		if queryStart.Before(start) {
+			queryStart = start
+		}
+
+		legs, err := dbTypes.GetOsmosisSwapLegs(db, chain.ID, queryStart, windowEnd)
+		if err != nil {
+			config.Log.Fatalf("Error getting the swaps from %s to %s. Err: %v", queryStart, windowEnd, err)
+		}
+
+		swaps := make([]prices.Swap, 0, len(legs))
+		for _, leg := range legs {
+			swap, ok := prices.NewSwap(leg, denomsByID)
+			if !ok {
+				config.Log.Debugf("Skipping swap of denoms %d and %d at %s, their units are unknown", leg.DenominationSentID, leg.DenominationReceivedID, leg.Time)
+				continue
+			}
+			swaps = append(swaps, swap)
+		}
+
+		derived := deriver.Derive(swaps)
+		err = dbTypes.UpsertPrices(db, derived)
+		if err != nil {
+			config.Log.Fatalf("Error storing the prices. Err: %v", err)
+		}
+
+		config.Log.Infof("Derived %d prices from %d swaps between %s and %s", len(derived), len(swaps), queryStart, windowEnd)
+		total += len(derived)
+	}
+
+	config.Log.Infof("Derived %d prices from the swaps of chain %s", total, chain.ChainID)
+}
+
+// getDenomIDs returns the IDs of the denoms of the currencies, the currencies are base denoms or symbols
+func getDenomIDs(db *gorm.DB, currencies []string) []uint {
+	var denomIDs []uint
+	for _, currency := range currencies {
+		denom, err := dbTypes.GetDenomForCurrency(db, currency)
+		if err != nil {
+			config.Log.Fatalf("Error getting the denom of currency %s. Err: %v", currency, err)
+		}
+		denomIDs = append(denomIDs, denom.ID)
+	}
+	return denomIDs
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	incomeSummaryConfig       config.IncomeSummaryConfig
+	incomeSummaryDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&incomeSummaryConfig.Log, incomeSummaryCmd)
+	config.SetupDatabaseFlags(&incomeSummaryConfig.Database, incomeSummaryCmd)
+	config.SetupIncomeSummarySpecificFlags(&incomeSummaryConfig, incomeSummaryCmd)
+	rootCmd.AddCommand(incomeSummaryCmd)
+}
+
+var incomeSummaryCmd = &cobra.Command{
+	Use:   "income-summary",
+	Short: "Summarizes the income of the addresses per month, denom and source.",
+	Long: `Aggregates the staking rewards (including the rewards withdrawn automatically by staking messages), validator
+	commission, Osmosis LP incentives, protorev developer rewards and airdrops received by the addresses between the dates
+	per month, denom and source type. When --fiat is set the income is valued at receipt and totaled per month, e.g. for
+	estimated tax payments.`,
+	PreRunE: setupIncomeSummary,
+	Run:     incomeSummary,
+}
+
+func setupIncomeSummary(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+
+	err := incomeSummaryConfig.Validate()
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousIncomeSummaryKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(incomeSummaryConfig.Log.Level, incomeSummaryConfig.Log.Path, incomeSummaryConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(incomeSummaryConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	incomeSummaryDbConnection = db
+
+	dbTypes.CacheDenoms(db)
+	dbTypes.CacheIBCDenoms(db)
+
+	return nil
+}
+
+func incomeSummary(cmd *cobra.Command, args []string) {
+	cfg := incomeSummaryConfig
+	db := incomeSummaryDbConnection
+
+	var startDate *time.Time
+	var endDate *time.Time
+	expectedLayout := "2006-01-02:15:04:05"
+	if cfg.Base.StartDate != "" {
+		parsedDate, _ := time.Parse(expectedLayout, cfg.Base.StartDate)
+		startDate = &parsedDate
+	}
+	if cfg.Base.EndDate != "" {
+		parsedDate, _ := time.Parse(expectedLayout, cfg.Base.EndDate)
+		endDate = &parsedDate
+	}
+
+	// 0x addresses are queried by the Bech32 addresses of the same account
+	addresses, err := dbTypes.ResolveEVMAddresses(cfg.Base.Addresses, db)
+	if err != nil {
+		config.Log.Fatal("Error resolving EVM addresses", err)
+	}
+
+	summary, err := csv.IncomeSummaryForAddresses(addresses, startDate, endDate, db, cfg.Base.Fiat)
+	if err != nil {
+		config.Log.Fatal("Error summarizing the income", err)
+	}
+
+	switch cfg.Base.Format {
+	case "json":
+		output, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			config.Log.Fatal("Error generating JSON", err)
+		}
+		fmt.Println(string(output))
+	default:
+		csvRows, headers := csv.IncomeSummaryToCsvRows(summary)
+		buffer, err := csv.ToCsv(csvRows, headers)
+		if err != nil {
+			config.Log.Fatal("Error generating CSV", err)
+		}
+		fmt.Println(buffer.String())
+	}
+}
@@ -420,6 +420,7 @@ type epochEventsDBData struct {
 	blockHeight         int64
 	epochIdentifier     string
 	epochNumber         uint
+	redemptionRates     []dbTypes.RedemptionRate
 }
 
 func (idxr *Indexer) indexBlockEvents(wg *sync.WaitGroup, failedBlockHandler core.FailedBlockHandler, blockEventsDataChan chan *blockEventsDBData) {
@@ -479,6 +480,10 @@ func (idxr *Indexer) indexBlockEvents(wg *sync.WaitGroup, failedBlockHandler cor
 			}
 		case len(blockRelevantEvents) != 0:
 			result, err := rpc.GetBlock(idxr.cl, bresults.Height)
+			if err == nil {
+				// The slash events do not have the slash fraction, it is queried from the chain
+				blockRelevantEvents, err = core.ResolveSlashes(idxr.cl, bresults.Height, blockRelevantEvents)
+			}
 			if err != nil {
 				failedBlockHandler(currentHeight, core.FailedBlockEventHandling, err)
 
@@ -585,6 +590,11 @@ func (idxr *Indexer) indexEpochEvents(wg *sync.WaitGroup, failedBlockHandler cor
 
 		blockRelevantEvents, err := core.ProcessRPCEpochEvents(bresults, epochIdentifier)
 
+		var redemptionRates []dbTypes.RedemptionRate
+		if err == nil {
+			redemptionRates, err = core.ProcessRPCEpochRedemptionRates(idxr.cl, bresults.Height, epochIdentifier)
+		}
+
 		if err != nil {
 			failedBlockHandler(int64(epoch.StartHeight), core.FailedBlockEventHandling, err)
 			err := dbTypes.UpsertFailedEventBlock(idxr.db, int64(epoch.StartHeight), idxr.cfg.Lens.ChainID, idxr.cfg.Lens.ChainName)
@@ -611,6 +621,7 @@ func (idxr *Indexer) indexEpochEvents(wg *sync.WaitGroup, failedBlockHandler cor
 					blockRelevantEvents: blockRelevantEvents,
 					epochIdentifier:     epochIdentifier,
 					epochNumber:         epoch.EpochNumber,
+					redemptionRates:     redemptionRates,
 				}
 			}
 		}
@@ -732,6 +743,11 @@ func (idxr *Indexer) doDBUpdates(wg *sync.WaitGroup, txDataChan chan *dbData, bl
 				}
 			}
 
+			err = dbTypes.IndexRedemptionRates(idxr.db, idxr.dryRun, epochEventData.blockHeight, epochEventData.blockTime, epochEventData.epochNumber, epochEventData.redemptionRates, idxr.cfg.Lens.ChainID, idxr.cfg.Lens.ChainName)
+			if err != nil {
+				config.Log.Fatal(fmt.Sprintf("Error indexing redemption rates for %s.", identifierLoggingString), err)
+			}
+
 			err = dbTypes.UpdateEpochIndexingStatus(idxr.db, idxr.dryRun, epochEventData.epochNumber, epochEventData.epochIdentifier, idxr.cfg.Lens.ChainID, idxr.cfg.Lens.ChainName)
 			if err != nil {
 				config.Log.Fatal(fmt.Sprintf("Error indexing block events for %s. Could not mark Epoch indexed.", identifierLoggingString), err)
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
@@ -50,15 +51,36 @@ var queryCmd = &cobra.Command{
 			endDate = &parsedDate
 		}
 
-		csvRows, headers, _, err := csv.ParseForAddress(queryConfig.Base.Addresses, startDate, endDate, db, queryConfig.Base.Format)
+		// 0x addresses are queried by the Bech32 addresses of the same account
+		addresses, err := dbTypes.ResolveEVMAddresses(queryConfig.Base.Addresses, db)
+		if err != nil {
+			config.Log.Fatal("Error resolving EVM addresses", err)
+		}
+
+		csvRows, headers, _, err := csv.ParseForAddressAggregated(addresses, startDate, endDate, db, queryConfig.Base.Format, queryConfig.Base.Aggregate)
 		if err != nil {
 			log.Println(queryConfig.Base.Addresses)
 			config.Log.Fatal("Error calling parser for address", err)
 		}
 
-		buffer, err := csv.ToCsv(csvRows, headers)
+		// The plain text formats are only written as text
+		if csv.IsTextFormat(queryConfig.Base.Format) {
+			buffer := csv.ToText(csvRows, headers)
+			fmt.Print(buffer.String())
+			return
+		}
+
+		buffer, err := csv.Encode(csvRows, headers, queryConfig.Base.Format, queryConfig.Base.Encoding)
 		if err != nil {
-			config.Log.Fatal("Error generating CSV", err)
+			config.Log.Fatalf("Error generating %s. Err: %v", queryConfig.Base.Encoding, err)
+		}
+
+		// Parquet is binary, it is written as is
+		if queryConfig.Base.Encoding == csv.EncodingParquet {
+			if _, err := os.Stdout.Write(buffer.Bytes()); err != nil {
+				config.Log.Fatal("Error writing Parquet", err)
+			}
+			return
 		}
 		fmt.Println(buffer.String())
 	},
@@ -70,7 +92,7 @@ func setupQuery(cmd *cobra.Command, args []string) error {
 	}
 
 	bindFlags(cmd, viperConf)
-	err := queryConfig.Validate(validParserKeys)
+	err := queryConfig.Validate(validParserKeys, csv.Encodings, csv.Aggregations)
 	if err != nil {
 		return err
 	}
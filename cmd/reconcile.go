@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/reconcile"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	reconcileConfig       config.ReconcileConfig
+	reconcileDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&reconcileConfig.Log, reconcileCmd)
+	config.SetupDatabaseFlags(&reconcileConfig.Database, reconcileCmd)
+	config.SetupLensFlags(&reconcileConfig.Lens, reconcileCmd)
+	config.SetupReconcileSpecificFlags(&reconcileConfig, reconcileCmd)
+	rootCmd.AddCommand(reconcileCmd)
+}
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Compares the balances replayed from the indexed data with the on-chain bank balances of the addresses.",
+	Long: `Replays the indexed taxable txs, fees, taxable events and delegations of the addresses per denom up to a height
+	and compares the net balances with the bank balances queried from the node at that height. For every mismatched denom
+	the first height the balances diverge at is searched, which points at the missing handlers and ignored messages.
+	The node must be an archive node keeping the state of the indexed heights.`,
+	PreRunE: setupReconcile,
+	Run:     reconcileBalances,
+}
+
+func setupReconcile(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+
+	err := reconcileConfig.Validate()
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousReconcileKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(reconcileConfig.Log.Level, reconcileConfig.Log.Path, reconcileConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(reconcileConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	reconcileDbConnection = db
+
+	return nil
+}
+
+func reconcileBalances(cmd *cobra.Command, args []string) {
+	cfg := reconcileConfig
+	db := reconcileDbConnection
+
+	cl := config.GetLensClient(cfg.Lens)
+
+	var chain dbTypes.Chain
+	result := db.Where("chain_id = ?", cl.Config.ChainID).Limit(1).Find(&chain)
+	if result.Error != nil {
+		config.Log.Fatalf("Error getting Chain model. Err: %v", result.Error)
+	}
+
+	if chain.ID == 0 {
+		config.Log.Fatalf("Chain %s is not indexed", cl.Config.ChainID)
+	}
+
+	// 0x addresses are reconciled by the Bech32 addresses of the same account
+	addresses, err := dbTypes.ResolveEVMAddresses(cfg.Base.Addresses, db)
+	if err != nil {
+		config.Log.Fatal("Error resolving EVM addresses", err)
+	}
+
+	lowestBlock, err := dbTypes.GetLowestIndexedBlock(db, chain.ID)
+	if err != nil {
+		config.Log.Fatalf("Error getting the lowest indexed block. Err: %v", err)
+	}
+
+	highestBlock := dbTypes.GetHighestIndexedBlock(db, chain.ID)
+	if highestBlock.ID == 0 {
+		config.Log.Fatalf("No block of chain %s indexed", chain.ChainID)
+	}
+
+	height := highestBlock.Height
+	if cfg.Base.Height != 0 {
+		if cfg.Base.Height > highestBlock.Height || cfg.Base.Height < lowestBlock.Height {
+			config.Log.Fatalf("Height %d is outside of the indexed blocks %d to %d", cfg.Base.Height, lowestBlock.Height, highestBlock.Height)
+		}
+		height = cfg.Base.Height
+	}
+
+	config.Log.Infof("Reconciling %d addresses at height %d, indexed from height %d", len(addresses), height, lowestBlock.Height)
+
+	var discrepancies []reconcile.Discrepancy
+	for _, address := range addresses {
+		addressDiscrepancies, err := reconcile.Address(cl, db, chain.ID, address, lowestBlock.Height, height)
+		if err != nil {
+			config.Log.Fatalf("Error reconciling address %s. Err: %v", address, err)
+		}
+
+		if len(addressDiscrepancies) == 0 {
+			config.Log.Infof("The indexed balances of address %s match the chain", address)
+		}
+
+		discrepancies = append(discrepancies, addressDiscrepancies...)
+	}
+
+	switch cfg.Base.Format {
+	case "json":
+		output, err := json.MarshalIndent(discrepancies, "", "  ")
+		if err != nil {
+			config.Log.Fatal("Error generating JSON", err)
+		}
+		fmt.Println(string(output))
+	default:
+		csvRows, headers := csv.ReconcileToCsvRows(discrepancies)
+		buffer, err := csv.ToCsv(csvRows, headers)
+		if err != nil {
+			config.Log.Fatal("Error generating CSV", err)
+		}
+		fmt.Println(buffer.String())
+	}
+}
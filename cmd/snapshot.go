@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/snapshot"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	snapshotConfig       config.SnapshotConfig
+	snapshotDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&snapshotConfig.Log, snapshotCmd)
+	config.SetupDatabaseFlags(&snapshotConfig.Database, snapshotCmd)
+	config.SetupLensFlags(&snapshotConfig.Lens, snapshotCmd)
+	config.SetupSnapshotSpecificFlags(&snapshotConfig, snapshotCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Reports the staking positions, unclaimed rewards and balances of the addresses at a date.",
+	Long: `Maps the date to the last indexed block at or before it and queries the node for the delegations, unbonding
+	delegations, unclaimed rewards, bank balances and LP shares of the addresses at that height. The report is meant to be
+	filed with the tax export for the year ending at the date. The node must be an archive node keeping the state of that height.`,
+	PreRunE: setupSnapshot,
+	Run:     takeSnapshot,
+}
+
+func setupSnapshot(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+
+	err := snapshotConfig.Validate()
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousSnapshotKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(snapshotConfig.Log.Level, snapshotConfig.Log.Path, snapshotConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(snapshotConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	snapshotDbConnection = db
+
+	dbTypes.CacheDenoms(db)
+	dbTypes.CacheIBCDenoms(db)
+
+	return nil
+}
+
+func takeSnapshot(cmd *cobra.Command, args []string) {
+	cfg := snapshotConfig
+	db := snapshotDbConnection
+
+	cl := config.GetLensClient(cfg.Lens)
+
+	// 0x addresses are queried by the Bech32 addresses of the same account
+	addresses, err := dbTypes.ResolveEVMAddresses(cfg.Base.Addresses, db)
+	if err != nil {
+		config.Log.Fatal("Error resolving EVM addresses", err)
+	}
+
+	date, _ := time.Parse("2006-01-02:15:04:05", cfg.Base.Date)
+
+	block, err := dbTypes.GetBlockAtTime(db, cl.Config.ChainID, date)
+	if err != nil {
+		config.Log.Fatalf("Error getting the block at %s. Err: %v", cfg.Base.Date, err)
+	}
+
+	if block.ID == 0 {
+		config.Log.Fatalf("No block of chain %s indexed at or before %s", cl.Config.ChainID, cfg.Base.Date)
+	}
+
+	// The indexed blocks may not reach the date, the snapshot is then taken at an earlier state than requested
+	highestBlock := dbTypes.GetHighestIndexedBlock(db, block.BlockchainID)
+	if highestBlock.Height == block.Height {
+		config.Log.Warnf("Block %d at %s is the highest indexed block, blocks up to %s may not be indexed", block.Height, block.TimeStamp, cfg.Base.Date)
+	}
+
+	config.Log.Infof("Taking snapshot at height %d (%s)", block.Height, block.TimeStamp)
+
+	snap, err := snapshot.Take(cl, date, block.Height, block.TimeStamp, addresses)
+	if err != nil {
+		config.Log.Fatalf("Error taking snapshot at height %d. Err: %v", block.Height, err)
+	}
+
+	switch cfg.Base.Format {
+	case "json":
+		output, err := json.MarshalIndent(snap, "", "  ")
+		if err != nil {
+			config.Log.Fatal("Error generating JSON", err)
+		}
+		fmt.Println(string(output))
+	default:
+		csvRows, headers := csv.SnapshotToCsvRows(snap)
+		buffer, err := csv.ToCsv(csvRows, headers)
+		if err != nil {
+			config.Log.Fatal("Error generating CSV", err)
+		}
+		fmt.Println(buffer.String())
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/gains"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	taxReportConfig       config.TaxReportConfig
+	taxReportDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&taxReportConfig.Log, taxReportCmd)
+	config.SetupDatabaseFlags(&taxReportConfig.Database, taxReportCmd)
+	config.SetupTaxReportSpecificFlags(gains.ParserKeys, csv.Reports, &taxReportConfig, taxReportCmd)
+	rootCmd.AddCommand(taxReportCmd)
+}
+
+var taxReportCmd = &cobra.Command{
+	Use:   "tax-report",
+	Short: "Reports the realized gains of the addresses on IRS Form 8949 or their Schedule D totals.",
+	Long: `Computes the realized gains of the addresses with a cost basis method over the indexed data and reports the
+	disposals between the dates as Form 8949 lines, split into short-term (Part I) and long-term (Part II) and into the boxes
+	of the digital assets not reported on a Form 1099-B or 1099-DA. The schedule-d report totals the boxes on their Schedule D
+	lines. Disposals with an unknown price or cost basis are reported without them and counted in the totals.`,
+	PreRunE: setupTaxReport,
+	Run:     taxReport,
+}
+
+func setupTaxReport(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+
+	err := taxReportConfig.Validate(gains.ParserKeys, csv.Reports)
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousTaxReportKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(taxReportConfig.Log.Level, taxReportConfig.Log.Path, taxReportConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(taxReportConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	taxReportDbConnection = db
+
+	dbTypes.CacheDenoms(db)
+	dbTypes.CacheIBCDenoms(db)
+
+	return nil
+}
+
+func taxReport(cmd *cobra.Command, args []string) {
+	cfg := taxReportConfig
+	db := taxReportDbConnection
+
+	var startDate *time.Time
+	var endDate *time.Time
+	expectedLayout := "2006-01-02:15:04:05"
+	if cfg.Base.StartDate != "" {
+		parsedDate, _ := time.Parse(expectedLayout, cfg.Base.StartDate)
+		startDate = &parsedDate
+	}
+	if cfg.Base.EndDate != "" {
+		parsedDate, _ := time.Parse(expectedLayout, cfg.Base.EndDate)
+		endDate = &parsedDate
+	}
+
+	// 0x addresses are queried by the Bech32 addresses of the same account
+	addresses, err := dbTypes.ResolveEVMAddresses(cfg.Base.Addresses, db)
+	if err != nil {
+		config.Log.Fatal("Error resolving EVM addresses", err)
+	}
+
+	lines, err := csv.Form8949ForAddresses(addresses, startDate, endDate, db, cfg.Base.Method)
+	if err != nil {
+		config.Log.Fatal("Error computing the realized gains", err)
+	}
+
+	var report interface{} = lines
+	var csvRows []parsers.CsvRow
+	var headers []string
+	if cfg.Base.Report == csv.ReportScheduleD {
+		scheduleD := csv.ScheduleDTotals(lines)
+		report = scheduleD
+		csvRows, headers = csv.ScheduleDToCsvRows(scheduleD)
+	} else {
+		csvRows, headers = csv.Form8949ToCsvRows(lines)
+	}
+
+	switch cfg.Base.Format {
+	case "json":
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			config.Log.Fatal("Error generating JSON", err)
+		}
+		fmt.Println(string(output))
+	default:
+		buffer, err := csv.ToCsv(csvRows, headers)
+		if err != nil {
+			config.Log.Fatal("Error generating CSV", err)
+		}
+		fmt.Println(buffer.String())
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis"
 	epochsTypes "github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/epochs"
 	"github.com/DefiantLabs/cosmos-tax-cli/rpc"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride"
+	strideEpochsTypes "github.com/DefiantLabs/cosmos-tax-cli/stride/modules/epochs"
 	"github.com/DefiantLabs/lens/client"
+	osmosisEpochs "github.com/osmosis-labs/osmosis/x/epochs/types"
 	"github.com/spf13/cobra"
 	"gorm.io/gorm"
 )
@@ -18,6 +21,20 @@ var (
 	updateEpochsDbConnection *gorm.DB
 )
 
+// chainIndexableEpochs are the epoch identifiers that can be indexed for each chain with an Epochs module
+var chainIndexableEpochs = map[string]map[string]bool{
+	osmosis.ChainID: epochsTypes.OsmosisIndexableEpochs,
+	stride.ChainID:  strideEpochsTypes.StrideIndexableEpochs,
+}
+
+// getEpochsAtHeight routes the Epochs query to the Epochs module of the chain
+func getEpochsAtHeight(cl *client.ChainClient, height int64) (*osmosisEpochs.QueryEpochsInfoResponse, error) {
+	if cl.Config.ChainID == stride.ChainID {
+		return rpc.GetStrideEpochsAtHeight(cl, height)
+	}
+	return rpc.GetEpochsAtHeight(cl, height)
+}
+
 func init() {
 	config.SetupLogFlags(&updateEpochsConfig.Log, updateEpochsCmd)
 	config.SetupDatabaseFlags(&updateEpochsConfig.Database, updateEpochsCmd)
@@ -29,8 +46,8 @@ func init() {
 
 var updateEpochsCmd = &cobra.Command{
 	Use:   "update-epochs",
-	Short: "Gather Epoch information from the blockchain and index it. Currently only supports the Osmosis Epochs module.",
-	Long: `Indexes Epoch information from the blockchain. This command currently only support the Osmosis Epochs module.
+	Short: "Gather Epoch information from the blockchain and index it. Currently supports the Osmosis and Stride Epochs modules.",
+	Long: `Indexes Epoch information from the blockchain. This command currently supports the Osmosis and Stride Epochs modules.
 	Future versions will support the same concept of Epochs for other Cosmos chains if they exist.`,
 	PreRunE: setupUpdateEpochs,
 	Run:     updateEpochs,
@@ -70,7 +87,7 @@ func updateEpochs(cmd *cobra.Command, args []string) {
 
 	epochIdentifier := cfg.Base.EpochIdentifier
 
-	if cl.Config.ChainID == osmosis.ChainID {
+	if _, ok := chainIndexableEpochs[cl.Config.ChainID]; ok {
 		// Setup Chain model item
 		var chain dbTypes.Chain
 		chain.ChainID = cl.Config.ChainID
@@ -163,7 +180,7 @@ func indexEpochsAtStartingHeight(db *gorm.DB, cl *client.ChainClient, startingHe
 	var lastIndexedItem dbTypes.Epoch
 	for {
 		time.Sleep(time.Second * time.Duration(throttling))
-		resp, err := rpc.GetEpochsAtHeight(cl, currentHeight)
+		resp, err := getEpochsAtHeight(cl, currentHeight)
 		if err != nil {
 			config.Log.Fatalf("Error getting epochs at height %d. Err: %v", currentHeight, err)
 		}
@@ -179,8 +196,8 @@ func indexEpochsAtStartingHeight(db *gorm.DB, cl *client.ChainClient, startingHe
 		// Get the index of the shortest duration EpochInfo, this will be used for the querying mechanism
 		for _, epoch := range resp.Epochs {
 			// Make sure we have the ability to index this EpochInfo
-			// This will save us trouble if Osmosis adds more Epochs in the future
-			indexable, identifierExists := epochsTypes.OsmosisIndexableEpochs[epoch.Identifier]
+			// This will save us trouble if the chain adds more Epochs in the future
+			indexable, identifierExists := chainIndexableEpochs[cl.Config.ChainID][epoch.Identifier]
 			if identifierExists && indexable && epoch.Identifier == identifierToIndex {
 
 				if epoch.CurrentEpochStartHeight <= 0 {
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/prices"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	updatePricesConfig       config.UpdatePricesConfig
+	updatePricesDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&updatePricesConfig.Log, updatePricesCmd)
+	config.SetupDatabaseFlags(&updatePricesConfig.Database, updatePricesCmd)
+	config.SetupUpdatePricesSpecificFlags(&updatePricesConfig, updatePricesCmd)
+	rootCmd.AddCommand(updatePricesCmd)
+}
+
+var updatePricesCmd = &cobra.Command{
+	Use:   "update-prices",
+	Short: "Imports a price dump into the prices table used to value the exports.",
+	Long: `Imports historical prices from an offline CSV or JSON price dump into the prices table. The prices are keyed by denom,
+	timestamp and fiat currency and are interpolated to the block times when the exports fill their fiat columns.
+	Currencies are matched with the base denom first and then with the symbol of the indexed denoms.`,
+	PreRunE: setupUpdatePrices,
+	Run:     updatePrices,
+}
+
+func setupUpdatePrices(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+
+	err := updatePricesConfig.Validate()
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousUpdatePricesKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(updatePricesConfig.Log.Level, updatePricesConfig.Log.Path, updatePricesConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(updatePricesConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	updatePricesDbConnection = db
+
+	return nil
+}
+
+func updatePrices(cmd *cobra.Command, args []string) {
+	cfg := updatePricesConfig
+	db := updatePricesDbConnection
+
+	file, err := os.Open(cfg.Base.File)
+	if err != nil {
+		config.Log.Fatalf("Error opening price dump %s. Err: %v", cfg.Base.File, err)
+	}
+	defer file.Close()
+
+	var records []prices.Record
+	switch cfg.Base.Format {
+	case "json":
+		records, err = prices.ParseJSON(file, cfg.Base.Denom, cfg.Base.FiatCurrency)
+	default:
+		records, err = prices.ParseCSV(file, cfg.Base.Denom, cfg.Base.FiatCurrency)
+	}
+	if err != nil {
+		config.Log.Fatalf("Error parsing price dump %s. Err: %v", cfg.Base.File, err)
+	}
+
+	denoms := make(map[string]dbTypes.Denom)
+	var dbPrices []dbTypes.Price
+	for _, record := range records {
+		denom, ok := denoms[record.Currency]
+		if !ok {
+			denom, err = dbTypes.GetDenomForCurrency(db, record.Currency)
+			if err != nil {
+				config.Log.Fatalf("Error getting the denom of currency %s. Err: %v", record.Currency, err)
+			}
+			denoms[record.Currency] = denom
+		}
+
+		dbPrices = append(dbPrices, dbTypes.Price{
+			DenominationID: denom.ID,
+			Timestamp:      record.Timestamp,
+			FiatCurrency:   record.FiatCurrency,
+			Source:         dbTypes.PriceSourceImport,
+			Price:          record.Price,
+		})
+	}
+
+	err = dbTypes.UpsertPrices(db, dbPrices)
+	if err != nil {
+		config.Log.Fatalf("Error storing the prices. Err: %v", err)
+	}
+
+	config.Log.Infof("Imported %d prices of %d currencies from %s", len(dbPrices), len(denoms), cfg.Base.File)
+}
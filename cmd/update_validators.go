@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/rpc"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	updateValidatorsConfig       config.UpdateValidatorsConfig
+	updateValidatorsDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&updateValidatorsConfig.Log, updateValidatorsCmd)
+	config.SetupDatabaseFlags(&updateValidatorsConfig.Database, updateValidatorsCmd)
+	config.SetupLensFlags(&updateValidatorsConfig.Lens, updateValidatorsCmd)
+	rootCmd.AddCommand(updateValidatorsCmd)
+}
+
+var updateValidatorsCmd = &cobra.Command{
+	Use:   "update-validators",
+	Short: "Gather the validators of the blockchain and index their consensus addresses and monikers.",
+	Long: `Indexes the current validators of the chain from the staking module, with their consensus addresses and monikers.
+	Validators are otherwise only indexed from MsgCreateValidator and MsgEditValidator, which misses the genesis validators.
+	The monikers are used to describe the staking rewards in the CSV exports and the consensus addresses to attribute slashes.`,
+	PreRunE: setupUpdateValidators,
+	Run:     updateValidators,
+}
+
+func setupUpdateValidators(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+
+	err := updateValidatorsConfig.Validate()
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousUpdateValidatorsKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(updateValidatorsConfig.Log.Level, updateValidatorsConfig.Log.Path, updateValidatorsConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(updateValidatorsConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	updateValidatorsDbConnection = db
+
+	return nil
+}
+
+func updateValidators(cmd *cobra.Command, args []string) {
+	cfg := updateValidatorsConfig
+	db := updateValidatorsDbConnection
+
+	cl := config.GetLensClient(cfg.Lens)
+
+	// Setup Chain model item
+	var chain dbTypes.Chain
+	chain.ChainID = cl.Config.ChainID
+	chain.Name = cfg.Lens.ChainName
+	res := db.FirstOrCreate(&chain)
+
+	if res.Error != nil {
+		config.Log.Fatalf("Error setting up Chain model. Err: %v", res.Error)
+	}
+
+	config.Log.Infof("Running validator update task for chain %s", chain.ChainID)
+
+	validators, err := rpc.GetValidators(cl)
+	if err != nil {
+		config.Log.Fatalf("Error getting validators. Err: %v", err)
+	}
+
+	dbValidators := make([]dbTypes.Validator, 0, len(validators))
+	for _, validator := range validators {
+		dbValidator := dbTypes.Validator{
+			OperatorAddress: validator.OperatorAddress,
+			Moniker:         validator.Description.Moniker,
+		}
+
+		if pubKey, err := validator.ConsPubKey(); err == nil {
+			dbValidator.ConsensusAddress = pubKey.Address().String()
+		} else {
+			config.Log.Warnf("Could not get the consensus pubkey of validator %s. Err: %v", validator.OperatorAddress, err)
+		}
+
+		dbValidators = append(dbValidators, dbValidator)
+	}
+
+	err = dbTypes.UpsertValidators(db, chain.ID, dbValidators)
+	if err != nil {
+		config.Log.Fatalf("Error indexing validators. Err: %v", err)
+	}
+
+	config.Log.Infof("Indexed %d validators", len(dbValidators))
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"gorm.io/gorm"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	vestingScheduleConfig       config.VestingScheduleConfig
+	vestingScheduleDbConnection *gorm.DB
+)
+
+func init() {
+	config.SetupLogFlags(&vestingScheduleConfig.Log, vestingScheduleCmd)
+	config.SetupDatabaseFlags(&vestingScheduleConfig.Database, vestingScheduleCmd)
+	config.SetupVestingScheduleSpecificFlags(&vestingScheduleConfig, vestingScheduleCmd)
+	rootCmd.AddCommand(vestingScheduleCmd)
+}
+
+var vestingScheduleCmd = &cobra.Command{
+	Use:   "vesting-schedule",
+	Short: "Reports the vesting unlocks of the indexed vesting accounts.",
+	Long: `Computes the tokens unlocked per period by the vesting accounts of the addresses from the indexed
+	vesting schedules and generates a CSV report. Vesting unlocks are not on-chain events, with --materialize
+	the unlocks are stored as taxable events so they are included as income in the query output.`,
+	PreRunE: setupVestingSchedule,
+	Run: func(cmd *cobra.Command, args []string) {
+		db := vestingScheduleDbConnection
+
+		// 0x addresses are queried by the Bech32 addresses of the same account
+		addresses, err := dbTypes.ResolveEVMAddresses(vestingScheduleConfig.Base.Addresses, db)
+		if err != nil {
+			config.Log.Fatal("Error resolving EVM addresses", err)
+		}
+
+		accounts, err := dbTypes.GetVestingAccounts(addresses, db)
+		if err != nil {
+			config.Log.Fatal("Error getting vesting accounts", err)
+		}
+
+		if len(accounts) == 0 {
+			config.Log.Infof("No vesting accounts found for addresses %v", vestingScheduleConfig.Base.Addresses)
+			return
+		}
+
+		// Default to the full range of the vesting schedules
+		startDate, endDate, err := dbTypes.GetVestingReportRange(accounts)
+		if err != nil && (vestingScheduleConfig.Base.StartDate == "" || vestingScheduleConfig.Base.EndDate == "") {
+			config.Log.Infof("Nothing to report: %v", err)
+			return
+		}
+
+		expectedLayout := "2006-01-02:15:04:05"
+		if vestingScheduleConfig.Base.StartDate != "" {
+			startDate, _ = time.Parse(expectedLayout, vestingScheduleConfig.Base.StartDate)
+		}
+		if vestingScheduleConfig.Base.EndDate != "" {
+			endDate, _ = time.Parse(expectedLayout, vestingScheduleConfig.Base.EndDate)
+		}
+
+		unlocks := csv.ComputeVestingSchedule(accounts, startDate, endDate, vestingScheduleConfig.Base.Period)
+
+		if vestingScheduleConfig.Base.Materialize {
+			skipped, err := dbTypes.MaterializeVestingUnlocks(db, accounts, startDate, endDate)
+			if err != nil {
+				config.Log.Fatal("Error storing vesting unlocks", err)
+			}
+			if skipped > 0 {
+				config.Log.Warnf("%d vesting unlocks after the last indexed block were not stored", skipped)
+			}
+		}
+
+		csvRows, headers := csv.VestingScheduleToCsvRows(unlocks)
+		buffer, err := csv.ToCsv(csvRows, headers)
+		if err != nil {
+			config.Log.Fatal("Error generating CSV", err)
+		}
+		fmt.Println(buffer.String())
+	},
+}
+
+func setupVestingSchedule(cmd *cobra.Command, args []string) error {
+	bindFlags(cmd, viperConf)
+	err := vestingScheduleConfig.Validate(dbTypes.VestingReportPeriods)
+	if err != nil {
+		return err
+	}
+
+	ignoredKeys := config.CheckSuperfluousVestingScheduleKeys(viperConf.AllKeys())
+
+	if len(ignoredKeys) > 0 {
+		config.Log.Warnf("Warning, the following invalid keys will be ignored: %v", ignoredKeys)
+	}
+
+	setupLogger(vestingScheduleConfig.Log.Level, vestingScheduleConfig.Log.Path, vestingScheduleConfig.Log.Pretty)
+
+	db, err := connectToDBAndMigrate(vestingScheduleConfig.Database)
+	if err != nil {
+		config.Log.Fatal("Could not establish connection to the database", err)
+	}
+
+	vestingScheduleDbConnection = db
+
+	dbTypes.CacheDenoms(db)
+	dbTypes.CacheIBCDenoms(db)
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var CLPositionsFormats = []string{"csv", "json"}
+
+type CLPositionsConfig struct {
+	Database Database
+	Log      log
+	Base     clPositionsBase
+}
+
+type clPositionsBase struct {
+	Addresses []string `mapstructure:"addresses"`
+	Format    string   `mapstructure:"format"`
+}
+
+func SetupCLPositionsSpecificFlags(conf *CLPositionsConfig, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&conf.Base.Addresses, "address", nil, "A comma separated list of the address(s) to report on. (Both '--address addr1,addr2' and '--address addr1 --address addr2' are valid)")
+	cmd.Flags().StringVar(&conf.Base.Format, "format", "csv", "The format to output (csv or json)")
+}
+
+func (conf *CLPositionsConfig) Validate() error {
+	err := validateDatabaseConf(conf.Database)
+	if err != nil {
+		return err
+	}
+
+	if len(conf.Base.Addresses) == 0 {
+		return fmt.Errorf("at least one address must be set")
+	}
+
+	// Validate addresses
+	for _, address := range conf.Base.Addresses {
+		if strings.Contains(address, ",") {
+			return fmt.Errorf("invalid address %s, addresses cannot contain commas", address)
+		} else if strings.Contains(address, " ") {
+			return fmt.Errorf("invalid address '%v', addresses cannot contain spaces", address)
+		}
+	}
+
+	if !slices.Contains(CLPositionsFormats, conf.Base.Format) {
+		return fmt.Errorf("invalid format %s, valid formats are %s", conf.Base.Format, CLPositionsFormats)
+	}
+
+	return nil
+}
+
+func CheckSuperfluousCLPositionsKeys(keys []string) []string {
+	validKeys := make(map[string]struct{})
+
+	addDatabaseConfigKeys(validKeys)
+	addLogConfigKeys(validKeys)
+
+	// add base keys
+	for _, key := range getValidConfigKeys(clPositionsBase{}, "base") {
+		validKeys[key] = struct{}{}
+	}
+
+	// Check keys
+	ignoredKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := validKeys[key]; !ok {
+			ignoredKeys = append(ignoredKeys, key)
+		}
+	}
+
+	return ignoredKeys
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type DerivePricesConfig struct {
+	Database Database
+	Log      log
+	Base     derivePricesBase
+}
+
+type derivePricesBase struct {
+	ChainID      string        `mapstructure:"chain-id"`
+	References   []string      `mapstructure:"references"`
+	Liquid       []string      `mapstructure:"liquid"`
+	Bucket       time.Duration `mapstructure:"bucket"`
+	StartDate    string        `mapstructure:"start-date"`
+	EndDate      string        `mapstructure:"end-date"`
+	FiatCurrency string        `mapstructure:"fiat"`
+}
+
+func SetupDerivePricesSpecificFlags(conf *DerivePricesConfig, cmd *cobra.Command) {
+	cmd.Flags().StringVar(&conf.Base.ChainID, "chain-id", "osmosis-1", "The chain ID of the indexed Osmosis chain to derive the prices from")
+	cmd.Flags().StringSliceVar(&conf.Base.References, "reference", []string{"USDC"}, "A comma separated list of the base denoms or symbols of the stable denoms worth one unit of the fiat currency")
+	cmd.Flags().StringSliceVar(&conf.Base.Liquid, "liquid", []string{"OSMO", "ATOM"}, "A comma separated list of the base denoms or symbols of the liquid denoms the other denoms are priced through")
+	cmd.Flags().DurationVar(&conf.Base.Bucket, "bucket", time.Hour, "The time bucket the swaps are volume weighted over")
+	cmd.Flags().StringVar(&conf.Base.StartDate, "start-date", "", "If set, swaps before this date will be ignored. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+	cmd.Flags().StringVar(&conf.Base.EndDate, "end-date", "", "If set, swaps on or after this date will be ignored. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+	cmd.Flags().StringVar(&conf.Base.FiatCurrency, "fiat", "USD", "The fiat currency the reference denoms are pegged to")
+}
+
+func (conf *DerivePricesConfig) Validate() error {
+	err := validateDatabaseConf(conf.Database)
+	if err != nil {
+		return err
+	}
+
+	if conf.Base.ChainID == "" {
+		return fmt.Errorf("a chain ID must be set")
+	}
+
+	if len(conf.Base.References) == 0 {
+		return fmt.Errorf("at least one reference denom must be set")
+	}
+
+	if conf.Base.Bucket < time.Minute || (24*time.Hour)%conf.Base.Bucket != 0 {
+		return fmt.Errorf("invalid bucket %s, the bucket must be at least a minute and divide a day", conf.Base.Bucket)
+	}
+
+	expectedLayout := "2006-01-02:15:04:05"
+
+	if conf.Base.StartDate != "" {
+		_, err := time.Parse(expectedLayout, conf.Base.StartDate)
+		if err != nil {
+			return fmt.Errorf("invalid start date '%v'", conf.Base.StartDate)
+		}
+	}
+	if conf.Base.EndDate != "" {
+		_, err := time.Parse(expectedLayout, conf.Base.EndDate)
+		if err != nil {
+			return fmt.Errorf("invalid end date '%v'", conf.Base.EndDate)
+		}
+	}
+
+	if conf.Base.FiatCurrency == "" {
+		return fmt.Errorf("a fiat currency must be set")
+	}
+
+	return nil
+}
+
+func CheckSuperfluousDerivePricesKeys(keys []string) []string {
+	validKeys := make(map[string]struct{})
+
+	addDatabaseConfigKeys(validKeys)
+	addLogConfigKeys(validKeys)
+
+	// add base keys
+	for _, key := range getValidConfigKeys(derivePricesBase{}, "base") {
+		validKeys[key] = struct{}{}
+	}
+
+	// Check keys
+	ignoredKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := validKeys[key]; !ok {
+			ignoredKeys = append(ignoredKeys, key)
+		}
+	}
+
+	return ignoredKeys
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var IncomeSummaryFormats = []string{"csv", "json"}
+
+type IncomeSummaryConfig struct {
+	Database Database
+	Log      log
+	Base     incomeSummaryBase
+}
+
+type incomeSummaryBase struct {
+	Addresses []string `mapstructure:"addresses"`
+	Fiat      string   `mapstructure:"fiat"`
+	Format    string   `mapstructure:"format"`
+	StartDate string   `mapstructure:"start-date"`
+	EndDate   string   `mapstructure:"end-date"`
+}
+
+func SetupIncomeSummarySpecificFlags(conf *IncomeSummaryConfig, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&conf.Base.Addresses, "address", nil, "A comma separated list of the address(s) to summarize. (Both '--address addr1,addr2' and '--address addr1 --address addr2' are valid)")
+	cmd.Flags().StringVar(&conf.Base.StartDate, "start-date", "", "If set, income before this date will be ignored. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+	cmd.Flags().StringVar(&conf.Base.EndDate, "end-date", "", "If set, income on or after this date will be ignored. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+	cmd.Flags().StringVar(&conf.Base.Fiat, "fiat", "", "If set, the income is valued at receipt in this fiat currency (e.g. USD) and totaled per month")
+	cmd.Flags().StringVar(&conf.Base.Format, "format", "csv", "The format to output (csv or json)")
+}
+
+func (conf *IncomeSummaryConfig) Validate() error {
+	err := validateDatabaseConf(conf.Database)
+	if err != nil {
+		return err
+	}
+
+	if len(conf.Base.Addresses) == 0 {
+		return fmt.Errorf("at least one address must be set")
+	}
+
+	// Validate addresses
+	for _, address := range conf.Base.Addresses {
+		if strings.Contains(address, ",") {
+			return fmt.Errorf("invalid address %s, addresses cannot contain commas", address)
+		} else if strings.Contains(address, " ") {
+			return fmt.Errorf("invalid address '%v', addresses cannot contain spaces", address)
+		}
+	}
+
+	if !slices.Contains(IncomeSummaryFormats, conf.Base.Format) {
+		return fmt.Errorf("invalid format %s, valid formats are %s", conf.Base.Format, IncomeSummaryFormats)
+	}
+
+	if strings.ContainsAny(conf.Base.Fiat, ", ") {
+		return fmt.Errorf("invalid fiat currency '%v'", conf.Base.Fiat)
+	}
+
+	expectedLayout := "2006-01-02:15:04:05"
+
+	if conf.Base.StartDate != "" {
+		_, err := time.Parse(expectedLayout, conf.Base.StartDate)
+		if err != nil {
+			return fmt.Errorf("invalid start date '%v'", conf.Base.StartDate)
+		}
+	}
+	if conf.Base.EndDate != "" {
+		_, err := time.Parse(expectedLayout, conf.Base.EndDate)
+		if err != nil {
+			return fmt.Errorf("invalid end date '%v'", conf.Base.EndDate)
+		}
+	}
+
+	return nil
+}
+
+func CheckSuperfluousIncomeSummaryKeys(keys []string) []string {
+	validKeys := make(map[string]struct{})
+
+	addDatabaseConfigKeys(validKeys)
+	addLogConfigKeys(validKeys)
+
+	// add base keys
+	for _, key := range getValidConfigKeys(incomeSummaryBase{}, "base") {
+		validKeys[key] = struct{}{}
+	}
+
+	// Check keys
+	ignoredKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := validKeys[key]; !ok {
+			ignoredKeys = append(ignoredKeys, key)
+		}
+	}
+
+	return ignoredKeys
+}
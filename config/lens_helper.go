@@ -1,6 +1,17 @@
 package config
 
 import (
+	nftTypes "cosmossdk.io/x/nft"
+	crescentClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/crescent"
+	quicksilverClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/quicksilver"
+	stargazeClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/stargaze"
+	nftTransferTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/nfttransfer"
+	lsmTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmoshub/types/lsm"
+	cosmosEVMTypes "github.com/DefiantLabs/cosmos-tax-cli/ethermint/types/cosmosevm"
+	ethermintTypes "github.com/DefiantLabs/cosmos-tax-cli/ethermint/types/ethermint"
+	injectiveTypes "github.com/DefiantLabs/cosmos-tax-cli/ethermint/types/injective"
+	strideClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/stride/types/claim"
+	strideStakeibcTypes "github.com/DefiantLabs/cosmos-tax-cli/stride/types/stakeibc"
 	lensClient "github.com/DefiantLabs/lens/client"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	ibcTypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
@@ -23,6 +34,21 @@ func RegisterAdditionalTypes(cc *lensClient.ChainClient) {
 	// Register IBC types
 	// ibcTypes.RegisterLegacyAminoCodec(cc.Codec.Amino)
 	ibcTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+
+	// Register the x/nft types, the module is not part of the lens module basics
+	nftTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+
+	// Register the vendored chain specific types that are not part of the lens module basics
+	nftTransferTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	lsmTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	strideStakeibcTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	strideClaimTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	ethermintTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	injectiveTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	cosmosEVMTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	stargazeClaimTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	crescentClaimTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
+	quicksilverClaimTypes.RegisterInterfaces(cc.Codec.InterfaceRegistry)
 }
 
 func GetLensConfig(conf lens, debug bool) *lensClient.ChainClientConfig {
@@ -17,6 +17,8 @@ type QueryConfig struct {
 type queryBase struct {
 	Addresses []string `mapstructure:"addresses"`
 	Format    string   `mapstructure:"format"`
+	Encoding  string   `mapstructure:"encoding"`
+	Aggregate string   `mapstructure:"aggregate"`
 	StartDate string   `mapstructure:"start-date"`
 	EndDate   string   `mapstructure:"end-date"`
 }
@@ -31,9 +33,11 @@ func SetupQuerySpecificFlags(validParserKeys []string, conf *QueryConfig, cmd *c
 	}
 
 	cmd.Flags().StringVar(&conf.Base.Format, "format", defaultParser, "The format to output")
+	cmd.Flags().StringVar(&conf.Base.Encoding, "encoding", "csv", "The encoding of the output (csv, jsonl or parquet)")
+	cmd.Flags().StringVar(&conf.Base.Aggregate, "aggregate", "", "If set, the reward and income rows are aggregated per day, week or month, denom and source")
 }
 
-func (conf *QueryConfig) Validate(validCsvParsers []string) error {
+func (conf *QueryConfig) Validate(validCsvParsers []string, validEncodings []string, validAggregations []string) error {
 	found := false
 
 	for _, v := range validCsvParsers {
@@ -47,6 +51,32 @@ func (conf *QueryConfig) Validate(validCsvParsers []string) error {
 		return fmt.Errorf("invalid format %s, valid formats are %s", conf.Base.Format, validCsvParsers)
 	}
 
+	found = false
+	for _, v := range validEncodings {
+		if v == conf.Base.Encoding {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("invalid encoding %s, valid encodings are %s", conf.Base.Encoding, validEncodings)
+	}
+
+	if conf.Base.Aggregate != "" {
+		found = false
+		for _, v := range validAggregations {
+			if v == conf.Base.Aggregate {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("invalid aggregation %s, valid aggregations are %s", conf.Base.Aggregate, validAggregations)
+		}
+	}
+
 	// Validate addresses
 	for _, address := range conf.Base.Addresses {
 		if strings.Contains(address, ",") {
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var ReconcileFormats = []string{"csv", "json"}
+
+type ReconcileConfig struct {
+	Database Database
+	Lens     lens
+	Log      log
+	Base     reconcileBase
+}
+
+type reconcileBase struct {
+	Addresses []string `mapstructure:"addresses"`
+	Height    int64    `mapstructure:"height"`
+	Format    string   `mapstructure:"format"`
+}
+
+func SetupReconcileSpecificFlags(conf *ReconcileConfig, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&conf.Base.Addresses, "address", nil, "A comma separated list of the address(s) to reconcile. (Both '--address addr1,addr2' and '--address addr1 --address addr2' are valid)")
+	cmd.Flags().Int64Var(&conf.Base.Height, "height", 0, "The height to reconcile the balances at, defaults to the highest indexed block")
+	cmd.Flags().StringVar(&conf.Base.Format, "format", "csv", "The format to output (csv or json)")
+}
+
+func (conf *ReconcileConfig) Validate() error {
+	err := validateDatabaseConf(conf.Database)
+	if err != nil {
+		return err
+	}
+
+	lensConf := conf.Lens
+
+	lensConf, err = validateLensConf(lensConf)
+	if err != nil {
+		return err
+	}
+
+	conf.Lens = lensConf
+
+	if len(conf.Base.Addresses) == 0 {
+		return fmt.Errorf("at least one address must be set")
+	}
+
+	// Validate addresses
+	for _, address := range conf.Base.Addresses {
+		if strings.Contains(address, ",") {
+			return fmt.Errorf("invalid address %s, addresses cannot contain commas", address)
+		} else if strings.Contains(address, " ") {
+			return fmt.Errorf("invalid address '%v', addresses cannot contain spaces", address)
+		}
+	}
+
+	if conf.Base.Height < 0 {
+		return fmt.Errorf("invalid height %d", conf.Base.Height)
+	}
+
+	found := false
+	for _, v := range ReconcileFormats {
+		if v == conf.Base.Format {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("invalid format %s, valid formats are %s", conf.Base.Format, ReconcileFormats)
+	}
+
+	return nil
+}
+
+func CheckSuperfluousReconcileKeys(keys []string) []string {
+	validKeys := make(map[string]struct{})
+
+	addDatabaseConfigKeys(validKeys)
+	addLogConfigKeys(validKeys)
+	addLensConfigKeys(validKeys)
+
+	// add base keys
+	for _, key := range getValidConfigKeys(reconcileBase{}, "base") {
+		validKeys[key] = struct{}{}
+	}
+
+	// Check keys
+	ignoredKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := validKeys[key]; !ok {
+			ignoredKeys = append(ignoredKeys, key)
+		}
+	}
+
+	return ignoredKeys
+}
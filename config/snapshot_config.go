@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var SnapshotFormats = []string{"csv", "json"}
+
+type SnapshotConfig struct {
+	Database Database
+	Lens     lens
+	Log      log
+	Base     snapshotBase
+}
+
+type snapshotBase struct {
+	Addresses []string `mapstructure:"addresses"`
+	Date      string   `mapstructure:"date"`
+	Format    string   `mapstructure:"format"`
+}
+
+func SetupSnapshotSpecificFlags(conf *SnapshotConfig, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&conf.Base.Addresses, "address", nil, "A comma separated list of the address(s) to snapshot. (Both '--address addr1,addr2' and '--address addr1 --address addr2' are valid)")
+	cmd.Flags().StringVar(&conf.Base.Date, "date", "", "The date of the snapshot, usually the end of the tax year. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+	cmd.Flags().StringVar(&conf.Base.Format, "format", "csv", "The format to output (csv or json)")
+}
+
+func (conf *SnapshotConfig) Validate() error {
+	err := validateDatabaseConf(conf.Database)
+	if err != nil {
+		return err
+	}
+
+	lensConf := conf.Lens
+
+	lensConf, err = validateLensConf(lensConf)
+	if err != nil {
+		return err
+	}
+
+	conf.Lens = lensConf
+
+	if len(conf.Base.Addresses) == 0 {
+		return fmt.Errorf("at least one address must be set")
+	}
+
+	// Validate addresses
+	for _, address := range conf.Base.Addresses {
+		if strings.Contains(address, ",") {
+			return fmt.Errorf("invalid address %s, addresses cannot contain commas", address)
+		} else if strings.Contains(address, " ") {
+			return fmt.Errorf("invalid address '%v', addresses cannot contain spaces", address)
+		}
+	}
+
+	expectedLayout := "2006-01-02:15:04:05"
+
+	if conf.Base.Date == "" {
+		return fmt.Errorf("date must be set")
+	}
+	_, err = time.Parse(expectedLayout, conf.Base.Date)
+	if err != nil {
+		return fmt.Errorf("invalid date '%v'", conf.Base.Date)
+	}
+
+	found := false
+	for _, v := range SnapshotFormats {
+		if v == conf.Base.Format {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("invalid format %s, valid formats are %s", conf.Base.Format, SnapshotFormats)
+	}
+
+	return nil
+}
+
+func CheckSuperfluousSnapshotKeys(keys []string) []string {
+	validKeys := make(map[string]struct{})
+
+	addDatabaseConfigKeys(validKeys)
+	addLogConfigKeys(validKeys)
+	addLensConfigKeys(validKeys)
+
+	// add base keys
+	for _, key := range getValidConfigKeys(snapshotBase{}, "base") {
+		validKeys[key] = struct{}{}
+	}
+
+	// Check keys
+	ignoredKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := validKeys[key]; !ok {
+			ignoredKeys = append(ignoredKeys, key)
+		}
+	}
+
+	return ignoredKeys
+}
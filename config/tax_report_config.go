@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var TaxReportFormats = []string{"csv", "json"}
+
+type TaxReportConfig struct {
+	Database Database
+	Log      log
+	Base     taxReportBase
+}
+
+type taxReportBase struct {
+	Addresses []string `mapstructure:"addresses"`
+	Method    string   `mapstructure:"method"`
+	Report    string   `mapstructure:"report"`
+	Format    string   `mapstructure:"format"`
+	StartDate string   `mapstructure:"start-date"`
+	EndDate   string   `mapstructure:"end-date"`
+}
+
+func SetupTaxReportSpecificFlags(validMethods []string, validReports []string, conf *TaxReportConfig, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&conf.Base.Addresses, "address", nil, "A comma separated list of the address(s) to report. (Both '--address addr1,addr2' and '--address addr1 --address addr2' are valid)")
+	cmd.Flags().StringVar(&conf.Base.StartDate, "start-date", "", "If set, disposals before this date will not be reported, usually the start of the tax year. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+	cmd.Flags().StringVar(&conf.Base.EndDate, "end-date", "", "If set, disposals on or after this date will not be reported, usually the start of the next tax year. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+
+	defaultMethod := ""
+	if len(validMethods) != 0 {
+		defaultMethod = validMethods[0]
+	}
+	defaultReport := ""
+	if len(validReports) != 0 {
+		defaultReport = validReports[0]
+	}
+
+	cmd.Flags().StringVar(&conf.Base.Method, "method", defaultMethod, fmt.Sprintf("The cost basis method of the realized gains (%s)", strings.Join(validMethods, ", ")))
+	cmd.Flags().StringVar(&conf.Base.Report, "report", defaultReport, fmt.Sprintf("The report to output (%s)", strings.Join(validReports, " or ")))
+	cmd.Flags().StringVar(&conf.Base.Format, "format", "csv", "The format to output (csv or json)")
+}
+
+func (conf *TaxReportConfig) Validate(validMethods []string, validReports []string) error {
+	err := validateDatabaseConf(conf.Database)
+	if err != nil {
+		return err
+	}
+
+	if len(conf.Base.Addresses) == 0 {
+		return fmt.Errorf("at least one address must be set")
+	}
+
+	// Validate addresses
+	for _, address := range conf.Base.Addresses {
+		if strings.Contains(address, ",") {
+			return fmt.Errorf("invalid address %s, addresses cannot contain commas", address)
+		} else if strings.Contains(address, " ") {
+			return fmt.Errorf("invalid address '%v', addresses cannot contain spaces", address)
+		}
+	}
+
+	if !slices.Contains(validMethods, conf.Base.Method) {
+		return fmt.Errorf("invalid method %s, valid methods are %s", conf.Base.Method, validMethods)
+	}
+
+	if !slices.Contains(validReports, conf.Base.Report) {
+		return fmt.Errorf("invalid report %s, valid reports are %s", conf.Base.Report, validReports)
+	}
+
+	if !slices.Contains(TaxReportFormats, conf.Base.Format) {
+		return fmt.Errorf("invalid format %s, valid formats are %s", conf.Base.Format, TaxReportFormats)
+	}
+
+	expectedLayout := "2006-01-02:15:04:05"
+
+	if conf.Base.StartDate != "" {
+		_, err := time.Parse(expectedLayout, conf.Base.StartDate)
+		if err != nil {
+			return fmt.Errorf("invalid start date '%v'", conf.Base.StartDate)
+		}
+	}
+	if conf.Base.EndDate != "" {
+		_, err := time.Parse(expectedLayout, conf.Base.EndDate)
+		if err != nil {
+			return fmt.Errorf("invalid end date '%v'", conf.Base.EndDate)
+		}
+	}
+
+	return nil
+}
+
+func CheckSuperfluousTaxReportKeys(keys []string) []string {
+	validKeys := make(map[string]struct{})
+
+	addDatabaseConfigKeys(validKeys)
+	addLogConfigKeys(validKeys)
+
+	// add base keys
+	for _, key := range getValidConfigKeys(taxReportBase{}, "base") {
+		validKeys[key] = struct{}{}
+	}
+
+	// Check keys
+	ignoredKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := validKeys[key]; !ok {
+			ignoredKeys = append(ignoredKeys, key)
+		}
+	}
+
+	return ignoredKeys
+}
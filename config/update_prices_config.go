@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var UpdatePricesFormats = []string{"csv", "json"}
+
+type UpdatePricesConfig struct {
+	Database Database
+	Log      log
+	Base     updatePricesBase
+}
+
+type updatePricesBase struct {
+	File         string `mapstructure:"file"`
+	Format       string `mapstructure:"format"`
+	Denom        string `mapstructure:"denom"`
+	FiatCurrency string `mapstructure:"fiat"`
+}
+
+func SetupUpdatePricesSpecificFlags(conf *UpdatePricesConfig, cmd *cobra.Command) {
+	cmd.Flags().StringVar(&conf.Base.File, "file", "", "The price dump to import")
+	cmd.Flags().StringVar(&conf.Base.Format, "format", "", "The format of the price dump (csv or json), defaults to the file extension")
+	cmd.Flags().StringVar(&conf.Base.Denom, "denom", "", "The base denom or symbol of the prices in dumps without a currency column")
+	cmd.Flags().StringVar(&conf.Base.FiatCurrency, "fiat", "USD", "The fiat currency of the prices in dumps without a fiat column")
+}
+
+func (conf *UpdatePricesConfig) Validate() error {
+	err := validateDatabaseConf(conf.Database)
+	if err != nil {
+		return err
+	}
+
+	if conf.Base.File == "" {
+		return fmt.Errorf("a price dump file must be set")
+	}
+
+	if conf.Base.Format == "" {
+		conf.Base.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(conf.Base.File)), ".")
+	}
+
+	found := false
+	for _, v := range UpdatePricesFormats {
+		if v == conf.Base.Format {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("invalid format %s, valid formats are %s", conf.Base.Format, UpdatePricesFormats)
+	}
+
+	if conf.Base.FiatCurrency == "" {
+		return fmt.Errorf("a fiat currency must be set")
+	}
+
+	return nil
+}
+
+func CheckSuperfluousUpdatePricesKeys(keys []string) []string {
+	validKeys := make(map[string]struct{})
+
+	addDatabaseConfigKeys(validKeys)
+	addLogConfigKeys(validKeys)
+
+	// add base keys
+	for _, key := range getValidConfigKeys(updatePricesBase{}, "base") {
+		validKeys[key] = struct{}{}
+	}
+
+	// Check keys
+	ignoredKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := validKeys[key]; !ok {
+			ignoredKeys = append(ignoredKeys, key)
+		}
+	}
+
+	return ignoredKeys
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type VestingScheduleConfig struct {
+	Database Database
+	Log      log
+	Base     vestingScheduleBase
+}
+
+type vestingScheduleBase struct {
+	Addresses   []string `mapstructure:"addresses"`
+	Period      string   `mapstructure:"period"`
+	StartDate   string   `mapstructure:"start-date"`
+	EndDate     string   `mapstructure:"end-date"`
+	Materialize bool     `mapstructure:"materialize"`
+}
+
+func SetupVestingScheduleSpecificFlags(conf *VestingScheduleConfig, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&conf.Base.Addresses, "address", nil, "A comma separated list of the address(s) to report on. (Both '--address addr1,addr2' and '--address addr1 --address addr2' are valid)")
+	cmd.Flags().StringVar(&conf.Base.Period, "period", "month", "The period the unlocks are grouped by (day, week, month or year)")
+	cmd.Flags().StringVar(&conf.Base.StartDate, "start-date", "", "If set, unlocks before this date will be ignored, defaults to the start of the first vesting schedule. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+	cmd.Flags().StringVar(&conf.Base.EndDate, "end-date", "", "If set, unlocks on or after this date will be ignored, defaults to the end of the last vesting schedule. (Dates must be specified in the format 'YYYY-MM-DD:HH:MM:SS' in UTC)")
+	cmd.Flags().BoolVar(&conf.Base.Materialize, "materialize", false, "If set, the unlocks are stored as taxable events and included in the query output")
+}
+
+func (conf *VestingScheduleConfig) Validate(validPeriods []string) error {
+	if len(conf.Base.Addresses) == 0 {
+		return fmt.Errorf("at least one address must be set")
+	}
+
+	// Validate addresses
+	for _, address := range conf.Base.Addresses {
+		if strings.Contains(address, ",") {
+			return fmt.Errorf("invalid address %s, addresses cannot contain commas", address)
+		} else if strings.Contains(address, " ") {
+			return fmt.Errorf("invalid address '%v', addresses cannot contain spaces", address)
+		}
+	}
+
+	found := false
+	for _, v := range validPeriods {
+		if v == conf.Base.Period {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("invalid period %s, valid periods are %s", conf.Base.Period, validPeriods)
+	}
+
+	expectedLayout := "2006-01-02:15:04:05"
+
+	if conf.Base.StartDate != "" {
+		_, err := time.Parse(expectedLayout, conf.Base.StartDate)
+		if err != nil {
+			return fmt.Errorf("invalid start date '%v'", conf.Base.StartDate)
+		}
+	}
+	if conf.Base.EndDate != "" {
+		_, err := time.Parse(expectedLayout, conf.Base.EndDate)
+		if err != nil {
+			return fmt.Errorf("invalid end date '%v'", conf.Base.EndDate)
+		}
+	}
+
+	return nil
+}
+
+func CheckSuperfluousVestingScheduleKeys(keys []string) []string {
+	validKeys := make(map[string]struct{})
+
+	addDatabaseConfigKeys(validKeys)
+	addLogConfigKeys(validKeys)
+
+	// add base keys
+	for _, key := range getValidConfigKeys(vestingScheduleBase{}, "base") {
+		validKeys[key] = struct{}{}
+	}
+
+	// Check keys
+	ignoredKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, ok := validKeys[key]; !ok {
+			ignoredKeys = append(ignoredKeys, key)
+		}
+	}
+
+	return ignoredKeys
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/ethcrypto"
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
@@ -59,6 +60,22 @@ func IsAddressEqual(addr1 string, prefix1 string, addr2 string, prefix2 string)
 	return bytes.Equal(bAddr1, bAddr2)
 }
 
+// IsSameAccount returns true if the addresses are equal or are the same account on chains with different bech32 prefixes
+func IsSameAccount(addr1 string, addr2 string) bool {
+	if addr1 == addr2 {
+		return true
+	}
+
+	// The human readable part of a bech32 address ends at its last separator
+	separator1 := strings.LastIndex(addr1, "1")
+	separator2 := strings.LastIndex(addr2, "1")
+	if separator1 < 1 || separator2 < 1 {
+		return false
+	}
+
+	return IsAddressEqual(addr1, addr1[:separator1], addr2, addr2[:separator2])
+}
+
 func SetupAddressRegex(addressRegexPattern string) {
 	addressRegex, _ = regexp.Compile(addressRegexPattern)
 }
@@ -150,6 +167,14 @@ func bytesToPubkey(bz []byte, keytype string) (cryptotypes.PubKey, bool) {
 		}
 	}
 
+	// Ethermint keys have the same size as secp256k1 keys but derive the address differently, so the keytype is required
+	if keytype == ethcrypto.KeyType {
+		if len(bz) == ethcrypto.PubKeySize {
+			return &ethcrypto.PubKey{Key: bz}, true
+		}
+		return nil, false
+	}
+
 	if len(bz) == secp256k1.PubKeySize {
 		return &secp256k1.PubKey{Key: bz}, true
 	}
@@ -2,16 +2,35 @@ package core
 
 import (
 	"fmt"
+	"strings"
 
+	sdkMath "cosmossdk.io/math"
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	eventTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/events"
+	govEventTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/events/gov"
+	stakingEventTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/events/staking"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmoshub"
 	"github.com/DefiantLabs/cosmos-tax-cli/rpc"
+	lensClient "github.com/DefiantLabs/lens/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/shopspring/decimal"
 )
 
 var (
-	beginBlockerEventTypeHandlers = map[string][]func() eventTypes.CosmosEvent{}
-	endBlockerEventTypeHandlers   = map[string][]func() eventTypes.CosmosEvent{}
+	beginBlockerEventTypeHandlers = map[string][]func() eventTypes.CosmosEvent{
+		// Validators are slashed for downtime and double signing by the slashing and evidence BeginBlockers
+		stakingEventTypes.BlockEventSlash: {func() eventTypes.CosmosEvent { return &stakingEventTypes.WrapperBlockEventSlash{} }},
+	}
+	endBlockerEventTypeHandlers = map[string][]func() eventTypes.CosmosEvent{
+		// The gov module refunds or burns the proposal deposits in its EndBlocker on every chain
+		govEventTypes.BlockEventActiveProposal:   {func() eventTypes.CosmosEvent { return &govEventTypes.WrapperBlockEventProposalResult{} }},
+		govEventTypes.BlockEventInactiveProposal: {func() eventTypes.CosmosEvent { return &govEventTypes.WrapperBlockEventProposalResult{} }},
+		// The staking module completes the matured unbondings and redelegations in its EndBlocker
+		stakingEventTypes.BlockEventCompleteUnbonding:    {func() eventTypes.CosmosEvent { return &stakingEventTypes.WrapperBlockEventCompleteUnbonding{} }},
+		stakingEventTypes.BlockEventCompleteRedelegation: {func() eventTypes.CosmosEvent { return &stakingEventTypes.WrapperBlockEventCompleteRedelegation{} }},
+	}
 )
 
 func ChainSpecificEndBlockerEventTypeHandlerBootstrap(chainID string) {
@@ -35,7 +54,7 @@ func ChainSpecificBeginBlockerEventTypeHandlerBootstrap(chainID string) {
 func ProcessRPCBlockEvents(blockResults *rpc.CustomBlockResults) ([]eventTypes.EventRelevantInformation, error) {
 	var taxableEvents []eventTypes.EventRelevantInformation
 	if len(endBlockerEventTypeHandlers) != 0 {
-		for _, event := range blockResults.EndBlockEvents {
+		for i, event := range blockResults.EndBlockEvents {
 			handlers, handlersFound := endBlockerEventTypeHandlers[event.Type]
 
 			if !handlersFound {
@@ -45,6 +64,9 @@ func ProcessRPCBlockEvents(blockResults *rpc.CustomBlockResults) ([]eventTypes.E
 			var err error
 			for _, handler := range handlers {
 				cosmosEventHandler := handler()
+				if blockContextEvent, ok := cosmosEventHandler.(eventTypes.BlockContextEvent); ok {
+					blockContextEvent.SetPrecedingEvents(blockResults.EndBlockEvents[:i])
+				}
 				err = cosmosEventHandler.HandleEvent(event.Type, event)
 				if err != nil {
 					config.Log.Debug(fmt.Sprintf("[Block: %v] Cosmos Block EndBlocker event of known type: %s. Handler failed", blockResults.Height, event.Type), err)
@@ -98,3 +120,109 @@ func ProcessRPCBlockEvents(blockResults *rpc.CustomBlockResults) ([]eventTypes.E
 
 	return taxableEvents, nil
 }
+
+// ResolveSlashes sets the infraction height and the slash fraction of the validator slashes of the block, the slash events only
+// have the burned coins. Downtime is slashed at the validator power of the block before the last one, double signing at the
+// height of its evidence. Slash events without burned coins (before Cosmos SDK v0.50) burn the slash fraction of the tokens of
+// the validator power in the bond denom, assuming the default power reduction. They are dropped when their reason is unknown.
+func ResolveSlashes(cl *lensClient.ChainClient, height int64, blockEvents []eventTypes.EventRelevantInformation) ([]eventTypes.EventRelevantInformation, error) {
+	var params *slashingTypes.Params
+	var bondDenom string
+	var equivocationHeights map[string]int64
+	resolved := make([]eventTypes.EventRelevantInformation, 0, len(blockEvents))
+	for _, blockEvent := range blockEvents {
+		if blockEvent.Slash == nil {
+			resolved = append(resolved, blockEvent)
+			continue
+		}
+
+		if params == nil {
+			slashingParams, err := rpc.GetSlashingParamsAtHeight(cl, height-1)
+			if err != nil {
+				return nil, fmt.Errorf("error getting the slashing params: %w", err)
+			}
+			params = &slashingParams
+		}
+
+		var fraction sdkMath.LegacyDec
+		switch blockEvent.Slash.Reason {
+		case slashingTypes.AttributeValueMissingSignature:
+			fraction = params.SlashFractionDowntime
+			blockEvent.Slash.InfractionHeight = height - sdk.ValidatorUpdateDelay - 1
+		case slashingTypes.AttributeValueDoubleSign:
+			if equivocationHeights == nil {
+				var err error
+				equivocationHeights, err = getEquivocationHeights(cl, height)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			fraction = params.SlashFractionDoubleSign
+			infractionHeight, ok := equivocationHeights[blockEvent.Slash.ConsensusAddress]
+			if !ok {
+				config.Log.Warnf("[Block: %v] No evidence found for the double signing of %s, the slash only applies to the bonded tokens", height, blockEvent.Slash.ConsensusAddress)
+				infractionHeight = height
+			}
+			blockEvent.Slash.InfractionHeight = infractionHeight - sdk.ValidatorUpdateDelay
+		default:
+			config.Log.Warnf("[Block: %v] Unknown reason %s of the slash of %s, it is not attributed", height, blockEvent.Slash.Reason, blockEvent.Slash.ConsensusAddress)
+			if !blockEvent.Slash.BurnedUnknown {
+				resolved = append(resolved, blockEvent)
+			}
+			continue
+		}
+
+		slashFraction, err := decimal.NewFromString(fraction.String())
+		if err != nil {
+			return nil, err
+		}
+		blockEvent.Slash.Fraction = slashFraction
+
+		if blockEvent.Slash.BurnedUnknown {
+			if bondDenom == "" {
+				stakingParams, err := rpc.GetStakingParamsAtHeight(cl, height-1)
+				if err != nil {
+					return nil, fmt.Errorf("error getting the staking params: %w", err)
+				}
+				bondDenom = stakingParams.BondDenom
+			}
+			blockEvent.Denomination = bondDenom
+			blockEvent.Amount = SlashedTokens(blockEvent.Slash.Power, fraction).BigInt()
+		}
+
+		resolved = append(resolved, blockEvent)
+	}
+
+	return resolved, nil
+}
+
+// SlashedTokens returns the tokens burned by slashing the fraction of the validator power, the power is converted to tokens with
+// the default power reduction
+func SlashedTokens(power int64, fraction sdkMath.LegacyDec) sdkMath.Int {
+	tokens := sdk.TokensFromConsensusPower(power, sdk.DefaultPowerReduction)
+	return sdkMath.LegacyNewDecFromInt(tokens).Mul(fraction).TruncateInt()
+}
+
+// getEquivocationHeights returns the height of the latest double signing evidence of each hex consensus address
+func getEquivocationHeights(cl *lensClient.ChainClient, height int64) (map[string]int64, error) {
+	equivocations, err := rpc.GetEquivocationsAtHeight(cl, height)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the double signing evidence: %w", err)
+	}
+
+	heights := make(map[string]int64)
+	for _, equivocation := range equivocations {
+		_, addressBytes, err := bech32.DecodeAndConvert(equivocation.ConsensusAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		consensusAddress := strings.ToUpper(fmt.Sprintf("%x", addressBytes))
+		if equivocation.Height > heights[consensusAddress] {
+			heights[consensusAddress] = equivocation.Height
+		}
+	}
+
+	return heights, nil
+}
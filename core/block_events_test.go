@@ -0,0 +1,14 @@
+package core
+
+import (
+	"testing"
+
+	sdkMath "cosmossdk.io/math"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashedTokens(t *testing.T) {
+	// A validator with a power of 1000 has 1000000000 tokens, downtime burns 0.01% of them
+	assert.Equal(t, "100000", SlashedTokens(1000, sdkMath.LegacyMustNewDecFromStr("0.0001")).String())
+	assert.Equal(t, "50000000", SlashedTokens(1000, sdkMath.LegacyMustNewDecFromStr("0.05")).String())
+}
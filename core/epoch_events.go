@@ -5,17 +5,27 @@ import (
 
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	eventTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/events"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
 	osmosisTypes "github.com/DefiantLabs/cosmos-tax-cli/osmosis"
 	osmosisEpochTypes "github.com/DefiantLabs/cosmos-tax-cli/osmosis/epochs"
 	"github.com/DefiantLabs/cosmos-tax-cli/rpc"
+	strideTypes "github.com/DefiantLabs/cosmos-tax-cli/stride"
+	strideEpochTypes "github.com/DefiantLabs/cosmos-tax-cli/stride/epochs"
+	"github.com/DefiantLabs/lens/client"
 )
 
 var epochIdentifierEventTypeHandlers = map[string]map[string]map[string][]func() eventTypes.CosmosEvent{}
 
+// redemptionRateEpochIdentifiers are the epochs the liquid staking redemption rates are updated in
+var redemptionRateEpochIdentifiers = map[string]bool{}
+
 func ChainSpecificEpochIdentifierEventTypeHandlersBootstrap(chainID string) {
 	if chainID == osmosisTypes.ChainID {
 		// This is overwriting the entire map, but we only have one epoch module to worry about for now
 		epochIdentifierEventTypeHandlers = osmosisEpochTypes.EpochIdentifierBlockEventHandlers
+	} else if chainID == strideTypes.ChainID {
+		epochIdentifierEventTypeHandlers = strideEpochTypes.EpochIdentifierBlockEventHandlers
+		redemptionRateEpochIdentifiers = strideEpochTypes.RedemptionRateEpochIdentifiers
 	}
 }
 
@@ -88,3 +98,18 @@ func ProcessRPCEpochEvents(blockResults *rpc.CustomBlockResults, epochIdentifier
 
 	return taxableEvents, nil
 }
+
+// ProcessRPCEpochRedemptionRates returns the redemption rates of the liquid staking host zones at the start height of an epoch
+// they are updated in, the rates are not part of the block events
+func ProcessRPCEpochRedemptionRates(cl *client.ChainClient, height int64, epochIdentifier string) ([]dbTypes.RedemptionRate, error) {
+	if !redemptionRateEpochIdentifiers[epochIdentifier] {
+		return nil, nil
+	}
+
+	hostZones, err := rpc.GetStrideHostZones(cl, height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get the host zones at height %d: %w", height, err)
+	}
+
+	return strideEpochTypes.ParseRedemptionRates(hostZones)
+}
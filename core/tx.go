@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 	"unsafe"
@@ -14,9 +15,11 @@ import (
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/authz"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/bank"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/claim"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/gov"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/ibc"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/slashing"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
 	txtypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
@@ -24,7 +27,11 @@ import (
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmwasm"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmwasm/modules/wasm"
 	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/ethcrypto"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/modules/evm"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/concentratedliquidity"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/gamm"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/incentives"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/lockup"
@@ -33,14 +40,17 @@ import (
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/superfluid"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/tokenfactory"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/valsetpref"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride"
 	"github.com/DefiantLabs/cosmos-tax-cli/tendermint/modules/liquidity"
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 	"github.com/DefiantLabs/lens/client"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	cryptoTypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/types"
 	cosmosTx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
 	sdkMath "cosmossdk.io/math"
@@ -54,6 +64,7 @@ var messageTypeHandler = map[string][]func() txtypes.CosmosMessage{
 	distribution.MsgWithdrawDelegatorReward:     {func() txtypes.CosmosMessage { return &distribution.WrapperMsgWithdrawDelegatorReward{} }},
 	distribution.MsgWithdrawValidatorCommission: {func() txtypes.CosmosMessage { return &distribution.WrapperMsgWithdrawValidatorCommission{} }},
 	distribution.MsgFundCommunityPool:           {func() txtypes.CosmosMessage { return &distribution.WrapperMsgFundCommunityPool{} }},
+	distribution.MsgSetWithdrawAddress:          {func() txtypes.CosmosMessage { return &distribution.WrapperMsgSetWithdrawAddress{} }},
 	gov.MsgDeposit:                              {func() txtypes.CosmosMessage { return &gov.WrapperMsgDeposit{} }},
 	gov.MsgSubmitProposal:                       {func() txtypes.CosmosMessage { return &gov.WrapperMsgSubmitProposal{} }},
 	gov.MsgDepositV1:                            {func() txtypes.CosmosMessage { return &gov.WrapperMsgDepositV1{} }},
@@ -61,10 +72,27 @@ var messageTypeHandler = map[string][]func() txtypes.CosmosMessage{
 	staking.MsgDelegate:                         {func() txtypes.CosmosMessage { return &staking.WrapperMsgDelegate{} }},
 	staking.MsgUndelegate:                       {func() txtypes.CosmosMessage { return &staking.WrapperMsgUndelegate{} }},
 	staking.MsgBeginRedelegate:                  {func() txtypes.CosmosMessage { return &staking.WrapperMsgBeginRedelegate{} }},
+	staking.MsgCreateValidator:                  {func() txtypes.CosmosMessage { return &staking.WrapperMsgCreateValidator{} }},
+	staking.MsgEditValidator:                    {func() txtypes.CosmosMessage { return &staking.WrapperMsgEditValidator{} }},
+	staking.MsgCancelUnbondingDelegation:        {func() txtypes.CosmosMessage { return &staking.WrapperMsgCancelUnbondingDelegation{} }},
+	staking.MsgTokenizeShares:                   {func() txtypes.CosmosMessage { return &staking.WrapperMsgTokenizeShares{} }},
+	staking.MsgRedeemTokensForShares:            {func() txtypes.CosmosMessage { return &staking.WrapperMsgRedeemTokensForShares{} }},
+	staking.MsgTransferTokenizeShareRecord:      {func() txtypes.CosmosMessage { return &staking.WrapperMsgTransferTokenizeShareRecord{} }},
+	staking.MsgValidatorBond:                    {func() txtypes.CosmosMessage { return &staking.WrapperMsgValidatorBond{} }},
 	ibc.MsgRecvPacket:                           {func() txtypes.CosmosMessage { return &ibc.WrapperMsgRecvPacket{} }},
 	ibc.MsgAcknowledgement:                      {func() txtypes.CosmosMessage { return &ibc.WrapperMsgAcknowledgement{} }},
-	// Support is not fully built out for this message parser
-	// auction.MsgAuctionBid:                       {func() txtypes.CosmosMessage { return &auction.WrapperMsgAuctionBid{} }},
+	ibc.MsgTimeout:                              {func() txtypes.CosmosMessage { return &ibc.WrapperMsgTimeout{} }},
+	ibc.MsgTimeoutOnClose:                       {func() txtypes.CosmosMessage { return &ibc.WrapperMsgTimeout{} }},
+	auction.MsgAuctionBid:                       {func() txtypes.CosmosMessage { return &auction.WrapperMsgAuctionBid{} }},
+	nft.MsgSend:                                 {func() txtypes.CosmosMessage { return &nft.WrapperMsgSend{} }},
+	claim.StargazeMsgInitialClaim:               {func() txtypes.CosmosMessage { return &claim.WrapperMsgClaim{} }},
+	claim.StargazeMsgClaimFor:                   {func() txtypes.CosmosMessage { return &claim.WrapperMsgClaim{} }},
+	claim.CrescentMsgClaim:                      {func() txtypes.CosmosMessage { return &claim.WrapperMsgClaim{} }},
+	claim.QuicksilverMsgClaim:                   {func() txtypes.CosmosMessage { return &claim.WrapperMsgClaim{} }},
+	vesting.MsgCreateVestingAccount:             {func() txtypes.CosmosMessage { return &vesting.WrapperMsgCreateVestingAccount{} }},
+	vesting.MsgCreatePermanentLockedAccount:     {func() txtypes.CosmosMessage { return &vesting.WrapperMsgCreatePermanentLockedAccount{} }},
+	vesting.MsgCreatePeriodicVestingAccount:     {func() txtypes.CosmosMessage { return &vesting.WrapperMsgCreatePeriodicVestingAccount{} }},
+	ibc.MsgNFTTransfer:                          {func() txtypes.CosmosMessage { return &ibc.WrapperMsgNFTTransfer{} }},
 }
 
 // These messages are ignored for tax purposes.
@@ -80,10 +108,8 @@ var messageTypeIgnorer = map[string]interface{}{
 
 	// block-sdk auction module parameter updates are not taxable
 	auction.MsgUpdateParams: nil,
-	auction.MsgAuctionBid:   nil,
 
 	// Making a config change is not taxable
-	distribution.MsgSetWithdrawAddress: nil,
 	// Making a stableswap config change is not taxable
 	gamm.MsgStableSwapAdjustScalingFactors: nil,
 	// Voting is not taxable
@@ -94,8 +120,6 @@ var messageTypeIgnorer = map[string]interface{}{
 	// The IBC msgs below do not create taxable events
 	ibc.MsgTransfer:              nil,
 	ibc.MsgUpdateClient:          nil,
-	ibc.MsgTimeout:               nil,
-	ibc.MsgTimeoutOnClose:        nil,
 	ibc.MsgCreateClient:          nil,
 	ibc.MsgConnectionOpenTry:     nil,
 	ibc.MsgConnectionOpenConfirm: nil,
@@ -128,14 +152,15 @@ var messageTypeIgnorer = map[string]interface{}{
 	slashing.MsgUnjail:       nil,
 	slashing.MsgUpdateParams: nil,
 
+	// Toggling LSM tokenization and unbonding a validator do not move funds
+	staking.MsgDisableTokenizeShares: nil,
+	staking.MsgEnableTokenizeShares:  nil,
+	staking.MsgUnbondValidator:       nil,
+
 	// Smart accounts are being explored
 	smartaccount.MsgAddAuthenticator:    nil,
 	smartaccount.MsgRemoveAuthenticator: nil,
 
-	// Creating and editing validator is not taxable
-	staking.MsgCreateValidator:           nil,
-	staking.MsgEditValidator:             nil,
-	staking.MsgCancelUnbondingDelegation: nil, // No rewards are withdrawn when cancelling an unbonding delegation
 	// Delegating and Locking are not taxable
 	superfluid.MsgSuperfluidDelegate:                                    nil,
 	superfluid.MsgSuperfluidUndelegate:                                  nil,
@@ -151,9 +176,6 @@ var messageTypeIgnorer = map[string]interface{}{
 	// Setting validator pref is not taxable
 	valsetpref.MsgSetValidatorSetPreference: nil,
 
-	// Create account is not taxable
-	vesting.MsgCreateVestingAccount: nil,
-
 	// Tendermint Liquidity messages are actually executed in batches during periodic EndBlocker events
 	// We ignore the Message types since the actual taxable events happen later, and the messages can fail/be refunded
 	liquidity.MsgCreatePool:          nil,
@@ -192,9 +214,26 @@ var messageTypeIgnorer = map[string]interface{}{
 // Chain specific handlers will be registered BEFORE any generic handlers.
 func ChainSpecificMessageTypeHandlerBootstrap(chainID string, lensClient *client.ChainClient) {
 	var customContractAddressHandlers []wasm.ContractExecutionMessageHandler
+	var customContractHandlerResolvers []wasm.ContractExecutionMessageHandlerResolver
 	var chainSpecificMessageTpeHandler map[string][]func() txtypes.CosmosMessage
 	if chainID == osmosis.ChainID {
 		chainSpecificMessageTpeHandler = osmosis.MessageTypeHandler
+
+		cosmWasmPoolResolver, err := osmosis.GetCosmWasmPoolContractResolver(lensClient)
+		if err != nil {
+			config.Log.Fatal("Error getting Osmosis CosmWasm pool handlers.", err)
+		}
+		customContractHandlerResolvers = append(customContractHandlerResolvers, cosmWasmPoolResolver)
+	} else if chainID == stride.ChainID {
+		chainSpecificMessageTpeHandler = stride.MessageTypeHandler
+	} else if ethermint.IsEVMChain(chainID) {
+		chainSpecificMessageTpeHandler = ethermint.MessageTypeHandler
+
+		evmDenom, ok := ethermint.GetEVMDenom(chainID)
+		if !ok {
+			config.Log.Warnf("Unknown EVM denom for chain %s, MsgEthereumTx value transfers cannot be parsed", chainID)
+		}
+		evm.SetEVMDenom(evmDenom)
 	}
 
 	for key, value := range chainSpecificMessageTpeHandler {
@@ -205,7 +244,7 @@ func ChainSpecificMessageTypeHandlerBootstrap(chainID string, lensClient *client
 		}
 	}
 
-	cosmWasmHandlers, err := cosmwasm.GetCosmWasmMessageTypeHandlers(customContractAddressHandlers, lensClient)
+	cosmWasmHandlers, err := cosmwasm.GetCosmWasmMessageTypeHandlers(customContractAddressHandlers, customContractHandlerResolvers, lensClient)
 	if err != nil {
 		config.Log.Fatal("Error getting CosmWasm message type handlers.", err)
 	}
@@ -220,7 +259,7 @@ func ChainSpecificMessageTypeHandlerBootstrap(chainID string, lensClient *client
 }
 
 // ParseCosmosMessageJSON - Parse a SINGLE Cosmos Message into the appropriate type.
-func ParseCosmosMessage(message types.Msg, log txtypes.LogMessage) (txtypes.CosmosMessage, string, error) {
+func ParseCosmosMessage(message types.Msg, log txtypes.LogMessage, height int64, txEvents []txtypes.LogMessageEvent) (txtypes.CosmosMessage, string, error) {
 	var ok bool
 	var err error
 	var msgHandler txtypes.CosmosMessage
@@ -240,6 +279,12 @@ func ParseCosmosMessage(message types.Msg, log txtypes.LogMessage) (txtypes.Cosm
 		// Unmarshal the rest of the JSON now that we know the specific type.
 		// Note that depending on the type, it may or may not care about logs.
 		msgHandler = handlerFunc()
+		if heightAware, ok := msgHandler.(txtypes.HeightAwareMessage); ok {
+			heightAware.SetHeight(height)
+		}
+		if txEventsAware, ok := msgHandler.(txtypes.TxEventsAwareMessage); ok {
+			txEventsAware.SetTxEvents(txEvents)
+		}
 		err = msgHandler.HandleMsg(cosmosMessage.Type, message, &log)
 
 		// We're finished when a working handler is found
@@ -349,12 +394,16 @@ func ProcessRPCBlockByHeightTXs(db *gorm.DB, cl *client.ChainClient, blockResult
 			RawLog:    txResult.Log,
 			Log:       currLogMsgs,
 			Code:      txResult.Code,
+			TxEvents:  indexerEvents.ParseTxEventsWithoutMessageIndex(txResult.Events),
 		}
 
 		indexerTx.AuthInfo = *txFull.AuthInfo
 		txSigners, _, err := txFull.GetSigners(cl.Codec.Marshaler)
 		if err != nil {
-			return nil, blockTime, fmt.Errorf("error getting signers: %v", err)
+			txSigners, err = getEthereumTxSigners(txFull.GetMsgs(), err)
+			if err != nil {
+				return nil, blockTime, fmt.Errorf("error getting signers: %v", err)
+			}
 		}
 
 		for _, signer := range txSigners {
@@ -372,7 +421,7 @@ func ProcessRPCBlockByHeightTXs(db *gorm.DB, cl *client.ChainClient, blockResult
 
 		if len(indexerTx.Signers) > 0 {
 			processedTx.SignerAddress = dbTypes.Address{Address: indexerTx.Signers[0].String()}
-		} else {
+		} else if processedTx.SignerAddress.Address == "" {
 			return currTxDbWrappers, blockTime, fmt.Errorf("tx signers could not be processed, no signers found")
 		}
 
@@ -441,12 +490,16 @@ func ProcessRPCTXs(db *gorm.DB, cl *client.ChainClient, txEventResp *cosmosTx.Ge
 			RawLog:    currTxResp.RawLog,
 			Log:       currLogMsgs,
 			Code:      currTxResp.Code,
+			TxEvents:  indexerEvents.ParseTxEventsWithoutMessageIndex(currTxResp.Events),
 		}
 
 		indexerTx.AuthInfo = *currTx.AuthInfo
 		txSigners, _, err := currTx.GetSigners(cl.Codec.Marshaler)
 		if err != nil {
-			return nil, blockTime, fmt.Errorf("error getting signers: %v", err)
+			txSigners, err = getEthereumTxSigners(currMessages, err)
+			if err != nil {
+				return nil, blockTime, fmt.Errorf("error getting signers: %v", err)
+			}
 		}
 
 		for _, signer := range txSigners {
@@ -468,7 +521,7 @@ func ProcessRPCTXs(db *gorm.DB, cl *client.ChainClient, txEventResp *cosmosTx.Ge
 
 		if len(indexerTx.Signers) > 0 {
 			processedTx.SignerAddress = dbTypes.Address{Address: indexerTx.Signers[0].String()}
-		} else {
+		} else if processedTx.SignerAddress.Address == "" {
 			return currTxDbWrappers, blockTime, fmt.Errorf("tx signers could not be processed, no signers found")
 		}
 
@@ -478,6 +531,27 @@ func ProcessRPCTXs(db *gorm.DB, cl *client.ChainClient, txEventResp *cosmosTx.Ge
 	return currTxDbWrappers, blockTime, nil
 }
 
+// getEthereumTxSigners returns the senders of an Ethereum tx, which has no Cosmos signers. The senders are empty when the
+// messages do not set them, the signer is then the EVM sender in the message log. Other txs return the signers error.
+func getEthereumTxSigners(msgs []types.Msg, signersErr error) ([][]byte, error) {
+	if len(msgs) == 0 {
+		return nil, signersErr
+	}
+
+	var signers [][]byte
+	for _, msg := range msgs {
+		if !evm.IsMsgEthereumTx(types.MsgTypeURL(msg)) {
+			return nil, signersErr
+		}
+
+		if sender := evm.GetMsgSender(msg); sender != nil && len(signers) == 0 {
+			signers = append(signers, sender)
+		}
+	}
+
+	return signers, nil
+}
+
 var allSwaps = []gamm.ArbitrageTx{}
 
 func AnalyzeSwaps() {
@@ -513,9 +587,17 @@ func ProcessTx(cl *client.ChainClient, db *gorm.DB, tx txtypes.MergedTx) (txDBWa
 		return txDBWapper, txTime, err
 	}
 
+	height, err := strconv.ParseInt(tx.TxResponse.Height, 10, 64)
+	if err != nil {
+		config.Log.Error("Error parsing tx height.", err)
+		return txDBWapper, txTime, err
+	}
+
 	code := tx.TxResponse.Code
 
 	var messages []dbTypes.MessageDBWrapper
+	var taxableEvents []dbTypes.TaxableEvent
+	var evmFeePayer string
 
 	// non-zero code means the Tx was unsuccessful. We will still need to account for fees in both cases though.
 	if code == 0 {
@@ -527,7 +609,7 @@ func ProcessTx(cl *client.ChainClient, db *gorm.DB, tx txtypes.MergedTx) (txDBWa
 			// Get the message log that corresponds to the current message
 			var currMessageDBWrapper dbTypes.MessageDBWrapper
 			messageLog := txtypes.GetMessageLogForIndex(tx.TxResponse.Log, messageIndex)
-			cosmosMessage, msgType, err := ParseCosmosMessage(message, *messageLog)
+			cosmosMessage, msgType, err := ParseCosmosMessage(message, *messageLog, height, tx.TxResponse.TxEvents)
 			if err != nil {
 				currMessageType.MessageType = msgType
 				currMessage.MessageType = currMessageType
@@ -565,30 +647,20 @@ func ProcessTx(cl *client.ChainClient, db *gorm.DB, tx txtypes.MergedTx) (txDBWa
 						}
 
 						if v.DenominationSent != "" {
-							denomSent, err := getDenom(v.DenominationSent)
+							denomSent, err := getOrAddTxDenom(db, v.DenominationSent)
 							if err != nil {
-								// attempt to add missing denoms to the database
-								config.Log.Warnf("Denom lookup failed. Will be inserted as UNKNOWN. Denom Sent: %v. Err: %v", denomSent.Base, err)
-								denomSent, err = dbTypes.AddUnknownDenom(db, denomSent.Base)
-								if err != nil {
-									config.Log.Error(fmt.Sprintf("There was an error adding a missing denom. Denom sent: %v", denomSent.Base), err)
-									return txDBWapper, txTime, err
-								}
+								config.Log.Error(fmt.Sprintf("There was an error adding a missing denom. Denom sent: %v", v.DenominationSent), err)
+								return txDBWapper, txTime, err
 							}
 
 							taxableTxs[i].TaxableTx.DenominationSent = denomSent
 						}
 
 						if v.DenominationReceived != "" {
-							denomReceived, err := getDenom(v.DenominationReceived)
+							denomReceived, err := getOrAddTxDenom(db, v.DenominationReceived)
 							if err != nil {
-								// attempt to add missing denoms to the database
-								config.Log.Warnf("Denom lookup failed. Will be inserted as UNKNOWN. Denom Received: %v. Err: %v", denomReceived.Base, err)
-								denomReceived, err = dbTypes.AddUnknownDenom(db, denomReceived.Base)
-								if err != nil {
-									config.Log.Error(fmt.Sprintf("There was an error adding a missing denom. Denom received: %v", denomReceived.Base), err)
-									return txDBWapper, txTime, err
-								}
+								config.Log.Error(fmt.Sprintf("There was an error adding a missing denom. Denom received: %v", v.DenominationReceived), err)
+								return txDBWapper, txTime, err
 							}
 
 							taxableTxs[i].TaxableTx.DenominationReceived = denomReceived
@@ -596,11 +668,104 @@ func ProcessTx(cl *client.ChainClient, db *gorm.DB, tx txtypes.MergedTx) (txDBWa
 
 						taxableTxs[i].SenderAddress = dbTypes.Address{Address: strings.ToLower(v.SenderAddress)}
 						taxableTxs[i].ReceiverAddress = dbTypes.Address{Address: strings.ToLower(v.ReceiverAddress)}
+						taxableTxs[i].EarnerAddress = dbTypes.Address{Address: strings.ToLower(v.EarnerAddress)}
+						taxableTxs[i].TaxableTx.ValidatorAddress = v.ValidatorAddress
 					}
 					currMessageDBWrapper.TaxableTxs = taxableTxs
 				} else {
 					currMessageDBWrapper.TaxableTxs = []dbTypes.TaxableTxDBWrapper{}
 				}
+
+				// Concentrated liquidity messages also record the position history outside of the taxable txs
+				if positionLedgerMessage, ok := cosmosMessage.(concentratedliquidity.PositionLedgerMessage); ok {
+					clPositionEvents, err := toCLPositionEventDBWrappers(db, positionLedgerMessage.ParsePositionLedger())
+					if err != nil {
+						config.Log.Error(fmt.Sprintf("[Block: %v] Error processing CL position ledger for msg of type '%v'.", tx.TxResponse.Height, msgType), err)
+						return txDBWapper, txTime, err
+					}
+					currMessageDBWrapper.CLPositionEvents = clPositionEvents
+				}
+
+				// Auction bids link the bundled txs back to the bid message
+				if bundledTxsMessage, ok := cosmosMessage.(auction.BundledTxsMessage); ok {
+					currMessageDBWrapper.BundledTxHashes = bundledTxsMessage.GetBundledTxHashes()
+				}
+
+				// NFT transfers are recorded in the NFT transfer ledger, sales are linked to their payment leg
+				if transferMessage, ok := cosmosMessage.(nft.TransferMessage); ok {
+					currMessageDBWrapper.NFTTransfers = toNFTTransferDBWrappers(transferMessage.ParseNFTTransfers())
+				}
+
+				// Vesting account creations store the vesting schedule, the unlocks are computed from it
+				if scheduleMessage, ok := cosmosMessage.(vesting.ScheduleMessage); ok {
+					vestingAccount, err := toVestingAccountDBWrapper(db, scheduleMessage.ParseVestingSchedule(), txTime)
+					if err != nil {
+						config.Log.Error(fmt.Sprintf("[Block: %v] Error processing vesting schedule for msg of type '%v'.", tx.TxResponse.Height, msgType), err)
+						return txDBWapper, txTime, err
+					}
+					currMessageDBWrapper.VestingAccount = &vestingAccount
+				}
+
+				// Gov deposits are stored per proposal, burned deposits are attributed to their depositors from them
+				if depositMessage, ok := cosmosMessage.(gov.DepositMessage); ok {
+					if deposit := depositMessage.ParseDeposit(); len(deposit.Amount) != 0 {
+						govDeposit, err := toGovDepositDBWrapper(db, deposit)
+						if err != nil {
+							config.Log.Error(fmt.Sprintf("[Block: %v] Error processing gov deposit for msg of type '%v'.", tx.TxResponse.Height, msgType), err)
+							return txDBWapper, txTime, err
+						}
+						currMessageDBWrapper.GovDeposit = &govDeposit
+					}
+				}
+
+				// Delegation changes are stored to attribute validator slashes to the delegators
+				if delegationMessage, ok := cosmosMessage.(staking.DelegationMessage); ok {
+					delegationChanges, err := toDelegationChangeDBWrappers(db, delegationMessage.ParseDelegationChanges())
+					if err != nil {
+						config.Log.Error(fmt.Sprintf("[Block: %v] Error processing delegation changes for msg of type '%v'.", tx.TxResponse.Height, msgType), err)
+						return txDBWapper, txTime, err
+					}
+					currMessageDBWrapper.DelegationChanges = delegationChanges
+				}
+
+				if validatorMessage, ok := cosmosMessage.(staking.ValidatorMessage); ok {
+					operatorAddress, consensusAddress := validatorMessage.GetValidatorAddresses()
+					currMessageDBWrapper.Validator = &dbTypes.Validator{
+						OperatorAddress:  operatorAddress,
+						ConsensusAddress: consensusAddress,
+						Moniker:          validatorMessage.GetValidatorMoniker(),
+					}
+				}
+
+				// Withdraw addresses are tracked per delegator, rewards record the earning delegator next to the receiver
+				if withdrawAddressMessage, ok := cosmosMessage.(distribution.WithdrawAddressMessage); ok {
+					delegator, withdrawAddress := withdrawAddressMessage.GetWithdrawAddress()
+					currMessageDBWrapper.WithdrawAddress = &dbTypes.WithdrawAddressDBWrapper{
+						DelegatorAddress: dbTypes.Address{Address: strings.ToLower(delegator)},
+						WithdrawAddress:  dbTypes.Address{Address: strings.ToLower(withdrawAddress)},
+					}
+				}
+
+				// Ethereum txs pay the fees from the EVM sender
+				if feePayerMessage, ok := cosmosMessage.(evm.FeePayerMessage); ok && evmFeePayer == "" {
+					evmFeePayer = feePayerMessage.GetFeePayer()
+				}
+			}
+
+			// Airdrops claimed through the claim module hooks (e.g. on vote or delegate) happen in messages that are not claims
+			if _, ok := cosmosMessage.(claim.AirdropClaimMessage); !ok && messageLog != nil {
+				hookClaims, err := claim.ParseHookClaims(msgType, messageLog)
+				if err != nil {
+					config.Log.Error(fmt.Sprintf("[Block: %v] Error parsing hook airdrop claims for msg of type '%v'.", tx.TxResponse.Height, msgType), err)
+					return txDBWapper, txTime, err
+				}
+
+				hookClaimEvents, err := toTaxableEvents(db, hookClaims)
+				if err != nil {
+					config.Log.Error(fmt.Sprintf("[Block: %v] Error processing hook airdrop claims for msg of type '%v'.", tx.TxResponse.Height, msgType), err)
+					return txDBWapper, txTime, err
+				}
+				taxableEvents = append(taxableEvents, hookClaimEvents...)
 			}
 
 			if msgSwapExactIn, ok := cosmosMessage.(*gamm.WrapperMsgSwapExactAmountIn); ok {
@@ -616,8 +781,20 @@ func ProcessTx(cl *client.ChainClient, db *gorm.DB, tx txtypes.MergedTx) (txDBWa
 		return txDBWapper, txTime, err
 	}
 
+	if evmFeePayer != "" {
+		for i := range fees {
+			if fees[i].PayerAddress.Address == "" {
+				fees[i].PayerAddress.Address = evmFeePayer
+			}
+		}
+
+		// The EVM sender is the signer of Ethereum txs that do not set it in the message
+		txDBWapper.SignerAddress = dbTypes.Address{Address: evmFeePayer}
+	}
+
 	txDBWapper.Tx = dbTypes.Tx{Hash: tx.TxResponse.TxHash, Fees: fees, Code: code}
 	txDBWapper.Messages = messages
+	txDBWapper.TaxableEvents = taxableEvents
 
 	return txDBWapper, txTime, nil
 }
@@ -648,8 +825,28 @@ func ProcessFees(cl *client.ChainClient, db *gorm.DB, authInfo cosmosTx.AuthInfo
 			if payer != "" {
 				payerAddr.Address = payer
 			} else {
-				if authInfo.SignerInfos[0].PublicKey == nil && len(signers) > 0 {
+				// Ethereum txs have no signer infos, the payer is the EVM sender set by the caller
+				if len(authInfo.SignerInfos) == 0 {
+					if len(signers) > 0 {
+						payerAddr.Address = signers[0].String()
+					}
+				} else if authInfo.SignerInfos[0].PublicKey == nil && len(signers) > 0 {
 					payerAddr.Address = signers[0].String()
+				} else if ethermint.IsEthSecp256k1PubKeyType(authInfo.SignerInfos[0].PublicKey.TypeUrl) {
+					// The ethsecp256k1 keys share the layout of secp256k1 keys, but their addresses are derived the Ethereum way
+					var ethPubKey secp256k1.PubKey
+					err := ethPubKey.Unmarshal(authInfo.SignerInfos[0].PublicKey.Value)
+					if err != nil {
+						return nil, err
+					}
+
+					hexPub := hex.EncodeToString(ethPubKey.Bytes())
+					bechAddr, err := ParseSignerAddress(hexPub, ethcrypto.KeyType)
+					if err != nil {
+						config.Log.Error(fmt.Sprintf("Error parsing signer address '%v' for tx.", hexPub), err)
+					} else {
+						payerAddr.Address = bechAddr
+					}
 				} else {
 					var pubKey cryptoTypes.PubKey
 
@@ -687,37 +884,218 @@ func ProcessFees(cl *client.ChainClient, db *gorm.DB, authInfo cosmosTx.AuthInfo
 	return fees, nil
 }
 
-// getDenom handles denom processing for both IBC denoms and native denoms.
-// If the denom begins with ibc/ we know this is an IBC denom trace, and it's not guaranteed there is an entry in
-// the Denom table.
-func getDenom(denom string) (dbTypes.Denom, error) {
-	var (
-		denomSent dbTypes.Denom
-		err       error
-	)
-
-	// if this is an ibc denom trace, get the ibc denom then use the base denom to get the Denom from the db
-	if strings.HasPrefix(denom, "ibc/") {
-		ibcDenom, err := dbTypes.GetIBCDenom(denom)
+// getOrAddTxDenom resolves the denom of a taxable tx leg like GetOrAddDenom. LSM tokenize share record denoms have no bank
+// metadata, they are named after the validator and record when first seen instead.
+func getOrAddTxDenom(db *gorm.DB, base string) (dbTypes.Denom, error) {
+	validator, recordID, ok := staking.ParseTokenizeShareDenom(base)
+	if !ok {
+		return dbTypes.GetOrAddDenom(db, base)
+	}
+
+	if denom, err := dbTypes.GetDenomForBase(base); err == nil {
+		return denom, nil
+	}
+
+	return dbTypes.AddNamedDenom(db, base, fmt.Sprintf("Tokenized shares of %s (record %d)", validator, recordID), base)
+}
+
+// toTaxableEvents converts the taxable events found in a message log into DB events, resolving their denoms
+func toTaxableEvents(db *gorm.DB, relevantData []indexerEvents.EventRelevantInformation) ([]dbTypes.TaxableEvent, error) {
+	taxableEvents := make([]dbTypes.TaxableEvent, 0, len(relevantData))
+
+	for _, v := range relevantData {
+		denom, err := dbTypes.GetOrAddDenom(db, v.Denomination)
 		if err != nil {
-			config.Log.Warnf("IBC Denom lookup failed for  %s, err: %v", denom, err)
-		} else {
-			denomSent, err = dbTypes.GetDenomForBase(ibcDenom.BaseDenom)
+			return nil, err
+		}
+
+		taxableEvents = append(taxableEvents, dbTypes.TaxableEvent{
+			Source:       v.EventSource,
+			Amount:       util.ToNumeric(v.Amount),
+			Denomination: denom,
+			EventAddress: dbTypes.Address{Address: strings.ToLower(v.Address)},
+		})
+	}
+
+	return taxableEvents, nil
+}
+
+// toVestingAccountDBWrapper converts the vesting schedule into a DB wrapper. Accounts without a start time in the message start
+// vesting at the block time of their creation.
+func toVestingAccountDBWrapper(db *gorm.DB, schedule vesting.Schedule, blockTime time.Time) (dbTypes.VestingAccountDBWrapper, error) {
+	vestingAccount := dbTypes.VestingAccountDBWrapper{
+		Account: dbTypes.VestingAccount{
+			Type:      schedule.Type,
+			StartTime: schedule.StartTime,
+			EndTime:   schedule.EndTime,
+		},
+		Address:       dbTypes.Address{Address: strings.ToLower(schedule.Address)},
+		FunderAddress: dbTypes.Address{Address: strings.ToLower(schedule.Funder)},
+	}
+
+	if vestingAccount.Account.StartTime.IsZero() {
+		vestingAccount.Account.StartTime = blockTime.UTC()
+	}
+
+	// Permanently locked accounts never end vesting
+	if schedule.Type == vesting.AccountTypePermanentLocked {
+		vestingAccount.Account.EndTime = vestingAccount.Account.StartTime
+	}
+
+	denoms := map[string]dbTypes.Denom{}
+	lookupDenom := func(base string) (dbTypes.Denom, error) {
+		if denom, ok := denoms[base]; ok {
+			return denom, nil
+		}
+
+		denom, err := dbTypes.GetOrAddDenom(db, base)
+		if err != nil {
+			return denom, err
+		}
+
+		denoms[base] = denom
+		return denom, nil
+	}
+
+	for _, coin := range schedule.Amount {
+		denom, err := lookupDenom(coin.Denom)
+		if err != nil {
+			return vestingAccount, err
+		}
+
+		vestingAccount.Account.Amounts = append(vestingAccount.Account.Amounts, dbTypes.VestingAmount{
+			Amount:       util.ToNumeric(coin.Amount.BigInt()),
+			Denomination: denom,
+		})
+	}
+
+	for i, period := range schedule.Periods {
+		for _, coin := range period.Amount {
+			denom, err := lookupDenom(coin.Denom)
 			if err != nil {
-				config.Log.Warnf("Denom lookup failed for IBC base denom %s, err: %v", ibcDenom.BaseDenom, err)
-				return dbTypes.Denom{Base: ibcDenom.BaseDenom}, err
+				return vestingAccount, err
 			}
+
+			vestingAccount.Account.Periods = append(vestingAccount.Account.Periods, dbTypes.VestingPeriod{
+				PeriodIndex:  i,
+				Length:       int64(period.Length / time.Second),
+				Amount:       util.ToNumeric(coin.Amount.BigInt()),
+				Denomination: denom,
+			})
 		}
 	}
 
-	// if this is not an ibc denom trace or there was an issue querying the ibc denom trace in the other table,
-	// attempt to look up this denom in the regular Denom table
-	if denomSent.Base == "" {
-		denomSent, err = dbTypes.GetDenomForBase(denom)
+	return vestingAccount, nil
+}
+
+// toGovDepositDBWrapper converts the gov deposit into a DB wrapper with a deposit per denom
+func toGovDepositDBWrapper(db *gorm.DB, deposit gov.Deposit) (dbTypes.GovDepositDBWrapper, error) {
+	govDeposit := dbTypes.GovDepositDBWrapper{
+		Depositor: dbTypes.Address{Address: strings.ToLower(deposit.Depositor)},
+	}
+
+	for _, coin := range deposit.Amount {
+		denom, err := dbTypes.GetOrAddDenom(db, coin.Denom)
+		if err != nil {
+			return govDeposit, err
+		}
+
+		govDeposit.Deposits = append(govDeposit.Deposits, dbTypes.GovDeposit{
+			ProposalID:   deposit.ProposalID,
+			Amount:       util.ToNumeric(coin.Amount.BigInt()),
+			Denomination: denom,
+		})
+	}
+
+	return govDeposit, nil
+}
+
+// toDelegationChangeDBWrappers converts the delegation changes into DB wrappers
+func toDelegationChangeDBWrappers(db *gorm.DB, changes []staking.DelegationChange) ([]dbTypes.DelegationChangeDBWrapper, error) {
+	delegationChanges := make([]dbTypes.DelegationChangeDBWrapper, 0, len(changes))
+
+	for _, change := range changes {
+		denom, err := dbTypes.GetOrAddDenom(db, change.Denom)
 		if err != nil {
-			return dbTypes.Denom{Base: denom}, err
+			return delegationChanges, err
+		}
+
+		delegationChanges = append(delegationChanges, dbTypes.DelegationChangeDBWrapper{
+			Change: dbTypes.DelegationChange{
+				ValidatorAddress: change.Validator,
+				Amount:           util.ToNumeric(change.Amount),
+				Denomination:     denom,
+			},
+			DelegatorAddress: dbTypes.Address{Address: strings.ToLower(change.Delegator)},
+		})
+	}
+
+	return delegationChanges, nil
+}
+
+// toNFTTransferDBWrappers converts the parsed NFT transfers into DB wrappers, the transfers are in the order of their events
+func toNFTTransferDBWrappers(transfers []nft.Transfer) []dbTypes.NFTTransferDBWrapper {
+	nftTransfers := make([]dbTypes.NFTTransferDBWrapper, 0, len(transfers))
+
+	for i, transfer := range transfers {
+		nftTransfers = append(nftTransfers, dbTypes.NFTTransferDBWrapper{
+			NFT:             dbTypes.NFT{ClassID: transfer.ClassID, TokenID: transfer.TokenID},
+			Transfer:        dbTypes.NFTTransfer{EventIndex: i, Action: transfer.Action},
+			SenderAddress:   dbTypes.Address{Address: strings.ToLower(transfer.Sender)},
+			ReceiverAddress: dbTypes.Address{Address: strings.ToLower(transfer.Receiver)},
+			PaymentLegIndex: transfer.PaymentLegIndex,
+		})
+	}
+
+	return nftTransfers
+}
+
+// toCLPositionEventDBWrappers converts the parsed position ledger into DB wrappers, resolving the denoms of any tokens moved
+func toCLPositionEventDBWrappers(db *gorm.DB, entries []concentratedliquidity.PositionLedgerEntry) ([]dbTypes.CLPositionEventDBWrapper, error) {
+	clPositionEvents := make([]dbTypes.CLPositionEventDBWrapper, 0, len(entries))
+
+	for _, entry := range entries {
+		clPositionEvent := dbTypes.CLPositionEventDBWrapper{
+			Position: dbTypes.CLPosition{
+				PositionID: entry.PositionID,
+				PoolID:     entry.PoolID,
+				LowerTick:  entry.LowerTick,
+				UpperTick:  entry.UpperTick,
+			},
+			Event:        dbTypes.CLPositionEvent{Action: entry.Action},
+			OwnerAddress: dbTypes.Address{Address: strings.ToLower(entry.Owner)},
+		}
+
+		if !entry.Liquidity.IsNil() {
+			liquidity, err := decimal.NewFromString(entry.Liquidity.String())
+			if err != nil {
+				return nil, err
+			}
+			clPositionEvent.Event.Liquidity = liquidity
+		}
+
+		if entry.NewOwner != "" {
+			clPositionEvent.NewOwnerAddress = dbTypes.Address{Address: strings.ToLower(entry.NewOwner)}
 		}
+
+		for _, token := range entry.Tokens {
+			if !token.Amount.IsPositive() {
+				continue
+			}
+
+			denom, err := dbTypes.GetOrAddDenom(db, token.Denom)
+			if err != nil {
+				return nil, err
+			}
+
+			clPositionEvent.Amounts = append(clPositionEvent.Amounts, dbTypes.CLPositionEventAmount{
+				Amount:       util.ToNumeric(token.Amount.BigInt()),
+				Denomination: denom,
+			})
+		}
+
+		clPositionEvents = append(clPositionEvents, clPositionEvent)
 	}
 
-	return denomSent, nil
+	return clPositionEvents, nil
 }
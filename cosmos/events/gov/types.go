@@ -0,0 +1,174 @@
+package gov
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/events"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	abciTypes "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govTypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+// The gov EndBlocker emits these events once the deposit or voting period of a proposal ends. The deposits are refunded
+// (transfers from the gov module account) or burned (burns by the gov module account) before the event is emitted.
+const (
+	BlockEventActiveProposal   = govTypes.EventTypeActiveProposal
+	BlockEventInactiveProposal = govTypes.EventTypeInactiveProposal
+)
+
+var govModuleAddress = authTypes.NewModuleAddress(govTypes.ModuleName)
+
+// Refund is a deposit returned by the gov module account to its depositor
+type Refund struct {
+	Depositor string
+	Amount    sdk.Coins
+}
+
+type WrapperBlockEventProposalResult struct {
+	Event           abciTypes.Event
+	EventType       string
+	ProposalID      uint64
+	ProposalResult  string
+	Refunds         []Refund
+	Burned          sdk.Coins
+	precedingEvents []abciTypes.Event
+}
+
+func (sf *WrapperBlockEventProposalResult) GetType() string {
+	return sf.EventType
+}
+
+func (sf *WrapperBlockEventProposalResult) SetPrecedingEvents(precedingEvents []abciTypes.Event) {
+	sf.precedingEvents = precedingEvents
+}
+
+func (sf *WrapperBlockEventProposalResult) HandleEvent(eventType string, event abciTypes.Event) error {
+	sf.Event = event
+	sf.EventType = eventType
+
+	var proposalID string
+	for _, attribute := range event.Attributes {
+		switch attribute.Key {
+		case govTypes.AttributeKeyProposalID:
+			proposalID = attribute.Value
+		case govTypes.AttributeKeyProposalResult:
+			sf.ProposalResult = attribute.Value
+		}
+	}
+
+	if proposalID == "" {
+		return errors.New("proposal ID not found in proposal result event")
+	}
+
+	var err error
+	sf.ProposalID, err = strconv.ParseUint(proposalID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	// The refunds and burns of this proposal are emitted after the result of the previous proposal in the block
+	for i := len(sf.precedingEvents) - 1; i >= 0; i-- {
+		precedingEvent := sf.precedingEvents[i]
+		if precedingEvent.Type == BlockEventActiveProposal || precedingEvent.Type == BlockEventInactiveProposal {
+			break
+		}
+
+		switch precedingEvent.Type {
+		case bankTypes.EventTypeTransfer:
+			attributes := getAttributes(precedingEvent)
+			if !isGovModuleAddress(attributes[bankTypes.AttributeKeySender]) {
+				continue
+			}
+
+			amount, err := sdk.ParseCoinsNormalized(attributes[sdk.AttributeKeyAmount])
+			if err != nil {
+				return err
+			}
+
+			// Prepend to keep the refunds in event order
+			sf.Refunds = append([]Refund{{Depositor: attributes[bankTypes.AttributeKeyRecipient], Amount: amount}}, sf.Refunds...)
+		case bankTypes.EventTypeCoinBurn:
+			attributes := getAttributes(precedingEvent)
+			if !isGovModuleAddress(attributes[bankTypes.AttributeKeyBurner]) {
+				continue
+			}
+
+			amount, err := sdk.ParseCoinsNormalized(attributes[sdk.AttributeKeyAmount])
+			if err != nil {
+				return err
+			}
+
+			sf.Burned = sf.Burned.Add(amount...)
+		}
+	}
+
+	return nil
+}
+
+// ParseRelevantData returns the refunds for their depositors and the burned deposits of the proposal. The burns are attributed
+// to the depositors of the proposal when their taxable events are searched.
+func (sf *WrapperBlockEventProposalResult) ParseRelevantData() []events.EventRelevantInformation {
+	var relevantData []events.EventRelevantInformation
+
+	for _, refund := range sf.Refunds {
+		for _, coin := range refund.Amount {
+			relevantData = append(relevantData, events.EventRelevantInformation{
+				Address:      refund.Depositor,
+				Amount:       coin.Amount.BigInt(),
+				Denomination: coin.Denom,
+				EventSource:  dbTypes.GovDepositRefund,
+				ProposalID:   sf.ProposalID,
+			})
+		}
+	}
+
+	for _, coin := range sf.Burned {
+		relevantData = append(relevantData, events.EventRelevantInformation{
+			Amount:       coin.Amount.BigInt(),
+			Denomination: coin.Denom,
+			EventSource:  dbTypes.GovDepositBurn,
+			ProposalID:   sf.ProposalID,
+		})
+	}
+
+	return relevantData
+}
+
+func (sf *WrapperBlockEventProposalResult) String() string {
+	var refunded sdk.Coins
+	for _, refund := range sf.Refunds {
+		refunded = refunded.Add(refund.Amount...)
+	}
+
+	return fmt.Sprintf("Gov %s: proposal %d ended with %s, deposits refunded: %s, deposits burned: %s",
+		sf.EventType, sf.ProposalID, sf.ProposalResult, refunded, sf.Burned)
+}
+
+func getAttributes(event abciTypes.Event) map[string]string {
+	attributes := map[string]string{}
+	for _, attribute := range event.Attributes {
+		attributes[attribute.Key] = attribute.Value
+	}
+	return attributes
+}
+
+// isGovModuleAddress compares the address bytes, the gov module account has the same bytes on every chain
+func isGovModuleAddress(address string) bool {
+	if address == "" {
+		return false
+	}
+
+	_, addressBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(addressBytes, govModuleAddress)
+}
@@ -0,0 +1,53 @@
+package gov
+
+import (
+	"testing"
+
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	abciTypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProposalResultRefundsAndBurns(t *testing.T) {
+	govAddress, err := bech32.ConvertAndEncode("cosmos", govModuleAddress)
+	assert.Nil(t, err)
+
+	blockEvents := []abciTypes.Event{
+		// Refund of the previous proposal
+		{Type: "transfer", Attributes: []abciTypes.EventAttribute{
+			{Key: "recipient", Value: "cosmos1other"}, {Key: "sender", Value: govAddress}, {Key: "amount", Value: "5uatom"},
+		}},
+		{Type: BlockEventActiveProposal, Attributes: []abciTypes.EventAttribute{
+			{Key: "proposal_id", Value: "1"}, {Key: "proposal_result", Value: "proposal_passed"},
+		}},
+		{Type: "transfer", Attributes: []abciTypes.EventAttribute{
+			{Key: "recipient", Value: "cosmos1depositor"}, {Key: "sender", Value: govAddress}, {Key: "amount", Value: "10uatom"},
+		}},
+		{Type: "transfer", Attributes: []abciTypes.EventAttribute{
+			{Key: "recipient", Value: "cosmos1depositor"}, {Key: "sender", Value: "cosmos1notgov"}, {Key: "amount", Value: "7uatom"},
+		}},
+		{Type: "burn", Attributes: []abciTypes.EventAttribute{
+			{Key: "burner", Value: govAddress}, {Key: "amount", Value: "20uatom"},
+		}},
+		{Type: BlockEventActiveProposal, Attributes: []abciTypes.EventAttribute{
+			{Key: "proposal_id", Value: "2"}, {Key: "proposal_result", Value: "proposal_rejected"},
+		}},
+	}
+
+	wrapper := &WrapperBlockEventProposalResult{}
+	wrapper.SetPrecedingEvents(blockEvents[:5])
+	assert.Nil(t, wrapper.HandleEvent(BlockEventActiveProposal, blockEvents[5]))
+	assert.Equal(t, uint64(2), wrapper.ProposalID)
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 2)
+	assert.Equal(t, "cosmos1depositor", relevantData[0].Address)
+	assert.Equal(t, int64(10), relevantData[0].Amount.Int64())
+	assert.Equal(t, dbTypes.GovDepositRefund, relevantData[0].EventSource)
+	assert.Equal(t, uint64(2), relevantData[0].ProposalID)
+	assert.Equal(t, "", relevantData[1].Address)
+	assert.Equal(t, int64(20), relevantData[1].Amount.Int64())
+	assert.Equal(t, dbTypes.GovDepositBurn, relevantData[1].EventSource)
+	assert.Equal(t, uint64(2), relevantData[1].ProposalID)
+}
@@ -87,3 +87,26 @@ func ParseTxEventsToMessageIndexEvents(numMessages int, events []cometAbciTypes.
 
 	return parsedLogs, nil
 }
+
+// ParseTxEventsWithoutMessageIndex returns the tx events that are not part of a message, e.g. the fee deduction and the
+// other events of the ante handler. Events before Cosmos SDK v0.50 carry no msg_index, so the message events cannot be told
+// apart from the others and no events are returned.
+func ParseTxEventsWithoutMessageIndex(events []cometAbciTypes.Event) []txtypes.LogMessageEvent {
+	var txEvents []txtypes.LogMessageEvent
+	indexed := false
+	for _, event := range toNormalizedEvents(events) {
+		loopEvent := event
+		val, err := txtypes.GetValueForAttribute("msg_index", &loopEvent)
+		if err == nil && val != "" {
+			indexed = true
+			continue
+		}
+		txEvents = append(txEvents, event)
+	}
+
+	if !indexed {
+		return nil
+	}
+
+	return txEvents
+}
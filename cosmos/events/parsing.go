@@ -1,10 +1,31 @@
 package events
 
-import "math/big"
+import (
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
 
 type EventRelevantInformation struct {
 	Address      string
 	Amount       *big.Int
 	Denomination string
 	EventSource  uint
+	// Set on gov deposit refunds and burns, the burn has no depositor and is split between the depositors of the proposal when searched
+	ProposalID uint64
+	// Set on validator slashes, the slash has no delegator and is split between the indexed delegators of the validator when searched
+	Slash *SlashInformation
+}
+
+// SlashInformation identifies the slashed validator by its hex consensus address. The infraction height and the slash fraction
+// are not part of the slash event, they are resolved from the slashing params and the evidence of the chain.
+type SlashInformation struct {
+	ConsensusAddress string
+	Reason           string
+	InfractionHeight int64
+	Fraction         decimal.Decimal
+	// Slash events before Cosmos SDK v0.50 have no burned coins, the tokens burned are computed from the validator power and
+	// the slash fraction when the slash is resolved
+	Power         int64
+	BurnedUnknown bool
 }
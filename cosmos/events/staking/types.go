@@ -0,0 +1,208 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/events"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	abciTypes "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// The staking EndBlocker completes the matured unbondings and redelegations, the slashing and evidence BeginBlockers slash
+// validators for downtime and double signing
+const (
+	BlockEventCompleteUnbonding    = stakingTypes.EventTypeCompleteUnbonding
+	BlockEventCompleteRedelegation = stakingTypes.EventTypeCompleteRedelegation
+	BlockEventSlash                = slashingTypes.EventTypeSlash
+)
+
+type WrapperBlockEventCompleteUnbonding struct {
+	Event     abciTypes.Event
+	Delegator string
+	Validator string
+	Amount    sdk.Coins
+}
+
+type WrapperBlockEventCompleteRedelegation struct {
+	Event                abciTypes.Event
+	Delegator            string
+	SourceValidator      string
+	DestinationValidator string
+	Amount               sdk.Coins
+}
+
+// WrapperBlockEventSlash is a validator slash. Jailing events have no slashed validator address and no relevant data, slash
+// events of SDK versions before v0.50 have no burned coins.
+type WrapperBlockEventSlash struct {
+	Event            abciTypes.Event
+	ConsensusAddress string
+	Power            int64
+	Reason           string
+	BurnedCoins      sdk.Coins
+}
+
+func (sf *WrapperBlockEventCompleteUnbonding) GetType() string {
+	return BlockEventCompleteUnbonding
+}
+
+func (sf *WrapperBlockEventCompleteRedelegation) GetType() string {
+	return BlockEventCompleteRedelegation
+}
+
+func (sf *WrapperBlockEventSlash) GetType() string {
+	return BlockEventSlash
+}
+
+func (sf *WrapperBlockEventCompleteUnbonding) HandleEvent(_ string, event abciTypes.Event) error {
+	sf.Event = event
+	for _, attribute := range event.Attributes {
+		switch attribute.Key {
+		case stakingTypes.AttributeKeyDelegator:
+			sf.Delegator = attribute.Value
+		case stakingTypes.AttributeKeyValidator:
+			sf.Validator = attribute.Value
+		case sdk.AttributeKeyAmount:
+			amount, err := sdk.ParseCoinsNormalized(attribute.Value)
+			if err != nil {
+				return err
+			}
+			sf.Amount = amount
+		}
+	}
+
+	if sf.Delegator == "" {
+		return errors.New("delegator not found in complete unbonding event")
+	}
+
+	return nil
+}
+
+func (sf *WrapperBlockEventCompleteRedelegation) HandleEvent(_ string, event abciTypes.Event) error {
+	sf.Event = event
+	for _, attribute := range event.Attributes {
+		switch attribute.Key {
+		case stakingTypes.AttributeKeyDelegator:
+			sf.Delegator = attribute.Value
+		case stakingTypes.AttributeKeySrcValidator:
+			sf.SourceValidator = attribute.Value
+		case stakingTypes.AttributeKeyDstValidator:
+			sf.DestinationValidator = attribute.Value
+		case sdk.AttributeKeyAmount:
+			amount, err := sdk.ParseCoinsNormalized(attribute.Value)
+			if err != nil {
+				return err
+			}
+			sf.Amount = amount
+		}
+	}
+
+	if sf.Delegator == "" {
+		return errors.New("delegator not found in complete redelegation event")
+	}
+
+	return nil
+}
+
+func (sf *WrapperBlockEventSlash) HandleEvent(_ string, event abciTypes.Event) error {
+	sf.Event = event
+	for _, attribute := range event.Attributes {
+		switch attribute.Key {
+		case slashingTypes.AttributeKeyAddress:
+			_, addressBytes, err := bech32.DecodeAndConvert(attribute.Value)
+			if err != nil {
+				return err
+			}
+			sf.ConsensusAddress = strings.ToUpper(fmt.Sprintf("%x", addressBytes))
+		case slashingTypes.AttributeKeyPower:
+			power, err := strconv.ParseInt(attribute.Value, 10, 64)
+			if err != nil {
+				return err
+			}
+			sf.Power = power
+		case slashingTypes.AttributeKeyReason:
+			sf.Reason = attribute.Value
+		case slashingTypes.AttributeKeyBurnedCoins:
+			burnedCoins, err := sdk.ParseCoinsNormalized(attribute.Value)
+			if err != nil {
+				return err
+			}
+			sf.BurnedCoins = burnedCoins
+		}
+	}
+
+	return nil
+}
+
+// ParseRelevantData returns the matured unbonding, the tokens are returned to the delegator
+func (sf *WrapperBlockEventCompleteUnbonding) ParseRelevantData() []events.EventRelevantInformation {
+	var relevantData []events.EventRelevantInformation
+	for _, coin := range sf.Amount {
+		relevantData = append(relevantData, events.EventRelevantInformation{
+			Address:      sf.Delegator,
+			Amount:       coin.Amount.BigInt(),
+			Denomination: coin.Denom,
+			EventSource:  dbTypes.StakingUnbondingComplete,
+		})
+	}
+	return relevantData
+}
+
+// ParseRelevantData returns the matured redelegation, the tokens stay bonded with the destination validator
+func (sf *WrapperBlockEventCompleteRedelegation) ParseRelevantData() []events.EventRelevantInformation {
+	var relevantData []events.EventRelevantInformation
+	for _, coin := range sf.Amount {
+		relevantData = append(relevantData, events.EventRelevantInformation{
+			Address:      sf.Delegator,
+			Amount:       coin.Amount.BigInt(),
+			Denomination: coin.Denom,
+			EventSource:  dbTypes.StakingRedelegationComplete,
+		})
+	}
+	return relevantData
+}
+
+// ParseRelevantData returns the burned coins of the validator, the losses are attributed to the delegators when searched.
+// The infraction height and the slash fraction are resolved from the chain before the slash is indexed, as are the burned
+// tokens of the slashes without burned coins.
+func (sf *WrapperBlockEventSlash) ParseRelevantData() []events.EventRelevantInformation {
+	var relevantData []events.EventRelevantInformation
+	if sf.ConsensusAddress == "" {
+		return relevantData
+	}
+
+	if len(sf.BurnedCoins) == 0 {
+		return append(relevantData, events.EventRelevantInformation{
+			EventSource: dbTypes.StakingSlash,
+			Slash:       &events.SlashInformation{ConsensusAddress: sf.ConsensusAddress, Reason: sf.Reason, Power: sf.Power, BurnedUnknown: true},
+		})
+	}
+
+	for _, coin := range sf.BurnedCoins {
+		relevantData = append(relevantData, events.EventRelevantInformation{
+			Amount:       coin.Amount.BigInt(),
+			Denomination: coin.Denom,
+			EventSource:  dbTypes.StakingSlash,
+			Slash:        &events.SlashInformation{ConsensusAddress: sf.ConsensusAddress, Reason: sf.Reason},
+		})
+	}
+	return relevantData
+}
+
+func (sf *WrapperBlockEventCompleteUnbonding) String() string {
+	return fmt.Sprintf("Staking complete unbonding: delegator %s received %s unbonded from %s", sf.Delegator, sf.Amount, sf.Validator)
+}
+
+func (sf *WrapperBlockEventCompleteRedelegation) String() string {
+	return fmt.Sprintf("Staking complete redelegation: delegator %s redelegated %s from %s to %s", sf.Delegator, sf.Amount, sf.SourceValidator, sf.DestinationValidator)
+}
+
+func (sf *WrapperBlockEventSlash) String() string {
+	return fmt.Sprintf("Slashing slash: validator %s with power %d slashed for %s, burned %s", sf.ConsensusAddress, sf.Power, sf.Reason, sf.BurnedCoins)
+}
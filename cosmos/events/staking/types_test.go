@@ -0,0 +1,56 @@
+package staking
+
+import (
+	"testing"
+
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	abciTypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashRelevantData(t *testing.T) {
+	consensusAddress, err := bech32.ConvertAndEncode("cosmosvalcons", []byte{0x01, 0xab})
+	assert.Nil(t, err)
+
+	slash := &WrapperBlockEventSlash{}
+	err = slash.HandleEvent(BlockEventSlash, abciTypes.Event{Type: BlockEventSlash, Attributes: []abciTypes.EventAttribute{
+		{Key: "address", Value: consensusAddress},
+		{Key: "power", Value: "1000"},
+		{Key: "reason", Value: "missing_signature"},
+		{Key: "jailed", Value: consensusAddress},
+		{Key: "burned_coins", Value: "10000000uatom"},
+	}})
+	assert.Nil(t, err)
+
+	relevantData := slash.ParseRelevantData()
+	assert.Len(t, relevantData, 1)
+	assert.Equal(t, dbTypes.StakingSlash, relevantData[0].EventSource)
+	assert.Equal(t, "01AB", relevantData[0].Slash.ConsensusAddress)
+	assert.Equal(t, "missing_signature", relevantData[0].Slash.Reason)
+	assert.Equal(t, int64(10000000), relevantData[0].Amount.Int64())
+
+	// Jailing emits a slash event without the address of the slashed validator
+	jail := &WrapperBlockEventSlash{}
+	err = jail.HandleEvent(BlockEventSlash, abciTypes.Event{Type: BlockEventSlash, Attributes: []abciTypes.EventAttribute{
+		{Key: "jailed", Value: consensusAddress},
+	}})
+	assert.Nil(t, err)
+	assert.Empty(t, jail.ParseRelevantData())
+
+	// Slashes before Cosmos SDK v0.50 have no burned coins, they are resolved from the power and the slash fraction
+	legacySlash := &WrapperBlockEventSlash{}
+	err = legacySlash.HandleEvent(BlockEventSlash, abciTypes.Event{Type: BlockEventSlash, Attributes: []abciTypes.EventAttribute{
+		{Key: "address", Value: consensusAddress},
+		{Key: "power", Value: "1000"},
+		{Key: "reason", Value: "double_sign"},
+	}})
+	assert.Nil(t, err)
+
+	relevantData = legacySlash.ParseRelevantData()
+	assert.Len(t, relevantData, 1)
+	assert.Equal(t, dbTypes.StakingSlash, relevantData[0].EventSource)
+	assert.True(t, relevantData[0].Slash.BurnedUnknown)
+	assert.Equal(t, int64(1000), relevantData[0].Slash.Power)
+	assert.Empty(t, relevantData[0].Denomination)
+}
@@ -10,3 +10,10 @@ type CosmosEvent interface {
 	GetType() string
 	String() string
 }
+
+// BlockContextEvent is implemented by block events that are emitted after the events they summarize, e.g. the gov proposal
+// results are emitted after the deposit refunds and burns of the proposal. The handler receives the events emitted before it
+// in the same block phase.
+type BlockContextEvent interface {
+	SetPrecedingEvents([]types.Event)
+}
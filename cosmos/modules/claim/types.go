@@ -0,0 +1,244 @@
+package claim
+
+import (
+	"fmt"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/events"
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	crescentClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/crescent"
+	quicksilverClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/quicksilver"
+	stargazeClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/stargaze"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+
+	sdkMath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// Airdrop claim module messages. The airdrop is paid out of the claim module account to the claimer.
+// Osmosis and Evmos have no claim messages, their airdrops are only claimed through hooks (see ParseHookClaims).
+// The vendored claim types are registered with the chain client in config.RegisterAdditionalTypes.
+const (
+	StargazeMsgInitialClaim = "/publicawesome.stargaze.claim.v1beta1.MsgInitialClaim"
+	StargazeMsgClaimFor     = "/publicawesome.stargaze.claim.v1beta1.MsgClaimFor"
+	CrescentMsgClaim        = "/crescent.claim.v1beta1.MsgClaim"
+	QuicksilverMsgClaim     = "/quicksilver.airdrop.v1.MsgClaim"
+)
+
+// The claim event emitted by the Osmosis claim module and its forks (Stride, Stargaze, Evmos) whenever an airdrop is claimed
+const (
+	EventTypeClaim     = "claim"
+	AttributeKeySender = "sender"
+)
+
+// EvmosClaimsModuleName is the name of the Evmos claims module, its module account pays out the Evmos airdrop
+const EvmosClaimsModuleName = "claims"
+
+// AirdropClaimMessage is implemented by messages that claim an airdrop. The claim events in their log are already recorded
+// by the message and are not recorded again as hook claims.
+type AirdropClaimMessage interface {
+	IsAirdropClaim() bool
+}
+
+// Claim is a single payout of the airdrop, from the module account to the claimer
+type Claim struct {
+	Sender   string
+	Receiver string
+	Amount   sdk.Coins
+}
+
+type WrapperMsgClaim struct {
+	txModule.Message
+	Claimer string
+	Claims  []Claim
+}
+
+func (sf *WrapperMsgClaim) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+
+	// The claimer is the address for claims made on behalf of it (MsgClaimFor), the recipient or the sender otherwise
+	switch claimMsg := msg.(type) {
+	case *stargazeClaimTypes.MsgInitialClaim:
+		sf.Claimer = claimMsg.Sender
+	case *stargazeClaimTypes.MsgClaimFor:
+		sf.Claimer = claimMsg.Address
+	case *crescentClaimTypes.MsgClaim:
+		sf.Claimer = claimMsg.Recipient
+	case *quicksilverClaimTypes.MsgClaim:
+		sf.Claimer = claimMsg.Address
+	default:
+		return fmt.Errorf("unexpected message type %T for %s", msg, msgType)
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	var err error
+	sf.Claims, err = ParseClaims(msgType, sf.Claimer, log)
+	return err
+}
+
+// ParseClaims returns the transfers to the claimer in the message log, the sender of the transfers is the claim module account
+func ParseClaims(msgType string, claimer string, log *txModule.LogMessage) ([]Claim, error) {
+	transfers, err := parseTransfers(msgType, log)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims []Claim
+	for _, transfer := range transfers {
+		if transfer.Receiver == claimer {
+			claims = append(claims, transfer)
+		}
+	}
+
+	return claims, nil
+}
+
+// parseTransfers returns every bank transfer in the message log
+func parseTransfers(msgType string, log *txModule.LogMessage) ([]Claim, error) {
+	var transfers []Claim
+	for _, transferEvent := range txModule.GetEventsWithType(bankTypes.EventTypeTransfer, log) {
+		parsedTransfers, err := txModule.ParseTransferEvent(transferEvent)
+		if err != nil {
+			return nil, &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		}
+
+		for _, transfer := range parsedTransfers {
+			amount, err := sdk.ParseCoinsNormalized(transfer.Amount)
+			if err != nil {
+				return nil, &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+			}
+
+			transfers = append(transfers, Claim{Sender: transfer.Sender, Receiver: transfer.Recipient, Amount: amount})
+		}
+	}
+
+	return transfers, nil
+}
+
+// ClaimsToRelevantData records every coin claimed as a received leg from the claim module account
+func ClaimsToRelevantData(claims []Claim) []parsingTypes.MessageRelevantInformation {
+	var relevantData []parsingTypes.MessageRelevantInformation
+	for _, claim := range claims {
+		for _, coin := range claim.Amount {
+			relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+				AmountSent:           coin.Amount.BigInt(),
+				DenominationSent:     coin.Denom,
+				AmountReceived:       coin.Amount.BigInt(),
+				DenominationReceived: coin.Denom,
+				SenderAddress:        claim.Sender,
+				ReceiverAddress:      claim.Receiver,
+			})
+		}
+	}
+	return relevantData
+}
+
+func (sf *WrapperMsgClaim) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return ClaimsToRelevantData(sf.Claims)
+}
+
+func (sf *WrapperMsgClaim) IsAirdropClaim() bool {
+	return true
+}
+
+func (sf *WrapperMsgClaim) String() string {
+	var claimed sdk.Coins
+	for _, claim := range sf.Claims {
+		claimed = claimed.Add(claim.Amount...)
+	}
+
+	return fmt.Sprintf("%s: %s claimed %s", sf.Type, sf.Claimer, claimed)
+}
+
+// ParseHookClaims returns the airdrops claimed automatically by the claim module hooks (e.g. on vote or delegate) during
+// another message. Only the claim events whose coins were paid out to the claimer in the same message are claim hook events,
+// claim events of other modules are skipped. Evmos only emits the amount of the claim event without its denom, its claims
+// are the payouts of that amount by the Evmos claims module account.
+func ParseHookClaims(msgType string, log *txModule.LogMessage) ([]events.EventRelevantInformation, error) {
+	claimEvents := txModule.SplitEventsOnAttribute(EventTypeClaim, AttributeKeySender, log)
+	if len(claimEvents) == 0 {
+		return nil, nil
+	}
+
+	transfers, err := parseTransfers(msgType, log)
+	if err != nil {
+		return nil, err
+	}
+
+	var hookClaims []events.EventRelevantInformation
+	for _, claimEvent := range claimEvents {
+		claimer := claimEvent[AttributeKeySender]
+		if claimer == "" {
+			continue
+		}
+
+		claimed, err := sdk.ParseCoinsNormalized(claimEvent[txModule.EventAttributeAmount])
+		if err != nil {
+			claimed = getModulePayout(EvmosClaimsModuleName, claimer, claimEvent[txModule.EventAttributeAmount], transfers)
+		}
+
+		received := sdk.NewCoins()
+		for _, transfer := range transfers {
+			if transfer.Receiver == claimer {
+				received = received.Add(transfer.Amount...)
+			}
+		}
+
+		if claimed.Empty() || !received.IsAllGTE(claimed) {
+			continue
+		}
+
+		for _, coin := range claimed {
+			hookClaims = append(hookClaims, events.EventRelevantInformation{
+				Address:      claimer,
+				Amount:       coin.Amount.BigInt(),
+				Denomination: coin.Denom,
+				EventSource:  dbTypes.ClaimHookAirdrop,
+			})
+		}
+	}
+
+	return hookClaims, nil
+}
+
+// getModulePayout returns the coin of the amount the module account paid out to the receiver, the module account address
+// has the Bech32 prefix of the receiver
+func getModulePayout(moduleName string, receiver string, amount string, transfers []Claim) sdk.Coins {
+	payoutAmount, ok := sdkMath.NewIntFromString(amount)
+	if !ok {
+		return nil
+	}
+
+	prefix, _, err := bech32.DecodeAndConvert(receiver)
+	if err != nil {
+		return nil
+	}
+
+	moduleAddress, err := bech32.ConvertAndEncode(prefix, authTypes.NewModuleAddress(moduleName))
+	if err != nil {
+		return nil
+	}
+
+	for _, transfer := range transfers {
+		if transfer.Sender != moduleAddress || transfer.Receiver != receiver {
+			continue
+		}
+
+		for _, coin := range transfer.Amount {
+			if coin.Amount.Equal(payoutAmount) {
+				return sdk.NewCoins(coin)
+			}
+		}
+	}
+
+	return nil
+}
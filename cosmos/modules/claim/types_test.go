@@ -0,0 +1,131 @@
+package claim
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	crescentClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/crescent"
+	quicksilverClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/quicksilver"
+	stargazeClaimTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/claim/stargaze"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	lensClient "github.com/DefiantLabs/lens/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHookClaims(t *testing.T) {
+	voter, err := bech32.ConvertAndEncode("evmos", []byte("claim-test-voter0001"))
+	assert.Nil(t, err)
+	claimsModule, err := bech32.ConvertAndEncode("evmos", authTypes.NewModuleAddress(EvmosClaimsModuleName))
+	assert.Nil(t, err)
+
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "transfer", Attributes: []txModule.Attribute{
+				{Key: "recipient", Value: "osmo1delegator"},
+				{Key: "sender", Value: "osmo1claimmodule"},
+				{Key: "amount", Value: "100uosmo"},
+				{Key: "recipient", Value: voter},
+				{Key: "sender", Value: "evmos1someoneelse"},
+				{Key: "amount", Value: "250aother"},
+				{Key: "recipient", Value: voter},
+				{Key: "sender", Value: claimsModule},
+				{Key: "amount", Value: "250aevmos"},
+			}},
+			{Type: "claim", Attributes: []txModule.Attribute{
+				{Key: "sender", Value: "osmo1delegator"},
+				{Key: "amount", Value: "100uosmo"},
+				{Key: "sender", Value: voter},
+				{Key: "amount", Value: "250"},
+				{Key: "action", Value: "ACTION_VOTE"},
+				{Key: "sender", Value: "osmo1unpaid"},
+				{Key: "amount", Value: "5uosmo"},
+			}},
+		},
+	}
+
+	hookClaims, err := ParseHookClaims("/cosmos.gov.v1beta1.MsgVote", log)
+	assert.Nil(t, err)
+	assert.Len(t, hookClaims, 2, "claim events without a payout to the claimer are not claim hook events")
+
+	assert.Equal(t, "osmo1delegator", hookClaims[0].Address)
+	assert.Equal(t, "uosmo", hookClaims[0].Denomination)
+	assert.Equal(t, int64(100), hookClaims[0].Amount.Int64())
+	assert.Equal(t, dbTypes.ClaimHookAirdrop, hookClaims[0].EventSource)
+
+	assert.Equal(t, voter, hookClaims[1].Address)
+	assert.Equal(t, "aevmos", hookClaims[1].Denomination, "amounts without a denom are the payout of the claims module account")
+	assert.Equal(t, int64(250), hookClaims[1].Amount.Int64())
+}
+
+func TestParseHookClaimsWithoutModulePayout(t *testing.T) {
+	voter, err := bech32.ConvertAndEncode("evmos", []byte("claim-test-voter0001"))
+	assert.Nil(t, err)
+
+	// The coins received from other senders do not tell the denom of the claim
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "transfer", Attributes: []txModule.Attribute{
+				{Key: "recipient", Value: voter},
+				{Key: "sender", Value: "evmos1someoneelse"},
+				{Key: "amount", Value: "250aevmos"},
+			}},
+			{Type: "claim", Attributes: []txModule.Attribute{
+				{Key: "sender", Value: voter},
+				{Key: "amount", Value: "250"},
+				{Key: "action", Value: "ACTION_VOTE"},
+			}},
+		},
+	}
+
+	hookClaims, err := ParseHookClaims("/cosmos.gov.v1beta1.MsgVote", log)
+	assert.Nil(t, err)
+	assert.Empty(t, hookClaims)
+}
+
+func TestDecodeClaims(t *testing.T) {
+	cl := &lensClient.ChainClient{Codec: lensClient.MakeCodec(lensClient.ModuleBasics, "stars", "starsvaloper")}
+	config.RegisterAdditionalTypes(cl)
+
+	tests := []struct {
+		msgType string
+		msg     sdk.Msg
+		claimer string
+	}{
+		{StargazeMsgInitialClaim, &stargazeClaimTypes.MsgInitialClaim{Sender: "stars1claimer"}, "stars1claimer"},
+		{StargazeMsgClaimFor, &stargazeClaimTypes.MsgClaimFor{Sender: "stars1minter", Address: "stars1claimer", Action: stargazeClaimTypes.ActionMintNFT}, "stars1claimer"},
+		{CrescentMsgClaim, &crescentClaimTypes.MsgClaim{AirdropId: 1, Recipient: "cre1claimer", ConditionType: crescentClaimTypes.ConditionTypeSwap}, "cre1claimer"},
+		{QuicksilverMsgClaim, &quicksilverClaimTypes.MsgClaim{ChainId: "cosmoshub-4", Action: 3, Address: "quick1claimer", Proofs: [][]byte{{0x0a, 0x01, 0x01}}}, "quick1claimer"},
+	}
+
+	for _, tt := range tests {
+		txBuilder := cl.Codec.TxConfig.NewTxBuilder()
+		assert.Nil(t, txBuilder.SetMsgs(tt.msg))
+		txBytes, err := cl.Codec.TxConfig.TxEncoder()(txBuilder.GetTx())
+		assert.Nil(t, err)
+
+		decodedTx, err := cl.Codec.TxConfig.TxDecoder()(txBytes)
+		assert.Nil(t, err, tt.msgType)
+		assert.Len(t, decodedTx.GetMsgs(), 1)
+
+		log := &txModule.LogMessage{
+			Events: []txModule.LogMessageEvent{
+				{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: tt.msgType}}},
+				{Type: "transfer", Attributes: []txModule.Attribute{
+					{Key: "recipient", Value: tt.claimer},
+					{Key: "sender", Value: "claimmodule"},
+					{Key: "amount", Value: "10ustars"},
+				}},
+			},
+		}
+
+		wrapper := &WrapperMsgClaim{}
+		err = wrapper.HandleMsg(tt.msgType, decodedTx.GetMsgs()[0], log)
+		assert.Nil(t, err, tt.msgType)
+		assert.Equal(t, tt.claimer, wrapper.Claimer)
+		assert.Len(t, wrapper.ParseRelevantData(), 1)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 	stdTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	distTypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 )
@@ -17,10 +18,21 @@ const (
 	MsgFundCommunityPool           = "/cosmos.distribution.v1beta1.MsgFundCommunityPool"
 	MsgWithdrawValidatorCommission = "/cosmos.distribution.v1beta1.MsgWithdrawValidatorCommission"
 	MsgWithdrawDelegatorReward     = "/cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward"
-	MsgWithdrawRewards             = "withdraw-rewards"                                   // FIXME: this is used in 2 places and only 1 will work....
-	MsgSetWithdrawAddress          = "/cosmos.distribution.v1beta1.MsgSetWithdrawAddress" // An explicitly ignored msg for tx parsing purposes
+	MsgWithdrawRewards             = "withdraw-rewards" // FIXME: this is used in 2 places and only 1 will work....
+	MsgSetWithdrawAddress          = "/cosmos.distribution.v1beta1.MsgSetWithdrawAddress"
 )
 
+// ValidatorRewards are the rewards withdrawn from a single validator
+type ValidatorRewards struct {
+	Validator string
+	Rewards   stdTypes.Coins
+}
+
+// WithdrawAddressMessage is implemented by messages that change where the staking rewards of a delegator are paid out
+type WithdrawAddressMessage interface {
+	GetWithdrawAddress() (delegator string, withdrawAddress string)
+}
+
 type WrapperMsgFundCommunityPool struct {
 	txModule.Message
 	CosmosMsgFundCommunityPool *distTypes.MsgFundCommunityPool
@@ -32,6 +44,7 @@ type WrapperMsgWithdrawValidatorCommission struct {
 	txModule.Message
 	CosmosMsgWithdrawValidatorCommission *distTypes.MsgWithdrawValidatorCommission
 	DelegatorReceiverAddress             string
+	ValidatorAccountAddress              string
 	CoinsReceived                        stdTypes.Coin
 	MultiCoinsReceived                   stdTypes.Coins
 }
@@ -44,6 +57,13 @@ type WrapperMsgWithdrawDelegatorReward struct {
 	RecipientAddress                 string
 }
 
+type WrapperMsgSetWithdrawAddress struct {
+	txModule.Message
+	CosmosMsgSetWithdrawAddress *distTypes.MsgSetWithdrawAddress
+	DelegatorAddress            string
+	WithdrawAddress             string
+}
+
 // HandleMsg: Handle type checking for MsgFundCommunityPool
 func (sf *WrapperMsgFundCommunityPool) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
 	sf.Type = msgType
@@ -86,6 +106,7 @@ func (sf *WrapperMsgWithdrawValidatorCommission) HandleMsg(msgType string, msg s
 		}
 
 		sf.DelegatorReceiverAddress = receiverAddress
+		sf.ValidatorAccountAddress = validatorAccountAddress(sf.CosmosMsgWithdrawValidatorCommission.ValidatorAddress, receiverAddress)
 		coinsReceived, err := txModule.GetValueForAttribute("amount", delegatorReceivedCoinsEvt)
 		if err != nil {
 			return err
@@ -111,6 +132,7 @@ func (sf *WrapperMsgWithdrawValidatorCommission) HandleMsg(msgType string, msg s
 		}
 
 		sf.DelegatorReceiverAddress = receiverAddress
+		sf.ValidatorAccountAddress = validatorAccountAddress(sf.CosmosMsgWithdrawValidatorCommission.ValidatorAddress, receiverAddress)
 
 		amountRecieved, err := txModule.GetValueForAttribute("amount", transferEvt)
 		if err != nil {
@@ -192,6 +214,77 @@ func (sf *WrapperMsgWithdrawDelegatorReward) HandleMsg(msgType string, msg stdTy
 	return err
 }
 
+// HandleMsg: Handle type checking for MsgSetWithdrawAddress
+func (sf *WrapperMsgSetWithdrawAddress) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.CosmosMsgSetWithdrawAddress = msg.(*distTypes.MsgSetWithdrawAddress)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.DelegatorAddress = sf.CosmosMsgSetWithdrawAddress.DelegatorAddress
+	sf.WithdrawAddress = sf.CosmosMsgSetWithdrawAddress.WithdrawAddress
+
+	return nil
+}
+
+// GetValidatorRewards splits the rewards withdrawn by a message between the validators that paid them, using the
+// withdraw_rewards events of the log. Nothing is returned when the withdrawn rewards do not add up to the received total,
+// the validators of the received rewards are then unknown.
+func GetValidatorRewards(log *txModule.LogMessage, received stdTypes.Coins) []ValidatorRewards {
+	var validatorRewards []ValidatorRewards
+	withdrawn := stdTypes.NewCoins()
+
+	for _, attributes := range txModule.SplitEventsOnAttribute(distTypes.EventTypeWithdrawRewards, stdTypes.AttributeKeyAmount, log) {
+		validator := attributes[distTypes.AttributeKeyValidator]
+		if validator == "" {
+			return nil
+		}
+
+		rewards, err := stdTypes.ParseCoinsNormalized(attributes[stdTypes.AttributeKeyAmount])
+		if err != nil {
+			return nil
+		}
+
+		if rewards.IsZero() {
+			continue
+		}
+
+		validatorRewards = append(validatorRewards, ValidatorRewards{Validator: validator, Rewards: rewards})
+		withdrawn = withdrawn.Add(rewards...)
+	}
+
+	if !withdrawn.Equal(stdTypes.NewCoins(received...)) {
+		return nil
+	}
+
+	return validatorRewards
+}
+
+// validatorAccountAddress converts the validator operator address to the account address of the operator, using the
+// prefix of the given account address. It returns an empty string when either address cannot be decoded.
+func validatorAccountAddress(operatorAddress string, accountAddress string) string {
+	_, operatorBytes, err := bech32.DecodeAndConvert(operatorAddress)
+	if err != nil {
+		return ""
+	}
+
+	accountPrefix, _, err := bech32.DecodeAndConvert(accountAddress)
+	if err != nil {
+		return ""
+	}
+
+	address, err := bech32.ConvertAndEncode(accountPrefix, operatorBytes)
+	if err != nil {
+		return ""
+	}
+
+	return address
+}
+
 func (sf *WrapperMsgFundCommunityPool) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
 	relevantData := make([]parsingTypes.MessageRelevantInformation, len(sf.Funds))
 
@@ -214,6 +307,8 @@ func (sf *WrapperMsgWithdrawValidatorCommission) ParseRelevantData() []parsingTy
 				DenominationReceived: v.Denom,
 				SenderAddress:        "",
 				ReceiverAddress:      sf.DelegatorReceiverAddress,
+				EarnerAddress:        sf.ValidatorAccountAddress,
+				ValidatorAddress:     sf.CosmosMsgWithdrawValidatorCommission.ValidatorAddress,
 			}
 		}
 
@@ -230,6 +325,8 @@ func (sf *WrapperMsgWithdrawValidatorCommission) ParseRelevantData() []parsingTy
 		DenominationReceived: sf.CoinsReceived.Denom,
 		SenderAddress:        "",
 		ReceiverAddress:      sf.DelegatorReceiverAddress,
+		EarnerAddress:        sf.ValidatorAccountAddress,
+		ValidatorAddress:     sf.CosmosMsgWithdrawValidatorCommission.ValidatorAddress,
 	}
 	return relevantData
 }
@@ -243,6 +340,8 @@ func (sf *WrapperMsgWithdrawDelegatorReward) ParseRelevantData() []parsingTypes.
 				DenominationReceived: v.Denom,
 				SenderAddress:        "",
 				ReceiverAddress:      sf.RecipientAddress,
+				EarnerAddress:        sf.CosmosMsgWithdrawDelegatorReward.DelegatorAddress,
+				ValidatorAddress:     sf.CosmosMsgWithdrawDelegatorReward.ValidatorAddress,
 			}
 		}
 		return relevantData
@@ -253,10 +352,26 @@ func (sf *WrapperMsgWithdrawDelegatorReward) ParseRelevantData() []parsingTypes.
 		DenominationReceived: sf.CoinReceived.Denom,
 		SenderAddress:        "",
 		ReceiverAddress:      sf.RecipientAddress,
+		EarnerAddress:        sf.CosmosMsgWithdrawDelegatorReward.DelegatorAddress,
+		ValidatorAddress:     sf.CosmosMsgWithdrawDelegatorReward.ValidatorAddress,
 	}
 	return relevantData
 }
 
+// Setting the withdraw address moves no funds
+func (sf *WrapperMsgSetWithdrawAddress) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return nil
+}
+
+func (sf *WrapperMsgSetWithdrawAddress) GetWithdrawAddress() (string, string) {
+	return sf.DelegatorAddress, sf.WithdrawAddress
+}
+
+func (sf *WrapperMsgSetWithdrawAddress) String() string {
+	return fmt.Sprintf("MsgSetWithdrawAddress: Delegator %s set withdraw address %s",
+		sf.DelegatorAddress, sf.WithdrawAddress)
+}
+
 func (sf *WrapperMsgWithdrawDelegatorReward) String() string {
 	var coinsReceivedString string
 	if !sf.CoinReceived.IsNil() {
@@ -0,0 +1,36 @@
+package distribution
+
+import (
+	"testing"
+
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	stdTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetValidatorRewards(t *testing.T) {
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "withdraw_rewards", Attributes: []txModule.Attribute{
+				{Key: "amount", Value: "100uatom"},
+				{Key: "validator", Value: "cosmosvaloper1src"},
+				{Key: "delegator", Value: "cosmos1delegator"},
+				{Key: "amount", Value: ""},
+				{Key: "validator", Value: "cosmosvaloper1empty"},
+				{Key: "delegator", Value: "cosmos1delegator"},
+				{Key: "amount", Value: "25uatom,5uosmo"},
+				{Key: "validator", Value: "cosmosvaloper1dst"},
+				{Key: "delegator", Value: "cosmos1delegator"},
+			}},
+		},
+	}
+
+	validatorRewards := GetValidatorRewards(log, stdTypes.NewCoins(stdTypes.NewInt64Coin("uatom", 125), stdTypes.NewInt64Coin("uosmo", 5)))
+	assert.Len(t, validatorRewards, 2, "validators without rewards are left out")
+	assert.Equal(t, "cosmosvaloper1src", validatorRewards[0].Validator)
+	assert.Equal(t, "100uatom", validatorRewards[0].Rewards.String())
+	assert.Equal(t, "cosmosvaloper1dst", validatorRewards[1].Validator)
+	assert.Equal(t, "25uatom,5uosmo", validatorRewards[1].Rewards.String())
+
+	assert.Nil(t, GetValidatorRewards(log, stdTypes.NewCoins(stdTypes.NewInt64Coin("uatom", 100))), "rewards that do not add up to the received amounts are not split")
+}
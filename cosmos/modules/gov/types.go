@@ -2,6 +2,7 @@ package gov
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
@@ -9,6 +10,7 @@ import (
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 	stdTypes "github.com/cosmos/cosmos-sdk/types"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govModuleTypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	govTypesV1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	govTypes "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 )
@@ -24,9 +26,22 @@ const (
 	MsgVoteWeightedV1   = "/cosmos.gov.v1.MsgVoteWeighted"
 )
 
+// Deposit is a deposit made to a proposal, the deposits are stored to attribute burned deposits to their depositors
+type Deposit struct {
+	ProposalID uint64
+	Depositor  string
+	Amount     stdTypes.Coins
+}
+
+// DepositMessage is implemented by messages that deposit to a proposal
+type DepositMessage interface {
+	ParseDeposit() Deposit
+}
+
 type WrapperMsgSubmitProposal struct {
 	txModule.Message
 	CosmosMsgSubmitProposal *govTypes.MsgSubmitProposal
+	ProposalID              uint64
 	CoinReceived            stdTypes.Coin
 	MultiCoinsReceived      stdTypes.Coins
 	DepositReceiverAddress  string
@@ -43,6 +58,7 @@ type WrapperMsgDeposit struct {
 type WrapperMsgSubmitProposalV1 struct {
 	txModule.Message
 	CosmosMsgSubmitProposal *govTypesV1.MsgSubmitProposal
+	ProposalID              uint64
 	CoinReceived            stdTypes.Coin
 	MultiCoinsReceived      stdTypes.Coins
 	DepositReceiverAddress  string
@@ -155,6 +171,12 @@ func (sf *WrapperMsgSubmitProposal) HandleMsg(msgType string, msg stdTypes.Msg,
 		return util.ReturnInvalidLog(msgType, log)
 	}
 
+	proposalID, err := getSubmittedProposalID(msgType, log)
+	if err != nil {
+		return err
+	}
+	sf.ProposalID = proposalID
+
 	// If there was an initial deposit, there will be a transfer log with sender and amount
 	proposerDepositedCoinsEvt := txModule.GetEventWithType(bankTypes.EventTypeTransfer, log)
 	if proposerDepositedCoinsEvt == nil {
@@ -247,6 +269,12 @@ func (sf *WrapperMsgSubmitProposalV1) HandleMsg(msgType string, msg stdTypes.Msg
 		return util.ReturnInvalidLog(msgType, log)
 	}
 
+	proposalID, err := getSubmittedProposalID(msgType, log)
+	if err != nil {
+		return err
+	}
+	sf.ProposalID = proposalID
+
 	// If there was an initial deposit, there will be a transfer log with sender and amount
 	proposerDepositedCoinsEvt := txModule.GetEventWithType(bankTypes.EventTypeTransfer, log)
 	if proposerDepositedCoinsEvt == nil {
@@ -328,6 +356,37 @@ func (sf *WrapperMsgDepositV1) HandleMsg(msgType string, msg stdTypes.Msg, log *
 	return err
 }
 
+// getSubmittedProposalID returns the ID of the proposal created by the message
+func getSubmittedProposalID(msgType string, log *txModule.LogMessage) (uint64, error) {
+	submitProposalEvt := txModule.GetEventWithType(govModuleTypes.EventTypeSubmitProposal, log)
+	if submitProposalEvt == nil {
+		return 0, &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+	}
+
+	proposalID, err := txModule.GetValueForAttribute(govModuleTypes.AttributeKeyProposalID, submitProposalEvt)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(proposalID, 10, 64)
+}
+
+func (sf *WrapperMsgSubmitProposal) ParseDeposit() Deposit {
+	return Deposit{ProposalID: sf.ProposalID, Depositor: sf.CosmosMsgSubmitProposal.Proposer, Amount: sf.CosmosMsgSubmitProposal.InitialDeposit}
+}
+
+func (sf *WrapperMsgDeposit) ParseDeposit() Deposit {
+	return Deposit{ProposalID: sf.CosmosMsgDeposit.ProposalId, Depositor: sf.CosmosMsgDeposit.Depositor, Amount: sf.CosmosMsgDeposit.Amount}
+}
+
+func (sf *WrapperMsgSubmitProposalV1) ParseDeposit() Deposit {
+	return Deposit{ProposalID: sf.ProposalID, Depositor: sf.CosmosMsgSubmitProposal.Proposer, Amount: sf.CosmosMsgSubmitProposal.InitialDeposit}
+}
+
+func (sf *WrapperMsgDepositV1) ParseDeposit() Deposit {
+	return Deposit{ProposalID: sf.CosmosMsgDeposit.ProposalId, Depositor: sf.CosmosMsgDeposit.Depositor, Amount: sf.CosmosMsgDeposit.Amount}
+}
+
 func (sf *WrapperMsgDeposit) String() string {
 	return fmt.Sprintf("MsgDeposit: Address %s deposited %s",
 		sf.CosmosMsgDeposit.Depositor, sf.CosmosMsgDeposit.Amount)
@@ -0,0 +1,102 @@
+package ibc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	nftTransferTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/nfttransfer"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+	stdTypes "github.com/cosmos/cosmos-sdk/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// ICS-721 NFT transfer messages of the nft-transfer module, the vendored types are registered with the chain client in
+// config.RegisterAdditionalTypes.
+// Contract based ICS-721 transfers are CW721 send_nft executions and are recorded by the CW721 handling of MsgExecuteContract.
+const (
+	MsgNFTTransfer = "/ibc.applications.nft_transfer.v1.MsgTransfer"
+)
+
+// NonFungibleTokenPacketData is the ICS-721 packet data, the JSON field names are defined by the ICS-721 spec
+type NonFungibleTokenPacketData struct {
+	ClassID  string   `json:"classId"`
+	TokenIDs []string `json:"tokenIds"`
+	Sender   string   `json:"sender"`
+	Receiver string   `json:"receiver"`
+}
+
+// UnmarshalNFTPacketData unmarshals the ICS-721 packet data, packets of other applications return false
+func UnmarshalNFTPacketData(data []byte) (NonFungibleTokenPacketData, bool) {
+	var packetData NonFungibleTokenPacketData
+	if err := json.Unmarshal(data, &packetData); err != nil {
+		return packetData, false
+	}
+
+	return packetData, packetData.ClassID != "" && len(packetData.TokenIDs) > 0
+}
+
+// GetReceivedClassTrace returns the class trace of the NFTs on the receiving chain, as defined by ICS-721. Classes coming back
+// to the chain they were sent from lose the prefix of the packet source, other classes get the prefix of the packet destination.
+func GetReceivedClassTrace(packet chantypes.Packet, classID string) string {
+	sourcePrefix := fmt.Sprintf("%s/%s/", packet.SourcePort, packet.SourceChannel)
+	if strings.HasPrefix(classID, sourcePrefix) {
+		return strings.TrimPrefix(classID, sourcePrefix)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", packet.DestinationPort, packet.DestinationChannel, classID)
+}
+
+type WrapperMsgNFTTransfer struct {
+	txModule.Message
+	ClassID         string
+	TokenIDs        []string
+	SenderAddress   string
+	ReceiverAddress string
+}
+
+func (w *WrapperMsgNFTTransfer) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	w.Type = msgType
+
+	transferMsg := msg.(*nftTransferTypes.MsgTransfer)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(w.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	w.ClassID = transferMsg.GetClassId()
+	w.TokenIDs = transferMsg.GetTokenIds()
+	w.SenderAddress = transferMsg.GetSender()
+	w.ReceiverAddress = transferMsg.GetReceiver()
+
+	return nil
+}
+
+// ParseRelevantData returns nothing, the NFTs sent are recorded through ParseNFTTransfers
+func (w *WrapperMsgNFTTransfer) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return nil
+}
+
+func (w *WrapperMsgNFTTransfer) ParseNFTTransfers() []nft.Transfer {
+	transfers := make([]nft.Transfer, 0, len(w.TokenIDs))
+	for _, tokenID := range w.TokenIDs {
+		transfers = append(transfers, nft.Transfer{
+			ClassID:         w.ClassID,
+			TokenID:         tokenID,
+			Action:          nft.TransferActionIBCSend,
+			Sender:          w.SenderAddress,
+			Receiver:        w.ReceiverAddress,
+			PaymentLegIndex: nft.NoPaymentLeg,
+		})
+	}
+	return transfers
+}
+
+func (w *WrapperMsgNFTTransfer) String() string {
+	return fmt.Sprintf("MsgTransfer: IBC NFT transfer of %s %s from %s to %s", w.ClassID, strings.Join(w.TokenIDs, ", "), w.SenderAddress, w.ReceiverAddress)
+}
@@ -0,0 +1,113 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	nftTransferTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/types/nfttransfer"
+	lensClient "github.com/DefiantLabs/lens/client"
+	stdTypes "github.com/cosmos/cosmos-sdk/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeNFTTransfer(t *testing.T) {
+	cl := &lensClient.ChainClient{Codec: lensClient.MakeCodec(lensClient.ModuleBasics, "stars", "starsvaloper")}
+	config.RegisterAdditionalTypes(cl)
+
+	txBuilder := cl.Codec.TxConfig.NewTxBuilder()
+	err := txBuilder.SetMsgs(&nftTransferTypes.MsgTransfer{
+		SourcePort:       "nft-transfer",
+		SourceChannel:    "channel-0",
+		ClassId:          "nft-transfer/channel-1/kitties",
+		TokenIds:         []string{"kitty1", "kitty2"},
+		Sender:           "stars1sender",
+		Receiver:         "iaa1receiver",
+		TimeoutTimestamp: 1700000000000000000,
+	})
+	assert.Nil(t, err)
+	txBytes, err := cl.Codec.TxConfig.TxEncoder()(txBuilder.GetTx())
+	assert.Nil(t, err)
+
+	decodedTx, err := cl.Codec.TxConfig.TxDecoder()(txBytes)
+	assert.Nil(t, err)
+	assert.Len(t, decodedTx.GetMsgs(), 1)
+
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: MsgNFTTransfer}}},
+		},
+	}
+
+	wrapper := &WrapperMsgNFTTransfer{}
+	err = wrapper.HandleMsg(MsgNFTTransfer, decodedTx.GetMsgs()[0], log)
+	assert.Nil(t, err)
+
+	transfers := wrapper.ParseNFTTransfers()
+	assert.Len(t, transfers, 2)
+	assert.Equal(t, nft.Transfer{
+		ClassID: "nft-transfer/channel-1/kitties", TokenID: "kitty2", Action: nft.TransferActionIBCSend,
+		Sender: "stars1sender", Receiver: "iaa1receiver", PaymentLegIndex: nft.NoPaymentLeg,
+	}, transfers[1])
+}
+
+func TestNFTTransferRefunds(t *testing.T) {
+	packet := chantypes.Packet{
+		Sequence:   7,
+		SourcePort: "nft-transfer", SourceChannel: "channel-0",
+		DestinationPort: "nft-transfer", DestinationChannel: "channel-1",
+		Data: []byte(`{"classId":"kitties","tokenIds":["kitty1","kitty2"],"sender":"stars1sender","receiver":"iaa1receiver"}`),
+	}
+	refund := nft.Transfer{
+		ClassID: "kitties", TokenID: "kitty2", Action: nft.TransferActionIBCRefund, Receiver: "stars1sender", PaymentLegIndex: nft.NoPaymentLeg,
+	}
+
+	ackLog := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: AlternateMsgAcknowledgementLogAction}}},
+		},
+	}
+
+	// A successful acknowledgement leaves the NFTs on the receiving chain
+	ack := &WrapperMsgAcknowledgement{}
+	err := ack.HandleMsg(MsgAcknowledgement, &chantypes.MsgAcknowledgement{Packet: packet, Acknowledgement: []byte(`{"result":"AQ=="}`)}, ackLog)
+	assert.Nil(t, err)
+	assert.Empty(t, ack.ParseNFTTransfers())
+
+	ack = &WrapperMsgAcknowledgement{}
+	err = ack.HandleMsg(MsgAcknowledgement, &chantypes.MsgAcknowledgement{Packet: packet, Acknowledgement: []byte(`{"error":"class not found"}`)}, ackLog)
+	assert.Nil(t, err)
+	assert.Nil(t, ack.ParseRelevantData())
+	transfers := ack.ParseNFTTransfers()
+	assert.Len(t, transfers, 2)
+	assert.Equal(t, refund, transfers[1])
+
+	timeoutLogs := map[string]*txModule.LogMessage{
+		MsgTimeout:        {Events: []txModule.LogMessageEvent{{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: AlternateMsgTimeoutLogAction}}}}},
+		MsgTimeoutOnClose: {Events: []txModule.LogMessageEvent{{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: AlternateMsgTimeoutOnCloseLogAction}}}}},
+	}
+	timeoutMsgs := map[string]stdTypes.Msg{
+		MsgTimeout:        &chantypes.MsgTimeout{Packet: packet},
+		MsgTimeoutOnClose: &chantypes.MsgTimeoutOnClose{Packet: packet},
+	}
+
+	for msgType, msg := range timeoutMsgs {
+		timeout := &WrapperMsgTimeout{}
+		err = timeout.HandleMsg(msgType, msg, timeoutLogs[msgType])
+		assert.Nil(t, err)
+		assert.Nil(t, timeout.ParseRelevantData())
+		transfers = timeout.ParseNFTTransfers()
+		assert.Len(t, transfers, 2)
+		assert.Equal(t, refund, transfers[1])
+	}
+
+	// The timeouts of other packets are not checked against the log and refund nothing
+	transferPacket := packet
+	transferPacket.Data = []byte(`{"denom":"uatom","amount":"100","sender":"stars1sender","receiver":"iaa1receiver"}`)
+	timeout := &WrapperMsgTimeout{}
+	err = timeout.HandleMsg(MsgTimeoutOnClose, &chantypes.MsgTimeoutOnClose{Packet: transferPacket}, &txModule.LogMessage{})
+	assert.Nil(t, err)
+	assert.Empty(t, timeout.ParseNFTTransfers())
+}
@@ -6,6 +6,7 @@ import (
 
 	sdkMath "cosmossdk.io/math"
 	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
 	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 	stdTypes "github.com/cosmos/cosmos-sdk/types"
@@ -51,6 +52,8 @@ const (
 
 	AlternateMsgAcknowledgementLogAction = "acknowledge_packet"
 	AlternateMsgRcvLogAction             = "recv_packet"
+	AlternateMsgTimeoutLogAction         = "timeout_packet"
+	AlternateMsgTimeoutOnCloseLogAction  = "timeout_on_close_packet"
 )
 
 type WrapperMsgRecvPacket struct {
@@ -61,6 +64,7 @@ type WrapperMsgRecvPacket struct {
 	ReceiverAddress string
 	Amount          sdkMath.Int
 	Denom           string
+	NFTTransfers    []nft.Transfer
 }
 
 func (w *WrapperMsgRecvPacket) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
@@ -79,7 +83,10 @@ func (w *WrapperMsgRecvPacket) HandleMsg(msgType string, msg stdTypes.Msg, log *
 	var data types.FungibleTokenPacketData
 	if err := types.ModuleCdc.UnmarshalJSON(w.MsgRecvPacket.Packet.GetData(), &data); err != nil {
 		// If there was a failure then this recv was not for a token transfer packet,
-		// currently we only consider successful token transfers taxable events.
+		// currently we only consider successful token transfers and ICS-721 NFT transfers.
+		if nftData, ok := UnmarshalNFTPacketData(w.MsgRecvPacket.Packet.GetData()); ok {
+			w.handleNFTPacket(nftData)
+		}
 		return nil
 	}
 
@@ -124,7 +131,33 @@ func (w *WrapperMsgRecvPacket) ParseRelevantData() []parsingTypes.MessageRelevan
 	}}
 }
 
+// handleNFTPacket records the NFTs of an ICS-721 packet under their class trace on this chain
+func (w *WrapperMsgRecvPacket) handleNFTPacket(data NonFungibleTokenPacketData) {
+	w.SenderAddress = data.Sender
+	w.ReceiverAddress = data.Receiver
+	w.Sequence = w.MsgRecvPacket.Packet.Sequence
+
+	classTrace := GetReceivedClassTrace(w.MsgRecvPacket.Packet, data.ClassID)
+	for _, tokenID := range data.TokenIDs {
+		w.NFTTransfers = append(w.NFTTransfers, nft.Transfer{
+			ClassID:         classTrace,
+			TokenID:         tokenID,
+			Action:          nft.TransferActionIBCReceive,
+			Sender:          data.Sender,
+			Receiver:        data.Receiver,
+			PaymentLegIndex: nft.NoPaymentLeg,
+		})
+	}
+}
+
+func (w *WrapperMsgRecvPacket) ParseNFTTransfers() []nft.Transfer {
+	return w.NFTTransfers
+}
+
 func (w *WrapperMsgRecvPacket) String() string {
+	if len(w.NFTTransfers) > 0 {
+		return fmt.Sprintf("MsgRecvPacket: IBC NFT transfer of %d NFTs from %s to %s", len(w.NFTTransfers), w.SenderAddress, w.ReceiverAddress)
+	}
 	if w.Amount.IsNil() {
 		return "MsgRecvPacket: IBC transfer was not a FungibleTokenTransfer"
 	}
@@ -141,6 +174,7 @@ type WrapperMsgAcknowledgement struct {
 	Denom              string
 	AckType            int
 	AckResult          int
+	NFTTransfers       []nft.Transfer
 }
 
 func (w *WrapperMsgAcknowledgement) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
@@ -161,6 +195,19 @@ func (w *WrapperMsgAcknowledgement) HandleMsg(msgType string, msg stdTypes.Msg,
 		// If there was a failure then this ack was not for a token transfer packet,
 		// currently we only consider successful token transfers taxable events.
 		w.AckType = AckNotFungibleTokenTransfer
+
+		// The NFTs of failed ICS-721 transfers are refunded to the sender
+		if nftData, ok := UnmarshalNFTPacketData(w.MsgAcknowledgement.Packet.GetData()); ok {
+			var ack chantypes.Acknowledgement
+			if err := types.ModuleCdc.UnmarshalJSON(w.MsgAcknowledgement.Acknowledgement, &ack); err != nil {
+				return fmt.Errorf("cannot unmarshal ICS-721 transfer packet acknowledgement: %v", err)
+			}
+
+			if _, failed := ack.Response.(*chantypes.Acknowledgement_Error); failed {
+				w.AckResult = AckFailure
+				w.NFTTransfers = RefundNFTTransfers(nftData)
+			}
+		}
 		return nil
 	}
 
@@ -216,7 +263,15 @@ func (w *WrapperMsgAcknowledgement) ParseRelevantData() []parsingTypes.MessageRe
 	}}
 }
 
+func (w *WrapperMsgAcknowledgement) ParseNFTTransfers() []nft.Transfer {
+	return w.NFTTransfers
+}
+
 func (w *WrapperMsgAcknowledgement) String() string {
+	if len(w.NFTTransfers) > 0 {
+		return fmt.Sprintf("MsgAcknowledgement: IBC NFT transfer of %d NFTs from %s was not successful", len(w.NFTTransfers), w.NFTTransfers[0].Receiver)
+	}
+
 	if w.AckType == AckNotFungibleTokenTransfer {
 		return "MsgAcknowledgement: IBC transfer was not a FungibleTokenTransfer"
 	}
@@ -231,3 +286,73 @@ func (w *WrapperMsgAcknowledgement) String() string {
 
 	return fmt.Sprintf("MsgAcknowledgement: IBC transfer of %s%s from %s to %s", w.Amount, w.Denom, w.SenderAddress, w.ReceiverAddress)
 }
+
+// RefundNFTTransfers returns the NFTs of an ICS-721 packet refunded to the sender, the packet data holds the class of this chain
+func RefundNFTTransfers(data NonFungibleTokenPacketData) []nft.Transfer {
+	transfers := make([]nft.Transfer, 0, len(data.TokenIDs))
+	for _, tokenID := range data.TokenIDs {
+		transfers = append(transfers, nft.Transfer{
+			ClassID:         data.ClassID,
+			TokenID:         tokenID,
+			Action:          nft.TransferActionIBCRefund,
+			Receiver:        data.Sender,
+			PaymentLegIndex: nft.NoPaymentLeg,
+		})
+	}
+	return transfers
+}
+
+// WrapperMsgTimeout handles MsgTimeout and MsgTimeoutOnClose. The tokens of timed out ICS-20 transfers were never recorded as
+// sent, only the NFTs of timed out ICS-721 transfers are refunded to their sender.
+type WrapperMsgTimeout struct {
+	txModule.Message
+	Packet       chantypes.Packet
+	NFTTransfers []nft.Transfer
+}
+
+func (w *WrapperMsgTimeout) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	w.Type = msgType
+
+	alternateLogAction := AlternateMsgTimeoutLogAction
+	switch timeoutMsg := msg.(type) {
+	case *chantypes.MsgTimeout:
+		w.Packet = timeoutMsg.Packet
+	case *chantypes.MsgTimeoutOnClose:
+		w.Packet = timeoutMsg.Packet
+		alternateLogAction = AlternateMsgTimeoutOnCloseLogAction
+	}
+
+	// Only the timeouts of ICS-721 packets are relevant, the others are not checked against the log
+	nftData, ok := UnmarshalNFTPacketData(w.Packet.GetData())
+	if !ok {
+		return nil
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(w.GetType(), log)
+	alternateValidLog := txModule.IsMessageActionEquals(alternateLogAction, log)
+
+	if !validLog && !alternateValidLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	w.NFTTransfers = RefundNFTTransfers(nftData)
+
+	return nil
+}
+
+// ParseRelevantData returns nothing, the NFTs refunded are recorded through ParseNFTTransfers
+func (w *WrapperMsgTimeout) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return nil
+}
+
+func (w *WrapperMsgTimeout) ParseNFTTransfers() []nft.Transfer {
+	return w.NFTTransfers
+}
+
+func (w *WrapperMsgTimeout) String() string {
+	if len(w.NFTTransfers) > 0 {
+		return fmt.Sprintf("%s: IBC NFT transfer of %d NFTs refunded to %s", strings.TrimPrefix(w.Type, "/ibc.core.channel.v1."), len(w.NFTTransfers), w.NFTTransfers[0].Receiver)
+	}
+	return fmt.Sprintf("%s: IBC packet %d timed out", strings.TrimPrefix(w.Type, "/ibc.core.channel.v1."), w.Packet.Sequence)
+}
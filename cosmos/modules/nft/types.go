@@ -0,0 +1,90 @@
+package nft
+
+import (
+	"fmt"
+
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+
+	nftTypes "cosmossdk.io/x/nft"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Cosmos SDK x/nft messages, the x/nft types are registered with the chain client in config.RegisterAdditionalTypes
+const (
+	MsgSend = "/cosmos.nft.v1beta1.MsgSend"
+)
+
+// The movements of an NFT recorded in the NFT transfer ledger, IBC refunds return the NFTs of failed or timed out IBC transfers
+// to their sender
+const (
+	TransferActionMint       = "mint"
+	TransferActionTransfer   = "transfer"
+	TransferActionSend       = "send"
+	TransferActionBurn       = "burn"
+	TransferActionIBCSend    = "ibc_send"
+	TransferActionIBCReceive = "ibc_receive"
+	TransferActionIBCRefund  = "ibc_refund"
+)
+
+// NoPaymentLeg is the PaymentLegIndex of NFT transfers that are not paired with a fungible payment
+const NoPaymentLeg = -1
+
+// Transfer is a single movement of a non-fungible token. The class ID is the x/nft class, the CW721 collection contract or
+// the ICS-721 class trace. Sales pair the transfer with the fungible payment, the index of the payment in the relevant data
+// of the same message.
+type Transfer struct {
+	ClassID         string
+	TokenID         string
+	Action          string
+	Sender          string
+	Receiver        string
+	PaymentLegIndex int
+}
+
+// TransferMessage is implemented by messages that move NFTs, the transfers are recorded in the NFT transfer ledger
+type TransferMessage interface {
+	ParseNFTTransfers() []Transfer
+}
+
+type WrapperMsgSend struct {
+	txModule.Message
+	Transfer Transfer
+}
+
+func (sf *WrapperMsgSend) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+
+	sendMsg := msg.(*nftTypes.MsgSend)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.Transfer = Transfer{
+		ClassID:         sendMsg.GetClassId(),
+		TokenID:         sendMsg.GetId(),
+		Action:          TransferActionTransfer,
+		Sender:          sendMsg.GetSender(),
+		Receiver:        sendMsg.GetReceiver(),
+		PaymentLegIndex: NoPaymentLeg,
+	}
+
+	return nil
+}
+
+// ParseRelevantData returns nothing, the NFT moved is recorded through ParseNFTTransfers
+func (sf *WrapperMsgSend) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return nil
+}
+
+func (sf *WrapperMsgSend) ParseNFTTransfers() []Transfer {
+	return []Transfer{sf.Transfer}
+}
+
+func (sf *WrapperMsgSend) String() string {
+	return fmt.Sprintf("MsgSend: NFT %s/%s sent from %s to %s", sf.Transfer.ClassID, sf.Transfer.TokenID, sf.Transfer.Sender, sf.Transfer.Receiver)
+}
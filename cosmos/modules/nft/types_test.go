@@ -0,0 +1,40 @@
+package nft
+
+import (
+	"testing"
+
+	nftTypes "cosmossdk.io/x/nft"
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	lensClient "github.com/DefiantLabs/lens/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeNFTSend(t *testing.T) {
+	cl := &lensClient.ChainClient{Codec: lensClient.MakeCodec(lensClient.ModuleBasics, "cosmos", "cosmosvaloper")}
+	config.RegisterAdditionalTypes(cl)
+
+	txBuilder := cl.Codec.TxConfig.NewTxBuilder()
+	err := txBuilder.SetMsgs(&nftTypes.MsgSend{ClassId: "kitties", Id: "kitty1", Sender: "cosmos1sender", Receiver: "cosmos1receiver"})
+	assert.Nil(t, err)
+	txBytes, err := cl.Codec.TxConfig.TxEncoder()(txBuilder.GetTx())
+	assert.Nil(t, err)
+
+	decodedTx, err := cl.Codec.TxConfig.TxDecoder()(txBytes)
+	assert.Nil(t, err)
+	assert.Len(t, decodedTx.GetMsgs(), 1)
+
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: MsgSend}}},
+		},
+	}
+
+	wrapper := &WrapperMsgSend{}
+	err = wrapper.HandleMsg(MsgSend, decodedTx.GetMsgs()[0], log)
+	assert.Nil(t, err)
+	assert.Equal(t, []Transfer{{
+		ClassID: "kitties", TokenID: "kitty1", Action: TransferActionTransfer,
+		Sender: "cosmos1sender", Receiver: "cosmos1receiver", PaymentLegIndex: NoPaymentLeg,
+	}}, wrapper.ParseNFTTransfers())
+}
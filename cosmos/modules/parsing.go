@@ -5,6 +5,8 @@ import "math/big"
 type MessageRelevantInformation struct {
 	SenderAddress        string
 	ReceiverAddress      string
+	EarnerAddress        string // The account that earned a reward received, which may differ from the receiver when a withdraw address is set
+	ValidatorAddress     string // The operator address of the validator that paid a staking reward received
 	AmountSent           *big.Int
 	AmountReceived       *big.Int
 	DenominationSent     string
@@ -0,0 +1,320 @@
+package staking
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+
+	stdTypes "github.com/cosmos/cosmos-sdk/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// Liquid staking module (LSM) messages, as added to the Cosmos Hub staking module.
+// The LSM protobuf types are not part of the upstream Cosmos SDK, they are vendored in cosmoshub/types/lsm and registered
+// with the chain client codec. The wrappers read the decoded messages through their generated getters.
+const (
+	MsgTokenizeShares              = "/cosmos.staking.v1beta1.MsgTokenizeShares"
+	MsgRedeemTokensForShares       = "/cosmos.staking.v1beta1.MsgRedeemTokensForShares"
+	MsgTransferTokenizeShareRecord = "/cosmos.staking.v1beta1.MsgTransferTokenizeShareRecord"
+	MsgValidatorBond               = "/cosmos.staking.v1beta1.MsgValidatorBond"
+	MsgDisableTokenizeShares       = "/cosmos.staking.v1beta1.MsgDisableTokenizeShares"
+	MsgEnableTokenizeShares        = "/cosmos.staking.v1beta1.MsgEnableTokenizeShares"
+	MsgUnbondValidator             = "/cosmos.staking.v1beta1.MsgUnbondValidator"
+)
+
+// Tokenize share record denoms are the validator operator address and the record ID, e.g. cosmosvaloper1.../42
+const tokenizeShareValidatorSeparator = "/"
+
+type lsmDelegatorMsg interface {
+	GetDelegatorAddress() string
+}
+
+type lsmValidatorMsg interface {
+	GetValidatorAddress() string
+}
+
+type lsmAmountMsg interface {
+	GetAmount() stdTypes.Coin
+}
+
+type lsmTokenizeSharesMsg interface {
+	lsmDelegatorMsg
+	lsmValidatorMsg
+	lsmAmountMsg
+	GetTokenizedShareOwner() string
+}
+
+type lsmTransferTokenizeShareRecordMsg interface {
+	GetTokenizeShareRecordId() uint64
+	GetSender() string
+	GetNewOwner() string
+}
+
+type WrapperMsgTokenizeShares struct {
+	txModule.Message
+	DelegatorAddress      string
+	ValidatorAddress      string
+	TokenizedShareOwner   string
+	Amount                stdTypes.Coin
+	TokenizedShares       stdTypes.Coins
+	AutoWithdrawalRewards stdTypes.Coins
+}
+
+type WrapperMsgRedeemTokensForShares struct {
+	txModule.Message
+	DelegatorAddress      string
+	Amount                stdTypes.Coin
+	AutoWithdrawalRewards stdTypes.Coins
+}
+
+type WrapperMsgTransferTokenizeShareRecord struct {
+	txModule.Message
+	TokenizeShareRecordID uint64
+	Sender                string
+	NewOwner              string
+	// Any rewards the transfer withdraws to the previous owner of the record
+	AutoWithdrawalRewards stdTypes.Coins
+}
+
+type WrapperMsgValidatorBond struct {
+	txModule.Message
+	DelegatorAddress      string
+	ValidatorAddress      string
+	AutoWithdrawalRewards stdTypes.Coins
+}
+
+// ParseTokenizeShareDenom splits an LSM tokenize share record denom ({validator operator address}/{record ID})
+func ParseTokenizeShareDenom(denom string) (validator string, recordID uint64, ok bool) {
+	validator, recordIDString, found := strings.Cut(denom, tokenizeShareValidatorSeparator)
+	if !found || !strings.Contains(validator, "valoper1") {
+		return "", 0, false
+	}
+
+	recordID, err := strconv.ParseUint(recordIDString, 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return validator, recordID, true
+}
+
+// IsTokenizeShareDenom returns true for LSM tokenize share record denoms
+func IsTokenizeShareDenom(denom string) bool {
+	_, _, ok := ParseTokenizeShareDenom(denom)
+	return ok
+}
+
+// parseLSMCoinsReceived splits the coins received by the address into the tokenize share record tokens and everything
+// else, which are the delegation rewards withdrawn by the LSM operation.
+func parseLSMCoinsReceived(msgType string, address string, log *txModule.LogMessage) (tokenizedShares stdTypes.Coins, rewards stdTypes.Coins, err error) {
+	coinReceivedEvents := txModule.GetEventsWithType(bankTypes.EventTypeCoinReceived, log)
+	for _, coinsReceivedString := range txModule.GetCoinsReceived(address, coinReceivedEvents) {
+		coins, err := stdTypes.ParseCoinsNormalized(coinsReceivedString)
+		if err != nil {
+			return nil, nil, &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		}
+
+		for _, coin := range coins {
+			if IsTokenizeShareDenom(coin.Denom) {
+				tokenizedShares = tokenizedShares.Add(coin)
+			} else {
+				rewards = rewards.Add(coin)
+			}
+		}
+	}
+
+	return tokenizedShares, rewards, nil
+}
+
+func (sf *WrapperMsgTokenizeShares) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	tokenizeSharesMsg, ok := msg.(lsmTokenizeSharesMsg)
+	if !ok {
+		return errors.New("message is not an LSM MsgTokenizeShares")
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.DelegatorAddress = tokenizeSharesMsg.GetDelegatorAddress()
+	sf.ValidatorAddress = tokenizeSharesMsg.GetValidatorAddress()
+	sf.TokenizedShareOwner = tokenizeSharesMsg.GetTokenizedShareOwner()
+	sf.Amount = tokenizeSharesMsg.GetAmount()
+
+	// Tokenizing withdraws the rewards of the delegation to the delegator, the share tokens are minted to the owner
+	_, rewards, err := parseLSMCoinsReceived(msgType, sf.DelegatorAddress, log)
+	if err != nil {
+		return err
+	}
+	sf.AutoWithdrawalRewards = rewards
+
+	sf.TokenizedShares, _, err = parseLSMCoinsReceived(msgType, sf.TokenizedShareOwner, log)
+	return err
+}
+
+func (sf *WrapperMsgRedeemTokensForShares) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	delegatorMsg, ok := msg.(lsmDelegatorMsg)
+	if !ok {
+		return errors.New("message is not an LSM MsgRedeemTokensForShares")
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.DelegatorAddress = delegatorMsg.GetDelegatorAddress()
+	if amountMsg, ok := msg.(lsmAmountMsg); ok {
+		sf.Amount = amountMsg.GetAmount()
+	}
+
+	// Redeeming withdraws the rewards accrued by the tokenize share record to the redeemer
+	_, rewards, err := parseLSMCoinsReceived(msgType, sf.DelegatorAddress, log)
+	if err != nil {
+		return err
+	}
+	sf.AutoWithdrawalRewards = rewards
+
+	return nil
+}
+
+func (sf *WrapperMsgTransferTokenizeShareRecord) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	transferMsg, ok := msg.(lsmTransferTokenizeShareRecordMsg)
+	if !ok {
+		return errors.New("message is not an LSM MsgTransferTokenizeShareRecord")
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.TokenizeShareRecordID = transferMsg.GetTokenizeShareRecordId()
+	sf.Sender = transferMsg.GetSender()
+	sf.NewOwner = transferMsg.GetNewOwner()
+
+	_, rewards, err := parseLSMCoinsReceived(msgType, sf.Sender, log)
+	if err != nil {
+		return err
+	}
+	sf.AutoWithdrawalRewards = rewards
+
+	return nil
+}
+
+func (sf *WrapperMsgValidatorBond) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	delegatorMsg, ok := msg.(lsmDelegatorMsg)
+	if !ok {
+		return errors.New("message is not an LSM MsgValidatorBond")
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.DelegatorAddress = delegatorMsg.GetDelegatorAddress()
+	if validatorMsg, ok := msg.(lsmValidatorMsg); ok {
+		sf.ValidatorAddress = validatorMsg.GetValidatorAddress()
+	}
+
+	_, rewards, err := parseLSMCoinsReceived(msgType, sf.DelegatorAddress, log)
+	if err != nil {
+		return err
+	}
+	sf.AutoWithdrawalRewards = rewards
+
+	return nil
+}
+
+func lsmRewardsRelevantData(address string, rewards stdTypes.Coins) []parsingTypes.MessageRelevantInformation {
+	var relevantData []parsingTypes.MessageRelevantInformation
+	for _, coin := range rewards {
+		data := parsingTypes.MessageRelevantInformation{}
+		data.AmountReceived = coin.Amount.BigInt()
+		data.DenominationReceived = coin.Denom
+		data.ReceiverAddress = address
+		relevantData = append(relevantData, data)
+	}
+	return relevantData
+}
+
+// Tokenizing and redeeming shares converts between a delegation and share tokens, which is not a disposal.
+// The rewards the operation withdraws are relevant, tokenizing records the share tokens minted to the owner and redeeming
+// records the share tokens burned by the redeemer so that the share tokens can be followed from mint to redemption.
+func (sf *WrapperMsgTokenizeShares) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	relevantData := lsmRewardsRelevantData(sf.DelegatorAddress, sf.AutoWithdrawalRewards)
+	for _, coin := range sf.TokenizedShares {
+		relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+			AmountReceived:       coin.Amount.BigInt(),
+			DenominationReceived: coin.Denom,
+			ReceiverAddress:      sf.TokenizedShareOwner,
+		})
+	}
+	return relevantData
+}
+
+func (sf *WrapperMsgRedeemTokensForShares) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	relevantData := lsmRewardsRelevantData(sf.DelegatorAddress, sf.AutoWithdrawalRewards)
+	if !sf.Amount.Amount.IsNil() && sf.Amount.IsPositive() {
+		relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+			AmountSent:       sf.Amount.Amount.BigInt(),
+			DenominationSent: sf.Amount.Denom,
+			SenderAddress:    sf.DelegatorAddress,
+		})
+	}
+	return relevantData
+}
+
+func (sf *WrapperMsgTransferTokenizeShareRecord) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return lsmRewardsRelevantData(sf.Sender, sf.AutoWithdrawalRewards)
+}
+
+func (sf *WrapperMsgValidatorBond) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return lsmRewardsRelevantData(sf.DelegatorAddress, sf.AutoWithdrawalRewards)
+}
+
+func (sf *WrapperMsgTokenizeShares) String() string {
+	if len(sf.AutoWithdrawalRewards) > 0 {
+		return fmt.Sprintf("MsgTokenizeShares: Delegator %s tokenized %s with %s into %s and auto-withdrew %s",
+			sf.DelegatorAddress, sf.Amount, sf.ValidatorAddress, sf.TokenizedShares, sf.AutoWithdrawalRewards)
+	}
+	return fmt.Sprintf("MsgTokenizeShares: Delegator %s tokenized %s with %s into %s and did not auto-withdrawal rewards",
+		sf.DelegatorAddress, sf.Amount, sf.ValidatorAddress, sf.TokenizedShares)
+}
+
+func (sf *WrapperMsgRedeemTokensForShares) String() string {
+	if len(sf.AutoWithdrawalRewards) > 0 {
+		return fmt.Sprintf("MsgRedeemTokensForShares: Delegator %s redeemed %s and auto-withdrew %s", sf.DelegatorAddress, sf.Amount, sf.AutoWithdrawalRewards)
+	}
+	return fmt.Sprintf("MsgRedeemTokensForShares: Delegator %s redeemed %s and did not auto-withdrawal rewards", sf.DelegatorAddress, sf.Amount)
+}
+
+func (sf *WrapperMsgTransferTokenizeShareRecord) String() string {
+	if len(sf.AutoWithdrawalRewards) > 0 {
+		return fmt.Sprintf("MsgTransferTokenizeShareRecord: %s transferred record %d to %s and auto-withdrew %s",
+			sf.Sender, sf.TokenizeShareRecordID, sf.NewOwner, sf.AutoWithdrawalRewards)
+	}
+	return fmt.Sprintf("MsgTransferTokenizeShareRecord: %s transferred record %d to %s", sf.Sender, sf.TokenizeShareRecordID, sf.NewOwner)
+}
+
+func (sf *WrapperMsgValidatorBond) String() string {
+	if len(sf.AutoWithdrawalRewards) > 0 {
+		return fmt.Sprintf("MsgValidatorBond: Delegator %s validator bonded to %s and auto-withdrew %s", sf.DelegatorAddress, sf.ValidatorAddress, sf.AutoWithdrawalRewards)
+	}
+	return fmt.Sprintf("MsgValidatorBond: Delegator %s validator bonded to %s", sf.DelegatorAddress, sf.ValidatorAddress)
+}
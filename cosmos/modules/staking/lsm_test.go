@@ -0,0 +1,145 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmoshub/types/lsm"
+	lensClient "github.com/DefiantLabs/lens/client"
+	stdTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendBytesField(b []byte, num protowire.Number, value []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
+
+func appendVarintField(b []byte, num protowire.Number, value uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+func encodeAny(typeURL string, value []byte) []byte {
+	return appendBytesField(appendBytesField(nil, 1, []byte(typeURL)), 2, value)
+}
+
+func encodeCoin(denom, amount string) []byte {
+	return appendBytesField(appendBytesField(nil, 1, []byte(denom)), 2, []byte(amount))
+}
+
+// encodeTx encodes a signed TxRaw with the message, the wire format of a tx broadcast to the Cosmos Hub
+func encodeTx(typeURL string, msg []byte) []byte {
+	body := appendBytesField(nil, 1, encodeAny(typeURL, msg))
+
+	pubKey := appendBytesField(nil, 1, append([]byte{0x02}, make([]byte, 32)...))
+	modeInfo := appendBytesField(nil, 1, appendVarintField(nil, 1, 1))
+	signerInfo := appendBytesField(nil, 1, encodeAny("/cosmos.crypto.secp256k1.PubKey", pubKey))
+	signerInfo = appendBytesField(signerInfo, 2, modeInfo)
+	signerInfo = appendVarintField(signerInfo, 3, 7)
+	fee := appendBytesField(nil, 1, encodeCoin("uatom", "5000"))
+	fee = appendVarintField(fee, 2, 200000)
+	authInfo := appendBytesField(appendBytesField(nil, 1, signerInfo), 2, fee)
+
+	txRaw := appendBytesField(nil, 1, body)
+	txRaw = appendBytesField(txRaw, 2, authInfo)
+	return appendBytesField(txRaw, 3, make([]byte, 64))
+}
+
+func TestDecodeTokenizeSharesTx(t *testing.T) {
+	cl := &lensClient.ChainClient{Codec: lensClient.MakeCodec(lensClient.ModuleBasics, "cosmos", "cosmosvaloper")}
+	config.RegisterAdditionalTypes(cl)
+
+	delegator, err := bech32.ConvertAndEncode("cosmos", []byte("lsm-test-delegator01"))
+	assert.Nil(t, err)
+	validator, err := bech32.ConvertAndEncode("cosmosvaloper", []byte("lsm-test-validator01"))
+	assert.Nil(t, err)
+	owner, err := bech32.ConvertAndEncode("cosmos", []byte("lsm-test-share-owner"))
+	assert.Nil(t, err)
+
+	msg := appendBytesField(nil, 1, []byte(delegator))
+	msg = appendBytesField(msg, 2, []byte(validator))
+	msg = appendBytesField(msg, 3, encodeCoin("uatom", "1000000"))
+	msg = appendBytesField(msg, 4, []byte(owner))
+
+	decodedTx, err := cl.Codec.TxConfig.TxDecoder()(encodeTx(MsgTokenizeShares, msg))
+	assert.Nil(t, err)
+	assert.Len(t, decodedTx.GetMsgs(), 1)
+
+	tokenizeShares, ok := decodedTx.GetMsgs()[0].(*lsm.MsgTokenizeShares)
+	assert.True(t, ok)
+	assert.Equal(t, "1000000uatom", tokenizeShares.Amount.String())
+
+	signers, _, err := cl.Codec.Marshaler.GetMsgV1Signers(tokenizeShares)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("lsm-test-delegator01")}, signers)
+
+	// Tokenizing mints the share tokens to the owner and withdraws the delegation rewards
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: MsgTokenizeShares}}},
+			{Type: "coin_received", Attributes: []txModule.Attribute{
+				{Key: "receiver", Value: delegator},
+				{Key: "amount", Value: "1234uatom"},
+				{Key: "receiver", Value: owner},
+				{Key: "amount", Value: "1000000" + validator + "/42"},
+			}},
+		},
+	}
+
+	wrapper := &WrapperMsgTokenizeShares{}
+	err = wrapper.HandleMsg(MsgTokenizeShares, tokenizeShares, log)
+	assert.Nil(t, err)
+	assert.Equal(t, validator, wrapper.ValidatorAddress)
+	assert.Equal(t, "1000000"+validator+"/42", wrapper.TokenizedShares.String())
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 2)
+	assert.Equal(t, "uatom", relevantData[0].DenominationReceived)
+	assert.Equal(t, int64(1234), relevantData[0].AmountReceived.Int64())
+	assert.Equal(t, delegator, relevantData[0].ReceiverAddress)
+
+	// The share tokens are received by the owner, nothing is sent
+	assert.Equal(t, validator+"/42", relevantData[1].DenominationReceived)
+	assert.Equal(t, int64(1000000), relevantData[1].AmountReceived.Int64())
+	assert.Equal(t, owner, relevantData[1].ReceiverAddress)
+	assert.Empty(t, relevantData[1].SenderAddress)
+	assert.Nil(t, relevantData[1].AmountSent)
+}
+
+func TestRedeemTokensForSharesRelevantData(t *testing.T) {
+	delegator, err := bech32.ConvertAndEncode("cosmos", []byte("lsm-test-delegator01"))
+	assert.Nil(t, err)
+	validator, err := bech32.ConvertAndEncode("cosmosvaloper", []byte("lsm-test-validator01"))
+	assert.Nil(t, err)
+
+	// Redeeming burns the share tokens of the redeemer and withdraws the rewards of the record
+	msg := &lsm.MsgRedeemTokensForShares{DelegatorAddress: delegator, Amount: stdTypes.NewInt64Coin(validator+"/42", 1000000)}
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: MsgRedeemTokensForShares}}},
+			{Type: "coin_received", Attributes: []txModule.Attribute{
+				{Key: "receiver", Value: delegator},
+				{Key: "amount", Value: "1234uatom"},
+			}},
+		},
+	}
+
+	wrapper := &WrapperMsgRedeemTokensForShares{}
+	err = wrapper.HandleMsg(MsgRedeemTokensForShares, msg, log)
+	assert.Nil(t, err)
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 2)
+	assert.Equal(t, "uatom", relevantData[0].DenominationReceived)
+	assert.Equal(t, int64(1234), relevantData[0].AmountReceived.Int64())
+
+	// The share tokens are sent by the redeemer, nothing is received for them
+	assert.Equal(t, validator+"/42", relevantData[1].DenominationSent)
+	assert.Equal(t, int64(1000000), relevantData[1].AmountSent.Int64())
+	assert.Equal(t, delegator, relevantData[1].SenderAddress)
+	assert.Nil(t, relevantData[1].AmountReceived)
+}
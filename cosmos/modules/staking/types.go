@@ -1,14 +1,20 @@
 package staking
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 	"strings"
 
 	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
 	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 
+	cryptoTypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	stdTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	stakeTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
@@ -17,11 +23,32 @@ const (
 	MsgDelegate                  = "/cosmos.staking.v1beta1.MsgDelegate"
 	MsgUndelegate                = "/cosmos.staking.v1beta1.MsgUndelegate"
 	MsgBeginRedelegate           = "/cosmos.staking.v1beta1.MsgBeginRedelegate"
-	MsgCreateValidator           = "/cosmos.staking.v1beta1.MsgCreateValidator" // An explicitly ignored msg for tx parsing purposes
-	MsgEditValidator             = "/cosmos.staking.v1beta1.MsgEditValidator"   // An explicitly ignored msg for tx parsing purposes
+	MsgCreateValidator           = "/cosmos.staking.v1beta1.MsgCreateValidator"
+	MsgEditValidator             = "/cosmos.staking.v1beta1.MsgEditValidator"
 	MsgCancelUnbondingDelegation = "/cosmos.staking.v1beta1.MsgCancelUnbondingDelegation"
 )
 
+// DelegationChange is a change of the tokens a delegator has bonded to a validator, negative when the tokens are unbonded or
+// redelegated away. The changes are used to attribute validator slashes to the delegators.
+type DelegationChange struct {
+	Delegator string
+	Validator string
+	Denom     string
+	Amount    *big.Int
+}
+
+// DelegationMessage is implemented by messages that change the delegations of the delegator
+type DelegationMessage interface {
+	ParseDelegationChanges() []DelegationChange
+}
+
+// ValidatorMessage is implemented by messages that create or edit a validator, the consensus address is the hex address of
+// the consensus pubkey and is empty when the pubkey was not decoded. The moniker is empty when it was not changed.
+type ValidatorMessage interface {
+	GetValidatorAddresses() (operatorAddress string, consensusAddress string)
+	GetValidatorMoniker() string
+}
+
 type WrapperMsgDelegate struct {
 	txModule.Message
 	CosmosMsgDelegate     *stakeTypes.MsgDelegate
@@ -43,6 +70,22 @@ type WrapperMsgBeginRedelegate struct {
 	CosmosMsgBeginRedelegate *stakeTypes.MsgBeginRedelegate
 	DelegatorAddress         string
 	AutoWithdrawalRewards    stdTypes.Coins
+	ValidatorRewards         []distribution.ValidatorRewards
+}
+
+type WrapperMsgCreateValidator struct {
+	txModule.Message
+	CosmosMsgCreateValidator *stakeTypes.MsgCreateValidator
+}
+
+type WrapperMsgEditValidator struct {
+	txModule.Message
+	CosmosMsgEditValidator *stakeTypes.MsgEditValidator
+}
+
+type WrapperMsgCancelUnbondingDelegation struct {
+	txModule.Message
+	CosmosMsgCancelUnbondingDelegation *stakeTypes.MsgCancelUnbondingDelegation
 }
 
 // HandleMsg: Handle type checking for MsgFundCommunityPool
@@ -57,7 +100,7 @@ func (sf *WrapperMsgDelegate) HandleMsg(msgType string, msg stdTypes.Msg, log *t
 	}
 
 	// The attribute in the log message that shows you the delegator rewards auto-received
-	delegatorReceivedCoinsEvt := txModule.GetEventWithType(bankTypes.EventTypeTransfer, log)
+	delegatorReceivedCoinsEvt := getRewardTransferEvent(log)
 	if delegatorReceivedCoinsEvt == nil {
 		sf.AutoWithdrawalReward = nil
 		sf.DelegatorAddress = sf.CosmosMsgDelegate.DelegatorAddress
@@ -120,8 +163,9 @@ func (sf *WrapperMsgUndelegate) HandleMsg(msgType string, msg stdTypes.Msg, log
 		}
 
 		// Find delegator address in receivers if its there, find its paired amount and set as the withdrawn rewards
+		claimPayouts := getClaimPayouts(sf.DelegatorAddress, log)
 		for i, v := range receivers {
-			if v == sf.DelegatorAddress {
+			if v == sf.DelegatorAddress && !claimPayouts.consume(amounts[i]) {
 				coin, err := stdTypes.ParseCoinNormalized(amounts[i])
 				if err != nil {
 					var coins stdTypes.Coins
@@ -173,8 +217,9 @@ func (sf *WrapperMsgBeginRedelegate) HandleMsg(msgType string, msg stdTypes.Msg,
 
 		// Find delegator address in receivers if its there, find its paired amount and set as the withdrawn rewards
 		// We use a cosmos.Coins array type for redelegations as redelegating could force withdrawal from both validators
+		claimPayouts := getClaimPayouts(sf.DelegatorAddress, log)
 		for i, v := range receivers {
-			if v == sf.DelegatorAddress {
+			if v == sf.DelegatorAddress && !claimPayouts.consume(amounts[i]) {
 				coin, err := stdTypes.ParseCoinNormalized(amounts[i])
 				if err != nil {
 					var coins stdTypes.Coins
@@ -190,6 +235,124 @@ func (sf *WrapperMsgBeginRedelegate) HandleMsg(msgType string, msg stdTypes.Msg,
 				sf.AutoWithdrawalRewards = append(sf.AutoWithdrawalRewards, coin)
 			}
 		}
+
+		// Redelegating withdraws the rewards of both validators, split them between the validators when possible
+		sf.ValidatorRewards = distribution.GetValidatorRewards(log, sf.AutoWithdrawalRewards)
+	}
+
+	return nil
+}
+
+// The claim modules of Osmosis and its forks (claim) and of Evmos (claims) pay out the airdrops claimed by their hooks on
+// delegation changes. The payouts are recorded as claim hook airdrops and are not staking rewards.
+var claimModuleNames = []string{"claim", "claims"}
+
+func isClaimModuleAccount(address string) bool {
+	_, addressBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return false
+	}
+
+	for _, moduleName := range claimModuleNames {
+		if bytes.Equal(addressBytes, authTypes.NewModuleAddress(moduleName)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getRewardTransferEvent returns the first transfer in the message log that is not a claim module payout
+func getRewardTransferEvent(log *txModule.LogMessage) *txModule.LogMessageEvent {
+	for _, transferEvent := range txModule.GetEventsWithType(bankTypes.EventTypeTransfer, log) {
+		transfers, err := txModule.ParseTransferEvent(transferEvent)
+		if err != nil {
+			return &transferEvent
+		}
+
+		for _, transfer := range transfers {
+			if isClaimModuleAccount(transfer.Sender) {
+				continue
+			}
+
+			return &txModule.LogMessageEvent{
+				Type: bankTypes.EventTypeTransfer,
+				Attributes: []txModule.Attribute{
+					{Key: "recipient", Value: transfer.Recipient},
+					{Key: "sender", Value: transfer.Sender},
+					{Key: "amount", Value: transfer.Amount},
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+// claimPayouts counts the amounts the claim module accounts transferred to an address in a message log
+type claimPayouts map[string]int
+
+func getClaimPayouts(receiver string, log *txModule.LogMessage) claimPayouts {
+	payouts := claimPayouts{}
+	for _, transferEvent := range txModule.GetEventsWithType(bankTypes.EventTypeTransfer, log) {
+		transfers, err := txModule.ParseTransferEvent(transferEvent)
+		if err != nil {
+			continue
+		}
+
+		for _, transfer := range transfers {
+			if transfer.Recipient == receiver && isClaimModuleAccount(transfer.Sender) {
+				payouts[transfer.Amount]++
+			}
+		}
+	}
+
+	return payouts
+}
+
+// consume reports whether the amount received is a claim module payout, each payout matches a single amount received
+func (payouts claimPayouts) consume(amount string) bool {
+	if payouts[amount] == 0 {
+		return false
+	}
+	payouts[amount]--
+	return true
+}
+
+func (sf *WrapperMsgCreateValidator) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.CosmosMsgCreateValidator = msg.(*stakeTypes.MsgCreateValidator)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	return nil
+}
+
+func (sf *WrapperMsgEditValidator) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.CosmosMsgEditValidator = msg.(*stakeTypes.MsgEditValidator)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	return nil
+}
+
+func (sf *WrapperMsgCancelUnbondingDelegation) HandleMsg(msgType string, msg stdTypes.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.CosmosMsgCancelUnbondingDelegation = msg.(*stakeTypes.MsgCancelUnbondingDelegation)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
 	}
 
 	return nil
@@ -202,6 +365,8 @@ func (sf *WrapperMsgDelegate) ParseRelevantData() []parsingTypes.MessageRelevant
 		data.AmountReceived = sf.AutoWithdrawalReward.Amount.BigInt()
 		data.DenominationReceived = sf.AutoWithdrawalReward.Denom
 		data.ReceiverAddress = sf.DelegatorAddress
+		data.EarnerAddress = sf.CosmosMsgDelegate.DelegatorAddress
+		data.ValidatorAddress = sf.CosmosMsgDelegate.ValidatorAddress
 		relevantData = append(relevantData, data)
 	} else if len(sf.AutoWithdrawalRewards) > 0 {
 		for _, coin := range sf.AutoWithdrawalRewards {
@@ -209,6 +374,8 @@ func (sf *WrapperMsgDelegate) ParseRelevantData() []parsingTypes.MessageRelevant
 			data.AmountReceived = coin.Amount.BigInt()
 			data.DenominationReceived = coin.Denom
 			data.ReceiverAddress = sf.DelegatorAddress
+			data.EarnerAddress = sf.CosmosMsgDelegate.DelegatorAddress
+			data.ValidatorAddress = sf.CosmosMsgDelegate.ValidatorAddress
 			relevantData = append(relevantData, data)
 		}
 	}
@@ -222,6 +389,8 @@ func (sf *WrapperMsgUndelegate) ParseRelevantData() []parsingTypes.MessageReleva
 		data.AmountReceived = sf.AutoWithdrawalReward.Amount.BigInt()
 		data.DenominationReceived = sf.AutoWithdrawalReward.Denom
 		data.ReceiverAddress = sf.DelegatorAddress
+		data.EarnerAddress = sf.CosmosMsgUndelegate.DelegatorAddress
+		data.ValidatorAddress = sf.CosmosMsgUndelegate.ValidatorAddress
 		relevantData = append(relevantData, data)
 	} else if len(sf.AutoWithdrawalRewards) > 0 {
 		for _, coin := range sf.AutoWithdrawalRewards {
@@ -229,6 +398,8 @@ func (sf *WrapperMsgUndelegate) ParseRelevantData() []parsingTypes.MessageReleva
 			data.AmountReceived = coin.Amount.BigInt()
 			data.DenominationReceived = coin.Denom
 			data.ReceiverAddress = sf.DelegatorAddress
+			data.EarnerAddress = sf.CosmosMsgUndelegate.DelegatorAddress
+			data.ValidatorAddress = sf.CosmosMsgUndelegate.ValidatorAddress
 			relevantData = append(relevantData, data)
 		}
 	}
@@ -237,16 +408,141 @@ func (sf *WrapperMsgUndelegate) ParseRelevantData() []parsingTypes.MessageReleva
 
 func (sf *WrapperMsgBeginRedelegate) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
 	var relevantData []parsingTypes.MessageRelevantInformation
+	if len(sf.ValidatorRewards) > 0 {
+		for _, validatorRewards := range sf.ValidatorRewards {
+			for _, coin := range validatorRewards.Rewards {
+				data := parsingTypes.MessageRelevantInformation{}
+				data.AmountReceived = coin.Amount.BigInt()
+				data.DenominationReceived = coin.Denom
+				data.ReceiverAddress = sf.DelegatorAddress
+				data.EarnerAddress = sf.CosmosMsgBeginRedelegate.DelegatorAddress
+				data.ValidatorAddress = validatorRewards.Validator
+				relevantData = append(relevantData, data)
+			}
+		}
+		return relevantData
+	}
+
 	for _, coin := range sf.AutoWithdrawalRewards {
 		data := parsingTypes.MessageRelevantInformation{}
 		data.AmountReceived = coin.Amount.BigInt()
 		data.DenominationReceived = coin.Denom
 		data.ReceiverAddress = sf.DelegatorAddress
+		data.EarnerAddress = sf.CosmosMsgBeginRedelegate.DelegatorAddress
 		relevantData = append(relevantData, data)
 	}
 	return relevantData
 }
 
+// ParseRelevantData returns nothing, the self delegation stays with the validator operator
+func (sf *WrapperMsgCreateValidator) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return nil
+}
+
+// ParseRelevantData returns nothing, editing a validator moves no funds
+func (sf *WrapperMsgEditValidator) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return nil
+}
+
+// ParseRelevantData returns nothing, no rewards are withdrawn when cancelling an unbonding delegation
+func (sf *WrapperMsgCancelUnbondingDelegation) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return nil
+}
+
+func (sf *WrapperMsgDelegate) ParseDelegationChanges() []DelegationChange {
+	return []DelegationChange{{
+		Delegator: sf.CosmosMsgDelegate.DelegatorAddress,
+		Validator: sf.CosmosMsgDelegate.ValidatorAddress,
+		Denom:     sf.CosmosMsgDelegate.Amount.Denom,
+		Amount:    sf.CosmosMsgDelegate.Amount.Amount.BigInt(),
+	}}
+}
+
+func (sf *WrapperMsgUndelegate) ParseDelegationChanges() []DelegationChange {
+	return []DelegationChange{{
+		Delegator: sf.CosmosMsgUndelegate.DelegatorAddress,
+		Validator: sf.CosmosMsgUndelegate.ValidatorAddress,
+		Denom:     sf.CosmosMsgUndelegate.Amount.Denom,
+		Amount:    new(big.Int).Neg(sf.CosmosMsgUndelegate.Amount.Amount.BigInt()),
+	}}
+}
+
+func (sf *WrapperMsgBeginRedelegate) ParseDelegationChanges() []DelegationChange {
+	return []DelegationChange{
+		{
+			Delegator: sf.CosmosMsgBeginRedelegate.DelegatorAddress,
+			Validator: sf.CosmosMsgBeginRedelegate.ValidatorSrcAddress,
+			Denom:     sf.CosmosMsgBeginRedelegate.Amount.Denom,
+			Amount:    new(big.Int).Neg(sf.CosmosMsgBeginRedelegate.Amount.Amount.BigInt()),
+		},
+		{
+			Delegator: sf.CosmosMsgBeginRedelegate.DelegatorAddress,
+			Validator: sf.CosmosMsgBeginRedelegate.ValidatorDstAddress,
+			Denom:     sf.CosmosMsgBeginRedelegate.Amount.Denom,
+			Amount:    sf.CosmosMsgBeginRedelegate.Amount.Amount.BigInt(),
+		},
+	}
+}
+
+func (sf *WrapperMsgCreateValidator) ParseDelegationChanges() []DelegationChange {
+	return []DelegationChange{{
+		Delegator: sf.CosmosMsgCreateValidator.DelegatorAddress,
+		Validator: sf.CosmosMsgCreateValidator.ValidatorAddress,
+		Denom:     sf.CosmosMsgCreateValidator.Value.Denom,
+		Amount:    sf.CosmosMsgCreateValidator.Value.Amount.BigInt(),
+	}}
+}
+
+func (sf *WrapperMsgCancelUnbondingDelegation) ParseDelegationChanges() []DelegationChange {
+	return []DelegationChange{{
+		Delegator: sf.CosmosMsgCancelUnbondingDelegation.DelegatorAddress,
+		Validator: sf.CosmosMsgCancelUnbondingDelegation.ValidatorAddress,
+		Denom:     sf.CosmosMsgCancelUnbondingDelegation.Amount.Denom,
+		Amount:    sf.CosmosMsgCancelUnbondingDelegation.Amount.Amount.BigInt(),
+	}}
+}
+
+func (sf *WrapperMsgCreateValidator) GetValidatorAddresses() (string, string) {
+	var consensusAddress string
+	if sf.CosmosMsgCreateValidator.Pubkey != nil {
+		if pubKey, ok := sf.CosmosMsgCreateValidator.Pubkey.GetCachedValue().(cryptoTypes.PubKey); ok {
+			consensusAddress = pubKey.Address().String()
+		}
+	}
+
+	return sf.CosmosMsgCreateValidator.ValidatorAddress, consensusAddress
+}
+
+func (sf *WrapperMsgCreateValidator) GetValidatorMoniker() string {
+	return sf.CosmosMsgCreateValidator.Description.Moniker
+}
+
+// GetValidatorAddresses returns no consensus address, it cannot be changed by editing the validator
+func (sf *WrapperMsgEditValidator) GetValidatorAddresses() (string, string) {
+	return sf.CosmosMsgEditValidator.ValidatorAddress, ""
+}
+
+func (sf *WrapperMsgEditValidator) GetValidatorMoniker() string {
+	if sf.CosmosMsgEditValidator.Description.Moniker == stakeTypes.DoNotModifyDesc {
+		return ""
+	}
+	return sf.CosmosMsgEditValidator.Description.Moniker
+}
+
+func (sf *WrapperMsgEditValidator) String() string {
+	return fmt.Sprintf("MsgEditValidator: Validator %s edited", sf.CosmosMsgEditValidator.ValidatorAddress)
+}
+
+func (sf *WrapperMsgCreateValidator) String() string {
+	return fmt.Sprintf("MsgCreateValidator: Delegator %s created validator %s with %s",
+		sf.CosmosMsgCreateValidator.DelegatorAddress, sf.CosmosMsgCreateValidator.ValidatorAddress, sf.CosmosMsgCreateValidator.Value)
+}
+
+func (sf *WrapperMsgCancelUnbondingDelegation) String() string {
+	return fmt.Sprintf("MsgCancelUnbondingDelegation: Delegator %s cancelled unbonding %s from %s",
+		sf.CosmosMsgCancelUnbondingDelegation.DelegatorAddress, sf.CosmosMsgCancelUnbondingDelegation.Amount, sf.CosmosMsgCancelUnbondingDelegation.ValidatorAddress)
+}
+
 func (sf *WrapperMsgDelegate) String() string {
 	if sf.AutoWithdrawalReward != nil {
 		return fmt.Sprintf("MsgDelegate: Delegator %s auto-withdrew %s", sf.DelegatorAddress, sf.AutoWithdrawalReward)
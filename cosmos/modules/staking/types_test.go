@@ -0,0 +1,92 @@
+package staking
+
+import (
+	"testing"
+
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+
+	"cosmossdk.io/math"
+	stdTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authTypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakeTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// hookClaimLog returns the log of a staking message that withdrew the rewards of the validator and paid out a hook claim
+// from the claim module account to the delegator
+func hookClaimLog(t *testing.T, msgType string, delegator string, validator string) *txModule.LogMessage {
+	distribution, err := bech32.ConvertAndEncode("osmo", authTypes.NewModuleAddress("distribution"))
+	assert.Nil(t, err)
+	claimModule, err := bech32.ConvertAndEncode("osmo", authTypes.NewModuleAddress("claim"))
+	assert.Nil(t, err)
+
+	return &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: msgType}}},
+			{Type: "withdraw_rewards", Attributes: []txModule.Attribute{
+				{Key: "amount", Value: "1500uosmo"},
+				{Key: "validator", Value: validator},
+			}},
+			{Type: "coin_received", Attributes: []txModule.Attribute{
+				{Key: "receiver", Value: delegator},
+				{Key: "amount", Value: "1500uosmo"},
+				{Key: "receiver", Value: delegator},
+				{Key: "amount", Value: "250000uosmo"},
+			}},
+			{Type: "transfer", Attributes: []txModule.Attribute{
+				{Key: "recipient", Value: delegator},
+				{Key: "sender", Value: distribution},
+				{Key: "amount", Value: "1500uosmo"},
+			}},
+			{Type: "transfer", Attributes: []txModule.Attribute{
+				{Key: "recipient", Value: delegator},
+				{Key: "sender", Value: claimModule},
+				{Key: "amount", Value: "250000uosmo"},
+			}},
+			{Type: "claim", Attributes: []txModule.Attribute{
+				{Key: "sender", Value: delegator},
+				{Key: "amount", Value: "250000uosmo"},
+			}},
+		},
+	}
+}
+
+func TestHookClaimsAreNotRewards(t *testing.T) {
+	delegator, err := bech32.ConvertAndEncode("osmo", []byte("hook-claim-delegator"))
+	assert.Nil(t, err)
+	validator, err := bech32.ConvertAndEncode("osmovaloper", []byte("hook-claim-validator"))
+	assert.Nil(t, err)
+	dstValidator, err := bech32.ConvertAndEncode("osmovaloper", []byte("hook-claim-dst-valid"))
+	assert.Nil(t, err)
+	amount := stdTypes.NewCoin("uosmo", math.NewInt(1000000))
+
+	delegate := &WrapperMsgDelegate{}
+	err = delegate.HandleMsg(MsgDelegate, &stakeTypes.MsgDelegate{DelegatorAddress: delegator, ValidatorAddress: validator, Amount: amount},
+		hookClaimLog(t, MsgDelegate, delegator, validator))
+	assert.Nil(t, err)
+
+	undelegate := &WrapperMsgUndelegate{}
+	err = undelegate.HandleMsg(MsgUndelegate, &stakeTypes.MsgUndelegate{DelegatorAddress: delegator, ValidatorAddress: validator, Amount: amount},
+		hookClaimLog(t, MsgUndelegate, delegator, validator))
+	assert.Nil(t, err)
+
+	redelegate := &WrapperMsgBeginRedelegate{}
+	err = redelegate.HandleMsg(MsgBeginRedelegate, &stakeTypes.MsgBeginRedelegate{DelegatorAddress: delegator, ValidatorSrcAddress: validator,
+		ValidatorDstAddress: dstValidator, Amount: amount}, hookClaimLog(t, MsgBeginRedelegate, delegator, validator))
+	assert.Nil(t, err)
+
+	// Only the withdrawn rewards are recorded, the claim module payout is a claim hook airdrop
+	for _, relevantData := range [][]parsingTypes.MessageRelevantInformation{
+		delegate.ParseRelevantData(),
+		undelegate.ParseRelevantData(),
+		redelegate.ParseRelevantData(),
+	} {
+		assert.Len(t, relevantData, 1)
+		assert.Equal(t, int64(1500), relevantData[0].AmountReceived.Int64())
+		assert.Equal(t, "uosmo", relevantData[0].DenominationReceived)
+		assert.Equal(t, delegator, relevantData[0].ReceiverAddress)
+		assert.Equal(t, validator, relevantData[0].ValidatorAddress)
+	}
+}
@@ -64,6 +64,25 @@ func GetEventsWithType(eventType string, msg *LogMessage) []LogMessageEvent {
 	return events
 }
 
+// SplitEventsOnAttribute returns the attributes of every event of the given type, with a new group started each time
+// the split key is seen. This handles both normalized per-event logs and legacy logs where events are merged.
+func SplitEventsOnAttribute(eventType string, splitKey string, msg *LogMessage) []map[string]string {
+	var groups []map[string]string
+
+	for _, evt := range GetEventsWithType(eventType, msg) {
+		var current map[string]string
+		for _, attr := range evt.Attributes {
+			if attr.Key == splitKey || current == nil {
+				current = map[string]string{}
+				groups = append(groups, current)
+			}
+			current[attr.Key] = attr.Value
+		}
+	}
+
+	return groups
+}
+
 type TransferEvent struct {
 	Recipient string
 	Sender    string
@@ -53,6 +53,8 @@ type Response struct {
 	Code      uint32       `json:"code"`
 	RawLog    string       `json:"raw_log"`
 	Log       []LogMessage `json:"logs"`
+	// The events of the tx that are not part of a message log, e.g. the events of the ante handler
+	TxEvents []LogMessageEvent `json:"-"`
 }
 
 // TxLogMessage:
@@ -155,3 +157,15 @@ type CosmosMessage interface {
 	GetType() string
 	String() string
 }
+
+// HeightAwareMessage is implemented by messages whose parsing depends on the chain state at the height of the transaction.
+// The height is set before HandleMsg is called.
+type HeightAwareMessage interface {
+	SetHeight(int64)
+}
+
+// TxEventsAwareMessage is implemented by messages whose funds move outside of the message log, e.g. in the ante handler.
+// The tx events that are not part of a message log are set before HandleMsg is called.
+type TxEventsAwareMessage interface {
+	SetTxEvents([]LogMessageEvent)
+}
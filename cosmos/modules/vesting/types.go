@@ -1,6 +1,198 @@
 package vesting
 
-// Explicitly ignored messages for tx parsing purposes
+import (
+	"fmt"
+	"time"
+
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	vestingTypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+)
+
+const (
+	MsgCreateVestingAccount         = "/cosmos.vesting.v1beta1.MsgCreateVestingAccount"
+	MsgCreatePermanentLockedAccount = "/cosmos.vesting.v1beta1.MsgCreatePermanentLockedAccount"
+	MsgCreatePeriodicVestingAccount = "/cosmos.vesting.v1beta1.MsgCreatePeriodicVestingAccount"
+)
+
+// The vesting account types, these match the Cosmos SDK vesting account implementations
 const (
-	MsgCreateVestingAccount = "/cosmos.vesting.v1beta1.MsgCreateVestingAccount"
+	AccountTypeContinuous      = "continuous"
+	AccountTypeDelayed         = "delayed"
+	AccountTypePeriodic        = "periodic"
+	AccountTypePermanentLocked = "permanent_locked"
 )
+
+// Period is a single unlock of a periodic vesting account, the length is the duration since the end of the previous period
+type Period struct {
+	Length time.Duration
+	Amount sdk.Coins
+}
+
+// Schedule is the vesting schedule of a new vesting account. Continuous and delayed accounts start vesting at the block time of
+// their creation, the start time is left empty for the caller to fill from the block.
+type Schedule struct {
+	Type      string
+	Funder    string
+	Address   string
+	Amount    sdk.Coins
+	StartTime time.Time
+	EndTime   time.Time
+	Periods   []Period
+}
+
+// ScheduleMessage is implemented by messages that create vesting accounts, the schedules are stored for the vesting-schedule report
+type ScheduleMessage interface {
+	ParseVestingSchedule() Schedule
+}
+
+type WrapperMsgCreateVestingAccount struct {
+	txModule.Message
+	CosmosMsgCreateVestingAccount *vestingTypes.MsgCreateVestingAccount
+}
+
+type WrapperMsgCreatePermanentLockedAccount struct {
+	txModule.Message
+	CosmosMsgCreatePermanentLockedAccount *vestingTypes.MsgCreatePermanentLockedAccount
+}
+
+type WrapperMsgCreatePeriodicVestingAccount struct {
+	txModule.Message
+	CosmosMsgCreatePeriodicVestingAccount *vestingTypes.MsgCreatePeriodicVestingAccount
+}
+
+func (sf *WrapperMsgCreateVestingAccount) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.CosmosMsgCreateVestingAccount = msg.(*vestingTypes.MsgCreateVestingAccount)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	return nil
+}
+
+func (sf *WrapperMsgCreatePermanentLockedAccount) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.CosmosMsgCreatePermanentLockedAccount = msg.(*vestingTypes.MsgCreatePermanentLockedAccount)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	return nil
+}
+
+func (sf *WrapperMsgCreatePeriodicVestingAccount) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.CosmosMsgCreatePeriodicVestingAccount = msg.(*vestingTypes.MsgCreatePeriodicVestingAccount)
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	return nil
+}
+
+// ParseRelevantData records the tokens sent by the funder to the new vesting account. The vesting account holds them from its
+// creation but they are locked, they become income when they unlock. The unlocks are computed from the vesting schedule.
+func (sf *WrapperMsgCreateVestingAccount) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return fundingRelevantData(sf.CosmosMsgCreateVestingAccount.FromAddress, sf.CosmosMsgCreateVestingAccount.ToAddress, sf.CosmosMsgCreateVestingAccount.Amount)
+}
+
+func (sf *WrapperMsgCreatePermanentLockedAccount) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return fundingRelevantData(sf.CosmosMsgCreatePermanentLockedAccount.FromAddress, sf.CosmosMsgCreatePermanentLockedAccount.ToAddress, sf.CosmosMsgCreatePermanentLockedAccount.Amount)
+}
+
+func (sf *WrapperMsgCreatePeriodicVestingAccount) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	var amount sdk.Coins
+	for _, period := range sf.CosmosMsgCreatePeriodicVestingAccount.VestingPeriods {
+		amount = amount.Add(period.Amount...)
+	}
+	return fundingRelevantData(sf.CosmosMsgCreatePeriodicVestingAccount.FromAddress, sf.CosmosMsgCreatePeriodicVestingAccount.ToAddress, amount)
+}
+
+func fundingRelevantData(funder string, address string, amount sdk.Coins) []parsingTypes.MessageRelevantInformation {
+	relevantData := make([]parsingTypes.MessageRelevantInformation, len(amount))
+	for i, coin := range amount {
+		relevantData[i] = parsingTypes.MessageRelevantInformation{
+			AmountSent:           coin.Amount.BigInt(),
+			DenominationSent:     coin.Denom,
+			AmountReceived:       coin.Amount.BigInt(),
+			DenominationReceived: coin.Denom,
+			SenderAddress:        funder,
+			ReceiverAddress:      address,
+		}
+	}
+	return relevantData
+}
+
+func (sf *WrapperMsgCreateVestingAccount) ParseVestingSchedule() Schedule {
+	schedule := Schedule{
+		Type:    AccountTypeContinuous,
+		Funder:  sf.CosmosMsgCreateVestingAccount.FromAddress,
+		Address: sf.CosmosMsgCreateVestingAccount.ToAddress,
+		Amount:  sf.CosmosMsgCreateVestingAccount.Amount,
+		EndTime: time.Unix(sf.CosmosMsgCreateVestingAccount.EndTime, 0).UTC(),
+	}
+
+	if sf.CosmosMsgCreateVestingAccount.Delayed {
+		schedule.Type = AccountTypeDelayed
+	}
+
+	return schedule
+}
+
+func (sf *WrapperMsgCreatePermanentLockedAccount) ParseVestingSchedule() Schedule {
+	return Schedule{
+		Type:    AccountTypePermanentLocked,
+		Funder:  sf.CosmosMsgCreatePermanentLockedAccount.FromAddress,
+		Address: sf.CosmosMsgCreatePermanentLockedAccount.ToAddress,
+		Amount:  sf.CosmosMsgCreatePermanentLockedAccount.Amount,
+	}
+}
+
+func (sf *WrapperMsgCreatePeriodicVestingAccount) ParseVestingSchedule() Schedule {
+	schedule := Schedule{
+		Type:      AccountTypePeriodic,
+		Funder:    sf.CosmosMsgCreatePeriodicVestingAccount.FromAddress,
+		Address:   sf.CosmosMsgCreatePeriodicVestingAccount.ToAddress,
+		StartTime: time.Unix(sf.CosmosMsgCreatePeriodicVestingAccount.StartTime, 0).UTC(),
+	}
+
+	var totalLength int64
+	for _, period := range sf.CosmosMsgCreatePeriodicVestingAccount.VestingPeriods {
+		totalLength += period.Length
+		schedule.Amount = schedule.Amount.Add(period.Amount...)
+		schedule.Periods = append(schedule.Periods, Period{Length: time.Duration(period.Length) * time.Second, Amount: period.Amount})
+	}
+
+	schedule.EndTime = time.Unix(sf.CosmosMsgCreatePeriodicVestingAccount.StartTime+totalLength, 0).UTC()
+
+	return schedule
+}
+
+func (sf *WrapperMsgCreateVestingAccount) String() string {
+	return fmt.Sprintf("MsgCreateVestingAccount: %s created vesting account %s with %s vesting until %s (delayed: %t)",
+		sf.CosmosMsgCreateVestingAccount.FromAddress, sf.CosmosMsgCreateVestingAccount.ToAddress, sf.CosmosMsgCreateVestingAccount.Amount,
+		time.Unix(sf.CosmosMsgCreateVestingAccount.EndTime, 0).UTC(), sf.CosmosMsgCreateVestingAccount.Delayed)
+}
+
+func (sf *WrapperMsgCreatePermanentLockedAccount) String() string {
+	return fmt.Sprintf("MsgCreatePermanentLockedAccount: %s created permanently locked account %s with %s",
+		sf.CosmosMsgCreatePermanentLockedAccount.FromAddress, sf.CosmosMsgCreatePermanentLockedAccount.ToAddress, sf.CosmosMsgCreatePermanentLockedAccount.Amount)
+}
+
+func (sf *WrapperMsgCreatePeriodicVestingAccount) String() string {
+	return fmt.Sprintf("MsgCreatePeriodicVestingAccount: %s created periodic vesting account %s with %d periods",
+		sf.CosmosMsgCreatePeriodicVestingAccount.FromAddress, sf.CosmosMsgCreatePeriodicVestingAccount.ToAddress, len(sf.CosmosMsgCreatePeriodicVestingAccount.VestingPeriods))
+}
@@ -0,0 +1,13 @@
+package crescent
+
+import (
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInterfaces registers the claim messages with the interface registry, so txs containing them can be decoded
+func RegisterInterfaces(registry codecTypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgClaim{},
+	)
+}
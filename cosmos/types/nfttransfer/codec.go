@@ -0,0 +1,13 @@
+package nfttransfer
+
+import (
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInterfaces registers the ICS-721 transfer message with the interface registry, so txs containing it can be decoded
+func RegisterInterfaces(registry codecTypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgTransfer{},
+	)
+}
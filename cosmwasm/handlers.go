@@ -11,8 +11,8 @@ var defaultMessageTypeHandler = map[string][]func() txTypes.CosmosMessage{}
 
 var contractAddressRegistry = map[string]wasm.ContractExecutionMessageHandler{}
 
-func GetCosmWasmMessageTypeHandlers(customContractAddressHandlers []wasm.ContractExecutionMessageHandler, lensClient *client.ChainClient) (map[string][]func() txTypes.CosmosMessage, error) {
-	msgExecuteContractHandlers, err := configureMsgExecuteContractHandler(customContractAddressHandlers, lensClient)
+func GetCosmWasmMessageTypeHandlers(customContractAddressHandlers []wasm.ContractExecutionMessageHandler, customContractHandlerResolvers []wasm.ContractExecutionMessageHandlerResolver, lensClient *client.ChainClient) (map[string][]func() txTypes.CosmosMessage, error) {
+	msgExecuteContractHandlers, err := configureMsgExecuteContractHandler(customContractAddressHandlers, customContractHandlerResolvers, lensClient)
 	if err != nil {
 		return nil, err
 	}
@@ -23,7 +23,7 @@ func GetCosmWasmMessageTypeHandlers(customContractAddressHandlers []wasm.Contrac
 }
 
 // Configures a handler wrapper that will allow using registry values to find custom message handlers
-func configureMsgExecuteContractHandler(customContractAddressHandlers []wasm.ContractExecutionMessageHandler, lensClient *client.ChainClient) ([]func() txTypes.CosmosMessage, error) {
+func configureMsgExecuteContractHandler(customContractAddressHandlers []wasm.ContractExecutionMessageHandler, customContractHandlerResolvers []wasm.ContractExecutionMessageHandlerResolver, lensClient *client.ChainClient) ([]func() txTypes.CosmosMessage, error) {
 	for _, handler := range customContractAddressHandlers {
 		if castHandler, ok := handler.(wasm.ContractExecutionMessageHandlerByContractAddress); ok {
 			contractAddressRegistry[castHandler.ContractAddress()] = handler
@@ -40,13 +40,12 @@ func configureMsgExecuteContractHandler(customContractAddressHandlers []wasm.Con
 		}
 	}
 
-	configuredExecuteContractWrapper := wasm.WrapperMsgExecuteContract{
-		ContractAddressRegistry: contractAddressRegistry,
-	}
-
 	return []func() txTypes.CosmosMessage{
 		func() txTypes.CosmosMessage {
-			return configuredExecuteContractWrapper
+			return &wasm.WrapperMsgExecuteContract{
+				ContractAddressRegistry:  contractAddressRegistry,
+				ContractHandlerResolvers: customContractHandlerResolvers,
+			}
 		},
 	}, nil
 }
@@ -0,0 +1,144 @@
+package cw721
+
+import (
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+)
+
+// CW721 execute messages, the collection contract emits a wasm event for each of them
+const (
+	ExecuteTransferNft = "transfer_nft"
+	ExecuteSendNft     = "send_nft"
+	ExecuteMint        = "mint"
+	ExecuteBurn        = "burn"
+)
+
+const (
+	wasmEventType               = "wasm"
+	attributeKeyContractAddress = "_contract_address"
+	attributeKeyAction          = "action"
+	attributeKeySender          = "sender"
+	attributeKeyRecipient       = "recipient"
+	attributeKeyOwner           = "owner"
+	attributeKeyTokenID         = "token_id"
+)
+
+// Marketplace sale events. Marketplaces built on the Stargaze marketplace contracts emit a finalize-sale event for every sale
+// with the NFT sold, the seller, the buyer and the price paid by the buyer, either an amount of the native denom or a coin.
+const (
+	saleEventType          = "wasm-finalize-sale"
+	attributeKeyCollection = "collection"
+	attributeKeySeller     = "seller"
+	attributeKeyBuyer      = "buyer"
+	attributeKeyPrice      = "price"
+)
+
+var executeToTransferAction = map[string]string{
+	ExecuteTransferNft: nft.TransferActionTransfer,
+	ExecuteSendNft:     nft.TransferActionSend,
+	ExecuteMint:        nft.TransferActionMint,
+	ExecuteBurn:        nft.TransferActionBurn,
+}
+
+// ParseTransfers returns the NFT transfers of every CW721 contract executed in the message, including the executions
+// done by other contracts (e.g. a marketplace transferring the NFT it sold). The wasm events are split per contract.
+func ParseTransfers(log *txModule.LogMessage) []nft.Transfer {
+	var transfers []nft.Transfer
+	for _, wasmEvent := range txModule.SplitEventsOnAttribute(wasmEventType, attributeKeyContractAddress, log) {
+		action, ok := executeToTransferAction[wasmEvent[attributeKeyAction]]
+		if !ok || wasmEvent[attributeKeyTokenID] == "" || wasmEvent[attributeKeyContractAddress] == "" {
+			continue
+		}
+
+		transfer := nft.Transfer{
+			ClassID:         wasmEvent[attributeKeyContractAddress],
+			TokenID:         wasmEvent[attributeKeyTokenID],
+			Action:          action,
+			PaymentLegIndex: nft.NoPaymentLeg,
+		}
+
+		switch action {
+		case nft.TransferActionMint:
+			transfer.Receiver = wasmEvent[attributeKeyOwner]
+		case nft.TransferActionBurn:
+			transfer.Sender = wasmEvent[attributeKeySender]
+		default:
+			transfer.Sender = wasmEvent[attributeKeySender]
+			transfer.Receiver = wasmEvent[attributeKeyRecipient]
+		}
+
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers
+}
+
+// Sale is an NFT sold through a marketplace contract, the class ID is the CW721 collection contract
+type Sale struct {
+	Marketplace string
+	ClassID     string
+	TokenID     string
+	Seller      string
+	Buyer       string
+	Price       string
+}
+
+// ParseSales returns the NFT sales finalized by the marketplace contracts executed in the message
+func ParseSales(log *txModule.LogMessage) []Sale {
+	var sales []Sale
+	for _, saleEvent := range txModule.SplitEventsOnAttribute(saleEventType, attributeKeyContractAddress, log) {
+		sale := Sale{
+			Marketplace: saleEvent[attributeKeyContractAddress],
+			ClassID:     saleEvent[attributeKeyCollection],
+			TokenID:     saleEvent[attributeKeyTokenID],
+			Seller:      saleEvent[attributeKeySeller],
+			Buyer:       saleEvent[attributeKeyBuyer],
+			Price:       saleEvent[attributeKeyPrice],
+		}
+
+		if sale.Marketplace == "" || sale.ClassID == "" || sale.TokenID == "" || sale.Seller == "" || sale.Buyer == "" {
+			continue
+		}
+
+		sales = append(sales, sale)
+	}
+
+	return sales
+}
+
+// FindSaleTransfer returns the index of the transfer of the sold NFT to the buyer, -1 when the NFT was not transferred
+func FindSaleTransfer(transfers []nft.Transfer, sale Sale) int {
+	for i, transfer := range transfers {
+		if transfer.ClassID == sale.ClassID && transfer.TokenID == sale.TokenID && transfer.Receiver == sale.Buyer {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// PairPayments pairs the NFT transfers with the payment of the executor. A single NFT bought (received) by the executor is
+// paired with the funds they sent, a single NFT sold (sent) by the executor is paired with the funds they received.
+// Executions that move more than one NFT each way are left unpaired, the payment cannot be split between them.
+func PairPayments(transfers []nft.Transfer, executor string, paymentSentIndex int, paymentReceivedIndex int) {
+	var bought, sold []int
+	for i, transfer := range transfers {
+		if transfer.Action == nft.TransferActionBurn {
+			continue
+		}
+
+		if transfer.Receiver == executor {
+			bought = append(bought, i)
+		} else if transfer.Sender == executor {
+			sold = append(sold, i)
+		}
+	}
+
+	if len(bought) == 1 && paymentSentIndex != nft.NoPaymentLeg {
+		transfers[bought[0]].PaymentLegIndex = paymentSentIndex
+	}
+
+	if len(sold) == 1 && paymentReceivedIndex != nft.NoPaymentLeg {
+		transfers[sold[0]].PaymentLegIndex = paymentReceivedIndex
+	}
+}
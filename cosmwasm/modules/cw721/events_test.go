@@ -0,0 +1,48 @@
+package cw721
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTransfersMarketplaceSale(t *testing.T) {
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "wasm", Attributes: []txModule.Attribute{
+				{Key: "_contract_address", Value: "stars1marketplace"},
+				{Key: "action", Value: "buy_now"},
+				{Key: "token_id", Value: "42"},
+				{Key: "_contract_address", Value: "stars1collection"},
+				{Key: "action", Value: "transfer_nft"},
+				{Key: "sender", Value: "stars1marketplace"},
+				{Key: "recipient", Value: "stars1buyer"},
+				{Key: "token_id", Value: "42"},
+				{Key: "_contract_address", Value: "stars1collection"},
+				{Key: "action", Value: "mint"},
+				{Key: "minter", Value: "stars1minter"},
+				{Key: "owner", Value: "stars1buyer"},
+				{Key: "token_id", Value: "43"},
+			}},
+		},
+	}
+
+	transfers := ParseTransfers(log)
+	assert.Len(t, transfers, 2, "only the CW721 executions are transfers")
+
+	assert.Equal(t, nft.Transfer{
+		ClassID: "stars1collection", TokenID: "42", Action: nft.TransferActionTransfer,
+		Sender: "stars1marketplace", Receiver: "stars1buyer", PaymentLegIndex: nft.NoPaymentLeg,
+	}, transfers[0])
+	assert.Equal(t, nft.TransferActionMint, transfers[1].Action)
+	assert.Equal(t, "stars1buyer", transfers[1].Receiver)
+	assert.Equal(t, "", transfers[1].Sender)
+
+	PairPayments(transfers, "stars1buyer", 0, nft.NoPaymentLeg)
+	assert.Equal(t, nft.NoPaymentLeg, transfers[0].PaymentLegIndex, "the payment cannot be split between two NFTs")
+
+	PairPayments(transfers[:1], "stars1buyer", 0, nft.NoPaymentLeg)
+	assert.Equal(t, 0, transfers[0].PaymentLegIndex)
+}
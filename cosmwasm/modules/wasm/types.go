@@ -1,12 +1,17 @@
 package wasm
 
 import (
+	"encoding/json"
 	"fmt"
 
+	sdkMath "cosmossdk.io/math"
 	wasmTypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
 	txTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmwasm/modules/cw721"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
 const (
@@ -46,41 +51,302 @@ type ContractExecutionMessageHandlerByContractAddress interface {
 	ContractAddress() string
 }
 
+// ContractExecutionMessageHandlerResolver finds handlers for contracts whose registration depends on the chain state at the
+// height the contract is executed at, e.g. Osmosis CosmWasm pools that are created and reconfigured over time
+type ContractExecutionMessageHandlerResolver interface {
+	ResolveContractHandler(contractAddress string, height int64) (ContractExecutionMessageHandler, error)
+}
+
 type WrapperMsgExecuteContract struct {
 	txTypes.Message
 	CosmosMsgExecuteContract *wasmTypes.MsgExecuteContract
 	ContractAddressRegistry  map[string]ContractExecutionMessageHandler
+	ContractHandlerResolvers []ContractExecutionMessageHandlerResolver
+	Height                   int64
 	CurrentHandler           ContractExecutionMessageHandler
-	ContractAddress          string
+	// A fresh message from the current handler, this holds the parsed state for this execution only
+	CurrentMessage  txTypes.CosmosMessage
+	ContractAddress string
+	// NFTs moved by CW721 contracts in executions without a handler, with the fungible payment legs of NFT sales
+	NFTTransfers []nft.Transfer
+	PaymentLegs  []parsingTypes.MessageRelevantInformation
 }
 
-func (w WrapperMsgExecuteContract) HandleMsg(typeURL string, msg sdk.Msg, log *txTypes.LogMessage) error {
+func (w *WrapperMsgExecuteContract) SetHeight(height int64) {
+	w.Height = height
+}
+
+func (w *WrapperMsgExecuteContract) HandleMsg(typeURL string, msg sdk.Msg, log *txTypes.LogMessage) error {
 	w.Type = typeURL
 	w.CosmosMsgExecuteContract = msg.(*wasmTypes.MsgExecuteContract)
+	w.ContractAddress = w.CosmosMsgExecuteContract.Contract
+
+	handler, err := w.getContractHandler()
+	if err != nil {
+		return err
+	}
+
+	if handler == nil {
+		return w.handleCW721Transfers(log)
+	}
+
+	// Only executions with one of the handled top level fields are parsed, the rest are left unhandled
+	var executeMsg map[string]json.RawMessage
+	if err := json.Unmarshal(w.CosmosMsgExecuteContract.Msg, &executeMsg); err != nil {
+		return nil
+	}
+
+	for _, identifier := range handler.TopLevelFieldIdentifiers() {
+		if _, ok := executeMsg[identifier]; ok {
+			w.CurrentHandler = handler
+			w.CurrentMessage = handler.CosmosMessageType()
+			return w.CurrentMessage.HandleMsg(typeURL, msg, log)
+		}
+	}
+
+	return w.handleCW721Transfers(log)
+}
+
+// getContractHandler looks up the handler registered for the contract address, then asks the resolvers for the handler
+// of the contract at the height of the execution
+func (w *WrapperMsgExecuteContract) getContractHandler() (ContractExecutionMessageHandler, error) {
+	if handler, ok := w.ContractAddressRegistry[w.ContractAddress]; ok {
+		return handler, nil
+	}
+
+	for _, resolver := range w.ContractHandlerResolvers {
+		handler, err := resolver.ResolveContractHandler(w.ContractAddress, w.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		if handler != nil {
+			return handler, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// handleCW721Transfers records the NFTs moved by CW721 contracts during the execution. Marketplace sales pair the NFT with the
+// price paid by the buyer and the proceeds the seller received from the marketplace. Other executions pair the NFT with the
+// funds sent by the executor or the coins the executed contract sent back to them.
+func (w *WrapperMsgExecuteContract) handleCW721Transfers(log *txTypes.LogMessage) error {
+	w.NFTTransfers = cw721.ParseTransfers(log)
+	if len(w.NFTTransfers) == 0 {
+		return nil
+	}
 
-	if handler, ok := w.ContractAddressRegistry[w.CosmosMsgExecuteContract.Contract]; ok {
-		w.CurrentHandler = handler
-		return w.CurrentHandler.HandleMsg(typeURL, msg, log)
+	bankTransfers, err := parseBankTransfers(log)
+	if err != nil {
+		return &txTypes.MessageLogFormatError{MessageType: w.Type, Log: fmt.Sprintf("%+v", log)}
 	}
 
+	if sales := cw721.ParseSales(log); len(sales) > 0 {
+		if err := w.pairSalePayments(sales, bankTransfers); err != nil {
+			return &txTypes.MessageLogFormatError{MessageType: w.Type, Log: fmt.Sprintf("%+v", log)}
+		}
+		return nil
+	}
+
+	w.pairExecutorPayments(bankTransfers)
 	return nil
 }
 
-func (w WrapperMsgExecuteContract) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
-	if w.CurrentHandler != nil {
-		return w.CurrentHandler.ParseRelevantData()
+// pairSalePayments records a payment leg for each marketplace sale, from the buyer paying the price to the seller receiving
+// their proceeds. The proceeds are the coins the marketplace sent to the seller, net of the marketplace fees and royalties.
+// The transfers cannot be split between several sales of the same buyer or seller in one message, these only use the price.
+func (w *WrapperMsgExecuteContract) pairSalePayments(sales []cw721.Sale, bankTransfers []bankTransfer) error {
+	buyerSales := make(map[string]int)
+	sellerSales := make(map[string]int)
+	for _, sale := range sales {
+		buyerSales[sale.Marketplace+"/"+sale.Buyer]++
+		sellerSales[sale.Marketplace+"/"+sale.Seller]++
+	}
+
+	for _, sale := range sales {
+		transferIndex := cw721.FindSaleTransfer(w.NFTTransfers, sale)
+		if transferIndex < 0 {
+			continue
+		}
+
+		var paid, proceeds sdk.Coins
+		if buyerSales[sale.Marketplace+"/"+sale.Buyer] == 1 {
+			paid = sumBankTransfers(bankTransfers, sale.Buyer, sale.Marketplace)
+		}
+		if sellerSales[sale.Marketplace+"/"+sale.Seller] == 1 {
+			proceeds = sumBankTransfers(bankTransfers, sale.Marketplace, sale.Seller)
+		}
+
+		price, err := parseSalePrice(sale.Price, paid, proceeds)
+		if err != nil {
+			return err
+		}
+
+		var payment parsingTypes.MessageRelevantInformation
+		if price.IsValid() && price.IsPositive() {
+			payment.AmountSent = price.Amount.BigInt()
+			payment.DenominationSent = price.Denom
+			payment.SenderAddress = sale.Buyer
+		}
+
+		if len(proceeds) > 0 {
+			proceedsCoin := proceeds[0]
+			if proceeds.AmountOf(price.Denom).IsPositive() {
+				proceedsCoin = sdk.NewCoin(price.Denom, proceeds.AmountOf(price.Denom))
+			}
+
+			payment.AmountReceived = proceedsCoin.Amount.BigInt()
+			payment.DenominationReceived = proceedsCoin.Denom
+			payment.ReceiverAddress = sale.Seller
+		}
+
+		if payment.SenderAddress == "" && payment.ReceiverAddress == "" {
+			continue
+		}
+
+		w.NFTTransfers[transferIndex].PaymentLegIndex = len(w.PaymentLegs)
+		w.PaymentLegs = append(w.PaymentLegs, payment)
 	}
 
 	return nil
 }
 
-func (w WrapperMsgExecuteContract) GetType() string {
+// parseSalePrice returns the price of a sale. Prices without a denom are in the denom the buyer paid or the seller was paid in,
+// sales without a price use the coins the buyer sent to the marketplace.
+func parseSalePrice(price string, paid sdk.Coins, proceeds sdk.Coins) (sdk.Coin, error) {
+	if price == "" {
+		if len(paid) == 1 {
+			return paid[0], nil
+		}
+		return sdk.Coin{}, nil
+	}
+
+	if amount, ok := sdkMath.NewIntFromString(price); ok {
+		switch {
+		case len(paid) == 1:
+			return sdk.NewCoin(paid[0].Denom, amount), nil
+		case len(proceeds) == 1:
+			return sdk.NewCoin(proceeds[0].Denom, amount), nil
+		}
+		return sdk.Coin{}, nil
+	}
+
+	return sdk.ParseCoinNormalized(price)
+}
+
+// pairExecutorPayments records the funds sent by the executor and the coins the executed contract sent back to them, so a
+// single NFT bought or sold by the executor is paired with its price
+func (w *WrapperMsgExecuteContract) pairExecutorPayments(bankTransfers []bankTransfer) {
+	executor := w.CosmosMsgExecuteContract.Sender
+
+	paymentSentIndex := nft.NoPaymentLeg
+	for _, coin := range w.CosmosMsgExecuteContract.Funds {
+		if !coin.Amount.IsPositive() {
+			continue
+		}
+
+		if paymentSentIndex == nft.NoPaymentLeg {
+			paymentSentIndex = len(w.PaymentLegs)
+		}
+
+		w.PaymentLegs = append(w.PaymentLegs, parsingTypes.MessageRelevantInformation{
+			AmountSent:           coin.Amount.BigInt(),
+			DenominationSent:     coin.Denom,
+			AmountReceived:       coin.Amount.BigInt(),
+			DenominationReceived: coin.Denom,
+			SenderAddress:        executor,
+			ReceiverAddress:      w.ContractAddress,
+		})
+	}
+
+	paymentReceivedIndex := nft.NoPaymentLeg
+	for _, coin := range sumBankTransfers(bankTransfers, w.ContractAddress, executor) {
+		if paymentReceivedIndex == nft.NoPaymentLeg {
+			paymentReceivedIndex = len(w.PaymentLegs)
+		}
+
+		w.PaymentLegs = append(w.PaymentLegs, parsingTypes.MessageRelevantInformation{
+			AmountSent:           coin.Amount.BigInt(),
+			DenominationSent:     coin.Denom,
+			AmountReceived:       coin.Amount.BigInt(),
+			DenominationReceived: coin.Denom,
+			SenderAddress:        w.ContractAddress,
+			ReceiverAddress:      executor,
+		})
+	}
+
+	cw721.PairPayments(w.NFTTransfers, executor, paymentSentIndex, paymentReceivedIndex)
+}
+
+type bankTransfer struct {
+	Sender    string
+	Recipient string
+	Amount    sdk.Coins
+}
+
+// parseBankTransfers returns the bank transfers of the message, the funds sent with the execution and the transfers done by
+// the contracts
+func parseBankTransfers(log *txTypes.LogMessage) ([]bankTransfer, error) {
+	var transfers []bankTransfer
+	for _, transferEvent := range txTypes.SplitEventsOnAttribute(bankTypes.EventTypeTransfer, bankTypes.AttributeKeyRecipient, log) {
+		if transferEvent[sdk.AttributeKeyAmount] == "" {
+			continue
+		}
+
+		amount, err := sdk.ParseCoinsNormalized(transferEvent[sdk.AttributeKeyAmount])
+		if err != nil {
+			return nil, err
+		}
+
+		transfers = append(transfers, bankTransfer{
+			Sender:    transferEvent[bankTypes.AttributeKeySender],
+			Recipient: transferEvent[bankTypes.AttributeKeyRecipient],
+			Amount:    amount,
+		})
+	}
+
+	return transfers, nil
+}
+
+// sumBankTransfers returns the coins transferred from the sender to the recipient
+func sumBankTransfers(transfers []bankTransfer, sender string, recipient string) sdk.Coins {
+	coins := sdk.NewCoins()
+	for _, transfer := range transfers {
+		if transfer.Sender == sender && transfer.Recipient == recipient {
+			coins = coins.Add(transfer.Amount...)
+		}
+	}
+
+	return coins
+}
+
+func (w *WrapperMsgExecuteContract) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	if w.CurrentMessage != nil {
+		return w.CurrentMessage.ParseRelevantData()
+	}
+
+	return w.PaymentLegs
+}
+
+func (w *WrapperMsgExecuteContract) ParseNFTTransfers() []nft.Transfer {
+	if transferMessage, ok := w.CurrentMessage.(nft.TransferMessage); ok {
+		return transferMessage.ParseNFTTransfers()
+	}
+
+	return w.NFTTransfers
+}
+
+func (w *WrapperMsgExecuteContract) GetType() string {
 	return MsgExecuteContract
 }
 
-func (w WrapperMsgExecuteContract) String() string {
-	if w.CurrentHandler != nil {
-		return w.CurrentHandler.String()
+func (w *WrapperMsgExecuteContract) String() string {
+	if w.CurrentMessage != nil {
+		return w.CurrentMessage.String()
+	}
+	if len(w.NFTTransfers) > 0 {
+		return fmt.Sprintf("MsgExecuteContract: %d CW721 NFT transfers executed through contract address %s", len(w.NFTTransfers), w.ContractAddress)
 	}
 	return fmt.Sprintf("MsgExecuteContract: No handler found for contract address %s", w.ContractAddress)
 }
@@ -0,0 +1,121 @@
+package wasm
+
+import (
+	"testing"
+
+	wasmTypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	txTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketplaceSalePayment(t *testing.T) {
+	msg := &wasmTypes.MsgExecuteContract{
+		Sender:   "stars1buyer",
+		Contract: "stars1marketplace",
+		Msg:      []byte(`{"buy_now":{"collection":"stars1collection","token_id":"42"}}`),
+		Funds:    sdk.NewCoins(sdk.NewInt64Coin("ustars", 100)),
+	}
+
+	log := &txTypes.LogMessage{
+		Events: []txTypes.LogMessageEvent{
+			{Type: "coin_received", Attributes: []txTypes.Attribute{
+				{Key: "receiver", Value: "stars1marketplace"},
+				{Key: "amount", Value: "100ustars"},
+				{Key: "receiver", Value: "stars1buyer"},
+				{Key: "amount", Value: "7ustars"},
+			}},
+			{Type: "transfer", Attributes: []txTypes.Attribute{
+				{Key: "recipient", Value: "stars1marketplace"},
+				{Key: "sender", Value: "stars1buyer"},
+				{Key: "amount", Value: "100ustars"},
+				{Key: "recipient", Value: "stars1seller"},
+				{Key: "sender", Value: "stars1marketplace"},
+				{Key: "amount", Value: "90ustars"},
+				{Key: "recipient", Value: "stars1royalties"},
+				{Key: "sender", Value: "stars1marketplace"},
+				{Key: "amount", Value: "8ustars"},
+				{Key: "recipient", Value: "stars1buyer"},
+				{Key: "sender", Value: "stars1distribution"},
+				{Key: "amount", Value: "7ustars"},
+			}},
+			{Type: "wasm", Attributes: []txTypes.Attribute{
+				{Key: "_contract_address", Value: "stars1collection"},
+				{Key: "action", Value: "transfer_nft"},
+				{Key: "sender", Value: "stars1marketplace"},
+				{Key: "recipient", Value: "stars1buyer"},
+				{Key: "token_id", Value: "42"},
+			}},
+			{Type: "wasm-finalize-sale", Attributes: []txTypes.Attribute{
+				{Key: "_contract_address", Value: "stars1marketplace"},
+				{Key: "collection", Value: "stars1collection"},
+				{Key: "token_id", Value: "42"},
+				{Key: "seller", Value: "stars1seller"},
+				{Key: "buyer", Value: "stars1buyer"},
+				{Key: "price", Value: "100"},
+			}},
+		},
+	}
+
+	wrapper := &WrapperMsgExecuteContract{}
+	err := wrapper.HandleMsg(MsgExecuteContract, msg, log)
+	assert.Nil(t, err)
+
+	// The buyer paid the price and the seller received the proceeds net of the fees, the coins the buyer received from
+	// other modules are not part of the sale
+	payments := wrapper.ParseRelevantData()
+	assert.Len(t, payments, 1)
+	assert.Equal(t, "stars1buyer", payments[0].SenderAddress)
+	assert.Equal(t, "ustars", payments[0].DenominationSent)
+	assert.Equal(t, int64(100), payments[0].AmountSent.Int64())
+	assert.Equal(t, "stars1seller", payments[0].ReceiverAddress)
+	assert.Equal(t, "ustars", payments[0].DenominationReceived)
+	assert.Equal(t, int64(90), payments[0].AmountReceived.Int64())
+
+	transfers := wrapper.ParseNFTTransfers()
+	assert.Len(t, transfers, 1)
+	assert.Equal(t, 0, transfers[0].PaymentLegIndex)
+}
+
+func TestExecutorSalePayment(t *testing.T) {
+	msg := &wasmTypes.MsgExecuteContract{
+		Sender:   "stars1seller",
+		Contract: "stars1escrow",
+		Msg:      []byte(`{"swap":{}}`),
+	}
+
+	log := &txTypes.LogMessage{
+		Events: []txTypes.LogMessageEvent{
+			{Type: "transfer", Attributes: []txTypes.Attribute{
+				{Key: "recipient", Value: "stars1seller"},
+				{Key: "sender", Value: "stars1escrow"},
+				{Key: "amount", Value: "50ustars"},
+				{Key: "recipient", Value: "stars1seller"},
+				{Key: "sender", Value: "stars1distribution"},
+				{Key: "amount", Value: "3ustars"},
+			}},
+			{Type: "wasm", Attributes: []txTypes.Attribute{
+				{Key: "_contract_address", Value: "stars1collection"},
+				{Key: "action", Value: "transfer_nft"},
+				{Key: "sender", Value: "stars1seller"},
+				{Key: "recipient", Value: "stars1buyer"},
+				{Key: "token_id", Value: "7"},
+			}},
+		},
+	}
+
+	wrapper := &WrapperMsgExecuteContract{}
+	err := wrapper.HandleMsg(MsgExecuteContract, msg, log)
+	assert.Nil(t, err)
+
+	// Only the coins sent back by the executed contract are the payment
+	payments := wrapper.ParseRelevantData()
+	assert.Len(t, payments, 1)
+	assert.Equal(t, "stars1escrow", payments[0].SenderAddress)
+	assert.Equal(t, "stars1seller", payments[0].ReceiverAddress)
+	assert.Equal(t, int64(50), payments[0].AmountReceived.Int64())
+
+	transfers := wrapper.ParseNFTTransfers()
+	assert.Len(t, transfers, 1)
+	assert.Equal(t, 0, transfers[0].PaymentLegIndex)
+}
@@ -0,0 +1,155 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/ledger"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+)
+
+// The periods the reward and income rows of the exports can be aggregated per, no aggregation by default
+const (
+	AggregationNone  = ""
+	AggregationDay   = "day"
+	AggregationWeek  = "week"
+	AggregationMonth = "month"
+)
+
+var Aggregations = []string{AggregationDay, AggregationWeek, AggregationMonth}
+
+// aggregationPeriodKey returns the aggregation period of the time clipped to the export date range, the rewards before the
+// start date or at and after the end date are never merged with the rewards within it
+func aggregationPeriodKey(at time.Time, aggregation string, startDate, endDate *time.Time) string {
+	var clip string
+	switch {
+	case startDate != nil && at.Before(*startDate):
+		clip = "before"
+	case endDate != nil && !at.Before(*endDate):
+		clip = "after"
+	}
+
+	return aggregationPeriod(at, aggregation).Format(time.DateOnly) + clip
+}
+
+// aggregationPeriodStart returns the start of the aggregation period of the time in UTC, weeks start on Monday
+func aggregationPeriod(at time.Time, aggregation string) time.Time {
+	at = at.UTC()
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	switch aggregation {
+	case AggregationWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case AggregationMonth:
+		return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// AggregateTaxableTxs merges the income taxable txs of each period (clipped to the date range), chain, income source type,
+// denom, receiver and earner into the last of them, the other taxable txs are returned as is. The merged taxable tx keeps the tx of the last reward, so its
+// row is dated within the period, and has the heights of all the rewards. The fees of the txs are not merged.
+func AggregateTaxableTxs(taxableTxs []db.TaxableTransaction, aggregation string, startDate, endDate *time.Time) []db.TaxableTransaction {
+	if aggregation == AggregationNone {
+		return taxableTxs
+	}
+
+	var aggregated []db.TaxableTransaction
+	groups := make(map[string][]db.TaxableTransaction)
+	var keys []string
+	for _, taxableTx := range taxableTxs {
+		source := incomeMessageSources[taxableTx.Message.MessageType.MessageType]
+		if source == "" || taxableTx.DenominationSentID != nil || taxableTx.DenominationReceivedID == nil ||
+			parsers.IsTokenizedShareMint(taxableTx.Message.MessageType.MessageType, taxableTx.DenominationReceived.Base) {
+			aggregated = append(aggregated, taxableTx)
+			continue
+		}
+
+		block := taxableTx.Message.Tx.Block
+		key := fmt.Sprintf("%s|%d|%s|%d|%d|%d", aggregationPeriodKey(block.TimeStamp, aggregation, startDate, endDate), block.BlockchainID,
+			source, *taxableTx.DenominationReceivedID, taxableTx.ReceiverAddress.ID, taxableTx.EarnerAddress.ID)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], taxableTx)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) == 1 {
+			aggregated = append(aggregated, group[0])
+			continue
+		}
+
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Message.Tx.Block.Height < group[j].Message.Tx.Block.Height
+		})
+
+		merged := group[len(group)-1]
+		merged.AmountReceived = decimal.Zero
+		merged.AggregatedHeights = nil
+		for _, taxableTx := range group {
+			merged.AmountReceived = merged.AmountReceived.Add(taxableTx.AmountReceived)
+			merged.AggregatedHeights = append(merged.AggregatedHeights, taxableTx.Message.Tx.Block.Height)
+
+			// The rewards of several validators are not attributed to any of them
+			if taxableTx.ValidatorAddress != merged.ValidatorAddress {
+				merged.ValidatorAddress = ""
+				merged.ValidatorMoniker = ""
+			}
+		}
+		aggregated = append(aggregated, merged)
+	}
+
+	return aggregated
+}
+
+// AggregateTaxableEvents merges the income taxable events of each period (clipped to the date range), chain, source, denom
+// and address into the last of them, the other taxable events are returned as is
+func AggregateTaxableEvents(taxableEvents []db.TaxableEvent, aggregation string, startDate, endDate *time.Time) []db.TaxableEvent {
+	if aggregation == AggregationNone {
+		return taxableEvents
+	}
+
+	var aggregated []db.TaxableEvent
+	groups := make(map[string][]db.TaxableEvent)
+	var keys []string
+	for _, event := range taxableEvents {
+		if incomeEventSources[ledger.EventSourceName(event.Source)] == "" {
+			aggregated = append(aggregated, event)
+			continue
+		}
+
+		key := fmt.Sprintf("%s|%d|%d|%d|%d", aggregationPeriodKey(event.Block.TimeStamp, aggregation, startDate, endDate), event.Block.BlockchainID,
+			event.Source, event.DenominationID, event.AddressID)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], event)
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) == 1 {
+			aggregated = append(aggregated, group[0])
+			continue
+		}
+
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Block.Height < group[j].Block.Height
+		})
+
+		merged := group[len(group)-1]
+		merged.Amount = decimal.Zero
+		merged.AggregatedHeights = nil
+		for _, event := range group {
+			merged.Amount = merged.Amount.Add(event.Amount)
+			merged.AggregatedHeights = append(merged.AggregatedHeights, event.Block.Height)
+		}
+		aggregated = append(aggregated, merged)
+	}
+
+	return aggregated
+}
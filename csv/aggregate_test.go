@@ -0,0 +1,143 @@
+package csv
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/bank"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/accointing"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/gains"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestRewardTXs(t *testing.T, targetAddress db.Address, targetChain db.Chain) []db.TaxableTransaction {
+	sender := mkAddress(t, 2)
+	coinDenom, _ := mkDenom(1, "coin", "Some Coin", "SC")
+	rewardType := mkMsgType(1, distribution.MsgWithdrawDelegatorReward)
+	sendType := mkMsgType(2, bank.MsgSend)
+
+	// 2024-01-01 is a Monday
+	days := []time.Time{
+		time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 5, 12, 0, 0, 0, time.UTC),
+	}
+
+	var taxableTxs []db.TaxableTransaction
+	for i, day := range days {
+		id := uint(i + 1)
+		block := mkBlk(id, int64(id*100), day, targetChain)
+		tx := mkTx(id, "rewardhash"+string(rune('a'+i)), 0, block, targetAddress, nil)
+		reward := mkTaxableTransaction(id, mkMsg(id, tx, rewardType, 0), decimal.Zero, decimal.NewFromInt(10), db.Denom{}, coinDenom, db.Address{}, targetAddress)
+		reward.DenominationSentID = nil
+		reward.ValidatorAddress = "osmovaloper1"
+		taxableTxs = append(taxableTxs, reward)
+	}
+
+	block := mkBlk(10, 150, days[0], targetChain)
+	tx := mkTx(10, "sendhash", 0, block, sender, nil)
+	taxableTxs = append(taxableTxs, mkTaxableTransaction(10, mkMsg(10, tx, sendType, 0), decimal.NewFromInt(5), decimal.NewFromInt(5), coinDenom, coinDenom, sender, targetAddress))
+
+	return taxableTxs
+}
+
+func TestAggregateTaxableTxs(t *testing.T) {
+	targetAddress := mkAddress(t, 1)
+	chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+	taxableTxs := getTestRewardTXs(t, targetAddress, chain)
+
+	assert.Equal(t, taxableTxs, AggregateTaxableTxs(taxableTxs, AggregationNone, nil, nil))
+
+	monthly := AggregateTaxableTxs(taxableTxs, AggregationMonth, nil, nil)
+	assert.Equal(t, 3, len(monthly), "the send, the January rewards and the February reward")
+	assert.Equal(t, uint(10), monthly[0].ID, "transfers are not aggregated")
+	assert.Equal(t, "30", monthly[1].AmountReceived.String())
+	assert.Equal(t, []int64{100, 200, 300}, monthly[1].AggregatedHeights)
+	assert.Equal(t, "rewardhashc", monthly[1].Message.Tx.Hash, "the aggregated reward is dated at the last reward")
+	assert.Equal(t, "osmovaloper1", monthly[1].ValidatorAddress)
+	assert.Nil(t, monthly[2].AggregatedHeights)
+
+	weekly := AggregateTaxableTxs(taxableTxs, AggregationWeek, nil, nil)
+	assert.Equal(t, 4, len(weekly))
+	assert.Equal(t, []int64{100, 200}, weekly[1].AggregatedHeights)
+
+	daily := AggregateTaxableTxs(taxableTxs, AggregationDay, nil, nil)
+	assert.Equal(t, 5, len(daily))
+
+	parser := GetParser(accointing.ParserKey)
+	parser.InitializeParsingGroups()
+	err := parser.ProcessTaxableTx(targetAddress.Address, monthly, []db.Fee{})
+	assert.Nil(t, err)
+
+	rows, err := parser.GetRows(targetAddress.Address, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(rows))
+
+	var aggregatedRows int
+	for _, row := range rows {
+		if strings.Contains(row.(*accointing.Row).Comments, "Aggregated 3 rewards at heights 100, 200, 300") {
+			aggregatedRows++
+		}
+	}
+	assert.Equal(t, 1, aggregatedRows)
+}
+
+func TestAggregateTaxableEvents(t *testing.T) {
+	targetAddress := mkAddress(t, 1)
+	chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+	coinDenom, _ := mkDenom(1, "coin", "Some Coin", "SC")
+
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	events := []db.TaxableEvent{
+		mkTaxableEvent(1, decimal.NewFromInt(1), coinDenom, targetAddress, mkBlk(1, 10, day, chain)),
+		mkTaxableEvent(2, decimal.NewFromInt(2), coinDenom, targetAddress, mkBlk(2, 20, day.Add(time.Hour), chain)),
+		mkTaxableEvent(3, decimal.NewFromInt(4), coinDenom, targetAddress, mkBlk(3, 30, day.Add(time.Hour*24), chain)),
+	}
+	slash := mkTaxableEvent(4, decimal.NewFromInt(8), coinDenom, targetAddress, mkBlk(4, 40, day, chain))
+	slash.Source = db.StakingSlash
+	events = append(events, slash)
+
+	daily := AggregateTaxableEvents(events, AggregationDay, nil, nil)
+	assert.Equal(t, 3, len(daily))
+	assert.Equal(t, db.StakingSlash, daily[0].Source, "slashes are not income")
+	assert.Equal(t, "3", daily[1].Amount.String())
+	assert.Equal(t, []int64{10, 20}, daily[1].AggregatedHeights)
+	assert.Equal(t, uint(2), daily[1].ID)
+
+	monthly := AggregateTaxableEvents(events, AggregationMonth, nil, nil)
+	assert.Equal(t, 2, len(monthly))
+	assert.Equal(t, "7", monthly[1].Amount.String())
+}
+
+func TestAggregateClippedToDateRange(t *testing.T) {
+	targetAddress := mkAddress(t, 1)
+	chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+	taxableTxs := getTestRewardTXs(t, targetAddress, chain)
+
+	// The January rewards after the end date belong to the next export
+	endDate := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	monthly := AggregateTaxableTxs(taxableTxs, AggregationMonth, nil, &endDate)
+	assert.Equal(t, 4, len(monthly))
+	assert.Equal(t, []int64{100, 200}, monthly[1].AggregatedHeights)
+	assert.Equal(t, "rewardhashb", monthly[1].Message.Tx.Hash, "the aggregated reward is dated within the date range")
+	assert.Nil(t, monthly[2].AggregatedHeights)
+
+	// The January rewards before the start date belong to the previous export
+	startDate := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	monthly = AggregateTaxableTxs(taxableTxs, AggregationMonth, &startDate, nil)
+	assert.Equal(t, 4, len(monthly))
+	assert.Equal(t, "rewardhasha", monthly[1].Message.Tx.Hash)
+	assert.Nil(t, monthly[1].AggregatedHeights)
+	assert.Equal(t, []int64{200, 300}, monthly[2].AggregatedHeights)
+
+	// The gains formats value each reward as its own lot
+	_, isLotParser := GetParser(gains.ParserKeyFIFO).(parsers.LotParser)
+	assert.True(t, isLotParser)
+}
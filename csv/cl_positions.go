@@ -0,0 +1,137 @@
+package csv
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+)
+
+var clPositionsHeaders = []string{
+	"Chain", "Position ID", "Pool ID", "Lower Tick", "Upper Tick", "Owner", "Open", "Withdrawals", "Transfers",
+	"Currency", "Deposited", "Withdrawn", "Spread Rewards", "Incentives", "Net",
+}
+
+type clPositionsRow struct {
+	Chain         string
+	PositionID    string
+	PoolID        string
+	LowerTick     string
+	UpperTick     string
+	Owner         string
+	Open          string
+	Withdrawals   string
+	Transfers     string
+	Currency      string
+	Deposited     string
+	Withdrawn     string
+	SpreadRewards string
+	Incentives    string
+	Net           string
+}
+
+func (row clPositionsRow) GetRowForCsv() []string {
+	return []string{
+		row.Chain, row.PositionID, row.PoolID, row.LowerTick, row.UpperTick, row.Owner, row.Open, row.Withdrawals, row.Transfers,
+		row.Currency, row.Deposited, row.Withdrawn, row.SpreadRewards, row.Incentives, row.Net,
+	}
+}
+
+func (row clPositionsRow) GetDate() string {
+	return ""
+}
+
+// CLPositionsToCsvRows returns the cl-positions report rows and headers, one row per position and denom the position
+// held or paid out. The amounts are converted to the display unit of the denom when it is known
+func CLPositionsToCsvRows(summaries []db.CLPositionSummary) ([]parsers.CsvRow, []string) {
+	var rows []parsers.CsvRow
+	for _, summary := range summaries {
+		denoms := make(map[string]struct{})
+		for _, totals := range []map[string]decimal.Decimal{summary.Deposited, summary.Withdrawn, summary.SpreadRewards, summary.Incentives} {
+			for denom := range totals {
+				denoms[denom] = struct{}{}
+			}
+		}
+
+		sortedDenoms := make([]string, 0, len(denoms))
+		for denom := range denoms {
+			sortedDenoms = append(sortedDenoms, denom)
+		}
+		sort.Strings(sortedDenoms)
+
+		for _, base := range sortedDenoms {
+			row := clPositionsRow{
+				Chain:         summary.ChainID,
+				PositionID:    strconv.FormatUint(summary.PositionID, 10),
+				PoolID:        strconv.FormatUint(summary.PoolID, 10),
+				LowerTick:     strconv.FormatInt(summary.LowerTick, 10),
+				UpperTick:     strconv.FormatInt(summary.UpperTick, 10),
+				Owner:         summary.Owner,
+				Open:          strconv.FormatBool(summary.Open),
+				Withdrawals:   strconv.Itoa(len(summary.Withdrawals)),
+				Transfers:     strconv.Itoa(len(summary.Transfers)),
+				Currency:      base,
+				Deposited:     summary.Deposited[base].String(),
+				Withdrawn:     summary.Withdrawn[base].String(),
+				SpreadRewards: summary.SpreadRewards[base].String(),
+				Incentives:    summary.Incentives[base].String(),
+				Net:           summary.Net[base].String(),
+			}
+
+			exponent, symbol, err := clPositionDisplayUnit(base)
+			if err == nil {
+				shift := -int32(exponent)
+				row.Currency = symbol
+				row.Deposited = summary.Deposited[base].Shift(shift).String()
+				row.Withdrawn = summary.Withdrawn[base].Shift(shift).String()
+				row.SpreadRewards = summary.SpreadRewards[base].Shift(shift).String()
+				row.Incentives = summary.Incentives[base].Shift(shift).String()
+				row.Net = summary.Net[base].Shift(shift).String()
+			} else {
+				config.Log.Debugf("Cannot convert CL position amounts of denom %s, using the base amounts", base)
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, clPositionsHeaders
+}
+
+func clPositionDisplayUnit(base string) (uint, string, error) {
+	denom, err := db.GetDenomForBase(base)
+	if err != nil {
+		return 0, "", err
+	}
+	return db.GetDisplayExponent(denom)
+}
+
+// UniqueCLPositions returns the positions of the addresses sorted by chain and position ID, a position transferred between
+// the addresses is summarized for each of them and is only returned once
+func UniqueCLPositions(positions map[string][]db.CLPositionSummary) []db.CLPositionSummary {
+	var summaries []db.CLPositionSummary
+	seen := make(map[string]bool)
+	for _, addressSummaries := range positions {
+		for _, summary := range addressSummaries {
+			key := fmt.Sprintf("%s/%d", summary.ChainID, summary.PositionID)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			summaries = append(summaries, summary)
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].ChainID != summaries[j].ChainID {
+			return summaries[i].ChainID < summaries[j].ChainID
+		}
+		return summaries[i].PositionID < summaries[j].PositionID
+	})
+
+	return summaries
+}
@@ -0,0 +1,48 @@
+package csv
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLPositionsToCsvRows(t *testing.T) {
+	position := db.CLPositionSummary{
+		ChainID:       "osmosis-1",
+		PositionID:    42,
+		PoolID:        1,
+		LowerTick:     -100,
+		UpperTick:     100,
+		Owner:         "osmo1owner",
+		Deposited:     map[string]decimal.Decimal{"uosmo": decimal.NewFromInt(1000), "ibc/UNKNOWN": decimal.NewFromInt(500)},
+		Withdrawn:     map[string]decimal.Decimal{"uosmo": decimal.NewFromInt(900), "ibc/UNKNOWN": decimal.NewFromInt(550)},
+		SpreadRewards: map[string]decimal.Decimal{"uosmo": decimal.NewFromInt(20)},
+		Incentives:    map[string]decimal.Decimal{},
+		Net:           map[string]decimal.Decimal{"uosmo": decimal.NewFromInt(-80), "ibc/UNKNOWN": decimal.NewFromInt(50)},
+		Withdrawals:   []db.CLPositionWithdrawal{{}},
+	}
+
+	// The position was transferred from addr1 to addr2, it is summarized for both
+	positions := map[string][]db.CLPositionSummary{
+		"addr1": {position},
+		"addr2": {position, {ChainID: "osmosis-1", PositionID: 7}},
+	}
+
+	summaries := UniqueCLPositions(positions)
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, uint64(7), summaries[0].PositionID)
+	assert.Equal(t, uint64(42), summaries[1].PositionID)
+
+	rows, headers := CLPositionsToCsvRows(summaries)
+	assert.Equal(t, clPositionsHeaders, headers)
+	assert.Len(t, rows, 2, "a position without amounts has no rows")
+
+	// Denoms without known units keep their base amounts
+	assert.Equal(t, []string{
+		"osmosis-1", "42", "1", "-100", "100", "osmo1owner", "false", "1", "0",
+		"ibc/UNKNOWN", "500", "550", "0", "0", "50",
+	}, rows[0].GetRowForCsv())
+	assert.Equal(t, "uosmo", rows[1].GetRowForCsv()[9])
+}
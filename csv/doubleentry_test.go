@@ -0,0 +1,95 @@
+// nolint:unused
+package csv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/doubleentry"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoubleEntryOsmoLPBalanced(t *testing.T) {
+	for _, parserKey := range doubleentry.ParserKeys {
+		parser := GetParser(parserKey)
+		parser.InitializeParsingGroups()
+
+		targetAddress := mkAddress(t, 1)
+		chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+		swapTxs := getTestSwapTXs(t, targetAddress, chain)
+
+		err := parser.ProcessTaxableTx(targetAddress.Address, swapTxs, []db.Fee{})
+		assert.Nil(t, err, parserKey)
+
+		rows, err := parser.GetRows(targetAddress.Address, nil, nil)
+		assert.Nil(t, err, parserKey)
+		assert.Equal(t, 4, len(rows), "%s should have an entry for each tx", parserKey)
+
+		for _, row := range rows {
+			entry := row.(doubleentry.Entry)
+			sums := make(map[string]decimal.Decimal)
+			for _, posting := range entry.Postings {
+				sums[posting.Commodity] = sums[posting.Commodity].Add(posting.Amount)
+				if !strings.HasPrefix(posting.Account, doubleentry.WalletsAccount) {
+					assert.Equal(t, doubleentry.TradingAccount, posting.Account, parserKey)
+				}
+			}
+			for commodity, sum := range sums {
+				assert.Truef(t, sum.IsZero(), "%s entry %s is not balanced in %s", parserKey, entry.TxHash, commodity)
+			}
+		}
+
+		headers := strings.Join(parser.GetHeaders(), "\n")
+		output := ToText(rows, parser.GetHeaders())
+		if parserKey == doubleentry.ParserKeyBeancount {
+			assert.Contains(t, headers, "commodity SC1\n  denom: \"coin1\"")
+			assert.Contains(t, headers, "open "+doubleentry.TradingAccount)
+			assert.Contains(t, output.String(), "tx_hash: \"somehash1\"")
+		} else {
+			assert.Contains(t, headers, "commodity \"SC1\"\n    note coin1")
+			assert.Contains(t, headers, "account "+doubleentry.TradingAccount)
+			assert.Contains(t, output.String(), "; tx_hash: somehash1")
+		}
+	}
+}
+
+func TestDoubleEntryFeesAndRewards(t *testing.T) {
+	parser := GetParser(doubleentry.ParserKeyBeancount)
+	parser.InitializeParsingGroups()
+
+	targetAddress := mkAddress(t, 1)
+	chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+	events := getTestTaxableEvents(t, targetAddress, chain)
+
+	block := events[0].Block
+	tx := mkTx(10, "feehash", 0, block, targetAddress, nil)
+	fee := db.Fee{ID: 1, TxID: tx.ID, Tx: tx, Amount: decimal.NewFromInt(2500), Denomination: events[0].Denomination, PayerAddress: targetAddress}
+
+	err := parser.ProcessTaxableTx(targetAddress.Address, nil, []db.Fee{fee})
+	assert.Nil(t, err)
+	err = parser.ProcessTaxableEvent(events)
+	assert.Nil(t, err)
+
+	rows, err := parser.GetRows(targetAddress.Address, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(rows))
+
+	var feeAccount, rewardAccount bool
+	for _, row := range rows {
+		for _, posting := range row.(doubleentry.Entry).Postings {
+			switch {
+			case strings.HasPrefix(posting.Account, doubleentry.FeesAccount):
+				feeAccount = true
+				assert.Equal(t, "2500", posting.Amount.String())
+			case posting.Account == doubleentry.RewardIncomeAccount:
+				rewardAccount = true
+				assert.True(t, posting.Amount.IsNegative())
+			}
+		}
+	}
+	assert.True(t, feeAccount, "the fee should be posted to the fees account")
+	assert.True(t, rewardAccount, "the rewards should be posted to the rewards income account")
+}
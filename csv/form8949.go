@@ -0,0 +1,259 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/gains"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const form8949DateLayout = "01/02/2006"
+
+// The reports of the tax-report command and endpoints
+const (
+	ReportForm8949  = "form8949"
+	ReportScheduleD = "schedule-d"
+)
+
+var Reports = []string{ReportForm8949, ReportScheduleD}
+
+// The Form 8949 boxes of the disposals of digital assets without a Form 1099-B or 1099-DA, short-term and long-term. The
+// digital asset boxes were added to the form for the 2025 tax year.
+const (
+	BoxShortTerm             = "C"
+	BoxLongTerm              = "F"
+	BoxShortTermDigitalAsset = "I"
+	BoxLongTermDigitalAsset  = "L"
+	firstDigitalAssetBoxYear = 2025
+)
+
+// The Schedule D lines the totals of the Form 8949 boxes are reported on, and the net short-term and long-term lines
+var scheduleDLines = map[string]string{
+	BoxShortTerm:             "3",
+	BoxShortTermDigitalAsset: "3",
+	BoxLongTerm:              "10",
+	BoxLongTermDigitalAsset:  "10",
+}
+
+var form8949Headers = []string{
+	"Part", "Box", "(a) Description of property", "(b) Date acquired", "(c) Date sold or disposed of", "(d) Proceeds",
+	"(e) Cost or other basis", "(f) Code(s)", "(g) Amount of adjustment", "(h) Gain or (loss)", "Address", "Tx Hash",
+}
+
+var scheduleDHeaders = []string{
+	"Line", "Part", "Box", "(d) Proceeds", "(e) Cost or other basis", "(g) Adjustments", "(h) Gain or (loss)",
+	"Form 8949 Lines", "Lines Missing Values",
+}
+
+// Form8949Line is a line of Form 8949, the realized gain of a disposal for one holding period. The proceeds, cost basis and
+// gain are empty when the price or the acquisition of the disposed tokens is unknown.
+type Form8949Line struct {
+	Part         string
+	Box          string
+	Description  string
+	DateAcquired string
+	DateSold     string
+	Proceeds     string
+	CostBasis    string
+	Code         string
+	Adjustment   string
+	Gain         string
+	Address      string
+	TxHash       string
+}
+
+func (line Form8949Line) GetRowForCsv() []string {
+	return []string{
+		line.Part, line.Box, line.Description, line.DateAcquired, line.DateSold, line.Proceeds, line.CostBasis, line.Code,
+		line.Adjustment, line.Gain, line.Address, line.TxHash,
+	}
+}
+
+func (line Form8949Line) GetDate() string {
+	return line.DateSold
+}
+
+// ScheduleDLine is the total of a Form 8949 box on Schedule D, or the net short-term or long-term gain of the boxes
+type ScheduleDLine struct {
+	Line          string
+	Part          string
+	Box           string
+	Proceeds      decimal.Decimal
+	CostBasis     decimal.Decimal
+	Adjustments   decimal.Decimal
+	Gain          decimal.Decimal
+	Lines         int
+	MissingValues int
+}
+
+func (line ScheduleDLine) GetRowForCsv() []string {
+	return []string{
+		line.Line, line.Part, line.Box, line.Proceeds.StringFixed(2), line.CostBasis.StringFixed(2), line.Adjustments.StringFixed(2),
+		line.Gain.StringFixed(2), strconv.Itoa(line.Lines), strconv.Itoa(line.MissingValues),
+	}
+}
+
+func (line ScheduleDLine) GetDate() string {
+	return ""
+}
+
+// Form8949ForAddresses computes the realized gains of the addresses with the cost basis method of the gains parser key and
+// returns the Form 8949 lines of the disposals between the dates
+func Form8949ForAddresses(addresses []string, startDate, endDate *time.Time, pgSQL *gorm.DB, parserKey string) ([]Form8949Line, error) {
+	if !slices.Contains(gains.ParserKeys, parserKey) {
+		return nil, fmt.Errorf("invalid cost basis method %s, valid methods are %s", parserKey, gains.ParserKeys)
+	}
+
+	rows, _, _, err := ParseForAddress(addresses, startDate, endDate, pgSQL, parserKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return Form8949Lines(rows)
+}
+
+// The disposals reported on Form 8949: the trades and the tokens spent on fees. Transfers out of the address set are not
+// sales and the tokens lost to slashes and burned deposits are not sold, they are left out.
+var form8949DisposalTypes = map[string]bool{
+	gains.Trade: true,
+	gains.Fee:   true,
+}
+
+// Form8949Lines returns the Form 8949 lines of the realized gains rows, ordered by box and by date sold
+func Form8949Lines(rows []parsers.CsvRow) ([]Form8949Line, error) {
+	var lines []Form8949Line
+	var sold []time.Time
+	for _, csvRow := range rows {
+		row, ok := csvRow.(gains.Row)
+		if !ok {
+			return nil, errors.New("the Form 8949 lines are computed from the realized gains rows")
+		}
+		if !form8949DisposalTypes[row.Type] {
+			continue
+		}
+
+		dateSold, err := time.Parse(gains.TimeLayout, row.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		line := Form8949Line{
+			Description:  fmt.Sprintf("%s %s", row.Amount, row.Currency),
+			DateAcquired: row.DateAcquired,
+			DateSold:     dateSold.Format(form8949DateLayout),
+			Proceeds:     row.Proceeds,
+			CostBasis:    row.CostBasis,
+			Gain:         row.Gain,
+			Address:      row.Address,
+			TxHash:       row.TxHash,
+		}
+
+		if dateAcquired, err := time.Parse(gains.TimeLayout, row.DateAcquired); err == nil {
+			line.DateAcquired = dateAcquired.Format(form8949DateLayout)
+		}
+
+		// Disposals without a matched lot have an unknown holding period, they are reported as short-term
+		line.Part, line.Box = "I", BoxShortTerm
+		if row.Term == gains.LongTerm {
+			line.Part, line.Box = "II", BoxLongTerm
+		}
+		if dateSold.Year() >= firstDigitalAssetBoxYear {
+			line.Box = map[string]string{BoxShortTerm: BoxShortTermDigitalAsset, BoxLongTerm: BoxLongTermDigitalAsset}[line.Box]
+		}
+
+		lines = append(lines, line)
+		sold = append(sold, dateSold)
+	}
+
+	indexes := make([]int, len(lines))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		left, right := lines[indexes[i]], lines[indexes[j]]
+		if left.Box != right.Box {
+			return left.Box < right.Box
+		}
+		return sold[indexes[i]].Before(sold[indexes[j]])
+	})
+
+	sorted := make([]Form8949Line, len(lines))
+	for i, index := range indexes {
+		sorted[i] = lines[index]
+	}
+
+	return sorted, nil
+}
+
+// ScheduleDTotals returns the Schedule D totals of the Form 8949 boxes of the lines, followed by the net short-term (line 7)
+// and long-term (line 15) gains of the boxes. Lines missing the proceeds or the cost basis are only counted.
+func ScheduleDTotals(lines []Form8949Line) []ScheduleDLine {
+	totals := make(map[string]*ScheduleDLine)
+	var boxes []string
+	for _, line := range lines {
+		total, ok := totals[line.Box]
+		if !ok {
+			total = &ScheduleDLine{Line: scheduleDLines[line.Box], Part: line.Part, Box: line.Box}
+			totals[line.Box] = total
+			boxes = append(boxes, line.Box)
+		}
+
+		total.Lines++
+		proceeds, proceedsErr := decimal.NewFromString(line.Proceeds)
+		costBasis, costBasisErr := decimal.NewFromString(line.CostBasis)
+		if proceedsErr != nil || costBasisErr != nil {
+			total.MissingValues++
+			continue
+		}
+
+		total.Proceeds = total.Proceeds.Add(proceeds)
+		total.CostBasis = total.CostBasis.Add(costBasis)
+		total.Gain = total.Gain.Add(proceeds.Sub(costBasis))
+	}
+	sort.Strings(boxes)
+
+	net := map[string]*ScheduleDLine{
+		"I":  {Line: "7", Part: "I"},
+		"II": {Line: "15", Part: "II"},
+	}
+
+	var scheduleD []ScheduleDLine
+	for _, box := range boxes {
+		total := totals[box]
+		scheduleD = append(scheduleD, *total)
+
+		partTotal := net[total.Part]
+		partTotal.Proceeds = partTotal.Proceeds.Add(total.Proceeds)
+		partTotal.CostBasis = partTotal.CostBasis.Add(total.CostBasis)
+		partTotal.Gain = partTotal.Gain.Add(total.Gain)
+		partTotal.Lines += total.Lines
+		partTotal.MissingValues += total.MissingValues
+	}
+
+	return append(scheduleD, *net["I"], *net["II"])
+}
+
+// Form8949ToCsvRows returns the Form 8949 report rows and headers
+func Form8949ToCsvRows(lines []Form8949Line) ([]parsers.CsvRow, []string) {
+	rows := make([]parsers.CsvRow, len(lines))
+	for i, line := range lines {
+		rows[i] = line
+	}
+	return rows, form8949Headers
+}
+
+// ScheduleDToCsvRows returns the Schedule D report rows and headers
+func ScheduleDToCsvRows(lines []ScheduleDLine) ([]parsers.CsvRow, []string) {
+	rows := make([]parsers.CsvRow, len(lines))
+	for i, line := range lines {
+		rows[i] = line
+	}
+	return rows, scheduleDHeaders
+}
@@ -0,0 +1,59 @@
+package csv
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/gains"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForm8949BoxesAndScheduleDTotals(t *testing.T) {
+	rows := []parsers.CsvRow{
+		gains.Row{Type: gains.Trade, Date: "2024-03-01 10:00:00", Amount: "10", Currency: "OSMO", DateAcquired: "2023-01-01 10:00:00", Term: gains.LongTerm, Proceeds: "12.00", CostBasis: "5.00", Gain: "7.00"},
+		gains.Row{Type: gains.Trade, Date: "2024-02-01 10:00:00", Amount: "5", Currency: "ATOM", DateAcquired: "VARIOUS", Term: gains.ShortTerm, Proceeds: "50.00", CostBasis: "60.00", Gain: "-10.00"},
+		gains.Row{Type: gains.Trade, Date: "2025-01-15 10:00:00", Amount: "1", Currency: "ATOM", DateAcquired: "2024-12-01 10:00:00", Term: gains.ShortTerm, Proceeds: "8.00", CostBasis: "6.00", Gain: "2.00"},
+		gains.Row{Type: gains.Trade, Date: "2025-02-15 10:00:00", Amount: "2", Currency: "OSMO", Lots: "unmatched", Proceeds: "1.00"},
+		// Transfers out of the address set and lost tokens are not sales
+		gains.Row{Type: gains.Transfer, Date: "2024-05-01 10:00:00", Amount: "3", Currency: "ATOM", Term: gains.ShortTerm, CostBasis: "30.00"},
+		gains.Row{Type: gains.Lost, Date: "2024-06-01 10:00:00", Amount: "1", Currency: "ATOM", Term: gains.ShortTerm, Proceeds: "0.00", CostBasis: "10.00", Gain: "-10.00"},
+	}
+
+	lines, err := Form8949Lines(rows)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(lines))
+
+	// Ordered by box then by date sold, disposals from 2025 are in the digital asset boxes
+	assert.Equal(t, "C", lines[0].Box)
+	assert.Equal(t, "I", lines[0].Part)
+	assert.Equal(t, "VARIOUS", lines[0].DateAcquired)
+	assert.Equal(t, "02/01/2024", lines[0].DateSold)
+	assert.Equal(t, "F", lines[1].Box)
+	assert.Equal(t, "II", lines[1].Part)
+	assert.Equal(t, "01/01/2023", lines[1].DateAcquired)
+	assert.Equal(t, "10 OSMO", lines[1].Description)
+	assert.Equal(t, "I", lines[2].Box)
+	assert.Equal(t, "01/15/2025", lines[2].DateSold)
+	assert.Equal(t, "I", lines[3].Box, "the unmatched disposals are short-term")
+	assert.Equal(t, "", lines[3].CostBasis)
+
+	totals := ScheduleDTotals(lines)
+	assert.Equal(t, 5, len(totals))
+
+	byLine := make(map[string]ScheduleDLine)
+	for _, total := range totals {
+		byLine[total.Line+total.Box] = total
+	}
+
+	assert.Equal(t, "-10.00", byLine["3C"].Gain.StringFixed(2))
+	assert.Equal(t, "7.00", byLine["10F"].Gain.StringFixed(2))
+	assert.Equal(t, "2.00", byLine["3I"].Gain.StringFixed(2))
+	assert.Equal(t, 2, byLine["3I"].Lines)
+	assert.Equal(t, 1, byLine["3I"].MissingValues)
+
+	assert.Equal(t, "-8.00", byLine["7"].Gain.StringFixed(2))
+	assert.Equal(t, "58.00", byLine["7"].Proceeds.StringFixed(2))
+	assert.Equal(t, 3, byLine["7"].Lines)
+	assert.Equal(t, 1, byLine["7"].MissingValues)
+	assert.Equal(t, "7.00", byLine["15"].Gain.StringFixed(2))
+}
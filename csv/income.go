@@ -0,0 +1,256 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/claim"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/gains"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/ledger"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/concentratedliquidity"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/valsetpref"
+	strideClaim "github.com/DefiantLabs/cosmos-tax-cli/stride/modules/claim"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// The income source types of the income summary, and the source of the fiat total row of each month
+const (
+	IncomeStaking      = "staking"
+	IncomeLPIncentives = "lp_incentives"
+	IncomeProtorev     = "protorev"
+	IncomeAirdrop      = "airdrop"
+	IncomeVesting      = "vesting"
+	IncomeCommission   = "commission"
+	IncomeTotal        = "total"
+)
+
+const incomeMonthLayout = "2006-01"
+
+// The income source types of the tokens received by message type, staking messages withdraw the pending rewards automatically
+var incomeMessageSources = map[string]string{
+	distribution.MsgWithdrawRewards:                    IncomeStaking,
+	distribution.MsgWithdrawDelegatorReward:            IncomeStaking,
+	staking.MsgDelegate:                                IncomeStaking,
+	staking.MsgUndelegate:                              IncomeStaking,
+	staking.MsgBeginRedelegate:                         IncomeStaking,
+	staking.MsgTokenizeShares:                          IncomeStaking,
+	staking.MsgRedeemTokensForShares:                   IncomeStaking,
+	staking.MsgTransferTokenizeShareRecord:             IncomeStaking,
+	staking.MsgValidatorBond:                           IncomeStaking,
+	valsetpref.MsgDelegateBondedTokens:                 IncomeStaking,
+	valsetpref.MsgUndelegateFromValidatorSet:           IncomeStaking,
+	valsetpref.MsgRedelegateValidatorSet:               IncomeStaking,
+	valsetpref.MsgWithdrawDelegationRewards:            IncomeStaking,
+	valsetpref.MsgDelegateToValidatorSet:               IncomeStaking,
+	valsetpref.MsgUndelegateFromRebalancedValidatorSet: IncomeStaking,
+	distribution.MsgWithdrawValidatorCommission:        IncomeCommission,
+	concentratedliquidity.MsgCollectIncentives:         IncomeLPIncentives,
+	concentratedliquidity.MsgCollectSpreadRewards:      IncomeLPIncentives,
+	strideClaim.MsgClaimFreeAmount:                     IncomeAirdrop,
+	claim.StargazeMsgInitialClaim:                      IncomeAirdrop,
+	claim.StargazeMsgClaimFor:                          IncomeAirdrop,
+	claim.CrescentMsgClaim:                             IncomeAirdrop,
+	claim.QuicksilverMsgClaim:                          IncomeAirdrop,
+}
+
+// The income source types of the taxable event sources
+var incomeEventSources = map[string]string{
+	ledger.EventSourceName(db.OsmosisRewardDistribution):                  IncomeLPIncentives,
+	ledger.EventSourceName(db.OsmosisProtorevDeveloperRewardDistribution): IncomeProtorev,
+	ledger.EventSourceName(db.ClaimHookAirdrop):                           IncomeAirdrop,
+	ledger.EventSourceName(db.VestingAccountUnlock):                       IncomeVesting,
+}
+
+var incomeSummaryHeaders = []string{"Month", "Source", "Denom", "Symbol", "Amount", "Rows", "Fiat Value", "Fiat Currency", "Unpriced Rows"}
+
+// IncomeSummaryRow is the income of a source type in a denom during a month. The amount is in display units, or in the base
+// denom when the units of the denom are unknown. The total rows only have the fiat value of the month.
+type IncomeSummaryRow struct {
+	Month        string
+	Source       string
+	Denom        string
+	Symbol       string
+	Amount       decimal.Decimal
+	Rows         int
+	FiatValue    decimal.Decimal
+	FiatCurrency string
+	UnpricedRows int
+}
+
+func (row IncomeSummaryRow) GetRowForCsv() []string {
+	amount := row.Amount.String()
+	if row.Source == IncomeTotal {
+		amount = ""
+	}
+
+	fiatValue := ""
+	if row.FiatCurrency != "" {
+		fiatValue = row.FiatValue.StringFixed(2)
+	}
+
+	return []string{
+		row.Month, row.Source, row.Denom, row.Symbol, amount, strconv.Itoa(row.Rows), fiatValue, row.FiatCurrency,
+		strconv.Itoa(row.UnpricedRows),
+	}
+}
+
+func (row IncomeSummaryRow) GetDate() string {
+	return row.Month
+}
+
+// IncomeSummaryForAddresses returns the income of the addresses between the dates per month, source type and denom. The
+// income is valued at receipt in the fiat currency when it is set, with a total row per month.
+func IncomeSummaryForAddresses(addresses []string, startDate, endDate *time.Time, pgSQL *gorm.DB, fiatCurrency string) ([]IncomeSummaryRow, error) {
+	rows, _, _, err := ParseForAddress(addresses, startDate, endDate, pgSQL, ledger.ParserKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices parsers.PriceLookup
+	if fiatCurrency != "" {
+		var lookup parsers.PriceLookup = db.NewPriceLookup(pgSQL, fiatCurrency)
+		// The Coinbase prices are in USD, like the gains formats they are used for the denoms without imported prices
+		if fiatCurrency == gains.FiatCurrency {
+			lookup = parsers.PriceLookups{lookup, gains.NewCoinbasePrices()}
+		}
+		prices = lookup
+	}
+
+	return IncomeSummary(rows, addresses, prices, fiatCurrency)
+}
+
+// IncomeSource returns the income source type of a ledger leg, or an empty string when the leg is not income
+func IncomeSource(row ledger.Row) string {
+	switch row.Leg {
+	case ledger.LegReceived:
+		if parsers.IsTokenizedShareMint(row.MessageType, row.Denom) {
+			return ""
+		}
+		return incomeMessageSources[row.MessageType]
+	case ledger.LegEvent:
+		if row.Direction == ledger.DirectionIn {
+			return incomeEventSources[row.EventSource]
+		}
+	}
+	return ""
+}
+
+// IncomeSummary aggregates the income legs of the ledger rows of the addresses per month, source type and denom. Rewards paid to
+// a withdraw address are income of the address that earned them, each leg is counted once for the address set.
+func IncomeSummary(rows []parsers.CsvRow, addresses []string, prices parsers.PriceLookup, fiatCurrency string) ([]IncomeSummaryRow, error) {
+	if prices == nil {
+		fiatCurrency = ""
+	}
+
+	addressSet := make(map[string]bool)
+	for _, address := range addresses {
+		addressSet[address] = true
+	}
+
+	summary := make(map[string]*IncomeSummaryRow)
+	seen := make(map[string]bool)
+	for _, csvRow := range rows {
+		row, ok := csvRow.(ledger.Row)
+		if !ok {
+			return nil, errors.New("the income summary is computed from the ledger rows")
+		}
+
+		source := IncomeSource(row)
+		if source == "" || (row.Earner != "" && !addressSet[row.Earner]) {
+			continue
+		}
+
+		legKey := fmt.Sprintf("%s-%d-%s", row.RecordType, row.RecordID, row.Leg)
+		if seen[legKey] {
+			continue
+		}
+		seen[legKey] = true
+
+		amount := row.Amount
+		if row.ConvertedAmount != "" {
+			convertedAmount, err := decimal.NewFromString(row.ConvertedAmount)
+			if err != nil {
+				return nil, err
+			}
+			amount = convertedAmount
+		}
+
+		month := row.Time.UTC().Format(incomeMonthLayout)
+		key := fmt.Sprintf("%s|%s|%s", month, source, row.Denom)
+		income, ok := summary[key]
+		if !ok {
+			income = &IncomeSummaryRow{Month: month, Source: source, Denom: row.Denom, Symbol: row.Symbol, FiatCurrency: fiatCurrency}
+			summary[key] = income
+		}
+
+		income.Amount = income.Amount.Add(amount)
+		income.Rows++
+
+		if prices == nil {
+			continue
+		}
+
+		price, err := prices.GetPrice(row.Symbol, row.Time)
+		if row.Symbol == "" || err != nil {
+			income.UnpricedRows++
+			continue
+		}
+		income.FiatValue = income.FiatValue.Add(amount.Mul(price))
+	}
+
+	incomeRows := make([]IncomeSummaryRow, 0, len(summary))
+	for _, income := range summary {
+		incomeRows = append(incomeRows, *income)
+	}
+	sort.Slice(incomeRows, func(i, j int) bool {
+		left, right := incomeRows[i], incomeRows[j]
+		if left.Month != right.Month {
+			return left.Month < right.Month
+		}
+		if left.Source != right.Source {
+			return left.Source < right.Source
+		}
+		return left.Denom < right.Denom
+	})
+
+	if fiatCurrency == "" {
+		return incomeRows, nil
+	}
+
+	// Each month is followed by its fiat total
+	var withTotals []IncomeSummaryRow
+	for i, income := range incomeRows {
+		withTotals = append(withTotals, income)
+
+		if i == len(incomeRows)-1 || incomeRows[i+1].Month != income.Month {
+			total := IncomeSummaryRow{Month: income.Month, Source: IncomeTotal, FiatCurrency: fiatCurrency}
+			for _, monthIncome := range incomeRows {
+				if monthIncome.Month == income.Month {
+					total.Rows += monthIncome.Rows
+					total.FiatValue = total.FiatValue.Add(monthIncome.FiatValue)
+					total.UnpricedRows += monthIncome.UnpricedRows
+				}
+			}
+			withTotals = append(withTotals, total)
+		}
+	}
+
+	return withTotals, nil
+}
+
+// IncomeSummaryToCsvRows returns the income summary report rows and headers
+func IncomeSummaryToCsvRows(incomeRows []IncomeSummaryRow) ([]parsers.CsvRow, []string) {
+	rows := make([]parsers.CsvRow, len(incomeRows))
+	for i, income := range incomeRows {
+		rows[i] = income
+	}
+	return rows, incomeSummaryHeaders
+}
@@ -0,0 +1,96 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/bank"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/vesting"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/ledger"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedPrices map[string]decimal.Decimal
+
+func (prices fixedPrices) GetPrice(currency string, _ time.Time) (decimal.Decimal, error) {
+	if price, ok := prices[currency]; ok {
+		return price, nil
+	}
+	return decimal.Zero, errors.New("no price")
+}
+
+func TestIncomeSummary(t *testing.T) {
+	january := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	reward := func(address string, earner string, id uint, messageType string, amount string, at time.Time) ledger.Row {
+		return ledger.Row{
+			Time: at, RecordType: ledger.RecordTaxableTx, RecordID: id, Leg: ledger.LegReceived, Direction: ledger.DirectionIn,
+			Address: address, Earner: earner, MessageType: messageType, Denom: "uatom", Symbol: "ATOM", ConvertedAmount: amount,
+		}
+	}
+
+	rows := []parsers.CsvRow{
+		reward("addr1", "addr1", 1, distribution.MsgWithdrawDelegatorReward, "1.5", january),
+		// Rewards withdrawn automatically when delegating
+		reward("addr1", "addr1", 2, staking.MsgDelegate, "0.5", january),
+		// Rewards earned by addr1 and paid to its withdraw address addr2 appear for both addresses
+		reward("addr1", "addr1", 3, distribution.MsgWithdrawDelegatorReward, "2", february),
+		reward("addr2", "addr1", 3, distribution.MsgWithdrawDelegatorReward, "2", february),
+		// Transfers are not income
+		reward("addr1", "", 4, bank.MsgSend, "100", february),
+		ledger.Row{
+			Time: february, RecordType: ledger.RecordTaxableEvent, RecordID: 1, Leg: ledger.LegEvent, Direction: ledger.DirectionIn,
+			Address: "addr1", EventSource: ledger.EventSourceName(db.OsmosisRewardDistribution), Denom: "uosmo", Symbol: "OSMO", ConvertedAmount: "3",
+		},
+		ledger.Row{
+			Time: february, RecordType: ledger.RecordTaxableEvent, RecordID: 2, Leg: ledger.LegEvent, Direction: ledger.DirectionIn,
+			Address: "addr1", EventSource: ledger.EventSourceName(db.OsmosisProtorevDeveloperRewardDistribution), Denom: "ibc/ABC",
+			Amount: decimal.NewFromInt(7),
+		},
+	}
+
+	summary, err := IncomeSummary(rows, []string{"addr1", "addr2"}, nil, "USD")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(summary), "without prices there are no total rows")
+
+	assert.Equal(t, []string{"2024-01", IncomeStaking, "uatom", "ATOM", "2", "2", "", "", "0"}, summary[0].GetRowForCsv())
+	assert.Equal(t, "2024-02", summary[1].Month)
+	assert.Equal(t, IncomeLPIncentives, summary[1].Source)
+	assert.Equal(t, IncomeProtorev, summary[2].Source)
+	assert.Equal(t, "7", summary[2].Amount.String(), "amounts of denoms with unknown units are in the base denom")
+	assert.Equal(t, IncomeStaking, summary[3].Source)
+	assert.Equal(t, 1, summary[3].Rows, "the withdraw address rewards are counted once")
+
+	summary, err = IncomeSummary(rows, []string{"addr1", "addr2"}, fixedPrices{"ATOM": decimal.NewFromInt(10), "OSMO": decimal.NewFromInt(1)}, "USD")
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(summary))
+
+	assert.Equal(t, IncomeTotal, summary[1].Source)
+	assert.Equal(t, "20.00", summary[1].FiatValue.StringFixed(2))
+	assert.Equal(t, IncomeTotal, summary[5].Source)
+	assert.Equal(t, "23.00", summary[5].FiatValue.StringFixed(2))
+	assert.Equal(t, 1, summary[5].UnpricedRows)
+	assert.Equal(t, []string{"2024-02", IncomeTotal, "", "", "", "3", "23.00", "USD", "1"}, summary[5].GetRowForCsv())
+}
+
+func TestIncomeSourceVesting(t *testing.T) {
+	// The vesting unlocks materialized by the vesting-schedule command are income like in the exports
+	unlock := ledger.Row{
+		RecordType: ledger.RecordTaxableEvent, Leg: ledger.LegEvent, Direction: ledger.DirectionIn,
+		EventSource: ledger.EventSourceName(db.VestingAccountUnlock), Denom: "uatom",
+	}
+	assert.Equal(t, IncomeVesting, IncomeSource(unlock))
+
+	// The funding of the vesting account is not income, the tokens are income when they unlock
+	funding := ledger.Row{
+		RecordType: ledger.RecordTaxableTx, Leg: ledger.LegReceived, Direction: ledger.DirectionIn,
+		MessageType: vesting.MsgCreateVestingAccount, Denom: "uatom",
+	}
+	assert.Empty(t, IncomeSource(funding))
+}
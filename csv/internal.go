@@ -0,0 +1,56 @@
+package csv
+
+import (
+	"github.com/DefiantLabs/cosmos-tax-cli/core"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/bank"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/ibc"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+)
+
+// The message types that move tokens between addresses without trading them
+var transferMessageTypes = map[string]bool{
+	bank.MsgSend:           true,
+	bank.MsgSendV0:         true,
+	bank.MsgMultiSend:      true,
+	bank.MsgMultiSendV0:    true,
+	ibc.MsgTransfer:        true,
+	ibc.MsgRecvPacket:      true,
+	ibc.MsgAcknowledgement: true,
+}
+
+// IsOwnedAddress returns true if the address is one of the addresses or the same account on a chain with another bech32 prefix
+func IsOwnedAddress(addresses []string, address string) bool {
+	if address == "" {
+		return false
+	}
+	for _, owned := range addresses {
+		if core.IsSameAccount(owned, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkInternalTransfers marks the transfers between the addresses as internal, the addresses are treated as one owner so
+// the exports classify these transfers as moves between the owner's wallets instead of disposals and receipts. Their fees
+// are not affected.
+func MarkInternalTransfers(taxableTxs []db.TaxableTransaction, addresses []string) []db.TaxableTransaction {
+	for i, taxableTx := range taxableTxs {
+		if !transferMessageTypes[taxableTx.Message.MessageType.MessageType] {
+			continue
+		}
+
+		// A transfer of one denom for another is not a move of the same tokens
+		if taxableTx.DenominationSentID != nil && taxableTx.DenominationReceivedID != nil &&
+			*taxableTx.DenominationSentID != *taxableTx.DenominationReceivedID {
+			continue
+		}
+
+		sender := taxableTx.SenderAddress.Address
+		receiver := taxableTx.ReceiverAddress.Address
+		if sender != receiver && IsOwnedAddress(addresses, sender) && IsOwnedAddress(addresses, receiver) {
+			taxableTxs[i].InternalTransfer = true
+		}
+	}
+	return taxableTxs
+}
@@ -0,0 +1,63 @@
+package csv
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/accointing"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/ledger"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/taxbit"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test that a transfer to the same account on another chain is internal when only the source address is queried
+func TestMarkInternalTransfers(t *testing.T) {
+	me := db.Address{ID: 0, Address: "osmo18zljeu4lg4jppkz75en82qr3zymfcnchwvsqgu"}
+	alsoMe := db.Address{ID: 1, Address: "juno18zljeu4lg4jppkz75en82qr3zymfcnchs9qtej"}
+	someoneElse := db.Address{ID: 2, Address: "osmo14mmus5h7m6vkp0pteks8wawaj4wf3sx7fy3s2r"}
+
+	sourceChain := mkChain(1, osmosis.ChainID, osmosis.Name)
+	targetChain := mkChain(2, "juno-1", "juno")
+
+	assert.True(t, IsOwnedAddress([]string{me.Address}, alsoMe.Address))
+	assert.False(t, IsOwnedAddress([]string{me.Address}, someoneElse.Address))
+
+	internal := MarkInternalTransfers(getTestIbcTransferTXs(t, me, alsoMe, sourceChain, targetChain), []string{me.Address})
+	assert.True(t, internal[0].InternalTransfer)
+
+	external := MarkInternalTransfers(getTestIbcTransferTXs(t, me, someoneElse, sourceChain, targetChain), []string{me.Address})
+	assert.False(t, external[0].InternalTransfer)
+
+	parser := GetParser(accointing.ParserKey)
+	parser.InitializeParsingGroups()
+	err := parser.ProcessTaxableTx(me.Address, append(internal, external...), []db.Fee{})
+	assert.Nil(t, err)
+
+	rows, err := parser.GetRows(me.Address, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rows))
+	classifications := []string{rows[0].GetRowForCsv()[8], rows[1].GetRowForCsv()[8]}
+	assert.ElementsMatch(t, []string{"internal", ""}, classifications, "only the transfer to the same account is internal")
+
+	parser = GetParser(taxbit.ParserKey)
+	parser.InitializeParsingGroups()
+	err = parser.ProcessTaxableTx(me.Address, internal, []db.Fee{})
+	assert.Nil(t, err)
+
+	rows, err = parser.GetRows(me.Address, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, taxbit.TransfersOut, rows[0].(*taxbit.Row).TransactionType)
+
+	parser = GetParser(ledger.ParserKey)
+	parser.InitializeParsingGroups()
+	err = parser.ProcessTaxableTx(me.Address, internal, []db.Fee{})
+	assert.Nil(t, err)
+
+	rows, err = parser.GetRows(me.Address, nil, nil)
+	assert.Nil(t, err)
+	for _, row := range rows {
+		assert.True(t, row.(ledger.Row).InternalTransfer)
+	}
+}
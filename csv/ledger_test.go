@@ -0,0 +1,75 @@
+// nolint:unused
+package csv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/ledger"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerSwapLegs(t *testing.T) {
+	parser := GetParser(ledger.ParserKey)
+	parser.InitializeParsingGroups()
+
+	targetAddress := mkAddress(t, 1)
+	chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+	swapTxs := getTestSwapTXs(t, targetAddress, chain)
+
+	err := parser.ProcessTaxableTx(targetAddress.Address, swapTxs, []db.Fee{})
+	assert.Nil(t, err)
+
+	rows, err := parser.GetRows(targetAddress.Address, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*len(swapTxs), len(rows), "there should be a sent and a received leg for each swap")
+
+	headers := parser.GetHeaders()
+	for _, row := range rows {
+		cols := row.GetRowForCsv()
+		assert.Len(t, cols, len(headers))
+
+		_, err := time.Parse(ledger.TimeLayout, cols[0])
+		assert.Nil(t, err)
+		assert.Equal(t, osmosis.ChainID, cols[1])
+	}
+
+	// The first leg is the coins sent to join the pool, the raw amount is kept and the GAMM tokens are converted exactly
+	first := rows[0].GetRowForCsv()
+	assert.Equal(t, []string{"somehash1", "0", ledger.RecordTaxableTx, ledger.LegSent, ledger.DirectionOut, "coin1", "12000"},
+		[]string{first[3], first[5], first[7], first[9], first[10], first[20], first[21]})
+
+	second := rows[1].GetRowForCsv()
+	assert.Equal(t, []string{ledger.LegReceived, ledger.DirectionIn, "gamm/pool/1", "24000038", "0.000000000024000038"},
+		[]string{second[9], second[10], second[20], second[21], second[23]})
+
+	// The rows must fit the typed Parquet columns of the ledger
+	_, err = Encode(rows, headers, ledger.ParserKey, EncodingParquet)
+	assert.Nil(t, err)
+}
+
+func TestLedgerEventLegs(t *testing.T) {
+	parser := GetParser(ledger.ParserKey)
+	parser.InitializeParsingGroups()
+
+	targetAddress := mkAddress(t, 1)
+	chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+	events := getTestTaxableEvents(t, targetAddress, chain)
+
+	err := parser.ProcessTaxableEvent(events)
+	assert.Nil(t, err)
+
+	rows, err := parser.GetRows(targetAddress.Address, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, len(events), len(rows))
+
+	for _, row := range rows {
+		cols := row.GetRowForCsv()
+		assert.Equal(t, ledger.RecordTaxableEvent, cols[7])
+		assert.Equal(t, ledger.DirectionIn, cols[10])
+		assert.Equal(t, "osmosis_reward_distribution", cols[18])
+		assert.Equal(t, "", cols[5], "events have no message index")
+	}
+}
@@ -8,20 +8,41 @@ import (
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/accointing"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/coinledger"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/cointracker"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/cryptotaxcalculator"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/doubleentry"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/gains"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/koinly"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/ledger"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/taxbit"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/tokentax"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/zenledger"
 	"github.com/DefiantLabs/cosmos-tax-cli/db"
 
 	"gorm.io/gorm"
 )
 
 // Register new parsers by adding them to this list
-var supportedParsers = []string{accointing.ParserKey, koinly.ParserKey, cointracker.ParserKey, taxbit.ParserKey, cryptotaxcalculator.ParserKey}
+var supportedParsers = []string{
+	accointing.ParserKey, koinly.ParserKey, cointracker.ParserKey, taxbit.ParserKey, cryptotaxcalculator.ParserKey,
+	zenledger.ParserKey, coinledger.ParserKey, tokentax.ParserKey, ledger.ParserKey,
+}
 
 func init() {
 	parsers.RegisterParsers(supportedParsers)
+	parsers.RegisterParsers(gains.ParserKeys)
+	parsers.RegisterParsers(doubleentry.ParserKeys)
+}
+
+// IsTextFormat returns true for the plain text formats, their rows and headers are written as is by ToText instead of as CSV
+func IsTextFormat(parserKey string) bool {
+	for _, key := range doubleentry.ParserKeys {
+		if key == parserKey {
+			return true
+		}
+	}
+	return false
 }
 
 func GetParser(parserKey string) parsers.Parser {
@@ -41,17 +62,55 @@ func GetParser(parserKey string) parsers.Parser {
 	case cryptotaxcalculator.ParserKey:
 		parser := cryptotaxcalculator.Parser{}
 		return &parser
+	case zenledger.ParserKey:
+		parser := zenledger.Parser{}
+		return &parser
+	case coinledger.ParserKey:
+		parser := coinledger.Parser{}
+		return &parser
+	case tokentax.ParserKey:
+		parser := tokentax.Parser{}
+		return &parser
+	case ledger.ParserKey:
+		parser := ledger.Parser{}
+		return &parser
+	case gains.ParserKeyFIFO, gains.ParserKeyLIFO, gains.ParserKeyHIFO, gains.ParserKeyAverage:
+		return gains.NewParser(parserKey, gains.NewCoinbasePrices())
+	case doubleentry.ParserKeyBeancount, doubleentry.ParserKeyLedger:
+		return doubleentry.NewParser(parserKey)
 	}
 	return nil
 }
 
 func ParseForAddress(addresses []string, startDate, endDate *time.Time, pgSQL *gorm.DB, parserKey string) ([]parsers.CsvRow, []string, map[string]uint, error) {
+	return ParseForAddressAggregated(addresses, startDate, endDate, pgSQL, parserKey, AggregationNone)
+}
+
+// ParseForAddressAggregated parses the addresses like ParseForAddress with the reward and income rows aggregated per
+// aggregation period, denom and source. Swaps, transfers and fees are never aggregated. The addresses are treated as the
+// wallets of one owner, transfers between them are classified as internal moves.
+func ParseForAddressAggregated(addresses []string, startDate, endDate *time.Time, pgSQL *gorm.DB, parserKey string, aggregation string) ([]parsers.CsvRow, []string, map[string]uint, error) {
 	parser := GetParser(parserKey)
 	if parser == nil {
 		return nil, nil, nil, errors.New("invalid parser key")
 	}
 	parser.InitializeParsingGroups()
 
+	// Formats with fiat value columns are valued with the imported prices
+	if pricedParser, ok := parser.(parsers.PricedParser); ok {
+		pricedParser.SetPriceLookup(db.NewPriceLookup(pgSQL, db.DefaultFiatCurrency))
+	}
+
+	// Lot parsers value each reward at its own time
+	if _, ok := parser.(parsers.LotParser); ok {
+		aggregation = AggregationNone
+	}
+
+	addressSetParser, isAddressSetParser := parser.(parsers.AddressSetParser)
+	if isAddressSetParser {
+		addressSetParser.SetAddresses(addresses)
+	}
+
 	// Get data for each address
 	var headers []string
 	var csvRows []parsers.CsvRow
@@ -73,7 +132,10 @@ func ParseForAddress(addresses []string, startDate, endDate *time.Time, pgSQL *g
 			return nil, nil, nil, err
 		}
 
-		err = parser.ProcessTaxableTx(address, taxableTxs, taxableFees)
+		// Transfers between the queried addresses are moves within the wallets of one owner
+		taxableTxs = MarkInternalTransfers(taxableTxs, addresses)
+
+		err = parser.ProcessTaxableTx(address, AggregateTaxableTxs(taxableTxs, aggregation, startDate, endDate), taxableFees)
 		if err != nil {
 			config.Log.Error("Error processing taxable transaction.", err)
 			return nil, nil, nil, err
@@ -85,12 +147,17 @@ func ParseForAddress(addresses []string, startDate, endDate *time.Time, pgSQL *g
 			return nil, nil, nil, err
 		}
 
-		err = parser.ProcessTaxableEvent(taxableEvents)
+		err = parser.ProcessTaxableEvent(AggregateTaxableEvents(taxableEvents, aggregation, startDate, endDate))
 		if err != nil {
 			config.Log.Error("Error processing taxable events.", err)
 			return nil, nil, nil, err
 		}
 
+		// The rows of address set parsers depend on the addresses processed after this one
+		if isAddressSetParser {
+			continue
+		}
+
 		// Get rows once right at the end, also filter them by date
 		rows, err := parser.GetRows(address, startDate, endDate)
 		if err != nil {
@@ -101,6 +168,20 @@ func ParseForAddress(addresses []string, startDate, endDate *time.Time, pgSQL *g
 		addressRowsCount[address] = uint(len(rows))
 		headers = parser.GetHeaders()
 	}
+
+	if isAddressSetParser {
+		for _, address := range addresses {
+			rows, err := parser.GetRows(address, startDate, endDate)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			csvRows = append(csvRows, rows...)
+			addressRowsCount[address] = uint(len(rows))
+		}
+		headers = parser.GetHeaders()
+	}
+
 	// re-sort rows if needed
 	if len(addresses) > 1 {
 		SortRows(csvRows, parser.TimeLayout())
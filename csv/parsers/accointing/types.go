@@ -52,10 +52,12 @@ const (
 	LiquidityPool
 	RemoveFunds // Used for GAMM module exits, is this correct?
 	Ignored
+	Lost
+	Internal
 )
 
 func (ac Classification) String() string {
 	// Note that "None" returns empty string since we're using this for CSV parsing.
 	// Accointing considers 'Classification' an optional field, so empty is a valid value.
-	return [...]string{"", "staked", "airdrop", "payment", "fee", "liquidity_pool", "remove_funds", "ignored"}[ac]
+	return [...]string{"", "staked", "airdrop", "payment", "fee", "liquidity_pool", "remove_funds", "ignored", "lost", "internal"}[ac]
 }
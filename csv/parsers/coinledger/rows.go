@@ -0,0 +1,151 @@
+package coinledger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+)
+
+func (row Row) GetRowForCsv() []string {
+	return []string{
+		row.Date,
+		row.Platform,
+		row.AssetSent,
+		row.AmountSent,
+		row.AssetReceived,
+		row.AmountReceived,
+		row.FeeCurrency,
+		row.FeeAmount,
+		row.Type.String(),
+		row.Description,
+		row.TxHash,
+	}
+}
+
+func (row Row) GetDate() string {
+	return row.Date
+}
+
+// EventParseBasic handles the tokens received by taxable events, e.g. osmo rewards
+func (row *Row) EventParseBasic(event db.TaxableEvent) error {
+	row.Date = event.Block.TimeStamp.Format(TimeLayout)
+
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.Amount), event.Denomination)
+	if err == nil {
+		row.AmountReceived = conversionAmount.Text('f', -1)
+		row.AssetReceived = conversionSymbol
+	} else {
+		row.AmountReceived = util.NumericToString(event.Amount)
+		row.AssetReceived = event.Denomination.Base
+	}
+	row.Type = Income
+	return nil
+}
+
+// ParseBasic: Handles the fields that are shared between most types.
+func (row *Row) ParseBasic(address string, event db.TaxableTransaction) error {
+	row.Date = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+	row.TxHash = event.Message.Tx.Hash
+
+	// deposit
+	if event.ReceiverAddress.Address == address {
+		conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+		if err != nil {
+			return fmt.Errorf("cannot parse denom units for TX %s (classification: deposit)", row.TxHash)
+		}
+		row.AmountReceived = conversionAmount.Text('f', -1)
+		row.AssetReceived = conversionSymbol
+		row.Type = Deposit
+	} else if event.SenderAddress.Address == address { // withdrawal
+		conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+		if err != nil {
+			return fmt.Errorf("cannot parse denom units for TX %s (classification: withdrawal)", row.TxHash)
+		}
+		row.AmountSent = conversionAmount.Text('f', -1)
+		row.AssetSent = conversionSymbol
+		row.Type = Withdrawal
+	}
+
+	return nil
+}
+
+func (row *Row) ParseSwap(event db.TaxableTransaction) error {
+	row.Date = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+	row.TxHash = event.Message.Tx.Hash
+	row.Type = Trade
+
+	recievedConversionAmount, recievedConversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: swap received)", row.TxHash)
+	}
+
+	row.AmountReceived = recievedConversionAmount.Text('f', -1)
+	row.AssetReceived = recievedConversionSymbol
+
+	sentConversionAmount, sentConversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: swap sent)", row.TxHash)
+	}
+
+	row.AmountSent = sentConversionAmount.Text('f', -1)
+	row.AssetSent = sentConversionSymbol
+
+	return nil
+}
+
+func (row *Row) ParseFee(tx db.Tx, fee db.Fee) error {
+	row.Date = tx.Block.TimeStamp.Format(TimeLayout)
+	row.TxHash = tx.Hash
+	row.Type = Withdrawal
+	row.Description = "Fee"
+
+	sentConversionAmount, sentConversionSymbol, err := db.ConvertUnits(util.FromNumeric(fee.Amount), fee.Denomination)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: fee)", row.TxHash)
+	}
+
+	row.FeeAmount = sentConversionAmount.Text('f', -1)
+	row.FeeCurrency = sentConversionSymbol
+
+	return nil
+}
+
+func parseAndAddSentAmount(row *Row, event db.TaxableTransaction) error {
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+	if err != nil {
+		return errors.New("cannot parse denom units")
+	}
+	row.AmountSent = conversionAmount.Text('f', -1)
+	row.AssetSent = conversionSymbol
+
+	return nil
+}
+
+func parseAndAddSentAmountWithDefault(row *Row, event db.TaxableTransaction) {
+	err := parseAndAddSentAmount(row, event)
+	if err != nil {
+		row.AmountSent = util.NumericToString(event.AmountSent)
+		row.AssetSent = event.DenominationSent.Base
+	}
+}
+
+func parseAndAddReceivedAmount(row *Row, event db.TaxableTransaction) error {
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+	if err != nil {
+		return errors.New("cannot parse denom units")
+	}
+	row.AmountReceived = conversionAmount.Text('f', -1)
+	row.AssetReceived = conversionSymbol
+
+	return nil
+}
+
+func parseAndAddReceivedAmountWithDefault(row *Row, event db.TaxableTransaction) {
+	err := parseAndAddReceivedAmount(row, event)
+	if err != nil {
+		row.AmountReceived = util.NumericToString(event.AmountReceived)
+		row.AssetReceived = event.DenominationReceived.Base
+	}
+}
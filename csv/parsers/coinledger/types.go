@@ -0,0 +1,53 @@
+package coinledger
+
+import "github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+
+const (
+	// ParserKey is the key used to identify this parser
+	ParserKey = "coinledger"
+
+	// TimeLayout is the golang time format string for this parser
+	TimeLayout = "01/02/2006 15:04:05"
+)
+
+type Parser struct {
+	Rows          []Row
+	ParsingGroups []parsers.ParsingGroup
+}
+
+type Row struct {
+	Date           string
+	Platform       string
+	AssetSent      string
+	AmountSent     string
+	AssetReceived  string
+	AmountReceived string
+	FeeCurrency    string
+	FeeAmount      string
+	Type           Type
+	Description    string
+	TxHash         string
+}
+
+type Type int
+
+const (
+	None Type = iota
+
+	// incoming transactions
+	Deposit
+	Income
+	Staking
+	Airdrop
+
+	// outgoing transactions, fees without a sent amount are withdrawals of only the fee
+	Withdrawal
+	CasualtyLoss
+
+	// Trades
+	Trade
+)
+
+func (t Type) String() string {
+	return [...]string{"", "Deposit", "Income", "Staking", "Airdrop", "Withdrawal", "Casualty Loss", "Trade"}[t]
+}
@@ -5,19 +5,26 @@ import (
 	"sort"
 	"time"
 
+	"github.com/DefiantLabs/cosmos-tax-cli/block-sdk/modules/auction"
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/bank"
+	airdropClaim "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/claim"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/gov"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/ibc"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/vesting"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmwasm/modules/wasm"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
 	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/modules/evm"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/concentratedliquidity"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/gamm"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/poolmanager"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/tokenfactory"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/valsetpref"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/claim"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/stakeibc"
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 )
 
@@ -84,12 +91,39 @@ func (p *Parser) ProcessTaxableEvent(taxableEvents []db.TaxableEvent) error {
 		if err != nil {
 			return err
 		}
+		for i := range rows {
+			rows[i].Description = parsers.AggregationDescription(rows[i].Description, event.AggregatedHeights)
+		}
 		p.Rows = append(p.Rows, rows...)
 	}
 
 	return nil
 }
 
+// SetPriceLookup sets the prices the reference prices of the rows are looked up with
+func (p *Parser) SetPriceLookup(prices parsers.PriceLookup) {
+	p.Prices = prices
+}
+
+// referencePrice returns the price of one unit of the base currency of the row at the row date, income is valued at receipt
+func (p *Parser) referencePrice(row Row) (string, string) {
+	rowDate, err := time.Parse(TimeLayout, row.Date)
+	if err != nil {
+		return "", ""
+	}
+
+	if row.BaseCurrency == "" {
+		return "", ""
+	}
+
+	price, err := p.Prices.GetPrice(row.BaseCurrency, rowDate)
+	if err != nil {
+		return "", ""
+	}
+
+	return price.String(), db.DefaultFiatCurrency
+}
+
 func (p *Parser) GetRows(address string, startDate, endDate *time.Time) ([]parsers.CsvRow, error) {
 	// Combine all normal rows and parser group rows into 1
 	cryptoRows := p.Rows // contains TX rows and fees as well as taxable events
@@ -133,6 +167,9 @@ func (p *Parser) GetRows(address string, startDate, endDate *time.Time) ([]parse
 	// Copy AccointingRows into csvRows for return val
 	csvRows := make([]parsers.CsvRow, len(rowsToKeep))
 	for i, v := range rowsToKeep {
+		if p.Prices != nil && v.ReferencePricePerUnit == "" {
+			v.ReferencePricePerUnit, v.ReferencePriceCurrency = p.referencePrice(*v)
+		}
 		csvRows[i] = v
 	}
 
@@ -170,16 +207,77 @@ func ParseEvent(event db.TaxableEvent) (rows []Row, err error) {
 		rows = append(rows, row)
 	}
 
+	if event.Source == db.ClaimHookAirdrop {
+		row, err := ParseClaimHookAirdrop(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if event.Source == db.VestingAccountUnlock {
+		row, err := ParseVestingAccountUnlock(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if event.Source == db.GovDepositRefund {
+		row, err := ParseGovDepositRefund(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if event.Source == db.GovDepositBurn {
+		row, err := ParseGovDepositBurn(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if event.Source == db.StakingSlash {
+		row, err := ParseStakingSlash(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
 	return rows, nil
 }
 
 func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.CsvRow, err error) {
 	for _, event := range events {
+		// Vesting accounts receive their tokens as income when they unlock
+		if parsers.IsVestingFundingReceipt(address, event) {
+			continue
+		}
+
+		// Rewards paid to a withdraw address are split between the earner and the withdraw address
+		if parsers.IsRewardToWithdrawAddress(event) {
+			rewardRows, err := ParseRewardToWithdrawAddress(address, event)
+			if err != nil {
+				config.Log.Errorf("error parsing message type '%v': %v", event.Message.MessageType.MessageType, err)
+				continue
+			}
+			for _, row := range rewardRows {
+				row.Description = parsers.AggregationDescription(row.Description, event.AggregatedHeights)
+				rows = append(rows, row)
+			}
+			continue
+		}
+
 		var newRow Row
 		var err error
 		switch event.Message.MessageType.MessageType {
 		case bank.MsgSendV0:
 			newRow, err = ParseMsgSend(address, event)
+		case vesting.MsgCreateVestingAccount, vesting.MsgCreatePermanentLockedAccount, vesting.MsgCreatePeriodicVestingAccount:
+			newRow, err = ParseMsgSend(address, event)
 		case bank.MsgSend:
 			newRow, err = ParseMsgSend(address, event)
 		case bank.MsgMultiSendV0:
@@ -188,6 +286,8 @@ func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.Csv
 			newRow, err = ParseMsgMultiSend(address, event)
 		case distribution.MsgFundCommunityPool:
 			newRow, err = ParseMsgFundCommunityPool(address, event)
+		case auction.MsgAuctionBid:
+			newRow, err = ParseMsgAuctionBid(address, event)
 		case distribution.MsgWithdrawValidatorCommission:
 			newRow, err = ParseMsgWithdrawValidatorCommission(address, event)
 		case distribution.MsgWithdrawRewards:
@@ -200,6 +300,16 @@ func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.Csv
 			newRow, err = ParseMsgWithdrawDelegatorReward(address, event)
 		case staking.MsgBeginRedelegate:
 			newRow, err = ParseMsgWithdrawDelegatorReward(address, event)
+		case staking.MsgTokenizeShares, staking.MsgRedeemTokensForShares, staking.MsgTransferTokenizeShareRecord, staking.MsgValidatorBond:
+			newRow, err = parsers.TokenizedSharesRow(address, event, ParseMsgSend, ParseMsgWithdrawDelegatorReward)
+		case stakeibc.MsgLiquidStake, stakeibc.MsgLSMLiquidStake, stakeibc.MsgRedeemStake:
+			newRow, err = ParseStrideLiquidStake(address, event)
+		case claim.MsgClaimFreeAmount, airdropClaim.StargazeMsgInitialClaim, airdropClaim.StargazeMsgClaimFor, airdropClaim.CrescentMsgClaim, airdropClaim.QuicksilverMsgClaim:
+			newRow, err = ParseMsgClaim(address, event)
+		case evm.MsgEthereumTx, evm.InjectiveMsgEthereumTx, evm.CosmosEVMMsgEthereumTx:
+			newRow, err = ParseMsgEthereumTx(address, event)
+		case wasm.MsgExecuteContract:
+			newRow, err = ParseMsgExecuteContract(address, event)
 		case gamm.MsgSwapExactAmountIn:
 			newRow, err = ParseMsgSwapExactAmountIn(address, event)
 		case gamm.MsgSwapExactAmountOut:
@@ -232,6 +342,17 @@ func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.Csv
 			continue
 		}
 
+		// Transfers between the queried addresses are moves between the wallets of the owner, matched by their from and to
+		if event.InternalTransfer {
+			if event.ReceiverAddress.Address == address {
+				newRow.Type = Receive
+			} else {
+				newRow.Type = Send
+			}
+			newRow.Description = parsers.InternalTransferDescription(newRow.Description, event)
+		}
+
+		newRow.Description = parsers.AggregationDescription(newRow.Description, event.AggregatedHeights)
 		rows = append(rows, newRow)
 	}
 	return rows, nil
@@ -267,6 +388,10 @@ func ParseMsgWithdrawValidatorCommission(address string, event db.TaxableTransac
 		config.Log.Error("Error with ParseMsgWithdrawValidatorCommission.", err)
 	}
 	// row.Label = Unstake
+	if validator := parsers.ValidatorDescription(event); validator != "" {
+		row.Description = fmt.Sprintf("Commission from %s", validator)
+	}
+
 	return *row, err
 }
 
@@ -277,9 +402,34 @@ func ParseMsgWithdrawDelegatorReward(address string, event db.TaxableTransaction
 		config.Log.Error("Error with ParseMsgWithdrawDelegatorReward.", err)
 	}
 	row.Type = Staking
+	if validator := parsers.ValidatorDescription(event); validator != "" {
+		row.Description = fmt.Sprintf("Reward from %s", validator)
+	}
+
 	return *row, err
 }
 
+// ParseRewardToWithdrawAddress: A reward paid to a withdraw address is income of the earner, followed by a transfer of the
+// reward from the earner to the withdraw address. The withdraw address only receives the transfer.
+func ParseRewardToWithdrawAddress(address string, event db.TaxableTransaction) ([]Row, error) {
+	return parsers.RewardToWithdrawAddressRows(address, event, func(transfer db.TaxableTransaction, isEarner bool) (Row, error) {
+		row := &Row{}
+		err := row.ParseBasic(address, transfer)
+		if err != nil {
+			config.Log.Error("Error with ParseRewardToWithdrawAddress.", err)
+			return *row, err
+		}
+		if isEarner {
+			row.Type = Send
+			// The fee is already on the reward row
+			row.FeeAmount, row.FeeCurrency = "", ""
+		} else {
+			row.Type = Receive
+		}
+		return *row, nil
+	}, ParseMsgWithdrawValidatorCommission, ParseMsgWithdrawDelegatorReward)
+}
+
 func ParseMsgFundCommunityPool(address string, event db.TaxableTransaction) (Row, error) {
 	row := &Row{}
 	err := row.ParseBasic(address, event)
@@ -289,6 +439,16 @@ func ParseMsgFundCommunityPool(address string, event db.TaxableTransaction) (Row
 	return *row, err
 }
 
+// ParseMsgAuctionBid: The bid paid to the proposer and auction escrow is a cost to the bidder, refunds come back as deposits
+func ParseMsgAuctionBid(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgAuctionBid.", err)
+	}
+	return *row, err
+}
+
 func ParseMsgSubmitProposal(address string, event db.TaxableTransaction) (Row, error) {
 	row := &Row{}
 	err := row.ParseBasic(address, event)
@@ -414,6 +574,110 @@ func ParsePoolManagerSwap(address string, event db.TaxableTransaction) (Row, err
 	return *row, err
 }
 
+// ParseStrideLiquidStake parses Stride liquid stakes and redemptions, which exchange the native tokens and stTokens at the
+// redemption rate. Redemptions are booked to the redeemer, the native tokens are paid out to the receiver on the host zone.
+func ParseStrideLiquidStake(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseSwap(event, address, Buy)
+	if err != nil {
+		config.Log.Error("Error with ParseStrideLiquidStake.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgClaim parses airdrop claims, the coins received from the claim module account are airdrops
+func ParseMsgClaim(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgClaim.", err)
+	}
+	if event.ReceiverAddress.Address == address {
+		row.Type = AirDrop
+	}
+	return *row, err
+}
+
+// ParseClaimHookAirdrop parses airdrops claimed automatically by the claim module hooks
+func ParseClaimHookAirdrop(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseClaimHookAirdrop.", err)
+	}
+	row.Type = AirDrop
+	return *row, err
+}
+
+// ParseVestingAccountUnlock parses the tokens unlocked by a vesting account, these are materialized by the vesting-schedule command
+func ParseVestingAccountUnlock(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseVestingAccountUnlock.", err)
+	}
+	row.Type = Income
+	return *row, err
+}
+
+// ParseGovDepositRefund parses a gov proposal deposit returned to the depositor, the deposit is returned not earned
+func ParseGovDepositRefund(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseGovDepositRefund.", err)
+	}
+	row.Type = Receive
+	return *row, err
+}
+
+// ParseGovDepositBurn parses a gov proposal deposit burned by the gov module, these are reported as lost
+func ParseGovDepositBurn(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseGovDepositBurn.", err)
+	}
+	row.Type = Lost
+	return *row, err
+}
+
+// ParseStakingSlash parses the share of a validator slash lost by the delegator, these are reported as lost
+func ParseStakingSlash(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseStakingSlash.", err)
+	}
+	row.Type = Lost
+	return *row, err
+}
+
+func ParseMsgEthereumTx(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgEthereumTx.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgExecuteContract parses contract executions. Executions handled by a contract handler (e.g. swaps) carry both legs,
+// the payment legs of CW721 NFTs bought or sold on a marketplace carry a single leg.
+func ParseMsgExecuteContract(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	var err error
+	if event.DenominationSentID != nil && event.DenominationReceivedID != nil && *event.DenominationSentID != *event.DenominationReceivedID {
+		err = row.ParseSwap(event, address, Buy)
+	} else {
+		err = row.ParseBasic(address, event)
+	}
+	if err != nil {
+		config.Log.Error("Error with ParseMsgExecuteContract.", err)
+	}
+	return *row, err
+}
+
 func ParseConcentratedLiquidityCollection(event db.TaxableTransaction) (Row, error) {
 	row := &Row{}
 	denomToUse := event.DenominationReceived
@@ -443,6 +707,10 @@ func ParseValsetPrefRewards(event db.TaxableTransaction) (Row, error) {
 		return *row, err
 	}
 
+	if validator := parsers.ValidatorDescription(event); validator != "" {
+		row.Description = fmt.Sprintf("Reward from %s", validator)
+	}
+
 	return *row, nil
 }
 
@@ -15,6 +15,7 @@ const (
 type Parser struct {
 	Rows          []Row
 	ParsingGroups []parsers.ParsingGroup
+	Prices        parsers.PriceLookup
 }
 
 type Row struct {
@@ -40,7 +41,10 @@ const (
 	Buy            = "buy"
 	FlatDeposit    = "flat-deposit"
 	FlatWithdrawal = "flat-withdrawal"
+	Income         = "income"
+	Lost           = "lost"
 	Receive        = "receive"
+	Send           = "send"
 	Sell           = "sell"
 	Fee            = "fee"
 	Staking        = "staking"
@@ -0,0 +1,188 @@
+package doubleentry
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/block-sdk/modules/auction"
+	airdropClaim "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/claim"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/gov"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/concentratedliquidity"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/gamm"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/poolmanager"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/valsetpref"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/claim"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/stakeibc"
+)
+
+// The income accounts of the tokens received by message type
+var incomeAccounts = map[string]string{
+	distribution.MsgWithdrawRewards:                    StakingIncomeAccount,
+	distribution.MsgWithdrawDelegatorReward:            StakingIncomeAccount,
+	staking.MsgDelegate:                                StakingIncomeAccount,
+	staking.MsgUndelegate:                              StakingIncomeAccount,
+	staking.MsgBeginRedelegate:                         StakingIncomeAccount,
+	staking.MsgTokenizeShares:                          StakingIncomeAccount,
+	staking.MsgRedeemTokensForShares:                   StakingIncomeAccount,
+	staking.MsgTransferTokenizeShareRecord:             StakingIncomeAccount,
+	staking.MsgValidatorBond:                           StakingIncomeAccount,
+	valsetpref.MsgDelegateBondedTokens:                 StakingIncomeAccount,
+	valsetpref.MsgUndelegateFromValidatorSet:           StakingIncomeAccount,
+	valsetpref.MsgRedelegateValidatorSet:               StakingIncomeAccount,
+	valsetpref.MsgWithdrawDelegationRewards:            StakingIncomeAccount,
+	valsetpref.MsgDelegateToValidatorSet:               StakingIncomeAccount,
+	valsetpref.MsgUndelegateFromRebalancedValidatorSet: StakingIncomeAccount,
+	distribution.MsgWithdrawValidatorCommission:        CommissionIncomeAccount,
+	claim.MsgClaimFreeAmount:                           AirdropIncomeAccount,
+	airdropClaim.StargazeMsgInitialClaim:               AirdropIncomeAccount,
+	airdropClaim.StargazeMsgClaimFor:                   AirdropIncomeAccount,
+	airdropClaim.CrescentMsgClaim:                      AirdropIncomeAccount,
+	airdropClaim.QuicksilverMsgClaim:                   AirdropIncomeAccount,
+	concentratedliquidity.MsgCollectIncentives:         RewardIncomeAccount,
+	concentratedliquidity.MsgCollectSpreadRewards:      RewardIncomeAccount,
+}
+
+// The expense accounts of the tokens sent by message type, auction bids are a cost of the tx like its fees
+var expenseAccounts = map[string]string{
+	distribution.MsgFundCommunityPool: DonationsAccount,
+	auction.MsgAuctionBid:             FeesAccount,
+	gov.MsgSubmitProposal:             GovDepositsAccount,
+	gov.MsgSubmitProposalV1:           GovDepositsAccount,
+	gov.MsgDeposit:                    GovDepositsAccount,
+	gov.MsgDepositV1:                  GovDepositsAccount,
+}
+
+// The message types whose sent and received tokens are traded for each other through the trading account
+var isTrade = map[string]bool{
+	gamm.MsgSwapExactAmountIn:                   true,
+	gamm.MsgSwapExactAmountOut:                  true,
+	poolmanager.MsgSwapExactAmountIn:            true,
+	poolmanager.MsgSwapExactAmountOut:           true,
+	poolmanager.MsgSplitRouteSwapExactAmountIn:  true,
+	poolmanager.MsgSplitRouteSwapExactAmountOut: true,
+	stakeibc.MsgLiquidStake:                     true,
+	stakeibc.MsgLSMLiquidStake:                  true,
+	stakeibc.MsgRedeemStake:                     true,
+}
+
+func init() {
+	// Pool joins and exits trade the pooled tokens for the GAMM tokens
+	for messageType := range parsers.IsOsmosisLpTxGroup {
+		isTrade[messageType] = true
+	}
+}
+
+// sentCounterAccount returns the account the tokens sent by the address in a message are posted to
+func sentCounterAccount(messageType string, chainID string, address string) string {
+	switch {
+	case isTrade[messageType]:
+		return TradingAccount
+	case parsers.IsOsmosisConcentratedLiquidity[messageType]:
+		return walletAccount(PositionsAccount, chainID, address)
+	case expenseAccounts[messageType] == FeesAccount:
+		return chainAccount(FeesAccount, chainID)
+	case expenseAccounts[messageType] != "":
+		return expenseAccounts[messageType]
+	}
+	return TransfersAccount
+}
+
+// receivedCounterAccount returns the account the tokens received by the address in a message are posted from
+func receivedCounterAccount(messageType string, chainID string, address string) string {
+	switch {
+	case isTrade[messageType]:
+		return TradingAccount
+	case parsers.IsOsmosisConcentratedLiquidity[messageType]:
+		return walletAccount(PositionsAccount, chainID, address)
+	case incomeAccounts[messageType] != "":
+		return incomeAccounts[messageType]
+	}
+	return TransfersAccount
+}
+
+// rewardIncomeAccount returns the income account of a reward paid to a withdraw address
+func rewardIncomeAccount(event db.TaxableTransaction) string {
+	if account, ok := incomeAccounts[event.Message.MessageType.MessageType]; ok {
+		return account
+	}
+	return StakingIncomeAccount
+}
+
+// walletAccount returns the account of an address on a chain below the parent account, e.g. Assets:Wallets:Osmosis-1:Osmo1...
+func walletAccount(parent string, chainID string, address string) string {
+	return parent + ":" + accountComponent(chainID) + ":" + accountComponent(address)
+}
+
+// chainAccount returns the account of a chain below the parent account, e.g. Expenses:Fees:Osmosis-1
+func chainAccount(parent string, chainID string) string {
+	return parent + ":" + accountComponent(chainID)
+}
+
+// accountComponent returns a valid Beancount account name component: it starts with a capital letter or a digit and only
+// has letters, digits and dashes
+func accountComponent(name string) string {
+	if name == "" {
+		return "Unknown"
+	}
+
+	var component strings.Builder
+	for i, r := range name {
+		switch {
+		case r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if i == 0 {
+				component.WriteString("X")
+			}
+			component.WriteRune('-')
+		case i == 0:
+			component.WriteRune(unicode.ToUpper(r))
+		default:
+			component.WriteRune(r)
+		}
+	}
+
+	return component.String()
+}
+
+// commodityName returns a valid Beancount commodity name for a symbol: 2 to 24 capital letters, digits and ' . _ - that
+// start with a letter and end with a letter or a digit
+func commodityName(symbol string) string {
+	var name strings.Builder
+	for _, r := range strings.ToUpper(symbol) {
+		switch {
+		case r > unicode.MaxASCII:
+			name.WriteRune('-')
+		case unicode.IsUpper(r) || unicode.IsDigit(r) || strings.ContainsRune("'._-", r):
+			name.WriteRune(r)
+		default:
+			name.WriteRune('-')
+		}
+	}
+
+	commodity := name.String()
+	if commodity == "" || !unicode.IsUpper(rune(commodity[0])) {
+		commodity = "X" + commodity
+	}
+	if len(commodity) > 24 {
+		commodity = commodity[:24]
+	}
+	commodity = strings.TrimRight(commodity, "'._-")
+	for len(commodity) < 2 {
+		commodity += "X"
+	}
+
+	return commodity
+}
+
+// ledgerCommodity quotes the commodities ledger-cli does not read unquoted, the ones with other characters than letters
+func ledgerCommodity(commodity string) string {
+	for _, r := range commodity {
+		if !unicode.IsLetter(r) {
+			return `"` + commodity + `"`
+		}
+	}
+	return commodity
+}
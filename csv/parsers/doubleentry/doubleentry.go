@@ -0,0 +1,440 @@
+package doubleentry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+)
+
+// The narrations of the taxable event entries, the events of the other sources do not move the books
+var eventNarrations = map[uint]string{
+	db.OsmosisRewardDistribution:                  "Osmosis liquidity rewards",
+	db.OsmosisProtorevDeveloperRewardDistribution: "Osmosis protorev developer rewards",
+	db.ClaimHookAirdrop:                           "Airdrop claimed",
+	db.VestingAccountUnlock:                       "Vesting unlock",
+	db.GovDepositRefund:                           "Gov deposit refunded",
+	db.GovDepositBurn:                             "Gov deposit burned",
+	db.StakingSlash:                               "Slashed",
+	db.TendermintLiquidityDepositCoinsToPool:      "Liquidity pool deposit",
+	db.TendermintLiquidityDepositPoolCoinReceived: "Liquidity pool deposit",
+	db.TendermintLiquiditySwapTransactedCoinIn:    "Liquidity pool swap",
+	db.TendermintLiquiditySwapTransactedCoinOut:   "Liquidity pool swap",
+	db.TendermintLiquiditySwapTransactedFee:       "Liquidity pool swap fee",
+	db.TendermintLiquidityWithdrawPoolCoinSent:    "Liquidity pool withdrawal",
+	db.TendermintLiquidityWithdrawCoinReceived:    "Liquidity pool withdrawal",
+	db.TendermintLiquidityWithdrawFee:             "Liquidity pool withdrawal fee",
+}
+
+// NewParser returns the journal parser of the syntax of the parser key
+func NewParser(parserKey string) *Parser {
+	syntax := Beancount
+	if parserKey == ParserKeyLedger {
+		syntax = Ledger
+	}
+
+	return &Parser{
+		Syntax:         syntax,
+		commodities:    make(map[string]commodity),
+		commodityNames: make(map[string]string),
+		accounts:       make(map[string]time.Time),
+	}
+}
+
+func (p *Parser) TimeLayout() string {
+	return TimeLayout
+}
+
+// InitializeParsingGroups does nothing, the parsing groups hold the messages of a single address so they are created for
+// every address in ProcessTaxableTx
+func (p *Parser) InitializeParsingGroups() {}
+
+// ProcessTaxableTx adds an entry per tx of the address, the messages and fees of a tx are posted in the same entry
+func (p *Parser) ProcessTaxableTx(address string, taxableTxs []db.TaxableTransaction, taxableFees []db.Fee) error {
+	// Build a map, so we know which TX go with which messages
+	txMap := parsers.MakeTXMap(taxableTxs)
+
+	// Pull messages out of txMap that must be grouped together
+	parsingGroups := []parsers.ParsingGroup{
+		&OsmosisLpTxGroup{Parser: p, Address: address},
+		&OsmosisConcentratedLiquidityTxGroup{Parser: p, Address: address},
+	}
+	parsers.SeparateParsingGroups(txMap, parsingGroups)
+
+	entries := make(map[string]*Entry)
+	var order []string
+	addEntry := func(entry Entry) {
+		if existing, ok := entries[entry.TxHash]; ok {
+			existing.Narration = joinNarrations(existing.Narration, entry.Narration)
+			existing.Postings = append(existing.Postings, entry.Postings...)
+			return
+		}
+		entries[entry.TxHash] = &entry
+		order = append(order, entry.TxHash)
+	}
+
+	for _, txID := range sortedTxIDs(txMap) {
+		if len(txMap[txID]) != 0 {
+			addEntry(p.messagesEntry(address, txMap[txID]))
+		}
+	}
+
+	for _, parsingGroup := range parsingGroups {
+		err := parsingGroup.ParseGroup()
+		if err != nil {
+			return err
+		}
+		for _, row := range parsingGroup.GetRowsForParsingGroup() {
+			addEntry(row.(Entry))
+		}
+	}
+
+	// The fees of the txs of the address, including the txs without taxable messages
+	seenFees := make(map[uint]bool)
+	feeTxs := make(map[uint]db.Tx)
+	var fees []db.Fee
+	for _, taxableTx := range taxableTxs {
+		feeTxs[taxableTx.Message.Tx.ID] = taxableTx.Message.Tx
+		fees = append(fees, taxableTx.Message.Tx.Fees...)
+	}
+	fees = append(fees, taxableFees...)
+
+	for _, fee := range fees {
+		if seenFees[fee.ID] || fee.PayerAddress.Address != address {
+			continue
+		}
+		seenFees[fee.ID] = true
+
+		tx, ok := feeTxs[fee.TxID]
+		if !ok {
+			tx = fee.Tx
+		}
+
+		chainID := tx.Block.Chain.ChainID
+		postings := p.balancedPostings(walletAccount(WalletsAccount, chainID, address), chainAccount(FeesAccount, chainID), fee.Amount.Neg(), fee.Denomination)
+		addEntry(Entry{
+			Syntax:    p.Syntax,
+			Date:      tx.Block.TimeStamp.UTC(),
+			Address:   address,
+			Narration: "Fee",
+			TxHash:    tx.Hash,
+			Postings:  postings,
+		})
+	}
+
+	for _, txHash := range order {
+		p.addEntry(*entries[txHash])
+	}
+
+	return nil
+}
+
+// messagesEntry returns the entry of the messages of a tx
+func (p *Parser) messagesEntry(address string, messages []db.TaxableTransaction) Entry {
+	tx := messages[0].Message.Tx
+	entry := Entry{
+		Syntax:  p.Syntax,
+		Date:    tx.Block.TimeStamp.UTC(),
+		Address: address,
+		TxHash:  tx.Hash,
+	}
+
+	for _, message := range messages {
+		narration := parsers.InternalTransferDescription(messageName(message.Message.MessageType.MessageType), message)
+		narration = parsers.AggregationDescription(narration, message.AggregatedHeights)
+		entry.Narration = joinNarrations(entry.Narration, narration)
+		entry.Postings = append(entry.Postings, p.messagePostings(address, message)...)
+	}
+
+	return entry
+}
+
+// messagePostings returns the postings of the tokens the address sent and received in a message, each balanced against the
+// counter account of the message type
+func (p *Parser) messagePostings(address string, message db.TaxableTransaction) []Posting {
+	messageType := message.Message.MessageType.MessageType
+	chainID := message.Message.Tx.Block.Chain.ChainID
+	wallet := walletAccount(WalletsAccount, chainID, address)
+
+	// The reward is income of the earner, which passes it on to its withdraw address
+	if parsers.IsRewardToWithdrawAddress(message) {
+		switch address {
+		case message.EarnerAddress.Address:
+			return p.balancedPostings(TransfersAccount, rewardIncomeAccount(message), message.AmountReceived, message.DenominationReceived)
+		case message.ReceiverAddress.Address:
+			return p.balancedPostings(wallet, TransfersAccount, message.AmountReceived, message.DenominationReceived)
+		}
+		return nil
+	}
+
+	var postings []Posting
+	if message.DenominationSentID != nil && message.SenderAddress.Address == address {
+		counterAccount := sentCounterAccount(messageType, chainID, address)
+		if message.InternalTransfer {
+			counterAccount = InternalAccount
+		}
+		postings = append(postings, p.balancedPostings(wallet, counterAccount, message.AmountSent.Neg(), message.DenominationSent)...)
+	}
+	if message.DenominationReceivedID != nil && message.ReceiverAddress.Address == address {
+		counterAccount := receivedCounterAccount(messageType, chainID, address)
+		if message.InternalTransfer {
+			counterAccount = InternalAccount
+		} else if parsers.IsTokenizedShareMint(messageType, message.DenominationReceived.Base) {
+			counterAccount = TransfersAccount
+		} else if parsers.IsVestingFunding(message) {
+			// The tokens of a vesting account are locked until they unlock as income
+			counterAccount = VestingAccount
+		}
+		postings = append(postings, p.balancedPostings(wallet, counterAccount, message.AmountReceived, message.DenominationReceived)...)
+	}
+
+	return postings
+}
+
+// ProcessTaxableEvent adds an entry per taxable event that moves the books
+func (p *Parser) ProcessTaxableEvent(taxableEvents []db.TaxableEvent) error {
+	for _, event := range taxableEvents {
+		address := event.EventAddress.Address
+		chainID := event.Block.Chain.ChainID
+		wallet := walletAccount(WalletsAccount, chainID, address)
+
+		var postings []Posting
+		switch event.Source {
+		case db.OsmosisRewardDistribution, db.OsmosisProtorevDeveloperRewardDistribution:
+			postings = p.balancedPostings(wallet, RewardIncomeAccount, event.Amount, event.Denomination)
+		case db.ClaimHookAirdrop:
+			postings = p.balancedPostings(wallet, AirdropIncomeAccount, event.Amount, event.Denomination)
+		case db.VestingAccountUnlock:
+			// The tokens were locked in the vesting account when it was funded, the unlock makes them income
+			postings = p.balancedPostings(VestingAccount, VestingIncomeAccount, event.Amount, event.Denomination)
+		case db.GovDepositRefund:
+			postings = p.balancedPostings(wallet, GovDepositsAccount, event.Amount, event.Denomination)
+		case db.GovDepositBurn:
+			postings = p.balancedPostings(LossesAccount, GovDepositsAccount, event.Amount, event.Denomination)
+		case db.StakingSlash:
+			postings = p.balancedPostings(LossesAccount, StakingAccount, event.Amount, event.Denomination)
+		case db.StakingUnbondingComplete, db.StakingRedelegationComplete:
+			// The delegated tokens are not posted, so neither are the tokens returned by the unbondings
+			continue
+		case db.TendermintLiquiditySwapTransactedFee, db.TendermintLiquidityWithdrawFee:
+			postings = p.balancedPostings(wallet, chainAccount(FeesAccount, chainID), event.Amount.Neg(), event.Denomination)
+		default:
+			sign := db.TaxableEventBalanceSign(event.Source)
+			if sign == 0 {
+				continue
+			}
+			postings = p.balancedPostings(wallet, TradingAccount, event.Amount.Mul(decimal.NewFromInt(sign)), event.Denomination)
+		}
+
+		p.addEntry(Entry{
+			Syntax:    p.Syntax,
+			Date:      event.Block.TimeStamp.UTC(),
+			Address:   address,
+			Narration: parsers.AggregationDescription(eventNarrations[event.Source], event.AggregatedHeights),
+			Postings:  postings,
+		})
+	}
+
+	return nil
+}
+
+// balancedPostings returns the posting of the amount to the account and the opposite posting to the counter account
+func (p *Parser) balancedPostings(account string, counterAccount string, amount decimal.Decimal, denom db.Denom) []Posting {
+	if amount.IsZero() {
+		return nil
+	}
+
+	convertedAmount, commodity := p.convert(amount, denom)
+	return []Posting{
+		{Account: account, Amount: convertedAmount, Commodity: commodity},
+		{Account: counterAccount, Amount: convertedAmount.Neg(), Commodity: commodity},
+	}
+}
+
+// convert returns the exact amount in display units and the commodity of the denom, amounts of denoms with unknown units stay
+// in the base denom
+func (p *Parser) convert(amount decimal.Decimal, denom db.Denom) (decimal.Decimal, string) {
+	convertedAmount, symbol, err := db.ConvertUnitsExact(amount, denom)
+	if err != nil {
+		convertedAmount, symbol = amount, denom.Base
+	}
+
+	if name, ok := p.commodityNames[denom.Base]; ok {
+		return convertedAmount, name
+	}
+
+	// Denoms with the same symbol, e.g. USDC bridged over different routes, get the end of their base denom appended
+	name := commodityName(symbol)
+	for i := 1; ; i++ {
+		if existing, ok := p.commodities[name]; !ok || existing.base == denom.Base {
+			break
+		}
+		name = commodityName(fmt.Sprintf("%s-%s", symbol, baseSuffix(denom.Base, i)))
+	}
+
+	p.commodityNames[denom.Base] = name
+	p.commodities[name] = commodity{base: denom.Base}
+
+	return convertedAmount, name
+}
+
+// baseSuffix returns the last 6 letters and digits of the base denom, with the attempt number from the second attempt on
+func baseSuffix(base string, attempt int) string {
+	var alphanumeric []rune
+	for _, r := range base {
+		if r < 128 && (r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			alphanumeric = append(alphanumeric, r)
+		}
+	}
+	if len(alphanumeric) > 6 {
+		alphanumeric = alphanumeric[len(alphanumeric)-6:]
+	}
+
+	if attempt == 1 {
+		return string(alphanumeric)
+	}
+	return fmt.Sprintf("%s%d", string(alphanumeric), attempt)
+}
+
+// addEntry adds the entry when it has postings and records the first dates of its accounts and commodities
+func (p *Parser) addEntry(entry Entry) {
+	if len(entry.Postings) == 0 {
+		return
+	}
+
+	for _, posting := range entry.Postings {
+		if first, ok := p.accounts[posting.Account]; !ok || entry.Date.Before(first) {
+			p.accounts[posting.Account] = entry.Date
+		}
+		if c := p.commodities[posting.Commodity]; c.first.IsZero() || entry.Date.Before(c.first) {
+			c.first = entry.Date
+			p.commodities[posting.Commodity] = c
+		}
+	}
+
+	p.Entries = append(p.Entries, entry)
+}
+
+// GetHeaders returns the declarations of the commodities and accounts, they are written before the entries
+func (p *Parser) GetHeaders() []string {
+	var commodityNames []string
+	for name := range p.commodities {
+		commodityNames = append(commodityNames, name)
+	}
+	sort.Strings(commodityNames)
+
+	var accounts []string
+	for account := range p.accounts {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	var lines []string
+	for _, name := range commodityNames {
+		c := p.commodities[name]
+		if p.Syntax == Ledger {
+			lines = append(lines, fmt.Sprintf("commodity %s", ledgerCommodity(name)), fmt.Sprintf("    note %s", c.base))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s commodity %s", c.first.Format(DateLayout), name), fmt.Sprintf("  denom: %q", c.base))
+		}
+	}
+	for _, account := range accounts {
+		if p.Syntax == Ledger {
+			lines = append(lines, fmt.Sprintf("account %s", account))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s open %s", p.accounts[account].Format(DateLayout), account))
+		}
+	}
+
+	return lines
+}
+
+// GetRows returns the entries of the address ordered by date, the start date is inclusive and the end date exclusive
+func (p *Parser) GetRows(address string, startDate, endDate *time.Time) ([]parsers.CsvRow, error) {
+	var entries []Entry
+	for _, entry := range p.Entries {
+		if entry.Address != address {
+			continue
+		}
+		if startDate != nil && entry.Date.Before(*startDate) {
+			continue
+		}
+		if endDate != nil && !entry.Date.Before(*endDate) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.Before(entries[j].Date)
+		}
+		return entries[i].TxHash < entries[j].TxHash
+	})
+
+	rows := make([]parsers.CsvRow, len(entries))
+	for i, entry := range entries {
+		rows[i] = entry
+	}
+
+	return rows, nil
+}
+
+// GetRowForCsv returns the entry as a single column with the lines of the entry in the syntax of the journal
+func (entry Entry) GetRowForCsv() []string {
+	var lines []string
+	if entry.Syntax == Ledger {
+		lines = append(lines, fmt.Sprintf("%s * %s", entry.Date.Format(DateLayout), entry.Narration))
+		if entry.TxHash != "" {
+			lines = append(lines, fmt.Sprintf("    ; tx_hash: %s", entry.TxHash))
+		}
+		for _, posting := range entry.Postings {
+			lines = append(lines, fmt.Sprintf("    %s  %s %s", posting.Account, posting.Amount.String(), ledgerCommodity(posting.Commodity)))
+		}
+	} else {
+		lines = append(lines, fmt.Sprintf("%s * %q", entry.Date.Format(DateLayout), entry.Narration))
+		if entry.TxHash != "" {
+			lines = append(lines, fmt.Sprintf("  tx_hash: %q", entry.TxHash))
+		}
+		for _, posting := range entry.Postings {
+			lines = append(lines, fmt.Sprintf("  %s  %s %s", posting.Account, posting.Amount.String(), posting.Commodity))
+		}
+	}
+
+	return []string{strings.Join(lines, "\n")}
+}
+
+func (entry Entry) GetDate() string {
+	return entry.Date.Format(TimeLayout)
+}
+
+// sortedTxIDs returns the tx IDs of the map in order, so the commodity names are assigned in the same order on every export
+func sortedTxIDs(txMap map[uint][]db.TaxableTransaction) []uint {
+	txIDs := make([]uint, 0, len(txMap))
+	for txID := range txMap {
+		txIDs = append(txIDs, txID)
+	}
+	sort.Slice(txIDs, func(i, j int) bool { return txIDs[i] < txIDs[j] })
+	return txIDs
+}
+
+// messageName returns the name of a message type without its package, e.g. MsgSend
+func messageName(messageType string) string {
+	return messageType[strings.LastIndex(messageType, ".")+1:]
+}
+
+func joinNarrations(narration string, name string) string {
+	if narration == "" {
+		return name
+	}
+	for _, existing := range strings.Split(narration, ", ") {
+		if existing == name {
+			return narration
+		}
+	}
+	return narration + ", " + name
+}
@@ -0,0 +1,88 @@
+package doubleentry
+
+import (
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+)
+
+// OsmosisLpTxGroup groups the pool joins and exits of an address, they trade the pooled tokens for the GAMM tokens through
+// the trading account
+type OsmosisLpTxGroup struct {
+	Parser      *Parser
+	Address     string
+	GroupedTxes map[uint][]db.TaxableTransaction // TX db ID to its messages
+	Rows        []parsers.CsvRow
+}
+
+func (sf *OsmosisLpTxGroup) GetRowsForParsingGroup() []parsers.CsvRow {
+	return sf.Rows
+}
+
+func (sf *OsmosisLpTxGroup) BelongsToGroup(message db.TaxableTransaction) bool {
+	_, isInGroup := parsers.IsOsmosisLpTxGroup[message.Message.MessageType.MessageType]
+	return isInGroup
+}
+
+func (sf *OsmosisLpTxGroup) GetGroupedTxes() map[uint][]db.TaxableTransaction {
+	return sf.GroupedTxes
+}
+
+func (sf *OsmosisLpTxGroup) String() string {
+	return "OsmosisLpTxGroup"
+}
+
+func (sf *OsmosisLpTxGroup) AddTxToGroup(tx db.TaxableTransaction) {
+	if sf.GroupedTxes == nil {
+		sf.GroupedTxes = make(map[uint][]db.TaxableTransaction)
+	}
+	sf.GroupedTxes = parsers.AddTxToGroupMap(sf.GroupedTxes, tx)
+}
+
+// ParseGroup: Every tx of the group is an entry
+func (sf *OsmosisLpTxGroup) ParseGroup() error {
+	for _, txID := range sortedTxIDs(sf.GroupedTxes) {
+		sf.Rows = append(sf.Rows, sf.Parser.messagesEntry(sf.Address, sf.GroupedTxes[txID]))
+	}
+	return nil
+}
+
+// OsmosisConcentratedLiquidityTxGroup groups the concentrated liquidity position changes of an address, the tokens moved in
+// and out of the positions are posted to the positions account of the address
+type OsmosisConcentratedLiquidityTxGroup struct {
+	Parser      *Parser
+	Address     string
+	GroupedTxes map[uint][]db.TaxableTransaction // TX db ID to its messages
+	Rows        []parsers.CsvRow
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) GetRowsForParsingGroup() []parsers.CsvRow {
+	return sf.Rows
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) BelongsToGroup(message db.TaxableTransaction) bool {
+	_, isInGroup := parsers.IsOsmosisConcentratedLiquidity[message.Message.MessageType.MessageType]
+	return isInGroup
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) GetGroupedTxes() map[uint][]db.TaxableTransaction {
+	return sf.GroupedTxes
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) String() string {
+	return "OsmosisConcentratedLiquidityTxGroup"
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) AddTxToGroup(tx db.TaxableTransaction) {
+	if sf.GroupedTxes == nil {
+		sf.GroupedTxes = make(map[uint][]db.TaxableTransaction)
+	}
+	sf.GroupedTxes = parsers.AddTxToGroupMap(sf.GroupedTxes, tx)
+}
+
+// ParseGroup: Every tx of the group is an entry
+func (sf *OsmosisConcentratedLiquidityTxGroup) ParseGroup() error {
+	for _, txID := range sortedTxIDs(sf.GroupedTxes) {
+		sf.Rows = append(sf.Rows, sf.Parser.messagesEntry(sf.Address, sf.GroupedTxes[txID]))
+	}
+	return nil
+}
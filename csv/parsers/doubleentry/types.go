@@ -0,0 +1,84 @@
+package doubleentry
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// ParserKeyBeancount is the key of the Beancount journal
+	ParserKeyBeancount = "beancount"
+	// ParserKeyLedger is the key of the ledger-cli journal
+	ParserKeyLedger = "ledger-cli"
+
+	// TimeLayout is the golang time format string of the row dates, the journals only have the day
+	TimeLayout = time.RFC3339
+	// DateLayout is the golang time format string of the journal dates, both Beancount and ledger-cli read it
+	DateLayout = "2006-01-02"
+)
+
+var ParserKeys = []string{ParserKeyBeancount, ParserKeyLedger}
+
+// Syntax is the plain-text accounting syntax of a journal
+type Syntax int
+
+const (
+	Beancount Syntax = iota
+	Ledger
+)
+
+// The accounts the wallets are balanced against. The wallet, position and fee accounts have the chain and address components
+// appended, see walletAccount.
+const (
+	WalletsAccount          = "Assets:Wallets"
+	PositionsAccount        = "Assets:Positions"
+	GovDepositsAccount      = "Assets:Escrow:Gov-Deposits"
+	StakingIncomeAccount    = "Income:Staking"
+	CommissionIncomeAccount = "Income:Commission"
+	AirdropIncomeAccount    = "Income:Airdrops"
+	RewardIncomeAccount     = "Income:Rewards"
+	VestingIncomeAccount    = "Income:Vesting"
+	FeesAccount             = "Expenses:Fees"
+	LossesAccount           = "Expenses:Losses"
+	DonationsAccount        = "Expenses:Donations"
+	TradingAccount          = "Equity:Trading"
+	TransfersAccount        = "Equity:Transfers"
+	InternalAccount         = "Equity:Transfers:Internal"
+	StakingAccount          = "Equity:Staking"
+	VestingAccount          = "Equity:Vesting"
+)
+
+type Parser struct {
+	Syntax  Syntax
+	Entries []Entry
+
+	// The commodities of the entries by name, with the base denom and the first date they were posted on
+	commodities map[string]commodity
+	// The commodity names by base denom
+	commodityNames map[string]string
+	// The first date each account was posted to, Beancount requires the accounts to be opened before they are used
+	accounts map[string]time.Time
+}
+
+type commodity struct {
+	base  string
+	first time.Time
+}
+
+// Entry is a balanced journal transaction of an address, every tx and every taxable event is an entry
+type Entry struct {
+	Syntax    Syntax
+	Date      time.Time
+	Address   string
+	Narration string
+	TxHash    string
+	Postings  []Posting
+}
+
+// Posting is an amount in display units posted to an account, the postings of an entry sum to zero per commodity
+type Posting struct {
+	Account   string
+	Amount    decimal.Decimal
+	Commodity string
+}
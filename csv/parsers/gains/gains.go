@@ -0,0 +1,383 @@
+package gains
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/gov"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+)
+
+// The taxable event sources that move tokens in or out of the address set. Vesting unlocks acquire the tokens locked in the
+// vesting account when it was funded. Gov deposits are escrowed when deposited, refunds release the escrowed lots and burns dispose
+// of them.
+var taxableEventMovements = map[uint]struct {
+	acquire      bool
+	movementType string
+	escrowed     bool
+}{
+	db.OsmosisRewardDistribution:                  {true, Acquisition, false},
+	db.OsmosisProtorevDeveloperRewardDistribution: {true, Acquisition, false},
+	db.ClaimHookAirdrop:                           {true, Acquisition, false},
+	db.GovDepositRefund:                           {true, Release, false},
+	db.GovDepositBurn:                             {false, Lost, true},
+	db.TendermintLiquidityDepositPoolCoinReceived: {true, Acquisition, false},
+	db.TendermintLiquiditySwapTransactedCoinOut:   {true, Acquisition, false},
+	db.TendermintLiquidityWithdrawCoinReceived:    {true, Acquisition, false},
+	db.TendermintLiquidityDepositCoinsToPool:      {false, Trade, false},
+	db.TendermintLiquiditySwapTransactedCoinIn:    {false, Trade, false},
+	db.TendermintLiquidityWithdrawPoolCoinSent:    {false, Trade, false},
+	db.TendermintLiquiditySwapTransactedFee:       {false, Fee, false},
+	db.TendermintLiquidityWithdrawFee:             {false, Fee, false},
+	db.StakingSlash:                               {false, Lost, false},
+	db.VestingAccountUnlock:                       {true, Acquisition, false},
+}
+
+// The messages whose transfers out of the address set are gov deposits, held in escrow until refunded or burned
+var govDepositMessageTypes = map[string]bool{
+	gov.MsgSubmitProposal:   true,
+	gov.MsgSubmitProposalV1: true,
+	gov.MsgDeposit:          true,
+	gov.MsgDepositV1:        true,
+}
+
+// NewParser returns the gains parser for the cost basis method of the parser key
+func NewParser(parserKey string, prices parsers.PriceLookup) *Parser {
+	return &Parser{
+		Method:     methodsByParserKey[parserKey],
+		Prices:     prices,
+		addresses:  make(map[string]bool),
+		seenTxs:    make(map[uint]bool),
+		seenFees:   make(map[uint]bool),
+		seenEvents: make(map[string]bool),
+	}
+}
+
+func (p *Parser) TimeLayout() string {
+	return TimeLayout
+}
+
+// KeepsLots marks the parser as a lot parser, the rewards are acquired at the time and value of each reward
+func (p *Parser) KeepsLots() {}
+
+// InitializeParsingGroups does nothing, the lots are kept per currency so the messages of a tx need no grouping
+func (p *Parser) InitializeParsingGroups() {}
+
+// SetPriceLookup values the movements with the prices before the prices of the parser
+func (p *Parser) SetPriceLookup(prices parsers.PriceLookup) {
+	if p.Prices == nil {
+		p.Prices = prices
+		return
+	}
+	p.Prices = parsers.PriceLookups{prices, p.Prices}
+}
+
+// SetAddresses sets the address set the lots are shared by
+func (p *Parser) SetAddresses(addresses []string) {
+	for _, address := range addresses {
+		p.addresses[address] = true
+	}
+}
+
+func (p *Parser) ProcessTaxableTx(address string, taxableTxs []db.TaxableTransaction, taxableFees []db.Fee) error {
+	p.addresses[address] = true
+
+	// A tx between two addresses of the set is returned for both addresses
+	for _, taxableTx := range taxableTxs {
+		if p.seenTxs[taxableTx.ID] {
+			continue
+		}
+		p.seenTxs[taxableTx.ID] = true
+		p.addTaxableTx(taxableTx)
+	}
+
+	for _, fee := range taxableFees {
+		if p.seenFees[fee.ID] {
+			continue
+		}
+		p.seenFees[fee.ID] = true
+		p.movements = append(p.movements, p.newMovement(fee.Tx.Block.TimeStamp, fee.Tx.Hash, fee.PayerAddress.Address, fee.Amount, fee.Denomination, false, Fee))
+	}
+
+	return nil
+}
+
+func (p *Parser) ProcessTaxableEvent(taxableEvents []db.TaxableEvent) error {
+	// The burns and slash losses are computed when searched and are not stored, the events are identified by their hash
+	for _, event := range taxableEvents {
+		if p.seenEvents[event.EventHash] {
+			continue
+		}
+		p.seenEvents[event.EventHash] = true
+
+		eventMovement, ok := taxableEventMovements[event.Source]
+		if !ok {
+			continue
+		}
+
+		movement := p.newMovement(event.Block.TimeStamp, event.EventHash, event.EventAddress.Address, event.Amount,
+			event.Denomination, eventMovement.acquire, eventMovement.movementType)
+		movement.Escrowed = eventMovement.escrowed
+		p.movements = append(p.movements, movement)
+	}
+
+	return nil
+}
+
+func (p *Parser) addTaxableTx(taxableTx db.TaxableTransaction) {
+	at := taxableTx.Message.Tx.Block.TimeStamp
+	txHash := taxableTx.Message.Tx.Hash
+
+	// Legs without a counterparty (e.g. swaps) send and receive for the same address
+	sender := taxableTx.SenderAddress.Address
+	receiver := taxableTx.ReceiverAddress.Address
+	if sender == "" {
+		sender = receiver
+	}
+	if receiver == "" {
+		receiver = sender
+	}
+
+	hasSent := taxableTx.DenominationSentID != nil && taxableTx.AmountSent.IsPositive()
+	hasReceived := taxableTx.DenominationReceivedID != nil && taxableTx.AmountReceived.IsPositive()
+	// Internal transfers can be to the same account of a queried address on another chain
+	senderInSet := p.addresses[sender] || taxableTx.InternalTransfer
+	receiverInSet := p.addresses[receiver] || taxableTx.InternalTransfer
+	if parsers.IsVestingFunding(taxableTx) {
+		// The tokens received by a vesting account are acquired when they unlock
+		receiverInSet = false
+	}
+
+	switch {
+	case senderInSet && receiverInSet && hasSent && hasReceived && *taxableTx.DenominationSentID != *taxableTx.DenominationReceivedID:
+		// A swap, the value of the received tokens is the proceeds of the sent tokens and the cost of the received tokens
+		sent := p.newMovement(at, txHash, sender, taxableTx.AmountSent, taxableTx.DenominationSent, false, Trade)
+		received := p.newMovement(at, txHash, receiver, taxableTx.AmountReceived, taxableTx.DenominationReceived, true, Acquisition)
+		if received.ValueKnown {
+			sent.Value, sent.ValueKnown = received.Value, true
+		} else if sent.ValueKnown {
+			received.Value, received.ValueKnown = sent.Value, true
+		}
+		p.movements = append(p.movements, sent, received)
+	case senderInSet && receiverInSet:
+		// Transfers between the addresses of the set keep their lots
+	case senderInSet:
+		movementType := Transfer
+		if govDepositMessageTypes[taxableTx.Message.MessageType.MessageType] {
+			movementType = Escrow
+		}
+		if hasSent {
+			p.movements = append(p.movements, p.newMovement(at, txHash, sender, taxableTx.AmountSent, taxableTx.DenominationSent, false, movementType))
+		}
+	case receiverInSet:
+		if hasReceived {
+			p.movements = append(p.movements, p.newMovement(at, txHash, receiver, taxableTx.AmountReceived, taxableTx.DenominationReceived, true, Acquisition))
+		}
+	case p.addresses[taxableTx.EarnerAddress.Address] && hasReceived:
+		// Rewards paid to a withdraw address outside of the set are acquired by the earner and transferred out
+		earner := taxableTx.EarnerAddress.Address
+		p.movements = append(p.movements,
+			p.newMovement(at, txHash, earner, taxableTx.AmountReceived, taxableTx.DenominationReceived, true, Acquisition),
+			p.newMovement(at, txHash, earner, taxableTx.AmountReceived, taxableTx.DenominationReceived, false, Transfer))
+	}
+}
+
+// newMovement converts the amount to the display unit of the denom and values it at the given time
+func (p *Parser) newMovement(at time.Time, txHash string, address string, amount decimal.Decimal, denom db.Denom, acquire bool, movementType string) Movement {
+	movement := Movement{
+		Time:     at,
+		TxHash:   txHash,
+		Address:  address,
+		Currency: denom.Base,
+		Amount:   amount,
+		Acquire:  acquire,
+		Type:     movementType,
+	}
+
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(amount.BigInt(), denom)
+	if err == nil {
+		convertedAmount, err := decimal.NewFromString(conversionAmount.Text('f', -1))
+		if err == nil {
+			movement.Amount = convertedAmount
+			movement.Currency = conversionSymbol
+		}
+	}
+
+	// Lost tokens have no proceeds, the loss is their cost basis
+	if movementType == Lost {
+		movement.ValueKnown = true
+		return movement
+	}
+
+	// Transfers out of the address set are not sales, their lots leave the ledger without proceeds or a gain
+	if movementType == Transfer {
+		return movement
+	}
+
+	if p.Prices != nil {
+		price, err := p.Prices.GetPrice(movement.Currency, at)
+		if err != nil {
+			config.Log.Debugf("No price for %s at %s, the value of tx %s is unknown. Err: %v", movement.Currency, at, txHash, err)
+		} else {
+			movement.Value = movement.Amount.Mul(price)
+			movement.ValueKnown = true
+		}
+	}
+
+	return movement
+}
+
+func (p *Parser) GetRows(address string, startDate, endDate *time.Time) ([]parsers.CsvRow, error) {
+	if !p.rowsComputed {
+		p.computeRows()
+		p.rowsComputed = true
+	}
+
+	var rows []parsers.CsvRow
+	for _, row := range p.rows {
+		if row.Address != address {
+			continue
+		}
+		if startDate != nil && row.time.Before(*startDate) {
+			continue
+		}
+		if endDate != nil && !row.time.Before(*endDate) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// computeRows replays the movements of the address set through the lots, the acquisitions of a block are replayed before its
+// disposals
+func (p *Parser) computeRows() {
+	sort.SliceStable(p.movements, func(i, j int) bool {
+		if !p.movements[i].Time.Equal(p.movements[j].Time) {
+			return p.movements[i].Time.Before(p.movements[j].Time)
+		}
+		return p.movements[i].Acquire && !p.movements[j].Acquire
+	})
+
+	ledger := NewLedger(p.Method)
+	for _, movement := range p.movements {
+		if movement.Type == Release {
+			unreleased := ledger.Release(movement)
+			if !unreleased.IsPositive() {
+				continue
+			}
+
+			// The deposits made before the indexed history are acquired at the value of their refund
+			if movement.Amount.IsPositive() {
+				movement.Value = movement.Value.Mul(unreleased).Div(movement.Amount)
+			}
+			movement.Amount = unreleased
+		}
+
+		if movement.Acquire {
+			ledger.Acquire(movement)
+			continue
+		}
+
+		if movement.Type == Escrow {
+			ledger.Escrow(movement)
+			continue
+		}
+
+		disposal := ledger.Dispose(movement)
+		p.rows = append(p.rows, disposalRows(disposal)...)
+	}
+}
+
+// disposalRows returns a row for the short-term and the long-term part of the disposal, and one for the unmatched amount
+func disposalRows(disposal Disposal) []Row {
+	var rows []Row
+
+	var shortTerm, longTerm []LotConsumption
+	for _, consumption := range disposal.Consumed {
+		if consumption.LongTerm(disposal.Time) {
+			longTerm = append(longTerm, consumption)
+		} else {
+			shortTerm = append(shortTerm, consumption)
+		}
+	}
+
+	for _, term := range []struct {
+		name         string
+		consumptions []LotConsumption
+	}{{ShortTerm, shortTerm}, {LongTerm, longTerm}} {
+		if len(term.consumptions) == 0 {
+			continue
+		}
+
+		amount := decimal.Zero
+		costBasis := decimal.Zero
+		var lots []string
+		for _, consumption := range term.consumptions {
+			amount = amount.Add(consumption.Amount)
+			costBasis = costBasis.Add(consumption.CostBasis)
+			lots = append(lots, fmt.Sprintf("lot %d: %s acquired %s in %s", consumption.Lot.ID, consumption.Amount,
+				consumption.Lot.Acquired.UTC().Format(TimeLayout), consumption.Lot.TxHash))
+		}
+
+		row := newRow(disposal, amount)
+		row.Term = term.name
+		row.Lots = strings.Join(lots, "; ")
+		row.DateAcquired = "VARIOUS"
+		if len(term.consumptions) == 1 {
+			row.DateAcquired = term.consumptions[0].Lot.Acquired.UTC().Format(TimeLayout)
+		}
+
+		if disposal.BasisKnown {
+			row.CostBasis = costBasis.StringFixed(2)
+			if disposal.ValueKnown {
+				row.Gain = proceeds(disposal, amount).Sub(costBasis).StringFixed(2)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	if disposal.Unmatched.IsPositive() {
+		config.Log.Warnf("%s %s disposed by %s in %s exceed the acquired lots, the cost basis is unknown", disposal.Unmatched, disposal.Currency,
+			disposal.Address, disposal.TxHash)
+		row := newRow(disposal, disposal.Unmatched)
+		row.Lots = "unmatched"
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func newRow(disposal Disposal, amount decimal.Decimal) Row {
+	row := Row{
+		Date:         disposal.Time.UTC().Format(TimeLayout),
+		Address:      disposal.Address,
+		Type:         disposal.Type,
+		Amount:       amount.String(),
+		Currency:     disposal.Currency,
+		FiatCurrency: FiatCurrency,
+		TxHash:       disposal.TxHash,
+		time:         disposal.Time,
+	}
+
+	if disposal.ValueKnown {
+		row.Proceeds = proceeds(disposal, amount).StringFixed(2)
+	}
+
+	return row
+}
+
+// proceeds returns the share of the proceeds of the disposal for the amount
+func proceeds(disposal Disposal, amount decimal.Decimal) decimal.Decimal {
+	if !disposal.Amount.IsPositive() {
+		return decimal.Zero
+	}
+	return disposal.Value.Mul(amount).Div(disposal.Amount)
+}
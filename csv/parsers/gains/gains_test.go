@@ -0,0 +1,155 @@
+package gains
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/vesting"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlashLossesAreNotDeduped(t *testing.T) {
+	address := "cosmos1delegator"
+	atom := db.Denom{Base: "uatom"}
+	event := func(source uint, amount int64, at time.Time, hash string) db.TaxableEvent {
+		return db.TaxableEvent{
+			Source:       source,
+			Amount:       decimal.NewFromInt(amount),
+			Denomination: atom,
+			EventAddress: db.Address{Address: address},
+			EventHash:    hash,
+			Block:        db.Block{TimeStamp: at},
+		}
+	}
+
+	parser := NewParser(ParserKeyFIFO, nil)
+	parser.SetAddresses([]string{address})
+
+	// The stored airdrop has an ID, the slash losses are computed when searched and have none
+	airdrop := event(db.ClaimHookAirdrop, 100, lotsStart, "airdrop")
+	airdrop.ID = 1
+	err := parser.ProcessTaxableEvent([]db.TaxableEvent{
+		airdrop,
+		event(db.StakingSlash, 5, lotsStart.AddDate(0, 1, 0), "slash-1"),
+		event(db.StakingSlash, 3, lotsStart.AddDate(0, 2, 0), "slash-2"),
+	})
+	assert.Nil(t, err)
+
+	// The same events returned for another address of the set are only counted once
+	err = parser.ProcessTaxableEvent([]db.TaxableEvent{event(db.StakingSlash, 5, lotsStart.AddDate(0, 1, 0), "slash-1")})
+	assert.Nil(t, err)
+
+	rows, err := parser.GetRows(address, nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+	for i, amount := range []string{"5", "3"} {
+		row := rows[i].(Row)
+		assert.Equal(t, Lost, row.Type)
+		assert.Equal(t, amount, row.Amount)
+	}
+}
+
+type fixedPrices map[string]decimal.Decimal
+
+func (prices fixedPrices) GetPrice(currency string, _ time.Time) (decimal.Decimal, error) {
+	if price, ok := prices[currency]; ok {
+		return price, nil
+	}
+	return decimal.Zero, errors.New("no price")
+}
+
+func TestTransfersOutHaveNoGain(t *testing.T) {
+	address := "cosmos1sender"
+	atom := db.Denom{ID: 1, Base: "uatom"}
+	denomID := atom.ID
+
+	parser := NewParser(ParserKeyFIFO, fixedPrices{"uatom": decimal.NewFromInt(2)})
+	parser.SetAddresses([]string{address})
+
+	err := parser.ProcessTaxableEvent([]db.TaxableEvent{{
+		ID: 1, Source: db.ClaimHookAirdrop, Amount: decimal.NewFromInt(100), Denomination: atom,
+		EventAddress: db.Address{Address: address}, EventHash: "airdrop", Block: db.Block{TimeStamp: lotsStart},
+	}})
+	assert.Nil(t, err)
+
+	// A send to an address outside of the set, e.g. an exchange deposit, is valued higher than the lot
+	parser.Prices = fixedPrices{"uatom": decimal.NewFromInt(5)}
+	err = parser.ProcessTaxableTx(address, []db.TaxableTransaction{{
+		ID:                 1,
+		Message:            db.Message{Tx: db.Tx{Hash: "send", Block: db.Block{TimeStamp: lotsStart.AddDate(0, 1, 0)}}},
+		SenderAddress:      db.Address{Address: address},
+		ReceiverAddress:    db.Address{Address: "cosmos1exchange"},
+		AmountSent:         decimal.NewFromInt(40),
+		DenominationSentID: &denomID,
+		DenominationSent:   atom,
+	}}, nil)
+	assert.Nil(t, err)
+
+	rows, err := parser.GetRows(address, nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+
+	row := rows[0].(Row)
+	assert.Equal(t, Transfer, row.Type)
+	assert.Equal(t, "80.00", row.CostBasis)
+	assert.Empty(t, row.Proceeds)
+	assert.Empty(t, row.Gain)
+}
+
+func TestVestingTokensAreAcquiredWhenUnlocked(t *testing.T) {
+	address := "cosmos1vesting"
+	atom := db.Denom{ID: 1, Base: "uatom"}
+	denomID := atom.ID
+
+	parser := NewParser(ParserKeyFIFO, fixedPrices{"uatom": decimal.NewFromInt(2)})
+	parser.SetAddresses([]string{address})
+
+	// The funding of the vesting account locks the tokens, it is not an acquisition
+	err := parser.ProcessTaxableTx(address, []db.TaxableTransaction{{
+		ID: 1,
+		Message: db.Message{
+			MessageType: db.MessageType{MessageType: vesting.MsgCreateVestingAccount},
+			Tx:          db.Tx{Hash: "fund", Block: db.Block{TimeStamp: lotsStart}},
+		},
+		SenderAddress:          db.Address{Address: "cosmos1funder"},
+		ReceiverAddress:        db.Address{Address: address},
+		AmountSent:             decimal.NewFromInt(100),
+		DenominationSentID:     &denomID,
+		DenominationSent:       atom,
+		AmountReceived:         decimal.NewFromInt(100),
+		DenominationReceivedID: &denomID,
+		DenominationReceived:   atom,
+	}}, nil)
+	assert.Nil(t, err)
+
+	// The unlock acquires the tokens at their value when they unlock
+	parser.Prices = fixedPrices{"uatom": decimal.NewFromInt(3)}
+	err = parser.ProcessTaxableEvent([]db.TaxableEvent{{
+		ID: 1, Source: db.VestingAccountUnlock, Amount: decimal.NewFromInt(30), Denomination: atom,
+		EventAddress: db.Address{Address: address}, EventHash: "unlock", Block: db.Block{TimeStamp: lotsStart.AddDate(0, 1, 0)},
+	}})
+	assert.Nil(t, err)
+
+	parser.Prices = fixedPrices{"uatom": decimal.NewFromInt(5)}
+	err = parser.ProcessTaxableTx(address, []db.TaxableTransaction{{
+		ID:                 2,
+		Message:            db.Message{Tx: db.Tx{Hash: "send", Block: db.Block{TimeStamp: lotsStart.AddDate(0, 2, 0)}}},
+		SenderAddress:      db.Address{Address: address},
+		ReceiverAddress:    db.Address{Address: "cosmos1exchange"},
+		AmountSent:         decimal.NewFromInt(30),
+		DenominationSentID: &denomID,
+		DenominationSent:   atom,
+	}}, nil)
+	assert.Nil(t, err)
+
+	rows, err := parser.GetRows(address, nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+
+	row := rows[0].(Row)
+	assert.Equal(t, Transfer, row.Type)
+	assert.Equal(t, "90.00", row.CostBasis)
+}
@@ -0,0 +1,261 @@
+package gains
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// The cost basis methods, they select the lots a disposal consumes
+const (
+	FIFO    = "fifo"
+	LIFO    = "lifo"
+	HIFO    = "hifo"
+	Average = "average"
+)
+
+// The types of the movements, acquisitions are not reported so they are not typed further
+const (
+	Acquisition = "acquisition"
+	Trade       = "trade"
+	Transfer    = "transfer"
+	Fee         = "fee"
+	Lost        = "lost"
+	// Gov deposits are held in escrow by the gov module, the deposit and its refund are not a disposal and an acquisition
+	Escrow  = "escrow"
+	Release = "release"
+)
+
+// Movement is an amount of a currency entering or leaving the address set. Value is the fiat value of the amount at the
+// time of the movement: the cost basis of an acquisition or the proceeds of a disposal. Escrowed disposals (burned gov
+// deposits) consume the escrowed lots.
+type Movement struct {
+	Time       time.Time
+	TxHash     string
+	Address    string
+	Currency   string
+	Amount     decimal.Decimal
+	Acquire    bool
+	Type       string
+	Value      decimal.Decimal
+	ValueKnown bool
+	Escrowed   bool
+}
+
+// Lot is an acquired amount of a currency, it is consumed by the disposals until nothing remains
+type Lot struct {
+	ID         int
+	Address    string
+	Currency   string
+	Acquired   time.Time
+	TxHash     string
+	Amount     decimal.Decimal
+	Remaining  decimal.Decimal
+	UnitCost   decimal.Decimal
+	BasisKnown bool
+}
+
+// LotConsumption is the part of a lot consumed by a disposal with its cost basis
+type LotConsumption struct {
+	Lot       *Lot
+	Amount    decimal.Decimal
+	CostBasis decimal.Decimal
+}
+
+// LongTerm is true when the lot was held for more than one year at the disposal time. The holding period counts UTC calendar
+// dates, from the day after the acquisition, so a lot disposed on the anniversary of its acquisition is still short-term.
+func (c LotConsumption) LongTerm(disposed time.Time) bool {
+	acquired := c.Lot.Acquired.UTC()
+	anniversary := time.Date(acquired.Year()+1, acquired.Month(), acquired.Day(), 0, 0, 0, 0, time.UTC)
+	// A lot acquired on February 29 has its anniversary on February 28
+	if anniversary.Month() != acquired.Month() {
+		anniversary = anniversary.AddDate(0, 0, -anniversary.Day())
+	}
+
+	disposed = disposed.UTC()
+	return time.Date(disposed.Year(), disposed.Month(), disposed.Day(), 0, 0, 0, 0, time.UTC).After(anniversary)
+}
+
+// Disposal is a movement out of the address set with the lots it consumed. Unmatched is the amount disposed without an
+// open lot, e.g. tokens held before the indexed history. BasisKnown is false when a consumed lot has no known cost basis.
+type Disposal struct {
+	Movement
+	Consumed   []LotConsumption
+	Unmatched  decimal.Decimal
+	BasisKnown bool
+}
+
+// Ledger keeps the open lots of an address set per currency, and the lots held in escrow
+type Ledger struct {
+	method    string
+	nextLotID int
+	lots      map[string][]*Lot
+	escrow    map[string][]*Lot
+}
+
+func NewLedger(method string) *Ledger {
+	return &Ledger{method: method, nextLotID: 1, lots: make(map[string][]*Lot), escrow: make(map[string][]*Lot)}
+}
+
+// Acquire opens a lot for the movement, the value of the movement is the cost basis of the lot
+func (l *Ledger) Acquire(movement Movement) *Lot {
+	lot := &Lot{
+		ID:         l.nextLotID,
+		Address:    movement.Address,
+		Currency:   movement.Currency,
+		Acquired:   movement.Time,
+		TxHash:     movement.TxHash,
+		Amount:     movement.Amount,
+		Remaining:  movement.Amount,
+		BasisKnown: movement.ValueKnown,
+	}
+	if movement.ValueKnown && movement.Amount.IsPositive() {
+		lot.UnitCost = movement.Value.Div(movement.Amount)
+	}
+	l.nextLotID++
+
+	l.lots[movement.Currency] = append(l.lots[movement.Currency], lot)
+
+	return lot
+}
+
+// Dispose consumes the open lots of the currency of the movement in the order of the cost basis method, escrowed disposals
+// consume the escrowed lots
+func (l *Ledger) Dispose(movement Movement) Disposal {
+	if movement.Escrowed {
+		return l.dispose(movement, l.escrow[movement.Currency])
+	}
+	return l.dispose(movement, l.lots[movement.Currency])
+}
+
+// Escrow moves the lots consumed by the movement into escrow, keeping their acquisition time and cost basis. The amount
+// without an open lot is not escrowed.
+func (l *Ledger) Escrow(movement Movement) {
+	disposal := l.dispose(movement, l.lots[movement.Currency])
+	for _, consumption := range disposal.Consumed {
+		escrowed := *consumption.Lot
+		escrowed.Amount = consumption.Amount
+		escrowed.Remaining = consumption.Amount
+		if l.method == Average && escrowed.BasisKnown && consumption.Amount.IsPositive() {
+			escrowed.UnitCost = consumption.CostBasis.Div(consumption.Amount)
+		}
+		l.escrow[movement.Currency] = append(l.escrow[movement.Currency], &escrowed)
+	}
+}
+
+// Release moves the escrowed lots of the currency of the movement back to the open lots, oldest first, and returns the
+// amount that was not in escrow
+func (l *Ledger) Release(movement Movement) decimal.Decimal {
+	remaining := movement.Amount
+	for _, escrowed := range l.escrow[movement.Currency] {
+		if !remaining.IsPositive() {
+			break
+		}
+		if !escrowed.Remaining.IsPositive() {
+			continue
+		}
+
+		released := *escrowed
+		released.Amount = decimal.Min(remaining, escrowed.Remaining)
+		released.Remaining = released.Amount
+		escrowed.Remaining = escrowed.Remaining.Sub(released.Amount)
+		remaining = remaining.Sub(released.Amount)
+		l.lots[movement.Currency] = append(l.lots[movement.Currency], &released)
+	}
+
+	return remaining
+}
+
+func (l *Ledger) dispose(movement Movement, lots []*Lot) Disposal {
+	disposal := Disposal{Movement: movement, BasisKnown: true}
+
+	var open []*Lot
+	for _, lot := range lots {
+		if lot.Remaining.IsPositive() {
+			open = append(open, lot)
+		}
+	}
+
+	l.sortLots(open)
+
+	// The average cost of all the open lots is the cost of every unit disposed
+	averageCost, averageKnown := averageUnitCost(open)
+
+	remaining := movement.Amount
+	for _, lot := range open {
+		if !remaining.IsPositive() {
+			break
+		}
+
+		amount := decimal.Min(remaining, lot.Remaining)
+		consumption := LotConsumption{Lot: lot, Amount: amount}
+		switch {
+		case l.method == Average && averageKnown:
+			consumption.CostBasis = amount.Mul(averageCost)
+		case l.method != Average && lot.BasisKnown:
+			consumption.CostBasis = amount.Mul(lot.UnitCost)
+		default:
+			disposal.BasisKnown = false
+		}
+
+		lot.Remaining = lot.Remaining.Sub(amount)
+		remaining = remaining.Sub(amount)
+		disposal.Consumed = append(disposal.Consumed, consumption)
+	}
+
+	if remaining.IsPositive() {
+		disposal.Unmatched = remaining
+	}
+
+	// The remaining units keep the average cost of the pool
+	if l.method == Average && averageKnown {
+		for _, lot := range open {
+			lot.UnitCost = averageCost
+		}
+	}
+
+	return disposal
+}
+
+func (l *Ledger) sortLots(lots []*Lot) {
+	switch l.method {
+	case LIFO:
+		sort.SliceStable(lots, func(i, j int) bool {
+			return lots[i].ID > lots[j].ID
+		})
+	case HIFO:
+		// Lots without a known cost basis are consumed last
+		sort.SliceStable(lots, func(i, j int) bool {
+			if lots[i].BasisKnown != lots[j].BasisKnown {
+				return lots[i].BasisKnown
+			}
+			if !lots[i].UnitCost.Equal(lots[j].UnitCost) {
+				return lots[i].UnitCost.GreaterThan(lots[j].UnitCost)
+			}
+			return lots[i].ID < lots[j].ID
+		})
+	default:
+		sort.SliceStable(lots, func(i, j int) bool {
+			return lots[i].ID < lots[j].ID
+		})
+	}
+}
+
+func averageUnitCost(lots []*Lot) (decimal.Decimal, bool) {
+	total := decimal.Zero
+	cost := decimal.Zero
+	for _, lot := range lots {
+		if !lot.BasisKnown {
+			return decimal.Zero, false
+		}
+		total = total.Add(lot.Remaining)
+		cost = cost.Add(lot.Remaining.Mul(lot.UnitCost))
+	}
+
+	if !total.IsPositive() {
+		return decimal.Zero, false
+	}
+
+	return cost.Div(total), true
+}
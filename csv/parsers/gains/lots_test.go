@@ -0,0 +1,148 @@
+package gains
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var lotsStart = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// acquireLots acquires 10 ATOM at $10, $30 and $20 a month apart
+func acquireLots(ledger *Ledger) {
+	for i, price := range []int64{10, 30, 20} {
+		ledger.Acquire(Movement{
+			Time:       lotsStart.AddDate(0, i, 0),
+			Currency:   "ATOM",
+			Amount:     decimal.NewFromInt(10),
+			Acquire:    true,
+			Value:      decimal.NewFromInt(10 * price),
+			ValueKnown: true,
+		})
+	}
+}
+
+func dispose(ledger *Ledger, amount int64, at time.Time) Disposal {
+	return ledger.Dispose(Movement{
+		Time:       at,
+		Currency:   "ATOM",
+		Amount:     decimal.NewFromInt(amount),
+		Type:       Trade,
+		Value:      decimal.NewFromInt(amount * 25),
+		ValueKnown: true,
+	})
+}
+
+func costBasis(disposal Disposal) decimal.Decimal {
+	total := decimal.Zero
+	for _, consumption := range disposal.Consumed {
+		total = total.Add(consumption.CostBasis)
+	}
+	return total
+}
+
+func TestDisposeMethods(t *testing.T) {
+	tests := []struct {
+		method    string
+		lots      []int
+		costBasis int64
+	}{
+		{FIFO, []int{1, 2}, 10*10 + 5*30},
+		{LIFO, []int{3, 2}, 10*20 + 5*30},
+		{HIFO, []int{2, 3}, 10*30 + 5*20},
+		{Average, []int{1, 2}, 15 * 20},
+	}
+
+	for _, tt := range tests {
+		ledger := NewLedger(tt.method)
+		acquireLots(ledger)
+
+		disposal := dispose(ledger, 15, lotsStart.AddDate(0, 6, 0))
+		assert.True(t, disposal.BasisKnown, tt.method)
+		assert.True(t, disposal.Unmatched.IsZero(), tt.method)
+
+		var lots []int
+		for _, consumption := range disposal.Consumed {
+			lots = append(lots, consumption.Lot.ID)
+		}
+		assert.Equal(t, tt.lots, lots, tt.method)
+		assert.True(t, decimal.NewFromInt(tt.costBasis).Equal(costBasis(disposal)), "%s cost basis %s", tt.method, costBasis(disposal))
+	}
+}
+
+func TestDisposeAverageKeepsPoolCost(t *testing.T) {
+	ledger := NewLedger(Average)
+	acquireLots(ledger)
+
+	dispose(ledger, 15, lotsStart.AddDate(0, 6, 0))
+	disposal := dispose(ledger, 15, lotsStart.AddDate(0, 7, 0))
+
+	assert.True(t, decimal.NewFromInt(15*20).Equal(costBasis(disposal)))
+}
+
+func TestDisposeUnmatchedAndTerms(t *testing.T) {
+	ledger := NewLedger(FIFO)
+	acquireLots(ledger)
+
+	at := lotsStart.AddDate(1, 1, 15)
+	disposal := dispose(ledger, 35, at)
+
+	assert.True(t, decimal.NewFromInt(5).Equal(disposal.Unmatched))
+	assert.Len(t, disposal.Consumed, 3)
+	assert.True(t, disposal.Consumed[0].LongTerm(at))
+	assert.True(t, disposal.Consumed[1].LongTerm(at))
+	assert.False(t, disposal.Consumed[2].LongTerm(at))
+
+	rows := disposalRows(disposal)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, ShortTerm, rows[0].Term)
+	assert.Equal(t, "200.00", rows[0].CostBasis)
+	assert.Equal(t, "50.00", rows[0].Gain)
+	assert.Equal(t, LongTerm, rows[1].Term)
+	assert.Equal(t, "VARIOUS", rows[1].DateAcquired)
+	assert.Equal(t, "400.00", rows[1].CostBasis)
+	assert.Equal(t, "100.00", rows[1].Gain)
+	assert.Equal(t, "unmatched", rows[2].Lots)
+	assert.Equal(t, "", rows[2].CostBasis)
+	assert.Equal(t, "125.00", rows[2].Proceeds)
+}
+
+func TestLongTermCalendarDates(t *testing.T) {
+	lot := &Lot{Acquired: time.Date(2022, 3, 15, 23, 0, 0, 0, time.UTC)}
+	consumption := LotConsumption{Lot: lot}
+
+	assert.False(t, consumption.LongTerm(time.Date(2023, 3, 15, 23, 30, 0, 0, time.UTC)), "the anniversary is still short-term")
+	assert.True(t, consumption.LongTerm(time.Date(2023, 3, 16, 0, 30, 0, 0, time.UTC)), "the day after the anniversary is long-term")
+
+	// The dates are UTC dates, whatever the location of the times
+	est := time.FixedZone("EST", -5*60*60)
+	assert.True(t, consumption.LongTerm(time.Date(2023, 3, 15, 20, 0, 0, 0, est)), "March 16 in UTC")
+
+	leapDay := LotConsumption{Lot: &Lot{Acquired: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)}}
+	assert.False(t, leapDay.LongTerm(time.Date(2025, 2, 28, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, leapDay.LongTerm(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestEscrowKeepsLots(t *testing.T) {
+	ledger := NewLedger(FIFO)
+	acquireLots(ledger)
+
+	// 15 ATOM deposited from the first two lots, 5 refunded and 10 burned
+	ledger.Escrow(Movement{Time: lotsStart.AddDate(0, 3, 0), Currency: "ATOM", Amount: decimal.NewFromInt(15), Type: Escrow})
+	unreleased := ledger.Release(Movement{Time: lotsStart.AddDate(0, 4, 0), Currency: "ATOM", Amount: decimal.NewFromInt(5), Acquire: true, Type: Release})
+	assert.True(t, unreleased.IsZero())
+
+	burn := ledger.Dispose(Movement{Time: lotsStart.AddDate(0, 4, 0), Currency: "ATOM", Amount: decimal.NewFromInt(10), Type: Lost, ValueKnown: true, Escrowed: true})
+	assert.True(t, burn.Unmatched.IsZero())
+	assert.True(t, decimal.NewFromInt(5*10+5*30).Equal(costBasis(burn)), costBasis(burn).String())
+
+	// The refunded lot keeps its acquisition time and cost basis
+	disposal := dispose(ledger, 20, lotsStart.AddDate(1, 1, 0))
+	assert.True(t, disposal.Unmatched.IsZero())
+	assert.Len(t, disposal.Consumed, 3)
+	assert.Equal(t, 1, disposal.Consumed[0].Lot.ID)
+	assert.True(t, decimal.NewFromInt(5*10).Equal(disposal.Consumed[0].CostBasis))
+	assert.True(t, disposal.Consumed[0].LongTerm(disposal.Time))
+}
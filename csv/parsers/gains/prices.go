@@ -0,0 +1,40 @@
+package gains
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/preichenberger/go-coinbasepro/v2"
+	"github.com/shopspring/decimal"
+)
+
+// CoinbasePrices looks up the USD prices on Coinbase, the same as the Osmosis LP rows of the CSV parsers. It is the fallback
+// for the currencies without imported prices. The lookups are cached per minute since every movement of a tx is valued at
+// the same time.
+type CoinbasePrices struct {
+	client *coinbasepro.Client
+	cache  map[string]cachedPrice
+}
+
+type cachedPrice struct {
+	price decimal.Decimal
+	err   error
+}
+
+func NewCoinbasePrices() *CoinbasePrices {
+	return &CoinbasePrices{client: coinbasepro.NewClient(), cache: make(map[string]cachedPrice)}
+}
+
+func (c *CoinbasePrices) GetPrice(currency string, at time.Time) (decimal.Decimal, error) {
+	key := fmt.Sprintf("%s-%d", currency, at.Truncate(time.Minute).Unix())
+	if cached, ok := c.cache[key]; ok {
+		return cached.price, cached.err
+	}
+
+	rate, err := parsers.GetRate(c.client, currency, at)
+	cached := cachedPrice{price: decimal.NewFromFloat(rate), err: err}
+	c.cache[key] = cached
+
+	return cached.price, cached.err
+}
@@ -0,0 +1,94 @@
+package gains
+
+import (
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+)
+
+const (
+	// The keys used to identify this parser, one per cost basis method
+	ParserKeyFIFO    = "gains-fifo"
+	ParserKeyLIFO    = "gains-lifo"
+	ParserKeyHIFO    = "gains-hifo"
+	ParserKeyAverage = "gains-average"
+
+	// TimeLayout is the golang time format string for this parser
+	TimeLayout = "2006-01-02 15:04:05"
+
+	// FiatCurrency is the currency of the proceeds, cost basis and gains
+	FiatCurrency = "USD"
+
+	// The holding periods of the realized gains
+	ShortTerm = "short"
+	LongTerm  = "long"
+)
+
+// ParserKeys are the keys of the gains parser for each cost basis method
+var ParserKeys = []string{ParserKeyFIFO, ParserKeyLIFO, ParserKeyHIFO, ParserKeyAverage}
+
+var methodsByParserKey = map[string]string{
+	ParserKeyFIFO:    FIFO,
+	ParserKeyLIFO:    LIFO,
+	ParserKeyHIFO:    HIFO,
+	ParserKeyAverage: Average,
+}
+
+// Parser computes the realized gains of an address set. The lots are shared by all the addresses of the set, transfers
+// between the addresses of the set do not dispose of the lots.
+type Parser struct {
+	Method string
+	Prices parsers.PriceLookup
+
+	addresses    map[string]bool
+	movements    []Movement
+	seenTxs      map[uint]bool
+	seenFees     map[uint]bool
+	seenEvents   map[string]bool
+	rows         []Row
+	rowsComputed bool
+}
+
+// Row is the realized gain of a disposal for one holding period, Lots references the lots it consumed
+type Row struct {
+	Date         string
+	Address      string
+	Type         string
+	Amount       string
+	Currency     string
+	DateAcquired string
+	Term         string
+	Proceeds     string
+	CostBasis    string
+	Gain         string
+	FiatCurrency string
+	TxHash       string
+	Lots         string
+	time         time.Time
+}
+
+func (row Row) GetRowForCsv() []string {
+	return []string{
+		row.Date,
+		row.Address,
+		row.Type,
+		row.Amount,
+		row.Currency,
+		row.DateAcquired,
+		row.Term,
+		row.Proceeds,
+		row.CostBasis,
+		row.Gain,
+		row.FiatCurrency,
+		row.TxHash,
+		row.Lots,
+	}
+}
+
+func (row Row) GetDate() string {
+	return row.Date
+}
+
+func (p *Parser) GetHeaders() []string {
+	return []string{"Date Disposed", "Address", "Type", "Amount", "Currency", "Date Acquired", "Term", "Proceeds", "Cost Basis", "Gain", "Fiat Currency", "Tx Hash", "Lots"}
+}
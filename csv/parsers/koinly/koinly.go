@@ -7,19 +7,26 @@ import (
 	"time"
 
 	"github.com/DefiantLabs/cosmos-tax-cli/assetlists"
+	"github.com/DefiantLabs/cosmos-tax-cli/block-sdk/modules/auction"
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/bank"
+	airdropClaim "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/claim"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/gov"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/ibc"
 	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/vesting"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmwasm/modules/wasm"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
 	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/modules/evm"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/concentratedliquidity"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/gamm"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/poolmanager"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/tokenfactory"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/valsetpref"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/claim"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/stakeibc"
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 )
 
@@ -102,6 +109,9 @@ func (p *Parser) ProcessTaxableEvent(taxableEvents []db.TaxableEvent) error {
 		if err != nil {
 			return err
 		}
+		for i := range rows {
+			rows[i].Description = parsers.AggregationDescription(rows[i].Description, event.AggregatedHeights)
+		}
 		p.Rows = append(p.Rows, rows...)
 	}
 
@@ -112,6 +122,31 @@ func (p *Parser) InitializeParsingGroups() {
 	p.ParsingGroups = append(p.ParsingGroups, &OsmosisLpTxGroup{}, &OsmosisConcentratedLiquidityTxGroup{})
 }
 
+// SetPriceLookup sets the prices the net worth of the rows is looked up with
+func (p *Parser) SetPriceLookup(prices parsers.PriceLookup) {
+	p.Prices = prices
+}
+
+// netWorth values the received amount of the row, or the sent amount when the received amount has no price, at the row date.
+// Income is valued at receipt.
+func (p *Parser) netWorth(row Row) (string, string) {
+	rowDate, err := time.Parse(TimeLayout, row.Date)
+	if err != nil {
+		return "", ""
+	}
+
+	value := parsers.FiatValue(p.Prices, row.ReceivedAmount, row.ReceivedCurrency, rowDate)
+	if value == "" {
+		value = parsers.FiatValue(p.Prices, row.SentAmount, row.SentCurrency, rowDate)
+	}
+
+	if value == "" {
+		return "", ""
+	}
+
+	return value, db.DefaultFiatCurrency
+}
+
 func (p *Parser) GetRows(address string, startDate, endDate *time.Time) ([]parsers.CsvRow, error) {
 	// Combine all normal rows and parser group rows into 1
 	koinlyRows := p.Rows // contains TX rows and fees as well as taxable events
@@ -122,6 +157,10 @@ func (p *Parser) GetRows(address string, startDate, endDate *time.Time) ([]parse
 	}
 
 	for i, row := range koinlyRows {
+		// The value is looked up before the currencies are replaced by their Koinly IDs
+		if p.Prices != nil && row.NetWorthAmount == "" {
+			koinlyRows[i].NetWorthAmount, koinlyRows[i].NetWorthCurrency = p.netWorth(row)
+		}
 
 		if row.FeeCurrency != "" {
 			if _, ok := symbolsToKoinlyIDs[row.FeeCurrency]; ok {
@@ -270,6 +309,46 @@ func ParseEvent(event db.TaxableEvent) (rows []Row, err error) {
 		rows = append(rows, row)
 	}
 
+	if event.Source == db.ClaimHookAirdrop {
+		row, err := ParseClaimHookAirdrop(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if event.Source == db.VestingAccountUnlock {
+		row, err := ParseVestingAccountUnlock(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if event.Source == db.GovDepositRefund {
+		row, err := ParseGovDepositRefund(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if event.Source == db.GovDepositBurn {
+		row, err := ParseGovDepositBurn(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	if event.Source == db.StakingSlash {
+		row, err := ParseStakingSlash(event)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
 	return rows, nil
 }
 
@@ -278,11 +357,32 @@ func ParseEvent(event db.TaxableEvent) (rows []Row, err error) {
 // Use TX Parsing Groups to parse txes as a group
 func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.CsvRow, err error) {
 	for _, event := range events {
+		// Vesting accounts receive their tokens as income when they unlock
+		if parsers.IsVestingFundingReceipt(address, event) {
+			continue
+		}
+
+		// Rewards paid to a withdraw address are split between the earner and the withdraw address
+		if parsers.IsRewardToWithdrawAddress(event) {
+			rewardRows, err := ParseRewardToWithdrawAddress(address, event)
+			if err != nil {
+				config.Log.Errorf("error parsing message type '%v': %v", event.Message.MessageType.MessageType, err)
+				continue
+			}
+			for _, row := range rewardRows {
+				row.Description = parsers.AggregationDescription(row.Description, event.AggregatedHeights)
+				rows = append(rows, row)
+			}
+			continue
+		}
+
 		var newRow Row
 		var err error
 		switch event.Message.MessageType.MessageType {
 		case bank.MsgSendV0:
 			newRow, err = ParseMsgSend(address, event)
+		case vesting.MsgCreateVestingAccount, vesting.MsgCreatePermanentLockedAccount, vesting.MsgCreatePeriodicVestingAccount:
+			newRow, err = ParseMsgSend(address, event)
 		case bank.MsgSend:
 			newRow, err = ParseMsgSend(address, event)
 		case bank.MsgMultiSendV0:
@@ -291,6 +391,8 @@ func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.Csv
 			newRow, err = ParseMsgMultiSend(address, event)
 		case distribution.MsgFundCommunityPool:
 			newRow, err = ParseMsgFundCommunityPool(address, event)
+		case auction.MsgAuctionBid:
+			newRow, err = ParseMsgAuctionBid(address, event)
 		case distribution.MsgWithdrawValidatorCommission:
 			newRow, err = ParseMsgWithdrawValidatorCommission(address, event)
 		case distribution.MsgWithdrawRewards:
@@ -303,6 +405,16 @@ func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.Csv
 			newRow, err = ParseMsgWithdrawDelegatorReward(address, event)
 		case staking.MsgBeginRedelegate:
 			newRow, err = ParseMsgWithdrawDelegatorReward(address, event)
+		case staking.MsgTokenizeShares, staking.MsgRedeemTokensForShares, staking.MsgTransferTokenizeShareRecord, staking.MsgValidatorBond:
+			newRow, err = parsers.TokenizedSharesRow(address, event, ParseMsgSend, ParseMsgWithdrawDelegatorReward)
+		case stakeibc.MsgLiquidStake, stakeibc.MsgLSMLiquidStake, stakeibc.MsgRedeemStake:
+			newRow, err = ParseStrideLiquidStake(event)
+		case claim.MsgClaimFreeAmount, airdropClaim.StargazeMsgInitialClaim, airdropClaim.StargazeMsgClaimFor, airdropClaim.CrescentMsgClaim, airdropClaim.QuicksilverMsgClaim:
+			newRow, err = ParseMsgClaim(address, event)
+		case evm.MsgEthereumTx, evm.InjectiveMsgEthereumTx, evm.CosmosEVMMsgEthereumTx:
+			newRow, err = ParseMsgEthereumTx(address, event)
+		case wasm.MsgExecuteContract:
+			newRow, err = ParseMsgExecuteContract(address, event)
 		case gamm.MsgSwapExactAmountIn:
 			newRow, err = ParseMsgSwapExactAmountIn(event)
 		case gamm.MsgSwapExactAmountOut:
@@ -335,6 +447,14 @@ func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.Csv
 			continue
 		}
 
+		// Transfers between the queried addresses are moves between the wallets of the owner, Koinly matches the unlabeled
+		// send and receive of a transfer
+		if event.InternalTransfer {
+			newRow.Label = None
+			newRow.Description = parsers.InternalTransferDescription(newRow.Description, event)
+		}
+
+		newRow.Description = parsers.AggregationDescription(newRow.Description, event.AggregatedHeights)
 		rows = append(rows, newRow)
 	}
 	return rows, nil
@@ -349,6 +469,10 @@ func ParseMsgWithdrawValidatorCommission(address string, event db.TaxableTransac
 		config.Log.Error("Error with ParseMsgWithdrawValidatorCommission.", err)
 	}
 	row.Label = Unstake
+	if validator := parsers.ValidatorDescription(event); validator != "" {
+		row.Description = fmt.Sprintf("Commission from %s", validator)
+	}
+
 	return *row, err
 }
 
@@ -361,9 +485,28 @@ func ParseMsgWithdrawDelegatorReward(address string, event db.TaxableTransaction
 		config.Log.Error("Error with ParseMsgWithdrawDelegatorReward.", err)
 	}
 	row.Label = Unstake
+	if validator := parsers.ValidatorDescription(event); validator != "" {
+		row.Description = fmt.Sprintf("Reward from %s", validator)
+	}
+
 	return *row, err
 }
 
+// ParseRewardToWithdrawAddress: A reward paid to a withdraw address is income of the earner, followed by a transfer of the
+// reward from the earner to the withdraw address. The withdraw address only receives the transfer.
+func ParseRewardToWithdrawAddress(address string, event db.TaxableTransaction) ([]Row, error) {
+	return parsers.RewardToWithdrawAddressRows(address, event, func(transfer db.TaxableTransaction, _ bool) (Row, error) {
+		row := &Row{}
+		err := row.ParseBasic(address, transfer)
+		if err != nil {
+			config.Log.Error("Error with ParseRewardToWithdrawAddress.", err)
+			return *row, err
+		}
+		row.Label = None
+		return *row, nil
+	}, ParseMsgWithdrawValidatorCommission, ParseMsgWithdrawDelegatorReward)
+}
+
 // ParseMsgSend:
 // If the address we searched is the receiver, then this transaction is a deposit.
 // If the address we searched is the sender, then this transaction is a withdrawal.
@@ -394,6 +537,16 @@ func ParseMsgFundCommunityPool(address string, event db.TaxableTransaction) (Row
 	return *row, err
 }
 
+// ParseMsgAuctionBid: The bid paid to the proposer and auction escrow is a cost to the bidder, refunds come back as deposits
+func ParseMsgAuctionBid(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgAuctionBid.", err)
+	}
+	return *row, err
+}
+
 func ParseMsgSwapExactAmountIn(event db.TaxableTransaction) (Row, error) {
 	row := &Row{}
 	err := row.ParseSwap(event)
@@ -513,6 +666,114 @@ func ParsePoolManagerSwap(event db.TaxableTransaction) (Row, error) {
 	return *row, err
 }
 
+// ParseStrideLiquidStake parses Stride liquid stakes and redemptions, which exchange the native tokens and stTokens at the
+// redemption rate. Redemptions are booked to the redeemer, the native tokens are paid out to the receiver on the host zone.
+func ParseStrideLiquidStake(event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseSwap(event)
+	if err != nil {
+		config.Log.Error("Error with ParseStrideLiquidStake.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgClaim parses airdrop claims, the coins received from the claim module account are airdrops
+func ParseMsgClaim(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgClaim.", err)
+	}
+	if event.ReceiverAddress.Address == address {
+		row.Label = Airdrop
+	}
+	return *row, err
+}
+
+// ParseClaimHookAirdrop parses airdrops claimed automatically by the claim module hooks
+func ParseClaimHookAirdrop(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseClaimHookAirdrop.", err)
+	}
+	row.Label = Airdrop
+	return *row, err
+}
+
+// ParseVestingAccountUnlock parses the tokens unlocked by a vesting account, these are materialized by the vesting-schedule command
+func ParseVestingAccountUnlock(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseVestingAccountUnlock.", err)
+	}
+	row.Label = Income
+	return *row, err
+}
+
+// ParseGovDepositRefund parses a gov proposal deposit returned to the depositor, the deposit is returned not earned
+func ParseGovDepositRefund(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseGovDepositRefund.", err)
+	}
+	row.Label = None
+	return *row, err
+}
+
+// ParseGovDepositBurn parses a gov proposal deposit burned by the gov module, these are reported as lost
+func ParseGovDepositBurn(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseGovDepositBurn.", err)
+	}
+	row.SentAmount, row.SentCurrency = row.ReceivedAmount, row.ReceivedCurrency
+	row.ReceivedAmount, row.ReceivedCurrency = "", ""
+	row.Label = Lost
+	return *row, err
+}
+
+// ParseStakingSlash parses the share of a validator slash lost by the delegator, these are reported as lost
+func ParseStakingSlash(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseStakingSlash.", err)
+	}
+	row.SentAmount, row.SentCurrency = row.ReceivedAmount, row.ReceivedCurrency
+	row.ReceivedAmount, row.ReceivedCurrency = "", ""
+	row.Label = Lost
+	return *row, err
+}
+
+func ParseMsgEthereumTx(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgEthereumTx.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgExecuteContract parses contract executions. Executions handled by a contract handler (e.g. swaps) carry both legs,
+// the payment legs of CW721 NFTs bought or sold on a marketplace carry a single leg.
+func ParseMsgExecuteContract(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	var err error
+	if event.DenominationSentID != nil && event.DenominationReceivedID != nil && *event.DenominationSentID != *event.DenominationReceivedID {
+		err = row.ParseSwap(event)
+	} else {
+		err = row.ParseBasic(address, event)
+	}
+	if err != nil {
+		config.Log.Error("Error with ParseMsgExecuteContract.", err)
+	}
+	return *row, err
+}
+
 func ParseConcentratedLiquidityCollection(event db.TaxableTransaction) (Row, error) {
 	row := &Row{}
 	denomToUse := event.DenominationReceived
@@ -544,6 +805,10 @@ func ParseValsetPrefRewards(event db.TaxableTransaction) (Row, error) {
 		return *row, err
 	}
 
+	if validator := parsers.ValidatorDescription(event); validator != "" {
+		row.Description = fmt.Sprintf("Reward from %s", validator)
+	}
+
 	return *row, nil
 }
 
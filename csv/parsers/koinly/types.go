@@ -13,6 +13,7 @@ const (
 type Parser struct {
 	Rows          []Row
 	ParsingGroups []parsers.ParsingGroup
+	Prices        parsers.PriceLookup
 }
 
 type Row struct {
@@ -23,8 +24,8 @@ type Row struct {
 	ReceivedCurrency string
 	FeeAmount        string
 	FeeCurrency      string
-	NetWorthAmount   string // not going to use this for now?
-	NetWorthCurrency string // not going to use this for now?
+	NetWorthAmount   string // The fiat value of the received (or else sent) amount at the row date
+	NetWorthCurrency string
 	Label            Label
 	Description      string
 	TxHash           string
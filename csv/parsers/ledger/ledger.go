@@ -0,0 +1,273 @@
+package ledger
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/parquet"
+	"github.com/shopspring/decimal"
+)
+
+func (p *Parser) TimeLayout() string {
+	return TimeLayout
+}
+
+// InitializeParsingGroups does nothing, the ledger has a row per leg so messages are never grouped
+func (p *Parser) InitializeParsingGroups() {}
+
+// ProcessTaxableTx adds the legs of the taxable txs and the fees that move the bank balance of the address
+func (p *Parser) ProcessTaxableTx(address string, taxableTxs []db.TaxableTransaction, taxableFees []db.Fee) error {
+	for _, taxableTx := range taxableTxs {
+		if taxableTx.DenominationSentID != nil && taxableTx.SenderAddress.Address == address {
+			p.Rows = append(p.Rows, newTaxableTxRow(address, taxableTx, LegSent, DirectionOut, taxableTx.DenominationSent, taxableTx.AmountSent))
+		}
+
+		if taxableTx.DenominationReceivedID != nil {
+			switch address {
+			case taxableTx.ReceiverAddress.Address:
+				p.Rows = append(p.Rows, newTaxableTxRow(address, taxableTx, LegReceived, DirectionIn, taxableTx.DenominationReceived, taxableTx.AmountReceived))
+			case taxableTx.EarnerAddress.Address:
+				// Earned by the address but paid to its withdraw address
+				p.Rows = append(p.Rows, newTaxableTxRow(address, taxableTx, LegReceived, DirectionNone, taxableTx.DenominationReceived, taxableTx.AmountReceived))
+			}
+		}
+	}
+
+	for _, fee := range taxableFees {
+		tx := fee.Tx
+		row := Row{
+			Time:        tx.Block.TimeStamp.UTC(),
+			ChainID:     tx.Block.Chain.ChainID,
+			BlockHeight: tx.Block.Height,
+			TxHash:      tx.Hash,
+			TxCode:      strconv.FormatUint(uint64(tx.Code), 10),
+			RecordType:  RecordFee,
+			RecordID:    fee.ID,
+			Leg:         LegFee,
+			Direction:   DirectionOut,
+			Address:     address,
+			Sender:      fee.PayerAddress.Address,
+			Denom:       fee.Denomination.Base,
+			Amount:      fee.Amount,
+		}
+		row.Symbol, row.ConvertedAmount = convert(fee.Amount, fee.Denomination)
+		p.Rows = append(p.Rows, row)
+	}
+
+	return nil
+}
+
+func newTaxableTxRow(address string, taxableTx db.TaxableTransaction, leg string, direction string, denom db.Denom, amount decimal.Decimal) Row {
+	message := taxableTx.Message
+	tx := message.Tx
+
+	row := Row{
+		Time:              tx.Block.TimeStamp.UTC(),
+		ChainID:           tx.Block.Chain.ChainID,
+		BlockHeight:       tx.Block.Height,
+		TxHash:            tx.Hash,
+		TxCode:            strconv.FormatUint(uint64(tx.Code), 10),
+		MessageIndex:      message.MessageIndex,
+		MessageType:       message.MessageType.MessageType,
+		RecordType:        RecordTaxableTx,
+		RecordID:          taxableTx.ID,
+		Leg:               leg,
+		Direction:         direction,
+		Address:           address,
+		Sender:            taxableTx.SenderAddress.Address,
+		Receiver:          taxableTx.ReceiverAddress.Address,
+		Earner:            taxableTx.EarnerAddress.Address,
+		Signer:            tx.SignerAddress.Address,
+		Validator:         taxableTx.ValidatorAddress,
+		ValidatorMoniker:  taxableTx.ValidatorMoniker,
+		Denom:             denom.Base,
+		Amount:            amount,
+		AggregatedHeights: taxableTx.AggregatedHeights,
+		InternalTransfer:  taxableTx.InternalTransfer,
+	}
+	row.Symbol, row.ConvertedAmount = convert(amount, denom)
+
+	return row
+}
+
+// ProcessTaxableEvent adds a leg per taxable event
+func (p *Parser) ProcessTaxableEvent(taxableEvents []db.TaxableEvent) error {
+	for _, event := range taxableEvents {
+		direction := DirectionNone
+		switch db.TaxableEventBalanceSign(event.Source) {
+		case 1:
+			direction = DirectionIn
+		case -1:
+			direction = DirectionOut
+		}
+
+		row := Row{
+			Time:              event.Block.TimeStamp.UTC(),
+			ChainID:           event.Block.Chain.ChainID,
+			BlockHeight:       event.Block.Height,
+			RecordType:        RecordTaxableEvent,
+			RecordID:          event.ID,
+			Leg:               LegEvent,
+			Direction:         direction,
+			Address:           event.EventAddress.Address,
+			EventSource:       EventSourceName(event.Source),
+			EventHash:         event.EventHash,
+			Denom:             event.Denomination.Base,
+			Amount:            event.Amount,
+			AggregatedHeights: event.AggregatedHeights,
+		}
+		row.Symbol, row.ConvertedAmount = convert(event.Amount, event.Denomination)
+		p.Rows = append(p.Rows, row)
+	}
+
+	return nil
+}
+
+// EventSourceName returns the name of the taxable event source in the event_source column
+func EventSourceName(source uint) string {
+	if name, ok := eventSourceNames[source]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown_%d", source)
+}
+
+// convert returns the symbol and the exact amount in display units, or empty strings when the units of the denom are unknown
+func convert(amount decimal.Decimal, denom db.Denom) (string, string) {
+	convertedAmount, symbol, err := db.ConvertUnitsExact(amount, denom)
+	if err != nil {
+		return "", ""
+	}
+
+	return symbol, convertedAmount.String()
+}
+
+func (p *Parser) GetHeaders() []string {
+	return []string{
+		"time", "chain_id", "block_height", "tx_hash", "tx_code", "message_index", "message_type", "record_type", "record_id",
+		"leg", "direction", "address", "sender", "receiver", "earner", "signer", "validator", "validator_moniker", "event_source",
+		"event_hash", "denom", "amount", "symbol", "amount_converted", "aggregated_heights",
+		"internal_transfer",
+	}
+}
+
+// GetParquetColumns types the heights, indexes and amounts, the amounts are in the base units of the denom and the converted
+// amounts have up to 18 decimals
+func (p *Parser) GetParquetColumns() []parquet.Column {
+	columns := parquet.StringColumns(p.GetHeaders())
+	for i, column := range columns {
+		switch column.Name {
+		case "time":
+			columns[i].Type = parquet.TypeTimestamp
+		case "block_height", "record_id":
+			columns[i].Type = parquet.TypeInt64
+		case "tx_code", "message_index":
+			columns[i].Type = parquet.TypeInt64
+			columns[i].Optional = true
+		case "amount":
+			columns[i].Type = parquet.TypeDecimal
+			columns[i].Precision = 38
+		case "amount_converted":
+			columns[i].Type = parquet.TypeDecimal
+			columns[i].Optional = true
+			columns[i].Precision = 38
+			columns[i].Scale = 18
+		case "internal_transfer":
+			columns[i].Type = parquet.TypeBoolean
+		}
+	}
+	return columns
+}
+
+// GetRows returns the legs of the address ordered by time, block, tx, message and record, the start date is inclusive and the
+// end date exclusive
+func (p *Parser) GetRows(address string, startDate, endDate *time.Time) ([]parsers.CsvRow, error) {
+	var rows []Row
+	for _, row := range p.Rows {
+		if row.Address != address {
+			continue
+		}
+		if startDate != nil && row.Time.Before(*startDate) {
+			continue
+		}
+		if endDate != nil && !row.Time.Before(*endDate) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		left, right := rows[i], rows[j]
+		switch {
+		case !left.Time.Equal(right.Time):
+			return left.Time.Before(right.Time)
+		case left.BlockHeight != right.BlockHeight:
+			return left.BlockHeight < right.BlockHeight
+		case left.TxHash != right.TxHash:
+			return left.TxHash < right.TxHash
+		case left.RecordType != right.RecordType:
+			return left.RecordType < right.RecordType
+		case left.MessageIndex != right.MessageIndex:
+			return left.MessageIndex < right.MessageIndex
+		case left.RecordID != right.RecordID:
+			return left.RecordID < right.RecordID
+		}
+		return left.Leg > right.Leg
+	})
+
+	csvRows := make([]parsers.CsvRow, len(rows))
+	for i, row := range rows {
+		csvRows[i] = row
+	}
+
+	return csvRows, nil
+}
+
+func (row Row) GetRowForCsv() []string {
+	messageIndex := ""
+	if row.RecordType == RecordTaxableTx {
+		messageIndex = strconv.Itoa(row.MessageIndex)
+	}
+
+	heights := make([]string, len(row.AggregatedHeights))
+	for i, height := range row.AggregatedHeights {
+		heights[i] = strconv.FormatInt(height, 10)
+	}
+
+	return []string{
+		row.Time.Format(TimeLayout),
+		row.ChainID,
+		strconv.FormatInt(row.BlockHeight, 10),
+		row.TxHash,
+		row.TxCode,
+		messageIndex,
+		row.MessageType,
+		row.RecordType,
+		strconv.FormatUint(uint64(row.RecordID), 10),
+		row.Leg,
+		row.Direction,
+		row.Address,
+		row.Sender,
+		row.Receiver,
+		row.Earner,
+		row.Signer,
+		row.Validator,
+		row.ValidatorMoniker,
+		row.EventSource,
+		row.EventHash,
+		row.Denom,
+		row.Amount.String(),
+		row.Symbol,
+		row.ConvertedAmount,
+		strings.Join(heights, " "),
+		strconv.FormatBool(row.InternalTransfer),
+	}
+}
+
+func (row Row) GetDate() string {
+	return row.Time.Format(TimeLayout)
+}
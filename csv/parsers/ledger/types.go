@@ -0,0 +1,97 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// ParserKey is the key used to identify this parser
+	ParserKey = "ledger"
+
+	// TimeLayout is the golang time format string for this parser, the times are in UTC
+	TimeLayout = time.RFC3339
+)
+
+// The records a leg was read from
+const (
+	RecordTaxableTx    = "taxable_tx"
+	RecordFee          = "fee"
+	RecordTaxableEvent = "taxable_event"
+)
+
+// The legs of the records, a taxable tx has a sent and a received leg
+const (
+	LegSent     = "sent"
+	LegReceived = "received"
+	LegFee      = "fee"
+	LegEvent    = "event"
+)
+
+// The directions the legs move the bank balance of the address in. Legs that do not move it (e.g. rewards earned by the
+// address but paid to its withdraw address, vesting unlocks or slashes) have no direction.
+const (
+	DirectionIn   = "in"
+	DirectionOut  = "out"
+	DirectionNone = "none"
+)
+
+// The names of the taxable event sources in the event_source column, these are part of the format and must not change
+var eventSourceNames = map[uint]string{
+	db.OsmosisRewardDistribution:                  "osmosis_reward_distribution",
+	db.TendermintLiquidityDepositCoinsToPool:      "tendermint_liquidity_deposit_coins_to_pool",
+	db.TendermintLiquidityDepositPoolCoinReceived: "tendermint_liquidity_deposit_pool_coin_received",
+	db.TendermintLiquiditySwapTransactedCoinIn:    "tendermint_liquidity_swap_transacted_coin_in",
+	db.TendermintLiquiditySwapTransactedCoinOut:   "tendermint_liquidity_swap_transacted_coin_out",
+	db.TendermintLiquiditySwapTransactedFee:       "tendermint_liquidity_swap_transacted_fee",
+	db.TendermintLiquidityWithdrawPoolCoinSent:    "tendermint_liquidity_withdraw_pool_coin_sent",
+	db.TendermintLiquidityWithdrawCoinReceived:    "tendermint_liquidity_withdraw_coin_received",
+	db.TendermintLiquidityWithdrawFee:             "tendermint_liquidity_withdraw_fee",
+	db.OsmosisProtorevDeveloperRewardDistribution: "osmosis_protorev_developer_reward_distribution",
+	db.ClaimHookAirdrop:                           "claim_hook_airdrop",
+	db.VestingAccountUnlock:                       "vesting_account_unlock",
+	db.GovDepositRefund:                           "gov_deposit_refund",
+	db.GovDepositBurn:                             "gov_deposit_burn",
+	db.StakingUnbondingComplete:                   "staking_unbonding_complete",
+	db.StakingRedelegationComplete:                "staking_redelegation_complete",
+	db.StakingSlash:                               "staking_slash",
+}
+
+type Parser struct {
+	Rows []Row
+}
+
+// Row is a single leg, the columns are documented in the README and only ever added to at the end
+type Row struct {
+	Time             time.Time
+	ChainID          string
+	BlockHeight      int64
+	TxHash           string
+	TxCode           string
+	MessageIndex     int // Only set for the legs of taxable txs
+	MessageType      string
+	RecordType       string
+	RecordID         uint
+	Leg              string
+	Direction        string
+	Address          string
+	Sender           string
+	Receiver         string
+	Earner           string
+	Signer           string
+	Validator        string
+	ValidatorMoniker string
+	EventSource      string
+	EventHash        string
+	Denom            string
+	Amount           decimal.Decimal
+	// Symbol and ConvertedAmount are empty when the display units of the denom are unknown
+	Symbol          string
+	ConvertedAmount string
+	// The heights of the blocks of the rewards aggregated into the leg, empty when the exports are not aggregated
+	AggregatedHeights []int64
+	// Set on the legs of transfers between the queried addresses
+	InternalTransfer bool
+}
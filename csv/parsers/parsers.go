@@ -5,24 +5,24 @@ import "github.com/DefiantLabs/cosmos-tax-cli/db"
 // Parsers should be used to check in your parsers.
 var Parsers map[string]bool
 
+// The keys of the parsers in registration order, the first one is the default format
+var parserKeys []string
+
 func init() {
 	Parsers = make(map[string]bool)
 }
 
 func RegisterParsers(keys []string) {
 	for _, key := range keys {
+		if !Parsers[key] {
+			parserKeys = append(parserKeys, key)
+		}
 		Parsers[key] = true
 	}
 }
 
 func GetParserKeys() []string {
-	var parserKeys []string
-
-	for i := range Parsers {
-		parserKeys = append(parserKeys, i)
-	}
-
-	return parserKeys
+	return append([]string(nil), parserKeys...)
 }
 
 // MakeTXMap will make a map of transaction ID to list of taxable transactions
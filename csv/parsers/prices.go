@@ -0,0 +1,55 @@
+package parsers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PriceLookup returns the fiat price of one unit of a CSV row currency at a time
+type PriceLookup interface {
+	GetPrice(currency string, at time.Time) (decimal.Decimal, error)
+}
+
+// PricedParser is implemented by parsers whose format has fiat value columns, the prices are set before the rows are requested.
+// The Accointing, CoinTracker, TaxBit, ZenLedger, CoinLedger and TokenTax imports, the ledger format and the journals have no
+// fiat value column, the importing software or the reader values their rows.
+type PricedParser interface {
+	SetPriceLookup(prices PriceLookup)
+}
+
+// PriceLookups tries each lookup in order until one has a price
+type PriceLookups []PriceLookup
+
+func (lookups PriceLookups) GetPrice(currency string, at time.Time) (decimal.Decimal, error) {
+	err := errors.New("no price lookups")
+	for _, lookup := range lookups {
+		var price decimal.Decimal
+		price, err = lookup.GetPrice(currency, at)
+		if err == nil {
+			return price, nil
+		}
+	}
+	return decimal.Zero, err
+}
+
+// FiatValue returns the value of the amount of the currency at the time, formatted with 2 decimals. The value is empty when
+// there is no price or the amount is not a number.
+func FiatValue(prices PriceLookup, amount string, currency string, at time.Time) string {
+	if prices == nil || amount == "" || currency == "" {
+		return ""
+	}
+
+	parsedAmount, err := decimal.NewFromString(amount)
+	if err != nil {
+		return ""
+	}
+
+	price, err := prices.GetPrice(currency, at)
+	if err != nil {
+		return ""
+	}
+
+	return parsedAmount.Mul(price).StringFixed(2)
+}
@@ -0,0 +1,116 @@
+package tokentax
+
+import (
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/concentratedliquidity"
+)
+
+type OsmosisLpTxGroup struct {
+	GroupedTxes map[uint][]db.TaxableTransaction // TX db ID to its messages
+	Rows        []parsers.CsvRow
+}
+
+func (sf *OsmosisLpTxGroup) GetRowsForParsingGroup() []parsers.CsvRow {
+	return sf.Rows
+}
+
+func (sf *OsmosisLpTxGroup) BelongsToGroup(message db.TaxableTransaction) bool {
+	_, isInGroup := parsers.IsOsmosisLpTxGroup[message.Message.MessageType.MessageType]
+	return isInGroup
+}
+
+func (sf *OsmosisLpTxGroup) GetGroupedTxes() map[uint][]db.TaxableTransaction {
+	return sf.GroupedTxes
+}
+
+func (sf *OsmosisLpTxGroup) String() string {
+	return "OsmosisLpTxGroup"
+}
+
+func (sf *OsmosisLpTxGroup) AddTxToGroup(tx db.TaxableTransaction) {
+	if sf.GroupedTxes == nil {
+		sf.GroupedTxes = make(map[uint][]db.TaxableTransaction)
+	}
+	sf.GroupedTxes = parsers.AddTxToGroupMap(sf.GroupedTxes, tx)
+}
+
+// ParseGroup: Joins and exits are trades of the pooled tokens and the GAMM tokens, TokenTax tracks the cost basis of the GAMM
+// tokens like any other token
+func (sf *OsmosisLpTxGroup) ParseGroup() error {
+	for _, txMessages := range sf.GroupedTxes {
+		for _, message := range txMessages {
+			row := Row{}
+			row.Date = message.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+			row.Comment = message.Message.Tx.Hash
+			row.Type = Trade
+
+			parseAndAddReceivedAmountWithDefault(&row, message)
+			parseAndAddSentAmountWithDefault(&row, message)
+
+			sf.Rows = append(sf.Rows, row)
+		}
+	}
+	return nil
+}
+
+type OsmosisConcentratedLiquidityTxGroup struct {
+	GroupedTxes map[uint][]db.TaxableTransaction // TX db ID to its messages
+	Rows        []parsers.CsvRow
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) GetRowsForParsingGroup() []parsers.CsvRow {
+	return sf.Rows
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) BelongsToGroup(message db.TaxableTransaction) bool {
+	_, isInGroup := parsers.IsOsmosisConcentratedLiquidity[message.Message.MessageType.MessageType]
+	return isInGroup
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) GetGroupedTxes() map[uint][]db.TaxableTransaction {
+	return sf.GroupedTxes
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) String() string {
+	return "OsmosisConcentratedLiquidityTxGroup"
+}
+
+func (sf *OsmosisConcentratedLiquidityTxGroup) AddTxToGroup(tx db.TaxableTransaction) {
+	// Add tx to group using the TX ID as key and appending to array
+	if sf.GroupedTxes == nil {
+		sf.GroupedTxes = make(map[uint][]db.TaxableTransaction)
+	}
+	sf.GroupedTxes = parsers.AddTxToGroupMap(sf.GroupedTxes, tx)
+}
+
+// ParseGroup: Concentrated liquidity positions have no token, the tokens moved into a position are sent and the tokens moved out
+// of a position are received. The fees of the txs are reported by HandleFees.
+func (sf *OsmosisConcentratedLiquidityTxGroup) ParseGroup() error {
+	for _, txMessages := range sf.GroupedTxes {
+		for _, message := range txMessages {
+			row := Row{}
+			row.Date = message.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+			row.Comment = message.Message.Tx.Hash
+			switch message.Message.MessageType.MessageType {
+			case concentratedliquidity.MsgCreatePosition:
+				parseAndAddSentAmountWithDefault(&row, message)
+				row.Type = Withdrawal
+			case concentratedliquidity.MsgWithdrawPosition, concentratedliquidity.MsgTransferPositions:
+				parseAndAddReceivedAmountWithDefault(&row, message)
+				row.Type = Deposit
+			case concentratedliquidity.MsgAddToPosition:
+				if message.DenominationReceivedID != nil {
+					parseAndAddReceivedAmountWithDefault(&row, message)
+					row.Type = Deposit
+				} else {
+					parseAndAddSentAmountWithDefault(&row, message)
+					row.Type = Withdrawal
+				}
+			}
+
+			sf.Rows = append(sf.Rows, row)
+		}
+	}
+	return nil
+}
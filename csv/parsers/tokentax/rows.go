@@ -0,0 +1,150 @@
+package tokentax
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+)
+
+func (row Row) GetRowForCsv() []string {
+	return []string{
+		row.Type.String(),
+		row.BuyAmount,
+		row.BuyCurrency,
+		row.SellAmount,
+		row.SellCurrency,
+		row.FeeAmount,
+		row.FeeCurrency,
+		row.Exchange,
+		row.Group,
+		row.Comment,
+		row.Date,
+	}
+}
+
+func (row Row) GetDate() string {
+	return row.Date
+}
+
+// EventParseBasic handles the tokens received by taxable events, e.g. osmo rewards
+func (row *Row) EventParseBasic(event db.TaxableEvent) error {
+	row.Date = event.Block.TimeStamp.Format(TimeLayout)
+
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.Amount), event.Denomination)
+	if err == nil {
+		row.BuyAmount = conversionAmount.Text('f', -1)
+		row.BuyCurrency = conversionSymbol
+	} else {
+		row.BuyAmount = util.NumericToString(event.Amount)
+		row.BuyCurrency = event.Denomination.Base
+	}
+	row.Type = Income
+	return nil
+}
+
+// ParseBasic: Handles the fields that are shared between most types.
+func (row *Row) ParseBasic(address string, event db.TaxableTransaction) error {
+	row.Date = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+	row.Comment = event.Message.Tx.Hash
+
+	// deposit
+	if event.ReceiverAddress.Address == address {
+		conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+		if err != nil {
+			return fmt.Errorf("cannot parse denom units for TX %s (classification: deposit)", event.Message.Tx.Hash)
+		}
+		row.BuyAmount = conversionAmount.Text('f', -1)
+		row.BuyCurrency = conversionSymbol
+		row.Type = Deposit
+	} else if event.SenderAddress.Address == address { // withdrawal
+		conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+		if err != nil {
+			return fmt.Errorf("cannot parse denom units for TX %s (classification: withdrawal)", event.Message.Tx.Hash)
+		}
+		row.SellAmount = conversionAmount.Text('f', -1)
+		row.SellCurrency = conversionSymbol
+		row.Type = Withdrawal
+	}
+
+	return nil
+}
+
+func (row *Row) ParseSwap(event db.TaxableTransaction) error {
+	row.Date = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+	row.Comment = event.Message.Tx.Hash
+	row.Type = Trade
+
+	recievedConversionAmount, recievedConversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: swap received)", event.Message.Tx.Hash)
+	}
+
+	row.BuyAmount = recievedConversionAmount.Text('f', -1)
+	row.BuyCurrency = recievedConversionSymbol
+
+	sentConversionAmount, sentConversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: swap sent)", event.Message.Tx.Hash)
+	}
+
+	row.SellAmount = sentConversionAmount.Text('f', -1)
+	row.SellCurrency = sentConversionSymbol
+
+	return nil
+}
+
+func (row *Row) ParseFee(tx db.Tx, fee db.Fee) error {
+	row.Date = tx.Block.TimeStamp.Format(TimeLayout)
+	row.Comment = tx.Hash
+	row.Type = Withdrawal
+
+	sentConversionAmount, sentConversionSymbol, err := db.ConvertUnits(util.FromNumeric(fee.Amount), fee.Denomination)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: fee)", tx.Hash)
+	}
+
+	row.FeeAmount = sentConversionAmount.Text('f', -1)
+	row.FeeCurrency = sentConversionSymbol
+
+	return nil
+}
+
+func parseAndAddSentAmount(row *Row, event db.TaxableTransaction) error {
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+	if err != nil {
+		return errors.New("cannot parse denom units")
+	}
+	row.SellAmount = conversionAmount.Text('f', -1)
+	row.SellCurrency = conversionSymbol
+
+	return nil
+}
+
+func parseAndAddSentAmountWithDefault(row *Row, event db.TaxableTransaction) {
+	err := parseAndAddSentAmount(row, event)
+	if err != nil {
+		row.SellAmount = util.NumericToString(event.AmountSent)
+		row.SellCurrency = event.DenominationSent.Base
+	}
+}
+
+func parseAndAddReceivedAmount(row *Row, event db.TaxableTransaction) error {
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+	if err != nil {
+		return errors.New("cannot parse denom units")
+	}
+	row.BuyAmount = conversionAmount.Text('f', -1)
+	row.BuyCurrency = conversionSymbol
+
+	return nil
+}
+
+func parseAndAddReceivedAmountWithDefault(row *Row, event db.TaxableTransaction) {
+	err := parseAndAddReceivedAmount(row, event)
+	if err != nil {
+		row.BuyAmount = util.NumericToString(event.AmountReceived)
+		row.BuyCurrency = event.DenominationReceived.Base
+	}
+}
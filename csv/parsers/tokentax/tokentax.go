@@ -0,0 +1,615 @@
+package tokentax
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/block-sdk/modules/auction"
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/bank"
+	airdropClaim "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/claim"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/gov"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/ibc"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/vesting"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmwasm/modules/wasm"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/modules/evm"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/concentratedliquidity"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/gamm"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/poolmanager"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/tokenfactory"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/valsetpref"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/claim"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/stakeibc"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+	"github.com/shopspring/decimal"
+)
+
+func (p *Parser) TimeLayout() string {
+	return TimeLayout
+}
+
+func (p *Parser) ProcessTaxableTx(address string, taxableTxs []db.TaxableTransaction, taxableFees []db.Fee) error {
+	// Build a map, so we know which TX go with which messages
+	txMap := parsers.MakeTXMap(taxableTxs)
+
+	// Pull messages out of txMap that must be grouped together
+	parsers.SeparateParsingGroups(txMap, p.ParsingGroups)
+
+	// Parse all the potentially taxable events (one transaction group at a time)
+	for _, txGroup := range txMap {
+		// All messages have been removed into a parsing group
+		if len(txGroup) != 0 {
+			// For the current transaction group, generate the rows for the CSV.
+			// Usually (but not always) a transaction will only have a single row in the CSV.
+			txRows, err := ParseTx(address, txGroup)
+			if err != nil {
+				return err
+			}
+			for _, v := range txRows {
+				p.Rows = append(p.Rows, v.(Row))
+			}
+		}
+	}
+
+	// Parse all the TXs found in the Parsing Groups
+	for _, txParsingGroup := range p.ParsingGroups {
+		err := txParsingGroup.ParseGroup()
+		if err != nil {
+			return err
+		}
+	}
+
+	// The fees of all the txs, including the txs of the parsing groups and the txs without taxable messages, are fee rows
+	feeRows, err := HandleFees(address, taxableTxs, taxableFees)
+	if err != nil {
+		return err
+	}
+
+	p.Rows = append(p.Rows, feeRows...)
+
+	return nil
+}
+
+func (p *Parser) ProcessTaxableEvent(taxableEvents []db.TaxableEvent) error {
+	// Parse all the potentially taxable events
+	for _, event := range taxableEvents {
+		// generate the rows for the CSV.
+		rows, err := ParseEvent(event)
+		if err != nil {
+			return err
+		}
+		for i := range rows {
+			rows[i].Comment = parsers.AggregationDescription(rows[i].Comment, event.AggregatedHeights)
+		}
+		p.Rows = append(p.Rows, rows...)
+	}
+
+	return nil
+}
+
+func (p *Parser) InitializeParsingGroups() {
+	p.ParsingGroups = append(p.ParsingGroups, &OsmosisLpTxGroup{}, &OsmosisConcentratedLiquidityTxGroup{})
+}
+
+func (p *Parser) GetRows(address string, startDate, endDate *time.Time) ([]parsers.CsvRow, error) {
+	// Combine all normal rows and parser group rows into 1
+	tokenTaxRows := p.Rows // contains TX rows and fees as well as taxable events
+	for _, v := range p.ParsingGroups {
+		for _, row := range v.GetRowsForParsingGroup() {
+			tokenTaxRows = append(tokenTaxRows, row.(Row))
+		}
+	}
+
+	// Sort by date
+	sort.Slice(tokenTaxRows, func(i int, j int) bool {
+		leftDate, err := time.Parse(TimeLayout, tokenTaxRows[i].Date)
+		if err != nil {
+			config.Log.Error("Error sorting left date.", err)
+			return false
+		}
+		rightDate, err := time.Parse(TimeLayout, tokenTaxRows[j].Date)
+		if err != nil {
+			config.Log.Error("Error sorting right date.", err)
+			return false
+		}
+		return leftDate.Before(rightDate)
+	})
+
+	// Now that we are sorted, if we have a start date, drop everything from before it, if end date is set, drop everything after it
+	var rowsToKeep []*Row
+	for i := range tokenTaxRows {
+		rowDate, err := time.Parse(TimeLayout, tokenTaxRows[i].Date)
+		if err != nil {
+			config.Log.Error("Error parsing row date.", err)
+			return nil, err
+		}
+		if startDate != nil && rowDate.Before(*startDate) {
+			continue
+		}
+		if endDate != nil && rowDate.After(*endDate) {
+			break
+		}
+		rowsToKeep = append(rowsToKeep, &tokenTaxRows[i])
+	}
+
+	// Copy tokenTaxRows into csvRows for return val
+	csvRows := make([]parsers.CsvRow, len(rowsToKeep))
+	for i, v := range rowsToKeep {
+		csvRows[i] = v
+	}
+
+	return csvRows, nil
+}
+
+func (p Parser) GetHeaders() []string {
+	return []string{
+		"Type", "BuyAmount", "BuyCurrency", "SellAmount", "SellCurrency", "FeeAmount", "FeeCurrency", "Exchange", "Group",
+		"Comment", "Date",
+	}
+}
+
+// HandleFees: Every fee paid by the address is a withdrawal of only the fee, TokenTax deducts the fees as costs
+func HandleFees(address string, events []db.TaxableTransaction, allFees []db.Fee) (rows []Row, err error) {
+	// No events -- This address didn't pay any fees
+	if len(events) == 0 && len(allFees) == 0 {
+		return rows, nil
+	}
+
+	// We need to gather all unique fees, but we are receiving Messages not Txes
+	// Make a map from TX hash to fees array to keep unique
+	txToFeesMap := make(map[uint][]db.Fee)
+	txIDsToTX := make(map[uint]db.Tx)
+	for _, event := range events {
+		txID := event.Message.Tx.ID
+		feeStore := event.Message.Tx.Fees
+		txToFeesMap[txID] = feeStore
+		txIDsToTX[txID] = event.Message.Tx
+	}
+
+	// Due to the way we are parsing, we may have fees for TX that we don't have events for
+	for _, fee := range allFees {
+		txID := fee.Tx.ID
+		if _, ok := txToFeesMap[txID]; !ok {
+			txToFeesMap[txID] = []db.Fee{fee}
+			txIDsToTX[txID] = fee.Tx
+		}
+	}
+
+	for id, txFees := range txToFeesMap {
+		for _, fee := range txFees {
+			if fee.PayerAddress.Address == address {
+				newRow := Row{}
+				err = newRow.ParseFee(txIDsToTX[id], fee)
+				if err != nil {
+					return nil, err
+				}
+				rows = append(rows, newRow)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// ParseEvent: Parse the potentially taxable event
+func ParseEvent(event db.TaxableEvent) (rows []Row, err error) {
+	var row Row
+	switch event.Source {
+	case db.OsmosisRewardDistribution:
+		row, err = ParseOsmosisReward(event)
+	case db.ClaimHookAirdrop:
+		row, err = ParseClaimHookAirdrop(event)
+	case db.VestingAccountUnlock:
+		row, err = ParseVestingAccountUnlock(event)
+	case db.GovDepositRefund:
+		row, err = ParseGovDepositRefund(event)
+	case db.GovDepositBurn:
+		row, err = ParseGovDepositBurn(event)
+	case db.StakingSlash:
+		row, err = ParseStakingSlash(event)
+	default:
+		return rows, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(rows, row), nil
+}
+
+// ParseTx: Parse the potentially taxable TX and Messages
+// This function is used for parsing a single TX that will not need to relate to any others
+// Use TX Parsing Groups to parse txes as a group
+func ParseTx(address string, events []db.TaxableTransaction) (rows []parsers.CsvRow, err error) {
+	for _, event := range events {
+		// Vesting accounts receive their tokens as income when they unlock
+		if parsers.IsVestingFundingReceipt(address, event) {
+			continue
+		}
+
+		// Rewards paid to a withdraw address are split between the earner and the withdraw address
+		if parsers.IsRewardToWithdrawAddress(event) {
+			rewardRows, err := ParseRewardToWithdrawAddress(address, event)
+			if err != nil {
+				config.Log.Errorf("error parsing message type '%v': %v", event.Message.MessageType.MessageType, err)
+				continue
+			}
+			for _, row := range rewardRows {
+				row.Comment = parsers.AggregationDescription(row.Comment, event.AggregatedHeights)
+				rows = append(rows, row)
+			}
+			continue
+		}
+
+		var newRow Row
+		var err error
+		switch event.Message.MessageType.MessageType {
+		case bank.MsgSendV0, bank.MsgSend, bank.MsgMultiSendV0, bank.MsgMultiSend:
+			newRow, err = ParseMsgSend(address, event)
+		case vesting.MsgCreateVestingAccount, vesting.MsgCreatePermanentLockedAccount, vesting.MsgCreatePeriodicVestingAccount:
+			newRow, err = ParseMsgSend(address, event)
+		case distribution.MsgFundCommunityPool:
+			newRow, err = ParseMsgFundCommunityPool(address, event)
+		case auction.MsgAuctionBid:
+			newRow, err = ParseMsgAuctionBid(address, event)
+		case distribution.MsgWithdrawValidatorCommission:
+			newRow, err = ParseMsgWithdrawValidatorCommission(address, event)
+		case distribution.MsgWithdrawRewards, distribution.MsgWithdrawDelegatorReward:
+			newRow, err = ParseMsgWithdrawDelegatorReward(address, event)
+		case staking.MsgDelegate, staking.MsgUndelegate, staking.MsgBeginRedelegate:
+			newRow, err = ParseMsgWithdrawDelegatorReward(address, event)
+		case staking.MsgTokenizeShares, staking.MsgRedeemTokensForShares, staking.MsgTransferTokenizeShareRecord, staking.MsgValidatorBond:
+			newRow, err = parsers.TokenizedSharesRow(address, event, ParseMsgSend, ParseMsgWithdrawDelegatorReward)
+		case stakeibc.MsgLiquidStake, stakeibc.MsgLSMLiquidStake, stakeibc.MsgRedeemStake:
+			newRow, err = ParseStrideLiquidStake(event)
+		case claim.MsgClaimFreeAmount, airdropClaim.StargazeMsgInitialClaim, airdropClaim.StargazeMsgClaimFor, airdropClaim.CrescentMsgClaim, airdropClaim.QuicksilverMsgClaim:
+			newRow, err = ParseMsgClaim(address, event)
+		case evm.MsgEthereumTx, evm.InjectiveMsgEthereumTx, evm.CosmosEVMMsgEthereumTx:
+			newRow, err = ParseMsgEthereumTx(address, event)
+		case wasm.MsgExecuteContract:
+			newRow, err = ParseMsgExecuteContract(address, event)
+		case gamm.MsgSwapExactAmountIn, gamm.MsgSwapExactAmountOut:
+			newRow, err = ParseMsgSwap(event)
+		case poolmanager.MsgSplitRouteSwapExactAmountIn, poolmanager.MsgSwapExactAmountIn, poolmanager.MsgSwapExactAmountOut, poolmanager.MsgSplitRouteSwapExactAmountOut:
+			newRow, err = ParseMsgSwap(event)
+		case ibc.MsgTransfer:
+			newRow, err = ParseMsgTransfer(address, event)
+		case ibc.MsgAcknowledgement:
+			newRow, err = ParseMsgAcknowledgement(address, event)
+		case ibc.MsgRecvPacket:
+			newRow, err = ParseMsgRecvPacket(address, event)
+		case gov.MsgSubmitProposal, gov.MsgSubmitProposalV1, gov.MsgDeposit, gov.MsgDepositV1:
+			newRow, err = ParseMsgDeposit(address, event)
+		case concentratedliquidity.MsgCollectIncentives, concentratedliquidity.MsgCollectSpreadRewards:
+			newRow, err = ParseConcentratedLiquidityCollection(event)
+		case valsetpref.MsgDelegateBondedTokens, valsetpref.MsgUndelegateFromValidatorSet, valsetpref.MsgRedelegateValidatorSet, valsetpref.MsgWithdrawDelegationRewards, valsetpref.MsgDelegateToValidatorSet, valsetpref.MsgUndelegateFromRebalancedValidatorSet:
+			newRow, err = ParseValsetPrefRewards(event)
+		case tokenfactory.MsgMint, tokenfactory.MsgBurn:
+			newRow, err = ParseTokenFactoryEvents(address, event)
+		default:
+			config.Log.Errorf("no parser for message type '%v'", event.Message.MessageType.MessageType)
+			continue
+		}
+
+		if err != nil {
+			config.Log.Errorf("error parsing message type '%v': %v", event.Message.MessageType.MessageType, err)
+			continue
+		}
+
+		// Transfers between the queried addresses are moves between the wallets of the owner
+		if event.InternalTransfer {
+			if event.ReceiverAddress.Address == address {
+				newRow.Type = Deposit
+			} else {
+				newRow.Type = Withdrawal
+			}
+			newRow.Comment = parsers.InternalTransferDescription(newRow.Comment, event)
+		}
+
+		newRow.Comment = parsers.AggregationDescription(newRow.Comment, event.AggregatedHeights)
+		rows = append(rows, newRow)
+	}
+	return rows, nil
+}
+
+// ParseMsgWithdrawValidatorCommission: The commission withdrawn by a validator operator is income
+func ParseMsgWithdrawValidatorCommission(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgWithdrawValidatorCommission.", err)
+	}
+	row.Type = Income
+	if validator := parsers.ValidatorDescription(event); validator != "" {
+		row.Comment = fmt.Sprintf("%s. Commission from %s", row.Comment, validator)
+	}
+	return *row, err
+}
+
+// ParseMsgWithdrawDelegatorReward: The rewards withdrawn explicitly or automatically on a delegation change are staking rewards
+func ParseMsgWithdrawDelegatorReward(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgWithdrawDelegatorReward.", err)
+	}
+	row.Type = Staking
+	if validator := parsers.ValidatorDescription(event); validator != "" {
+		row.Comment = fmt.Sprintf("%s. Reward from %s", row.Comment, validator)
+	}
+	return *row, err
+}
+
+// ParseRewardToWithdrawAddress: A reward paid to a withdraw address is income of the earner, followed by a transfer of the
+// reward from the earner to the withdraw address. The withdraw address only receives the transfer.
+func ParseRewardToWithdrawAddress(address string, event db.TaxableTransaction) ([]Row, error) {
+	return parsers.RewardToWithdrawAddressRows(address, event, func(transfer db.TaxableTransaction, _ bool) (Row, error) {
+		row := &Row{}
+		err := row.ParseBasic(address, transfer)
+		if err != nil {
+			config.Log.Error("Error with ParseRewardToWithdrawAddress.", err)
+			return *row, err
+		}
+		return *row, nil
+	}, ParseMsgWithdrawValidatorCommission, ParseMsgWithdrawDelegatorReward)
+}
+
+// ParseMsgSend:
+// If the address we searched is the receiver, then this transaction is a deposit.
+// If the address we searched is the sender, then this transaction is a withdrawal.
+func ParseMsgSend(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgSend.", err)
+	}
+	return *row, err
+}
+
+func ParseMsgFundCommunityPool(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgFundCommunityPool.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgAuctionBid: The bid paid to the proposer and auction escrow is a cost to the bidder, refunds come back as deposits
+func ParseMsgAuctionBid(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgAuctionBid.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgSwap parses the gamm and poolmanager swaps
+func ParseMsgSwap(event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseSwap(event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgSwap.", err)
+	}
+	return *row, err
+}
+
+func ParseMsgTransfer(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgTransfer.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgAcknowledgement parses the refunds of failed IBC transfers and the sent amounts of acknowledged transfers
+func ParseMsgAcknowledgement(address string, event db.TaxableTransaction) (Row, error) {
+	return parseIBCPacket(address, event, event.AmountSent, event.DenominationSent)
+}
+
+// ParseMsgRecvPacket parses the tokens received over IBC
+func ParseMsgRecvPacket(address string, event db.TaxableTransaction) (Row, error) {
+	return parseIBCPacket(address, event, event.AmountReceived, event.DenominationReceived)
+}
+
+// parseIBCPacket parses the amount of the packet as received or sent by the address
+func parseIBCPacket(address string, event db.TaxableTransaction, amount decimal.Decimal, denom db.Denom) (Row, error) {
+	row := &Row{}
+
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(amount), denom)
+	if err != nil {
+		config.Log.Error("Error with parseIBCPacket.", err)
+		return *row, fmt.Errorf("cannot parse denom units for TX %s (classification: ibc packet)", event.Message.Tx.Hash)
+	}
+
+	if event.ReceiverAddress.Address == address {
+		row.BuyAmount = conversionAmount.Text('f', -1)
+		row.BuyCurrency = conversionSymbol
+		row.Type = Deposit
+	} else if event.SenderAddress.Address == address { // withdrawal
+		row.SellAmount = conversionAmount.Text('f', -1)
+		row.SellCurrency = conversionSymbol
+		row.Type = Withdrawal
+	}
+
+	row.Date = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+	row.Comment = event.Message.Tx.Hash
+
+	return *row, nil
+}
+
+// ParseMsgDeposit parses gov proposal deposits, the deposits are escrowed and either refunded or burned later
+func ParseMsgDeposit(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgDeposit.", err)
+	}
+	return *row, err
+}
+
+func ParseOsmosisReward(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseOsmosisReward.", err)
+	}
+	return *row, err
+}
+
+// ParseStrideLiquidStake parses Stride liquid stakes and redemptions, which exchange the native tokens and stTokens at the
+// redemption rate. Redemptions are booked to the redeemer, the native tokens are paid out to the receiver on the host zone.
+func ParseStrideLiquidStake(event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseSwap(event)
+	if err != nil {
+		config.Log.Error("Error with ParseStrideLiquidStake.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgClaim parses airdrop claims, the coins received from the claim module account are airdrops
+func ParseMsgClaim(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgClaim.", err)
+	}
+	if event.ReceiverAddress.Address == address {
+		row.Type = Airdrop
+	}
+	return *row, err
+}
+
+// ParseClaimHookAirdrop parses airdrops claimed automatically by the claim module hooks
+func ParseClaimHookAirdrop(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseClaimHookAirdrop.", err)
+	}
+	row.Type = Airdrop
+	return *row, err
+}
+
+// ParseVestingAccountUnlock parses the tokens unlocked by a vesting account, these are materialized by the vesting-schedule command
+func ParseVestingAccountUnlock(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseVestingAccountUnlock.", err)
+	}
+	row.Type = Income
+	return *row, err
+}
+
+// ParseGovDepositRefund parses a gov proposal deposit returned to the depositor, the deposit is returned not earned
+func ParseGovDepositRefund(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseGovDepositRefund.", err)
+	}
+	row.Type = Deposit
+	return *row, err
+}
+
+// ParseGovDepositBurn parses a gov proposal deposit burned by the gov module, these are reported as lost
+func ParseGovDepositBurn(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseGovDepositBurn.", err)
+	}
+	row.SellAmount, row.SellCurrency = row.BuyAmount, row.BuyCurrency
+	row.BuyAmount, row.BuyCurrency = "", ""
+	row.Type = Lost
+	return *row, err
+}
+
+// ParseStakingSlash parses the share of a validator slash lost by the delegator, these are reported as lost
+func ParseStakingSlash(event db.TaxableEvent) (Row, error) {
+	row := &Row{}
+	err := row.EventParseBasic(event)
+	if err != nil {
+		config.Log.Error("Error with ParseStakingSlash.", err)
+	}
+	row.SellAmount, row.SellCurrency = row.BuyAmount, row.BuyCurrency
+	row.BuyAmount, row.BuyCurrency = "", ""
+	row.Type = Lost
+	return *row, err
+}
+
+func ParseMsgEthereumTx(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseMsgEthereumTx.", err)
+	}
+	return *row, err
+}
+
+// ParseMsgExecuteContract parses contract executions. Executions handled by a contract handler (e.g. swaps) carry both legs,
+// the payment legs of CW721 NFTs bought or sold on a marketplace carry a single leg.
+func ParseMsgExecuteContract(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	var err error
+	if event.DenominationSentID != nil && event.DenominationReceivedID != nil && *event.DenominationSentID != *event.DenominationReceivedID {
+		err = row.ParseSwap(event)
+	} else {
+		err = row.ParseBasic(address, event)
+	}
+	if err != nil {
+		config.Log.Error("Error with ParseMsgExecuteContract.", err)
+	}
+	return *row, err
+}
+
+// ParseConcentratedLiquidityCollection parses the incentives and spread rewards collected from a position, which are income
+func ParseConcentratedLiquidityCollection(event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	row.Date = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+	row.Comment = event.Message.Tx.Hash
+	row.Type = Income
+
+	err := parseAndAddReceivedAmount(row, event)
+	if err != nil {
+		config.Log.Error("Error with ParseConcentratedLiquidityCollection.", err)
+		return *row, fmt.Errorf("cannot parse denom units for TX %s (classification: deposit)", event.Message.Tx.Hash)
+	}
+
+	return *row, nil
+}
+
+// ParseValsetPrefRewards parses the staking rewards withdrawn by the valset-pref messages
+func ParseValsetPrefRewards(event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	row.Date = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+	row.Comment = event.Message.Tx.Hash
+	row.Type = Staking
+
+	err := parseAndAddReceivedAmount(row, event)
+	if err != nil {
+		return *row, err
+	}
+
+	return *row, nil
+}
+
+func ParseTokenFactoryEvents(address string, event db.TaxableTransaction) (Row, error) {
+	row := &Row{}
+	err := row.ParseBasic(address, event)
+	if err != nil {
+		config.Log.Error("Error with ParseTokenFactoryEvents.", err)
+	}
+
+	return *row, nil
+}
@@ -0,0 +1,53 @@
+package tokentax
+
+import "github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+
+const (
+	// ParserKey is the key used to identify this parser
+	ParserKey = "tokentax"
+
+	// TimeLayout is the golang time format string for this parser
+	TimeLayout = "01/02/2006 15:04"
+)
+
+type Parser struct {
+	Rows          []Row
+	ParsingGroups []parsers.ParsingGroup
+}
+
+type Row struct {
+	Type         Type
+	BuyAmount    string
+	BuyCurrency  string
+	SellAmount   string
+	SellCurrency string
+	FeeAmount    string
+	FeeCurrency  string
+	Exchange     string
+	Group        string
+	Comment      string // The tx hash of the row
+	Date         string
+}
+
+type Type int
+
+const (
+	None Type = iota
+
+	// incoming transactions
+	Deposit
+	Income
+	Staking
+	Airdrop
+
+	// outgoing transactions, fees without a sent amount are withdrawals of only the fee
+	Withdrawal
+	Lost
+
+	// Trades
+	Trade
+)
+
+func (t Type) String() string {
+	return [...]string{"", "Deposit", "Income", "Staking", "Airdrop", "Withdrawal", "Lost", "Trade"}[t]
+}
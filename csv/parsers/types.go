@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/parquet"
 )
 
 type Parser interface {
@@ -15,6 +16,28 @@ type Parser interface {
 	TimeLayout() string
 }
 
+// AddressSetParser is implemented by parsers whose rows depend on the data of all the queried addresses, e.g. tax lots
+// shared by the addresses. The addresses are set before processing and the rows are only requested once all the addresses
+// were processed.
+type AddressSetParser interface {
+	Parser
+	SetAddresses(addresses []string)
+}
+
+// LotParser is implemented by parsers that value every acquisition as its own lot, the rewards they process are never
+// aggregated so each reward keeps its acquisition time and value
+type LotParser interface {
+	Parser
+	KeepsLots()
+}
+
+// ParquetParser is implemented by parsers whose columns are typed in the Parquet encoding, the columns of the other parsers
+// are UTF-8 strings
+type ParquetParser interface {
+	Parser
+	GetParquetColumns() []parquet.Column
+}
+
 type ParsingGroup interface {
 	BelongsToGroup(db.TaxableTransaction) bool
 	String() string
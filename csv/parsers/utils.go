@@ -2,8 +2,13 @@ package parsers
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/vesting"
 	"github.com/DefiantLabs/cosmos-tax-cli/db"
 	"github.com/preichenberger/go-coinbasepro/v2"
 )
@@ -51,3 +56,151 @@ func GetTxToFeesMap(groupedTxes map[uint][]db.TaxableTransaction) map[uint][]db.
 
 	return txToFees
 }
+
+// IsRewardToWithdrawAddress returns true when the reward was earned by another address than the one it was paid to,
+// which happens when the earner had set a withdraw address
+func IsRewardToWithdrawAddress(event db.TaxableTransaction) bool {
+	return event.EarnerAddress.Address != "" && event.EarnerAddress.Address != event.ReceiverAddress.Address
+}
+
+// SplitRewardToWithdrawAddress splits a reward paid to a withdraw address into the reward received by the earner and the
+// transfer of the reward from the earner to the withdraw address
+func SplitRewardToWithdrawAddress(event db.TaxableTransaction) (reward db.TaxableTransaction, transfer db.TaxableTransaction) {
+	reward = event
+	reward.ReceiverAddressID = event.EarnerAddressID
+	reward.ReceiverAddress = event.EarnerAddress
+
+	transfer = event
+	transfer.SenderAddressID = event.EarnerAddressID
+	transfer.SenderAddress = event.EarnerAddress
+	transfer.AmountSent = event.AmountReceived
+	transfer.DenominationSentID = event.DenominationReceivedID
+	transfer.DenominationSent = event.DenominationReceived
+
+	return reward, transfer
+}
+
+// RewardToWithdrawAddressRows returns the rows of a reward paid to a withdraw address with the row parsers of a format. The
+// withdraw address only gets the transfer row, the earner gets the reward row (commission or delegator reward) followed by
+// the transfer row. parseTransfer is told whether the address is the earner, the sender of the transfer.
+func RewardToWithdrawAddressRows[R any](address string, event db.TaxableTransaction,
+	parseTransfer func(transfer db.TaxableTransaction, isEarner bool) (R, error),
+	parseCommission, parseReward func(address string, event db.TaxableTransaction) (R, error),
+) ([]R, error) {
+	reward, transfer := SplitRewardToWithdrawAddress(event)
+
+	isEarner := address == event.EarnerAddress.Address
+	row, err := parseTransfer(transfer, isEarner)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEarner {
+		return []R{row}, nil
+	}
+
+	parseRewardRow := parseReward
+	if event.Message.MessageType.MessageType == distribution.MsgWithdrawValidatorCommission {
+		parseRewardRow = parseCommission
+	}
+
+	rewardRow, err := parseRewardRow(address, reward)
+	if err != nil {
+		return nil, err
+	}
+
+	return []R{rewardRow, row}, nil
+}
+
+// IsTokenizedShareMint returns true for the LSM share tokens minted by tokenizing a delegation. They are a conversion of the
+// delegation, not income like the rewards withdrawn by the same message.
+func IsTokenizedShareMint(messageType string, denom string) bool {
+	return messageType == staking.MsgTokenizeShares && staking.IsTokenizeShareDenom(denom)
+}
+
+// IsTokenizedShareTransfer returns true for the legs of the LSM share tokens minted by tokenizing a delegation or burned by
+// redeeming them
+func IsTokenizedShareTransfer(event db.TaxableTransaction) bool {
+	messageType := event.Message.MessageType.MessageType
+	if event.DenominationReceivedID != nil && IsTokenizedShareMint(messageType, event.DenominationReceived.Base) {
+		return true
+	}
+	return event.DenominationSentID != nil && messageType == staking.MsgRedeemTokensForShares &&
+		staking.IsTokenizeShareDenom(event.DenominationSent.Base)
+}
+
+// TokenizedSharesRow returns the row of an LSM message leg with the row parsers of a format. The share tokens are deposits
+// and withdrawals, parsed with parseTransfer, the rewards withdrawn by the message are income, parsed with parseReward.
+func TokenizedSharesRow[R any](address string, event db.TaxableTransaction,
+	parseTransfer, parseReward func(address string, event db.TaxableTransaction) (R, error),
+) (R, error) {
+	if IsTokenizedShareTransfer(event) {
+		return parseTransfer(address, event)
+	}
+	return parseReward(address, event)
+}
+
+// The messages that fund a new vesting account
+var vestingFundingMessageTypes = map[string]bool{
+	vesting.MsgCreateVestingAccount:         true,
+	vesting.MsgCreatePermanentLockedAccount: true,
+	vesting.MsgCreatePeriodicVestingAccount: true,
+}
+
+// IsVestingFunding returns true for the tokens sent by a funder to a new vesting account
+func IsVestingFunding(event db.TaxableTransaction) bool {
+	return vestingFundingMessageTypes[event.Message.MessageType.MessageType]
+}
+
+// IsVestingFundingReceipt returns true for the tokens received by the address as a new vesting account. They are locked, the
+// exports acquire them as income when they unlock (the vesting unlock events) instead of when they are received.
+func IsVestingFundingReceipt(address string, event db.TaxableTransaction) bool {
+	return IsVestingFunding(event) && event.ReceiverAddress.Address == address && event.SenderAddress.Address != address
+}
+
+// ValidatorDescription returns the moniker and operator address of the validator that paid a reward leg, or an empty string
+// when the validator of the leg is unknown
+func ValidatorDescription(event db.TaxableTransaction) string {
+	if event.ValidatorAddress == "" {
+		return ""
+	}
+
+	if event.ValidatorMoniker == "" {
+		return event.ValidatorAddress
+	}
+
+	return fmt.Sprintf("%s (%s)", event.ValidatorMoniker, event.ValidatorAddress)
+}
+
+// AggregationDescription appends the heights of the blocks of an aggregated reward to the description of its row, the
+// description is returned as is for rewards that were not aggregated
+func AggregationDescription(description string, heights []int64) string {
+	if len(heights) == 0 {
+		return description
+	}
+
+	heightStrs := make([]string, len(heights))
+	for i, height := range heights {
+		heightStrs[i] = strconv.FormatInt(height, 10)
+	}
+
+	aggregated := fmt.Sprintf("Aggregated %d rewards at heights %s", len(heights), strings.Join(heightStrs, ", "))
+	if description == "" {
+		return aggregated
+	}
+	return fmt.Sprintf("%s. %s", description, aggregated)
+}
+
+// InternalTransferDescription appends the owner's wallets a transfer moved the tokens between to the description of its row,
+// the description is returned as is for transfers that are not internal
+func InternalTransferDescription(description string, event db.TaxableTransaction) string {
+	if !event.InternalTransfer {
+		return description
+	}
+
+	internal := fmt.Sprintf("Internal transfer from %s to %s", event.SenderAddress.Address, event.ReceiverAddress.Address)
+	if description == "" {
+		return internal
+	}
+	return fmt.Sprintf("%s. %s", description, internal)
+}
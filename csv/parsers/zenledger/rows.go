@@ -0,0 +1,146 @@
+package zenledger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+)
+
+func (row Row) GetRowForCsv() []string {
+	return []string{
+		row.Timestamp,
+		row.Type.String(),
+		row.InAmount,
+		row.InCurrency,
+		row.OutAmount,
+		row.OutCurrency,
+		row.FeeAmount,
+		row.FeeCurrency,
+		row.Exchange,
+		row.USBased,
+	}
+}
+
+func (row Row) GetDate() string {
+	return row.Timestamp
+}
+
+// EventParseBasic handles the tokens received by taxable events, e.g. osmo rewards
+func (row *Row) EventParseBasic(event db.TaxableEvent) error {
+	row.Timestamp = event.Block.TimeStamp.Format(TimeLayout)
+
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.Amount), event.Denomination)
+	if err == nil {
+		row.InAmount = conversionAmount.Text('f', -1)
+		row.InCurrency = conversionSymbol
+	} else {
+		row.InAmount = util.NumericToString(event.Amount)
+		row.InCurrency = event.Denomination.Base
+	}
+	row.Type = Income
+	return nil
+}
+
+// ParseBasic: Handles the fields that are shared between most types.
+func (row *Row) ParseBasic(address string, event db.TaxableTransaction) error {
+	row.Timestamp = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+
+	// deposit
+	if event.ReceiverAddress.Address == address {
+		conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+		if err != nil {
+			return fmt.Errorf("cannot parse denom units for TX %s (classification: deposit)", event.Message.Tx.Hash)
+		}
+		row.InAmount = conversionAmount.Text('f', -1)
+		row.InCurrency = conversionSymbol
+		row.Type = Receive
+	} else if event.SenderAddress.Address == address { // withdrawal
+		conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+		if err != nil {
+			return fmt.Errorf("cannot parse denom units for TX %s (classification: withdrawal)", event.Message.Tx.Hash)
+		}
+		row.OutAmount = conversionAmount.Text('f', -1)
+		row.OutCurrency = conversionSymbol
+		row.Type = Send
+	}
+
+	return nil
+}
+
+func (row *Row) ParseSwap(event db.TaxableTransaction) error {
+	row.Timestamp = event.Message.Tx.Block.TimeStamp.Format(TimeLayout)
+	row.Type = Trade
+
+	recievedConversionAmount, recievedConversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: swap received)", event.Message.Tx.Hash)
+	}
+
+	row.InAmount = recievedConversionAmount.Text('f', -1)
+	row.InCurrency = recievedConversionSymbol
+
+	sentConversionAmount, sentConversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: swap sent)", event.Message.Tx.Hash)
+	}
+
+	row.OutAmount = sentConversionAmount.Text('f', -1)
+	row.OutCurrency = sentConversionSymbol
+
+	return nil
+}
+
+func (row *Row) ParseFee(tx db.Tx, fee db.Fee) error {
+	row.Timestamp = tx.Block.TimeStamp.Format(TimeLayout)
+	row.Type = Fee
+
+	sentConversionAmount, sentConversionSymbol, err := db.ConvertUnits(util.FromNumeric(fee.Amount), fee.Denomination)
+	if err != nil {
+		return fmt.Errorf("cannot parse denom units for TX %s (classification: fee)", tx.Hash)
+	}
+
+	row.FeeAmount = sentConversionAmount.Text('f', -1)
+	row.FeeCurrency = sentConversionSymbol
+
+	return nil
+}
+
+func parseAndAddSentAmount(row *Row, event db.TaxableTransaction) error {
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountSent), event.DenominationSent)
+	if err != nil {
+		return errors.New("cannot parse denom units")
+	}
+	row.OutAmount = conversionAmount.Text('f', -1)
+	row.OutCurrency = conversionSymbol
+
+	return nil
+}
+
+func parseAndAddSentAmountWithDefault(row *Row, event db.TaxableTransaction) {
+	err := parseAndAddSentAmount(row, event)
+	if err != nil {
+		row.OutAmount = util.NumericToString(event.AmountSent)
+		row.OutCurrency = event.DenominationSent.Base
+	}
+}
+
+func parseAndAddReceivedAmount(row *Row, event db.TaxableTransaction) error {
+	conversionAmount, conversionSymbol, err := db.ConvertUnits(util.FromNumeric(event.AmountReceived), event.DenominationReceived)
+	if err != nil {
+		return errors.New("cannot parse denom units")
+	}
+	row.InAmount = conversionAmount.Text('f', -1)
+	row.InCurrency = conversionSymbol
+
+	return nil
+}
+
+func parseAndAddReceivedAmountWithDefault(row *Row, event db.TaxableTransaction) {
+	err := parseAndAddReceivedAmount(row, event)
+	if err != nil {
+		row.InAmount = util.NumericToString(event.AmountReceived)
+		row.InCurrency = event.DenominationReceived.Base
+	}
+}
@@ -0,0 +1,53 @@
+package zenledger
+
+import "github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+
+const (
+	// ParserKey is the key used to identify this parser
+	ParserKey = "zenledger"
+
+	// TimeLayout is the golang time format string for this parser
+	TimeLayout = "01/02/2006 15:04:05"
+)
+
+type Parser struct {
+	Rows          []Row
+	ParsingGroups []parsers.ParsingGroup
+}
+
+type Row struct {
+	Timestamp   string
+	Type        Type
+	InAmount    string
+	InCurrency  string
+	OutAmount   string
+	OutCurrency string
+	FeeAmount   string
+	FeeCurrency string
+	Exchange    string
+	USBased     string
+}
+
+type Type int
+
+const (
+	None Type = iota
+
+	// incoming transactions
+	Receive
+	Income
+	StakingReward
+	Airdrop
+
+	// outgoing transactions
+	Send
+	Lost
+	Fee
+
+	// Trades
+	Trade
+)
+
+func (t Type) String() string {
+	return [...]string{"", "receive", "income", "staking_reward", "airdrop", "send", "lost", "fee", "trade"}[t]
+}
@@ -0,0 +1,52 @@
+package csv
+
+import (
+	"strconv"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/reconcile"
+)
+
+var reconcileHeaders = []string{"Address", "Height", "Currency", "Indexed", "On Chain", "Difference", "First Divergence Height"}
+
+type reconcileRow struct {
+	Address               string
+	Height                string
+	Currency              string
+	Indexed               string
+	OnChain               string
+	Difference            string
+	FirstDivergenceHeight string
+}
+
+func (row reconcileRow) GetRowForCsv() []string {
+	return []string{row.Address, row.Height, row.Currency, row.Indexed, row.OnChain, row.Difference, row.FirstDivergenceHeight}
+}
+
+func (row reconcileRow) GetDate() string {
+	return row.Height
+}
+
+// ReconcileToCsvRows returns the reconciliation report rows and headers for the discrepancies, the amounts are in the base unit
+// of the denom so they can be compared exactly
+func ReconcileToCsvRows(discrepancies []reconcile.Discrepancy) ([]parsers.CsvRow, []string) {
+	var rows []parsers.CsvRow
+	for _, discrepancy := range discrepancies {
+		row := reconcileRow{
+			Address:    discrepancy.Address,
+			Height:     strconv.FormatInt(discrepancy.Height, 10),
+			Currency:   discrepancy.Denom,
+			Indexed:    discrepancy.Indexed,
+			OnChain:    discrepancy.OnChain,
+			Difference: discrepancy.Difference,
+		}
+
+		if discrepancy.FirstDivergenceHeight != 0 {
+			row.FirstDivergenceHeight = strconv.FormatInt(discrepancy.FirstDivergenceHeight, 10)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, reconcileHeaders
+}
@@ -0,0 +1,78 @@
+package csv
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/snapshot"
+)
+
+const snapshotTimeLayout = "2006-01-02 15:04:05"
+
+var snapshotHeaders = []string{"Date", "Height", "Address", "Category", "Validator", "Amount", "Currency", "Base Amount", "Base Currency", "Completion Time"}
+
+type snapshotRow struct {
+	Date           string
+	Height         string
+	Address        string
+	Category       string
+	Validator      string
+	Amount         string
+	Currency       string
+	BaseAmount     string
+	BaseCurrency   string
+	CompletionTime string
+}
+
+func (row snapshotRow) GetRowForCsv() []string {
+	return []string{row.Date, row.Height, row.Address, row.Category, row.Validator, row.Amount, row.Currency, row.BaseAmount, row.BaseCurrency, row.CompletionTime}
+}
+
+func (row snapshotRow) GetDate() string {
+	return row.Date
+}
+
+// SnapshotToCsvRows returns the snapshot report rows and headers for the positions, the amounts are converted to the display
+// unit of the denom when it is known
+func SnapshotToCsvRows(snap snapshot.Snapshot) ([]parsers.CsvRow, []string) {
+	var rows []parsers.CsvRow
+	for _, position := range snap.Positions {
+		row := snapshotRow{
+			Date:         snap.Date.UTC().Format(snapshotTimeLayout),
+			Height:       strconv.FormatInt(snap.Height, 10),
+			Address:      position.Address,
+			Category:     position.Category,
+			Validator:    position.Validator,
+			Amount:       position.Amount,
+			Currency:     position.Denom,
+			BaseAmount:   position.Amount,
+			BaseCurrency: position.Denom,
+		}
+
+		if position.CompletionTime != nil {
+			row.CompletionTime = position.CompletionTime.UTC().Format(snapshotTimeLayout)
+		}
+
+		// Unclaimed rewards are decimal amounts, the fraction of the base unit is dropped for the conversion
+		integerAmount, _, _ := strings.Cut(position.Amount, ".")
+		amount, ok := new(big.Int).SetString(integerAmount, 10)
+		denom, err := db.GetDenomForBase(position.Denom)
+		if ok && err == nil {
+			conversionAmount, conversionSymbol, err := db.ConvertUnits(amount, denom)
+			if err == nil {
+				row.Amount = conversionAmount.Text('f', -1)
+				row.Currency = conversionSymbol
+			}
+		} else {
+			config.Log.Debugf("Cannot convert snapshot position of denom %s, using the base amount", position.Denom)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, snapshotHeaders
+}
@@ -0,0 +1,76 @@
+// nolint:unused
+package csv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/coinledger"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/tokentax"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers/zenledger"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis"
+	"github.com/stretchr/testify/assert"
+)
+
+var tradeFormats = []struct {
+	parserKey  string
+	timeLayout string
+	dateColumn int
+	typeColumn int
+	tradeType  string
+	rewardType string
+}{
+	{zenledger.ParserKey, zenledger.TimeLayout, 0, 1, zenledger.Trade.String(), zenledger.Income.String()},
+	{coinledger.ParserKey, coinledger.TimeLayout, 0, 8, coinledger.Trade.String(), coinledger.Income.String()},
+	{tokentax.ParserKey, tokentax.TimeLayout, 10, 0, tokentax.Trade.String(), tokentax.Income.String()},
+}
+
+func TestTradeFormatsOsmoLPParsing(t *testing.T) {
+	for _, format := range tradeFormats {
+		parser := GetParser(format.parserKey)
+		parser.InitializeParsingGroups()
+
+		targetAddress := mkAddress(t, 1)
+		chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+		transferTxs := getTestSwapTXs(t, targetAddress, chain)
+
+		err := parser.ProcessTaxableTx(targetAddress.Address, transferTxs, []db.Fee{})
+		assert.Nil(t, err, format.parserKey)
+
+		rows, err := parser.GetRows(targetAddress.Address, nil, nil)
+		assert.Nil(t, err, format.parserKey)
+		assert.Equalf(t, len(transferTxs), len(rows), "%s should have one row for each LP transaction", format.parserKey)
+
+		// Joins and exits are trades of the pooled tokens and the GAMM tokens
+		for _, row := range rows {
+			cols := row.GetRowForCsv()
+			_, err := time.Parse(format.timeLayout, cols[format.dateColumn])
+			assert.Nil(t, err, format.parserKey)
+			assert.Equal(t, format.tradeType, cols[format.typeColumn], format.parserKey)
+			assert.Len(t, cols, len(parser.GetHeaders()), format.parserKey)
+		}
+	}
+}
+
+func TestTradeFormatsOsmoRewardParsing(t *testing.T) {
+	for _, format := range tradeFormats {
+		parser := GetParser(format.parserKey)
+		parser.InitializeParsingGroups()
+
+		targetAddress := mkAddress(t, 1)
+		chain := mkChain(1, osmosis.ChainID, osmosis.Name)
+		taxableEvents := getTestTaxableEvents(t, targetAddress, chain)
+
+		err := parser.ProcessTaxableEvent(taxableEvents)
+		assert.Nil(t, err, format.parserKey)
+
+		rows, err := parser.GetRows(targetAddress.Address, nil, nil)
+		assert.Nil(t, err, format.parserKey)
+		assert.Equalf(t, len(taxableEvents), len(rows), "%s should have one row for each reward", format.parserKey)
+
+		for _, row := range rows {
+			assert.Equal(t, format.rewardType, row.GetRowForCsv()[format.typeColumn], format.parserKey)
+		}
+	}
+}
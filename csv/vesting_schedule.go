@@ -0,0 +1,69 @@
+package csv
+
+import (
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+)
+
+const vestingScheduleTimeLayout = "2006-01-02 15:04:05"
+
+var vestingScheduleHeaders = []string{"Address", "Account Type", "Period Start", "Period End", "Unlock Time", "Amount", "Currency"}
+
+type vestingScheduleRow struct {
+	Address     string
+	AccountType string
+	PeriodStart string
+	PeriodEnd   string
+	UnlockTime  string
+	Amount      string
+	Currency    string
+}
+
+func (row vestingScheduleRow) GetRowForCsv() []string {
+	return []string{row.Address, row.AccountType, row.PeriodStart, row.PeriodEnd, row.UnlockTime, row.Amount, row.Currency}
+}
+
+func (row vestingScheduleRow) GetDate() string {
+	return row.UnlockTime
+}
+
+// VestingScheduleToCsvRows returns the vesting-schedule report rows and headers for the unlocks, the amounts are converted
+// to the display unit of the denom when it is known
+func VestingScheduleToCsvRows(unlocks []db.VestingUnlock) ([]parsers.CsvRow, []string) {
+	var rows []parsers.CsvRow
+	for _, unlock := range unlocks {
+		row := vestingScheduleRow{
+			Address:     unlock.Account.Address.Address,
+			AccountType: unlock.Account.Type,
+			PeriodStart: unlock.PeriodStart.UTC().Format(vestingScheduleTimeLayout),
+			PeriodEnd:   unlock.PeriodEnd.UTC().Format(vestingScheduleTimeLayout),
+			UnlockTime:  unlock.UnlockTime.UTC().Format(vestingScheduleTimeLayout),
+			Amount:      unlock.Amount.String(),
+			Currency:    unlock.Denom.Base,
+		}
+
+		conversionAmount, conversionSymbol, err := db.ConvertUnits(unlock.Amount.BigInt(), unlock.Denom)
+		if err == nil {
+			row.Amount = conversionAmount.Text('f', -1)
+			row.Currency = conversionSymbol
+		} else {
+			config.Log.Debugf("Cannot convert vesting unlock of denom %s, using the base amount", unlock.Denom.Base)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, vestingScheduleHeaders
+}
+
+// ComputeVestingSchedule returns the unlocks of all the accounts between start and end, grouped by report period
+func ComputeVestingSchedule(accounts []db.VestingAccount, start time.Time, end time.Time, period string) []db.VestingUnlock {
+	var unlocks []db.VestingUnlock
+	for _, account := range accounts {
+		unlocks = append(unlocks, db.ComputeVestingUnlocks(account, start, end, period)...)
+	}
+	return unlocks
+}
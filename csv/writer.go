@@ -3,11 +3,38 @@ package csv
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
 	"github.com/DefiantLabs/cosmos-tax-cli/csv/parsers"
+	"github.com/DefiantLabs/cosmos-tax-cli/parquet"
 )
 
+// The encodings the rows can be written in
+const (
+	EncodingCSV       = "csv"
+	EncodingJSONLines = "jsonl"
+	EncodingParquet   = "parquet"
+)
+
+var Encodings = []string{EncodingCSV, EncodingJSONLines, EncodingParquet}
+
+// Encode writes the rows of the format in the encoding, see ToCsv, ToJSONLines and ToParquet
+func Encode(rows []parsers.CsvRow, headers []string, parserKey string, encoding string) (bytes.Buffer, error) {
+	switch encoding {
+	case EncodingJSONLines:
+		return ToJSONLines(rows, headers)
+	case EncodingParquet:
+		columns := parquet.StringColumns(headers)
+		if parquetParser, ok := GetParser(parserKey).(parsers.ParquetParser); ok {
+			columns = parquetParser.GetParquetColumns()
+		}
+		return ToParquet(rows, columns)
+	default:
+		return ToCsv(rows, headers)
+	}
+}
+
 // Create the CSV and write it to byte buffer
 func ToCsv(rows []parsers.CsvRow, headers []string) (bytes.Buffer, error) {
 	var b bytes.Buffer
@@ -37,3 +64,82 @@ func ToCsv(rows []parsers.CsvRow, headers []string) (bytes.Buffer, error) {
 
 	return b, nil
 }
+
+// ToJSONLines writes a JSON object per row with the fields in the order of the headers, the values are the same strings as
+// in the CSV
+func ToJSONLines(rows []parsers.CsvRow, headers []string) (bytes.Buffer, error) {
+	var b bytes.Buffer
+
+	for _, row := range rows {
+		values := row.GetRowForCsv()
+
+		b.WriteByte('{')
+		for i, header := range headers {
+			value := ""
+			if i < len(values) {
+				value = values[i]
+			}
+
+			key, err := json.Marshal(header)
+			if err != nil {
+				config.Log.Error("Error writing row to JSON lines", err)
+				return b, err
+			}
+			encodedValue, err := json.Marshal(value)
+			if err != nil {
+				config.Log.Error("Error writing row to JSON lines", err)
+				return b, err
+			}
+
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.Write(key)
+			b.WriteByte(':')
+			b.Write(encodedValue)
+		}
+		b.WriteString("}\n")
+	}
+
+	return b, nil
+}
+
+// ToParquet writes the rows as a Parquet file, the values are the same strings as in the CSV converted to the type of their
+// column
+func ToParquet(rows []parsers.CsvRow, columns []parquet.Column) (bytes.Buffer, error) {
+	var b bytes.Buffer
+
+	values := make([][]string, len(rows))
+	for i, row := range rows {
+		values[i] = make([]string, len(columns))
+		copy(values[i], row.GetRowForCsv())
+	}
+
+	if err := parquet.Write(&b, columns, values); err != nil {
+		config.Log.Error("Error writing Parquet", err)
+		return b, err
+	}
+
+	return b, nil
+}
+
+// ToText writes the rows of the plain text formats, the headers are lines written before the rows and every row is a single
+// column with the lines of the row, separated from the other rows by an empty line
+func ToText(rows []parsers.CsvRow, headers []string) bytes.Buffer {
+	var b bytes.Buffer
+
+	for _, header := range headers {
+		b.WriteString(header)
+		b.WriteByte('\n')
+	}
+
+	for _, row := range rows {
+		b.WriteByte('\n')
+		for _, column := range row.GetRowForCsv() {
+			b.WriteString(column)
+			b.WriteByte('\n')
+		}
+	}
+
+	return b
+}
@@ -0,0 +1,32 @@
+package db
+
+import (
+	b64 "encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// linkAuctionBundledTxs points the messages of every bundled tx at the auction bid message that included them.
+// The auction module reports the bundled tx hashes as lowercase hex, while txs are stored with the hash format of the
+// indexing source (uppercase hex from the tx search, base64 from block results), so every format is matched.
+func linkAuctionBundledTxs(db *gorm.DB, bidMessageID uint, bundledTxHashes []string) error {
+	var hashes []string
+	for _, txHash := range bundledTxHashes {
+		hashBytes, err := hex.DecodeString(txHash)
+		if err != nil {
+			continue
+		}
+
+		hashes = append(hashes, strings.ToUpper(txHash), strings.ToLower(txHash), b64.StdEncoding.EncodeToString(hashBytes))
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	return db.Model(&Message{}).
+		Where("tx_id IN (?)", db.Model(&Tx{}).Select("id").Where("hash IN ?", hashes)).
+		Update("auction_bid_message_id", bidMessageID).Error
+}
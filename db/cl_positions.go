@@ -0,0 +1,269 @@
+package db
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// Actions stored on CLPositionEvent, these match the concentrated liquidity position ledger actions
+const (
+	CLPositionActionCreate        = "create"
+	CLPositionActionWithdraw      = "withdraw"
+	CLPositionActionSpreadRewards = "spread_rewards"
+	CLPositionActionIncentives    = "incentives"
+	CLPositionActionTransfer      = "transfer"
+)
+
+func indexCLPositionEvent(db *gorm.DB, dbChainID uint, messageID uint, clPositionEvent CLPositionEventDBWrapper) error {
+	if err := db.Where(&clPositionEvent.OwnerAddress).FirstOrCreate(&clPositionEvent.OwnerAddress).Error; err != nil {
+		return err
+	}
+
+	position := CLPosition{BlockchainID: dbChainID, PositionID: clPositionEvent.Position.PositionID}
+	if err := db.Where(&position).FirstOrCreate(&position).Error; err != nil {
+		return err
+	}
+
+	positionUpdates := map[string]interface{}{}
+
+	// Only creates and withdrawals carry the position metadata
+	if clPositionEvent.Position.PoolID != 0 {
+		positionUpdates["pool_id"] = clPositionEvent.Position.PoolID
+		positionUpdates["lower_tick"] = clPositionEvent.Position.LowerTick
+		positionUpdates["upper_tick"] = clPositionEvent.Position.UpperTick
+	}
+
+	// Ownership is assigned on first sight of the position and moves with every transfer
+	if position.OwnerAddressID == nil || clPositionEvent.Event.Action == CLPositionActionCreate {
+		positionUpdates["owner_address_id"] = clPositionEvent.OwnerAddress.ID
+	}
+
+	event := clPositionEvent.Event
+	event.CLPositionID = position.ID
+	event.MessageID = messageID
+	event.AddressID = clPositionEvent.OwnerAddress.ID
+
+	if clPositionEvent.NewOwnerAddress.Address != "" {
+		if err := db.Where(&clPositionEvent.NewOwnerAddress).FirstOrCreate(&clPositionEvent.NewOwnerAddress).Error; err != nil {
+			return err
+		}
+		event.NewOwnerAddressID = &clPositionEvent.NewOwnerAddress.ID
+		positionUpdates["owner_address_id"] = clPositionEvent.NewOwnerAddress.ID
+	}
+
+	if len(positionUpdates) > 0 {
+		if err := db.Model(&position).Updates(positionUpdates).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := db.Where(CLPositionEvent{CLPositionID: event.CLPositionID, MessageID: event.MessageID, Action: event.Action}).
+		Assign(CLPositionEvent{AddressID: event.AddressID, NewOwnerAddressID: event.NewOwnerAddressID, Liquidity: event.Liquidity}).
+		FirstOrCreate(&event).Error; err != nil {
+		return err
+	}
+
+	for _, amountL := range clPositionEvent.Amounts {
+		amount := amountL
+		amountOnly := CLPositionEventAmount{
+			CLPositionEventID: event.ID,
+			Amount:            amount.Amount,
+			DenominationID:    amount.Denomination.ID,
+		}
+
+		if err := db.Where(CLPositionEventAmount{CLPositionEventID: amountOnly.CLPositionEventID, DenominationID: amountOnly.DenominationID}).
+			Assign(CLPositionEventAmount{Amount: amountOnly.Amount}).
+			FirstOrCreate(&amountOnly).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetCLPositions returns every position the address has owned or interacted with, along with the full position history.
+func GetCLPositions(address string, db *gorm.DB) ([]CLPosition, []CLPositionEvent, error) {
+	var positions []CLPosition
+
+	result := db.Where("cl_positions.id IN (?)",
+		db.Table("cl_position_events").Select("cl_position_events.cl_position_id").
+			Joins("JOIN addresses ON addresses.id = cl_position_events.address_id OR addresses.id = cl_position_events.new_owner_address_id").
+			Where("addresses.address = ?", address)).
+		Or("cl_positions.owner_address_id IN (?)", db.Table("addresses").Select("id").Where("address = ?", address)).
+		Preload("OwnerAddress").Preload("Chain").Find(&positions)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+
+	if len(positions) == 0 {
+		return positions, nil, nil
+	}
+
+	positionIDs := make([]uint, len(positions))
+	for i, position := range positions {
+		positionIDs[i] = position.ID
+	}
+
+	var events []CLPositionEvent
+	result = db.Where("cl_position_id IN ?", positionIDs).
+		Preload("Address").Preload("NewOwnerAddress").
+		Preload("Amounts").Preload("Amounts.Denomination").
+		Preload("Message").Preload("Message.MessageType").Preload("Message.Tx").Preload("Message.Tx.Block").
+		Find(&events)
+
+	return positions, events, result.Error
+}
+
+// CLPositionSummary is the history of a single concentrated liquidity position with per denom totals (in base units).
+type CLPositionSummary struct {
+	ChainID       string
+	PositionID    uint64
+	PoolID        uint64
+	LowerTick     int64
+	UpperTick     int64
+	Owner         string
+	Open          bool
+	Liquidity     decimal.Decimal
+	Deposited     map[string]decimal.Decimal
+	Withdrawn     map[string]decimal.Decimal
+	SpreadRewards map[string]decimal.Decimal
+	Incentives    map[string]decimal.Decimal
+	// Withdrawn + SpreadRewards + Incentives - Deposited, per denom
+	Net         map[string]decimal.Decimal
+	Withdrawals []CLPositionWithdrawal
+	Transfers   []CLPositionTransfer
+}
+
+// CLPositionWithdrawal pairs a withdrawal with the share of the original deposits it removed from the position
+type CLPositionWithdrawal struct {
+	Timestamp time.Time
+	TxHash    string
+	Liquidity decimal.Decimal
+	Tokens    map[string]decimal.Decimal
+	CostBasis map[string]decimal.Decimal
+}
+
+type CLPositionTransfer struct {
+	Timestamp time.Time
+	TxHash    string
+	From      string
+	To        string
+}
+
+func GetCLPositionSummaries(address string, db *gorm.DB) ([]CLPositionSummary, error) {
+	positions, events, err := GetCLPositions(address, db)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsByPosition := make(map[uint][]CLPositionEvent)
+	for _, event := range events {
+		eventsByPosition[event.CLPositionID] = append(eventsByPosition[event.CLPositionID], event)
+	}
+
+	summaries := make([]CLPositionSummary, 0, len(positions))
+	for _, position := range positions {
+		summaries = append(summaries, SummarizeCLPosition(position, eventsByPosition[position.ID]))
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].PositionID < summaries[j].PositionID
+	})
+
+	return summaries, nil
+}
+
+// SummarizeCLPosition replays the position events in block order. Each withdrawal is assigned the share of the
+// remaining deposits proportional to the share of the open liquidity it removed.
+func SummarizeCLPosition(position CLPosition, events []CLPositionEvent) CLPositionSummary {
+	summary := CLPositionSummary{
+		ChainID:       position.Chain.ChainID,
+		PositionID:    position.PositionID,
+		PoolID:        position.PoolID,
+		LowerTick:     position.LowerTick,
+		UpperTick:     position.UpperTick,
+		Owner:         position.OwnerAddress.Address,
+		Liquidity:     decimal.Zero,
+		Deposited:     map[string]decimal.Decimal{},
+		Withdrawn:     map[string]decimal.Decimal{},
+		SpreadRewards: map[string]decimal.Decimal{},
+		Incentives:    map[string]decimal.Decimal{},
+		Net:           map[string]decimal.Decimal{},
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Message.Tx.Block.Height != events[j].Message.Tx.Block.Height {
+			return events[i].Message.Tx.Block.Height < events[j].Message.Tx.Block.Height
+		}
+		return events[i].MessageID < events[j].MessageID
+	})
+
+	remainingBasis := map[string]decimal.Decimal{}
+
+	for _, event := range events {
+		tokens := map[string]decimal.Decimal{}
+		for _, amount := range event.Amounts {
+			tokens[amount.Denomination.Base] = tokens[amount.Denomination.Base].Add(amount.Amount)
+		}
+
+		switch event.Action {
+		case CLPositionActionCreate:
+			summary.Liquidity = summary.Liquidity.Add(event.Liquidity)
+			addDenomAmounts(summary.Deposited, tokens)
+			addDenomAmounts(remainingBasis, tokens)
+		case CLPositionActionWithdraw:
+			removed := event.Liquidity.Abs()
+			costBasis := map[string]decimal.Decimal{}
+
+			if summary.Liquidity.IsPositive() {
+				share := decimal.Min(removed.Div(summary.Liquidity), decimal.NewFromInt(1))
+				for denom, basis := range remainingBasis {
+					withdrawnBasis := basis.Mul(share).Round(0)
+					costBasis[denom] = withdrawnBasis
+					remainingBasis[denom] = basis.Sub(withdrawnBasis)
+				}
+			}
+
+			summary.Liquidity = summary.Liquidity.Sub(removed)
+			addDenomAmounts(summary.Withdrawn, tokens)
+			summary.Withdrawals = append(summary.Withdrawals, CLPositionWithdrawal{
+				Timestamp: event.Message.Tx.Block.TimeStamp,
+				TxHash:    event.Message.Tx.Hash,
+				Liquidity: removed,
+				Tokens:    tokens,
+				CostBasis: costBasis,
+			})
+		case CLPositionActionSpreadRewards:
+			addDenomAmounts(summary.SpreadRewards, tokens)
+		case CLPositionActionIncentives:
+			addDenomAmounts(summary.Incentives, tokens)
+		case CLPositionActionTransfer:
+			summary.Transfers = append(summary.Transfers, CLPositionTransfer{
+				Timestamp: event.Message.Tx.Block.TimeStamp,
+				TxHash:    event.Message.Tx.Hash,
+				From:      event.Address.Address,
+				To:        event.NewOwnerAddress.Address,
+			})
+		}
+	}
+
+	summary.Open = summary.Liquidity.IsPositive()
+
+	addDenomAmounts(summary.Net, summary.Withdrawn)
+	addDenomAmounts(summary.Net, summary.SpreadRewards)
+	addDenomAmounts(summary.Net, summary.Incentives)
+	for denom, amount := range summary.Deposited {
+		summary.Net[denom] = summary.Net[denom].Sub(amount)
+	}
+
+	return summary
+}
+
+func addDenomAmounts(totals map[string]decimal.Decimal, amounts map[string]decimal.Decimal) {
+	for denom, amount := range amounts {
+		totals[denom] = totals[denom].Add(amount)
+	}
+}
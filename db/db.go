@@ -44,7 +44,7 @@ func PostgresDbConnectLogInfo(host string, port string, database string, user st
 
 // MigrateModels runs the gorm automigrations with all the db models. This will migrate as needed and do nothing if nothing has changed.
 func MigrateModels(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
 		&Block{},
 		&FailedBlock{},
 		&FailedEventBlock{},
@@ -60,7 +60,35 @@ func MigrateModels(db *gorm.DB) error {
 		&DenomUnit{},
 		&IBCDenom{},
 		&Epoch{},
+		&CLPosition{},
+		&CLPositionEvent{},
+		&CLPositionEventAmount{},
+		&NFT{},
+		&NFTTransfer{},
+		&VestingAccount{},
+		&VestingAmount{},
+		&VestingPeriod{},
+		&GovDeposit{},
+		&GovProposalBurn{},
+		&Validator{},
+		&ValidatorSlash{},
+		&DelegationChange{},
+		&WithdrawAddress{},
+		&Price{},
+		&RedemptionRate{},
 	)
+	if err != nil {
+		return err
+	}
+
+	// NFT transfers used to be unique per message, NFT and action, which collapsed the movements of an NFT through multiple hops
+	if db.Migrator().HasIndex(&NFTTransfer{}, "idx_nfttransfer_msg_nft_action") {
+		if err := db.Migrator().DropIndex(&NFTTransfer{}, "idx_nfttransfer_msg_nft_action"); err != nil {
+			return err
+		}
+	}
+
+	return backfillEVMAddresses(db)
 }
 
 func GetFailedBlocks(db *gorm.DB, chainID uint) []FailedBlock {
@@ -108,6 +136,23 @@ func GetHighestIndexedBlock(db *gorm.DB, chainID uint) Block {
 	return block
 }
 
+// GetLowestIndexedBlock returns the first indexed block of the chain, the block is empty when no block was indexed
+func GetLowestIndexedBlock(db *gorm.DB, chainID uint) (Block, error) {
+	var block Block
+	result := db.Where("blockchain_id = ? AND indexed = true AND time_stamp != '0001-01-01T00:00:00.000Z'", chainID).Order("height asc").Limit(1).Find(&block)
+	return block, result.Error
+}
+
+// GetBlockAtTime returns the last indexed block of the chain with a timestamp at or before the given time, the chain state
+// at that block is the state at the given time. The block is empty when no block was indexed before the time.
+func GetBlockAtTime(db *gorm.DB, chainID string, blockTime time.Time) (Block, error) {
+	var block Block
+	result := db.Joins("JOIN chains ON chains.id = blocks.blockchain_id").
+		Where("chains.chain_id = ? AND blocks.indexed = true AND blocks.time_stamp != '0001-01-01T00:00:00.000Z' AND blocks.time_stamp <= ?", chainID, blockTime).
+		Order("blocks.height desc").Limit(1).Find(&block)
+	return block, result.Error
+}
+
 func UpsertFailedBlock(db *gorm.DB, blockHeight int64, chainID string, chainName string) error {
 	return db.Transaction(func(dbTransaction *gorm.DB) error {
 		failedBlock := FailedBlock{Height: blockHeight, Chain: Chain{ChainID: chainID, Name: chainName}}
@@ -167,6 +212,9 @@ func IndexNewBlock(db *gorm.DB, blockHeight int64, blockTime time.Time, txs []Tx
 			return err
 		}
 
+		// Bundled txs are indexed after their auction bid in the same block, they are linked once every tx is stored
+		auctionBundles := map[uint][]string{}
+
 		for _, tx := range txs {
 			transaction := tx
 			txOnly := Tx{
@@ -249,15 +297,19 @@ func IndexNewBlock(db *gorm.DB, blockHeight int64, blockTime time.Time, txs []Tx
 					return err
 				}
 
-				for _, taxableTxL := range message.TaxableTxs {
+				// The IDs of the stored taxable txs in message order, NFT sales link their payment by index (0 when it was skipped)
+				taxableTxIDs := make([]uint, len(message.TaxableTxs))
+
+				for i, taxableTxL := range message.TaxableTxs {
 					taxableTx := taxableTxL
 					if len(taxableTx.SenderAddress.Address) > maxAddrLen || len(taxableTx.ReceiverAddress.Address) > maxAddrLen {
 						continue
 					}
 					taxableTxOnly := TaxableTransaction{
-						MessageID:      msgOnly.ID,
-						AmountSent:     taxableTx.TaxableTx.AmountSent,
-						AmountReceived: taxableTx.TaxableTx.AmountReceived,
+						MessageID:        msgOnly.ID,
+						AmountSent:       taxableTx.TaxableTx.AmountSent,
+						AmountReceived:   taxableTx.TaxableTx.AmountReceived,
+						ValidatorAddress: taxableTx.TaxableTx.ValidatorAddress,
 					}
 					if taxableTx.TaxableTx.DenominationSent.ID != 0 {
 						taxableTxOnly.DenominationSentID = &taxableTx.TaxableTx.DenominationSent.ID
@@ -283,6 +335,14 @@ func IndexNewBlock(db *gorm.DB, blockHeight int64, blockTime time.Time, txs []Tx
 						taxableTxOnly.ReceiverAddressID = &taxableTx.ReceiverAddress.ID
 					}
 
+					if taxableTx.EarnerAddress.Address != "" && len(taxableTx.EarnerAddress.Address) <= maxAddrLen {
+						if err := dbTransaction.Where(&taxableTx.EarnerAddress).FirstOrCreate(&taxableTx.EarnerAddress).Error; err != nil {
+							config.Log.Errorf("Error getting/creating earner address for msg %v of tx hash %v. Err: %v", message.Message.MessageIndex, txOnly.Hash, err)
+							return err
+						}
+						taxableTxOnly.EarnerAddressID = &taxableTx.EarnerAddress.ID
+					}
+
 					// It is possible to have more than 1 taxable TX for a single msg. In most cases it should only be 1 or 2, but
 					// more is possible. Keying off of msg ID and amount may be sufficient....
 
@@ -302,6 +362,7 @@ func IndexNewBlock(db *gorm.DB, blockHeight int64, blockTime time.Time, txs []Tx
 							config.Log.Error("Error creating taxable transaction.", res.Error)
 							return res.Error
 						}
+						taxableTxIDs[i] = taxableTxOnly.ID
 					} else {
 						// Force update with new data
 						res := dbTransaction.Model(&foundRecord).Updates(&taxableTxOnly)
@@ -309,8 +370,74 @@ func IndexNewBlock(db *gorm.DB, blockHeight int64, blockTime time.Time, txs []Tx
 							config.Log.Error("Error updating taxable transaction.", res.Error)
 							return res.Error
 						}
+						taxableTxIDs[i] = foundRecord.ID
+					}
+				}
+
+				for _, clPositionEvent := range message.CLPositionEvents {
+					if err := indexCLPositionEvent(dbTransaction, dbChainID, msgOnly.ID, clPositionEvent); err != nil {
+						config.Log.Errorf("Error indexing CL position event for msg %v of tx hash %v. Err: %v", message.Message.MessageIndex, txOnly.Hash, err)
+						return err
+					}
+				}
+
+				for _, nftTransfer := range message.NFTTransfers {
+					if err := indexNFTTransfer(dbTransaction, dbChainID, msgOnly.ID, nftTransfer, taxableTxIDs); err != nil {
+						config.Log.Errorf("Error indexing NFT transfer for msg %v of tx hash %v. Err: %v", message.Message.MessageIndex, txOnly.Hash, err)
+						return err
+					}
+				}
+
+				if message.VestingAccount != nil {
+					if err := indexVestingAccount(dbTransaction, dbChainID, msgOnly.ID, *message.VestingAccount); err != nil {
+						config.Log.Errorf("Error indexing vesting account for msg %v of tx hash %v. Err: %v", message.Message.MessageIndex, txOnly.Hash, err)
+						return err
+					}
+				}
+
+				if message.GovDeposit != nil {
+					if err := indexGovDeposit(dbTransaction, dbChainID, msgOnly.ID, *message.GovDeposit); err != nil {
+						config.Log.Errorf("Error indexing gov deposit for msg %v of tx hash %v. Err: %v", message.Message.MessageIndex, txOnly.Hash, err)
+						return err
+					}
+				}
+
+				for _, delegationChange := range message.DelegationChanges {
+					if err := indexDelegationChange(dbTransaction, dbChainID, msgOnly.ID, blockOnly.Height, delegationChange); err != nil {
+						config.Log.Errorf("Error indexing delegation change for msg %v of tx hash %v. Err: %v", message.Message.MessageIndex, txOnly.Hash, err)
+						return err
+					}
+				}
+
+				if message.Validator != nil {
+					if err := indexValidator(dbTransaction, dbChainID, *message.Validator); err != nil {
+						config.Log.Errorf("Error indexing validator for msg %v of tx hash %v. Err: %v", message.Message.MessageIndex, txOnly.Hash, err)
+						return err
 					}
 				}
+
+				if message.WithdrawAddress != nil {
+					if err := indexWithdrawAddress(dbTransaction, dbChainID, msgOnly.ID, blockOnly.Height, *message.WithdrawAddress); err != nil {
+						config.Log.Errorf("Error indexing withdraw address for msg %v of tx hash %v. Err: %v", message.Message.MessageIndex, txOnly.Hash, err)
+						return err
+					}
+				}
+
+				if len(message.BundledTxHashes) > 0 {
+					auctionBundles[msgOnly.ID] = message.BundledTxHashes
+				}
+			}
+
+			if err := indexTxTaxableEvents(dbTransaction, blockOnly.ID, txOnly.Hash, transaction.TaxableEvents); err != nil {
+				config.Log.Errorf("Error indexing taxable events of tx hash %v. Err: %v", txOnly.Hash, err)
+				return err
+			}
+		}
+
+		for bidMessageID, bundledTxHashes := range auctionBundles {
+			if err := linkAuctionBundledTxs(dbTransaction, bidMessageID, bundledTxHashes); err != nil {
+				config.Log.Error("Error linking auction bid bundled txs.", err)
+				return err
 			}
 		}
 
@@ -9,6 +9,8 @@ import (
 	"sync"
 
 	"github.com/DefiantLabs/cosmos-tax-cli/chainregistry"
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -59,6 +61,53 @@ func GetIBCDenom(denomTrace string) (IBCDenom, error) {
 	return IBCDenom{}, fmt.Errorf("no IBC denom found for the specified denom trace %s", denomTrace)
 }
 
+// resolveDenom returns the denom with the base, IBC denom traces are resolved to the denom of their base denom
+func resolveDenom(base string) (Denom, error) {
+	var denom Denom
+
+	// if this is an ibc denom trace, get the ibc denom then use the base denom to get the Denom from the db
+	if strings.HasPrefix(base, "ibc/") {
+		ibcDenom, err := GetIBCDenom(base)
+		if err != nil {
+			config.Log.Warnf("IBC Denom lookup failed for %s, err: %v", base, err)
+		} else {
+			denom, err = GetDenomForBase(ibcDenom.BaseDenom)
+			if err != nil {
+				config.Log.Warnf("Denom lookup failed for IBC base denom %s, err: %v", ibcDenom.BaseDenom, err)
+				return Denom{Base: ibcDenom.BaseDenom}, err
+			}
+		}
+	}
+
+	// if this is not an ibc denom trace or there was an issue querying the ibc denom trace in the other table,
+	// attempt to look up this denom in the regular Denom table
+	if denom.Base == "" {
+		var err error
+		denom, err = GetDenomForBase(base)
+		if err != nil {
+			return Denom{Base: base}, err
+		}
+	}
+
+	return denom, nil
+}
+
+// GetOrAddDenom returns the denom with the base like resolveDenom, the denom is inserted as UNKNOWN when it is not known yet
+func GetOrAddDenom(db *gorm.DB, base string) (Denom, error) {
+	denom, err := resolveDenom(base)
+	if err == nil {
+		return denom, nil
+	}
+
+	config.Log.Warnf("Denom lookup failed. Will be inserted as UNKNOWN. Denom: %v. Err: %v", denom.Base, err)
+	denom, err = AddUnknownDenom(db, denom.Base)
+	if err != nil {
+		return Denom{}, fmt.Errorf("error adding missing denom %s: %w", base, err)
+	}
+
+	return denom, nil
+}
+
 func GetDenomUnitForDenom(denom Denom) (DenomUnit, error) {
 	for _, denomUnit := range CachedDenomUnits {
 		if denomUnit.DenomID == denom.ID {
@@ -115,10 +164,35 @@ func GetHighestDenomUnit(denomUnit DenomUnit, denomUnits []DenomUnit) (DenomUnit
 func ConvertUnits(amount *big.Int, denom Denom) (*big.Float, string, error) {
 	convertedAmount := new(big.Float).SetInt(amount)
 
+	exponent, symbol, err := GetDisplayExponent(denom)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// We were converting the units to big.Int, which would cause a Token to appear 0 if the conversion resulted in an amount < 1
+	power := math.Pow(10, float64(exponent))
+	dividedAmount := new(big.Float).Quo(convertedAmount, new(big.Float).SetFloat64(power))
+
+	return dividedAmount, symbol, nil
+}
+
+// ConvertUnitsExact converts an amount in the base denom to the display units of the denom without the float rounding of
+// ConvertUnits
+func ConvertUnitsExact(amount decimal.Decimal, denom Denom) (decimal.Decimal, string, error) {
+	exponent, symbol, err := GetDisplayExponent(denom)
+	if err != nil {
+		return decimal.Zero, "", err
+	}
+
+	return amount.Shift(-int32(exponent)), symbol, nil
+}
+
+// GetDisplayExponent returns the exponent between the base denom and the display units of the denom, and the symbol of the
+// display units
+func GetDisplayExponent(denom Denom) (uint, string, error) {
 	// Handle gamm special case
 	if strings.HasPrefix(denom.Base, "gamm/pool/") {
-		power := math.Pow(10, float64(18))
-		return new(big.Float).Quo(convertedAmount, new(big.Float).SetFloat64(power)), denom.Base, nil
+		return 18, denom.Base, nil
 	}
 
 	// Try chainregistry asset lists first
@@ -151,13 +225,13 @@ func ConvertUnits(amount *big.Int, denom Denom) (*big.Float, string, error) {
 		denomUnit, err := GetBaseDenomUnitForDenom(denom)
 		if err != nil {
 			fmt.Println("Error getting denom unit for denom", denom)
-			return nil, "", fmt.Errorf("error getting denom unit for denom %+v", denom)
+			return 0, "", fmt.Errorf("error getting denom unit for denom %+v", denom)
 		}
 
 		highestDenomUnit, err := GetHighestDenomUnit(denomUnit, CachedDenomUnits)
 		if err != nil {
 			fmt.Println("Error getting highest denom unit for denom", denom)
-			return nil, "", fmt.Errorf("error getting highest denom unit for denom %+v", denom)
+			return 0, "", fmt.Errorf("error getting highest denom unit for denom %+v", denom)
 		}
 
 		symbol = denomUnit.Denom.Symbol
@@ -165,14 +239,11 @@ func ConvertUnits(amount *big.Int, denom Denom) (*big.Float, string, error) {
 		baseExponent = denomUnit.Exponent
 		highestExponentName = highestDenomUnit.Name
 	}
-	// We were converting the units to big.Int, which would cause a Token to appear 0 if the conversion resulted in an amount < 1
-	power := math.Pow(10, float64(highestExponent-baseExponent))
-	dividedAmount := new(big.Float).Quo(convertedAmount, new(big.Float).SetFloat64(power))
 	if symbol == "UNKNOWN" || symbol == "" {
 		symbol = highestExponentName
 	}
 
-	return dividedAmount, symbol, nil
+	return highestExponent - baseExponent, symbol, nil
 }
 
 // This function assumes that the denom to be added is the base denom
@@ -180,7 +251,12 @@ func ConvertUnits(amount *big.Int, denom Denom) (*big.Float, string, error) {
 // a transaction message and not found in our database during tx parsing
 // Creates a single denom and a single denom_unit that fits our DB structure, adds them to the DB
 func AddUnknownDenom(db *gorm.DB, denom string) (Denom, error) {
-	denomToAdd := Denom{Base: denom, Name: "UNKNOWN", Symbol: "UNKNOWN"}
+	return AddNamedDenom(db, denom, "UNKNOWN", "UNKNOWN")
+}
+
+// AddNamedDenom adds a base denom without metadata like AddUnknownDenom, under the name and symbol given by the caller
+func AddNamedDenom(db *gorm.DB, denom string, name string, symbol string) (Denom, error) {
+	denomToAdd := Denom{Base: denom, Name: name, Symbol: symbol}
 	singleDenomUnit := DenomUnit{Exponent: 0, Name: denom}
 	denomUnitsToAdd := [...]DenomUnitDBWrapper{{DenomUnit: singleDenomUnit}}
 
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"gorm.io/gorm"
+)
+
+func indexWithdrawAddress(db *gorm.DB, dbChainID uint, messageID uint, height int64, withdrawAddress WithdrawAddressDBWrapper) error {
+	if err := db.Where(&withdrawAddress.DelegatorAddress).FirstOrCreate(&withdrawAddress.DelegatorAddress).Error; err != nil {
+		return err
+	}
+
+	if err := db.Where(&withdrawAddress.WithdrawAddress).FirstOrCreate(&withdrawAddress.WithdrawAddress).Error; err != nil {
+		return err
+	}
+
+	change := WithdrawAddress{MessageID: messageID}
+	changeUpdates := WithdrawAddress{
+		BlockchainID:       dbChainID,
+		Height:             height,
+		DelegatorAddressID: withdrawAddress.DelegatorAddress.ID,
+		WithdrawAddressID:  withdrawAddress.WithdrawAddress.ID,
+	}
+
+	return db.Where(change).Assign(changeUpdates).FirstOrCreate(&change).Error
+}
@@ -13,10 +13,51 @@ import (
 	"gorm.io/gorm"
 )
 
+// The block event sources whose event hash includes the source and the proposal ID. The hash of the older sources is kept
+// as it was to match the events already indexed.
+var sourceHashedEventSources = map[uint]bool{
+	GovDepositRefund:            true,
+	StakingUnbondingComplete:    true,
+	StakingRedelegationComplete: true,
+}
+
 func IndexBlockEvents(db *gorm.DB, dryRun bool, blockHeight int64, blockTime time.Time, blockEvents []events.EventRelevantInformation, dbChainID string, dbChainName string, identifierLoggingString string) error {
 	dbEvents := []TaxableEvent{}
 
+	var attributedEvents, burns, slashes []events.EventRelevantInformation
 	for _, blockEvent := range blockEvents {
+		switch {
+		case blockEvent.EventSource == GovDepositBurn:
+			// Burns are split between the depositors when searched, the deposits may not be indexed yet
+			burns = append(burns, blockEvent)
+		case blockEvent.EventSource == StakingSlash && blockEvent.Slash != nil:
+			// Slashes are attributed to the delegators when searched, the staking messages may not be indexed yet
+			slashes = append(slashes, blockEvent)
+		default:
+			attributedEvents = append(attributedEvents, blockEvent)
+		}
+	}
+
+	if len(burns) != 0 && !dryRun {
+		if err := indexGovDepositBurns(db, blockHeight, blockTime, burns, dbChainID, dbChainName); err != nil {
+			config.Log.Error("Error storing burned gov deposits.", err)
+			return err
+		}
+	}
+
+	if len(slashes) != 0 && !dryRun {
+		if err := indexValidatorSlashes(db, blockHeight, blockTime, slashes, dbChainID, dbChainName); err != nil {
+			config.Log.Error("Error storing validator slashes.", err)
+			return err
+		}
+	}
+
+	for _, blockEvent := range attributedEvents {
+		// Events are never stored without an address
+		if blockEvent.Address == "" {
+			config.Log.Warnf("Skipping block event of source %d without an address at height %d", blockEvent.EventSource, blockHeight)
+			continue
+		}
 
 		denom, err := GetDenomForBase(blockEvent.Denomination)
 		if err != nil {
@@ -34,6 +75,11 @@ func IndexBlockEvents(db *gorm.DB, dryRun bool, blockHeight int64, blockTime tim
 		// WARN: The space in the amount/denom hash part is deliberate, it matches an old version of the hash to maintain backwards
 		// compatibility with an old version of the indexer and old indexed data
 		hashParts := fmt.Sprint(blockEvent.Address, blockHeight, fmt.Sprintf(" %v%s", blockEvent.Amount, blockEvent.Denomination))
+		// The sources added later also hash the source and the proposal, so e.g. a refund and an unbonding completion of the same
+		// amount for the same address in one block are separate events
+		if sourceHashedEventSources[blockEvent.EventSource] {
+			hashParts = fmt.Sprint(hashParts, " ", blockEvent.EventSource, " ", blockEvent.ProposalID)
+		}
 		hash.Write([]byte(hashParts))
 
 		evt := TaxableEvent{
@@ -43,6 +89,7 @@ func IndexBlockEvents(db *gorm.DB, dryRun bool, blockHeight int64, blockTime tim
 			Denomination: denom,
 			Block:        Block{Height: blockHeight, TimeStamp: blockTime, Chain: Chain{ChainID: dbChainID, Name: dbChainName}},
 			EventAddress: Address{Address: blockEvent.Address},
+			ProposalID:   blockEvent.ProposalID,
 		}
 		dbEvents = append(dbEvents, evt)
 
@@ -90,6 +137,35 @@ func IndexBlockEvents(db *gorm.DB, dryRun bool, blockHeight int64, blockTime tim
 	return nil
 }
 
+// indexTxTaxableEvents stores the taxable events that happened during a tx. The event hash includes the tx hash and the index of
+// the event, so the events cannot collide with block events or with each other (e.g. two equal hook claims in one tx).
+func indexTxTaxableEvents(db *gorm.DB, blockID uint, txHash string, taxableEvents []TaxableEvent) error {
+	for i, eventL := range taxableEvents {
+		event := eventL
+		if err := db.Where(&event.EventAddress).FirstOrCreate(&event.EventAddress).Error; err != nil {
+			return err
+		}
+
+		hash := sha256.New()
+		hash.Write([]byte(fmt.Sprint(txHash, i, event.Source, event.EventAddress.Address, fmt.Sprintf(" %v%s", event.Amount, event.Denomination.Base))))
+
+		eventOnly := TaxableEvent{
+			Source:         event.Source,
+			Amount:         event.Amount,
+			DenominationID: event.Denomination.ID,
+			AddressID:      event.EventAddress.ID,
+			EventHash:      fmt.Sprintf("%x", hash.Sum(nil)),
+			BlockID:        blockID,
+		}
+
+		if err := db.Where(TaxableEvent{EventHash: eventOnly.EventHash}).FirstOrCreate(&eventOnly).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func UpdateEpochIndexingStatus(db *gorm.DB, dryRun bool, epochNumber uint, epochIdentifier string, dbChainID string, dbChainName string) error {
 	if !dryRun {
 		epochToUpdate := Epoch{
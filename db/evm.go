@@ -0,0 +1,83 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/ethcrypto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BeforeCreate sets the 0x hex form of the address, every Address is created through gorm so both forms are always stored together
+func (address *Address) BeforeCreate(tx *gorm.DB) error {
+	if evmAddress, ok := ethcrypto.Bech32ToHex(address.Address); ok {
+		address.EVMAddress = strings.ToLower(evmAddress)
+	}
+	return nil
+}
+
+// backfillEVMAddresses sets the hex form of the addresses indexed before the EVMAddress column existed, their column is NULL.
+// Addresses without a hex form (e.g. 32 byte contract addresses) are set to an empty string like new addresses, so they are
+// only processed once.
+func backfillEVMAddresses(db *gorm.DB) error {
+	var backfilled int
+	var batch []Address
+	result := db.Where("evm_address IS NULL").FindInBatches(&batch, 10000, func(tx *gorm.DB, _ int) error {
+		for i := range batch {
+			batch[i].EVMAddress = ""
+			if evmAddress, ok := ethcrypto.Bech32ToHex(batch[i].Address); ok {
+				batch[i].EVMAddress = strings.ToLower(evmAddress)
+			}
+		}
+
+		backfilled += len(batch)
+		return db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"evm_address"}),
+		}).Create(&batch).Error
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if backfilled > 0 {
+		config.Log.Infof("Backfilled the EVM address of %d addresses", backfilled)
+	}
+
+	return nil
+}
+
+// ResolveEVMAddresses replaces 0x hex addresses with the Bech32 addresses indexed for the same account.
+// Other addresses, and hex addresses without an indexed Bech32 form, are returned as is.
+func ResolveEVMAddresses(addressList []string, db *gorm.DB) ([]string, error) {
+	var resolved []string
+	seen := make(map[string]bool)
+
+	for _, address := range addressList {
+		matches := []string{address}
+
+		if ethcrypto.IsHexAddress(address) {
+			var indexedAddresses []Address
+			if err := db.Where("evm_address = ?", strings.ToLower(address)).Find(&indexedAddresses).Error; err != nil {
+				return nil, err
+			}
+
+			if len(indexedAddresses) > 0 {
+				matches = matches[:0]
+				for _, indexedAddress := range indexedAddresses {
+					matches = append(matches, indexedAddress.Address)
+				}
+			}
+		}
+
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				resolved = append(resolved, match)
+			}
+		}
+	}
+
+	return resolved, nil
+}
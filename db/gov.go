@@ -0,0 +1,156 @@
+package db
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/events"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func indexGovDeposit(db *gorm.DB, dbChainID uint, messageID uint, govDeposit GovDepositDBWrapper) error {
+	if err := db.Where(&govDeposit.Depositor).FirstOrCreate(&govDeposit.Depositor).Error; err != nil {
+		return err
+	}
+
+	for _, depositL := range govDeposit.Deposits {
+		deposit := GovDeposit{MessageID: messageID, DenominationID: depositL.Denomination.ID}
+		depositUpdates := GovDeposit{
+			BlockchainID: dbChainID,
+			ProposalID:   depositL.ProposalID,
+			AddressID:    govDeposit.Depositor.ID,
+			Amount:       depositL.Amount,
+		}
+
+		if err := db.Where(deposit).Assign(depositUpdates).FirstOrCreate(&deposit).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexGovDepositBurns stores the burned deposits of the proposals that ended in the block, a burn of the same proposal and denom
+// is replaced
+func indexGovDepositBurns(db *gorm.DB, blockHeight int64, blockTime time.Time, burns []events.EventRelevantInformation, dbChainID string, dbChainName string) error {
+	chain := Chain{ChainID: dbChainID, Name: dbChainName}
+	if err := db.Where("chain_id = ?", dbChainID).FirstOrCreate(&chain).Error; err != nil {
+		return err
+	}
+
+	block := Block{Height: blockHeight, BlockchainID: chain.ID}
+	if err := db.Where(block).Attrs(Block{TimeStamp: blockTime}).FirstOrCreate(&block).Error; err != nil {
+		return err
+	}
+
+	govDepositBurns := make([]GovProposalBurn, 0, len(burns))
+	for _, burn := range burns {
+		denom, err := GetOrAddDenom(db, burn.Denomination)
+		if err != nil {
+			return err
+		}
+
+		govDepositBurns = append(govDepositBurns, GovProposalBurn{
+			BlockchainID:   chain.ID,
+			ProposalID:     burn.ProposalID,
+			BlockID:        block.ID,
+			Amount:         util.ToNumeric(burn.Amount),
+			DenominationID: denom.ID,
+		})
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "blockchain_id"}, {Name: "proposal_id"}, {Name: "denomination_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_id", "amount"}),
+	}).Create(&govDepositBurns).Error
+}
+
+// GetGovDepositBurns returns the share of the address in the burned deposits of the proposals it deposited to. A burn is split
+// between the indexed depositors of the proposal in proportion to their deposits of the burned denom.
+func GetGovDepositBurns(db *gorm.DB, address string) ([]TaxableEvent, error) {
+	var burns []GovProposalBurn
+	result := db.Where("EXISTS (SELECT 1 FROM gov_deposits JOIN addresses ON addresses.id = gov_deposits.address_id "+
+		"WHERE gov_deposits.blockchain_id = gov_proposal_burns.blockchain_id AND gov_deposits.proposal_id = gov_proposal_burns.proposal_id "+
+		"AND gov_deposits.denomination_id = gov_proposal_burns.denomination_id AND addresses.address = ?)", address).
+		Preload("Denomination").Preload("Block").Preload("Block.Chain").
+		Find(&burns)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var burnEvents []TaxableEvent
+	for _, burn := range burns {
+		var deposits []GovDeposit
+		result := db.Where("blockchain_id = ? AND proposal_id = ? AND denomination_id = ?", burn.BlockchainID, burn.ProposalID, burn.DenominationID).
+			Preload("Address").
+			Find(&deposits)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		amount, ok := splitGovDepositBurn(util.FromNumeric(burn.Amount), deposits)[address]
+		if !ok || amount.Sign() == 0 {
+			continue
+		}
+
+		hash := sha256.Sum256([]byte(fmt.Sprint(address, burn.Block.Height, " ", amount, burn.Denomination.Base, " ", GovDepositBurn, " ", burn.ProposalID)))
+		burnEvents = append(burnEvents, TaxableEvent{
+			Source:         GovDepositBurn,
+			Amount:         util.ToNumeric(amount),
+			DenominationID: burn.DenominationID,
+			Denomination:   burn.Denomination,
+			EventAddress:   Address{Address: address},
+			EventHash:      fmt.Sprintf("%x", hash),
+			BlockID:        burn.BlockID,
+			Block:          burn.Block,
+			ProposalID:     burn.ProposalID,
+		})
+	}
+
+	return burnEvents, nil
+}
+
+// splitGovDepositBurn splits a burned deposit between the depositors in proportion to their deposits. The deposits are only
+// known if the deposit txs were indexed, a burn that does not match them is still split proportionally.
+func splitGovDepositBurn(burned *big.Int, deposits []GovDeposit) map[string]*big.Int {
+	depositedByAddress := map[string]*big.Int{}
+	total := new(big.Int)
+	for _, deposit := range deposits {
+		amount := util.FromNumeric(deposit.Amount)
+		if _, ok := depositedByAddress[deposit.Address.Address]; !ok {
+			depositedByAddress[deposit.Address.Address] = new(big.Int)
+		}
+		depositedByAddress[deposit.Address.Address].Add(depositedByAddress[deposit.Address.Address], amount)
+		total.Add(total, amount)
+	}
+
+	if total.Sign() == 0 {
+		return nil
+	}
+
+	depositors := make([]string, 0, len(depositedByAddress))
+	for depositor := range depositedByAddress {
+		depositors = append(depositors, depositor)
+	}
+	sort.Strings(depositors)
+
+	shares := make(map[string]*big.Int, len(depositors))
+	remaining := new(big.Int).Set(burned)
+	for i, depositor := range depositors {
+		amount := new(big.Int).Set(remaining)
+		// The last depositor gets the rounding remainder
+		if i != len(depositors)-1 {
+			amount.Mul(burned, depositedByAddress[depositor]).Quo(amount, total)
+			remaining.Sub(remaining, amount)
+		}
+
+		shares[depositor] = amount
+	}
+
+	return shares
+}
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitGovDepositBurn(t *testing.T) {
+	deposits := []GovDeposit{
+		{Address: Address{Address: "cosmos1b"}, Amount: util.ToNumeric(big.NewInt(2))},
+		{Address: Address{Address: "cosmos1a"}, Amount: util.ToNumeric(big.NewInt(1))},
+		{Address: Address{Address: "cosmos1b"}, Amount: util.ToNumeric(big.NewInt(1))},
+	}
+
+	shares := splitGovDepositBurn(big.NewInt(10), deposits)
+	assert.Len(t, shares, 2)
+	assert.Equal(t, int64(2), shares["cosmos1a"].Int64())
+	assert.Equal(t, int64(8), shares["cosmos1b"].Int64(), "the last depositor gets the rounding remainder")
+
+	assert.Nil(t, splitGovDepositBurn(big.NewInt(10), nil), "burns without indexed deposits are not attributed")
+}
@@ -62,6 +62,8 @@ type Fee struct {
 type Address struct {
 	ID      uint
 	Address string `gorm:"uniqueIndex"`
+	// EVMAddress is the lowercase 0x hex form of the address bytes, so EVM chain accounts can be looked up by either form
+	EVMAddress string `gorm:"index"`
 }
 
 type MessageType struct {
@@ -76,6 +78,9 @@ type Message struct {
 	MessageTypeID uint `gorm:"foreignKey:MessageTypeID,index:idx_txid_typeid"`
 	MessageType   MessageType
 	MessageIndex  int
+	// Set on the messages of txs bundled in a Block SDK auction bid, points to the MsgAuctionBid message
+	AuctionBidMessageID *uint    `gorm:"index:idx_auction_bid_msg"`
+	AuctionBidMessage   *Message `gorm:"foreignKey:AuctionBidMessageID"`
 }
 
 const (
@@ -89,13 +94,24 @@ const (
 	TendermintLiquidityWithdrawCoinReceived
 	TendermintLiquidityWithdrawFee
 	OsmosisProtorevDeveloperRewardDistribution
+	// Airdrops claimed automatically by the claim module hooks during another message (e.g. on vote or delegate)
+	ClaimHookAirdrop
+	// Tokens unlocked by a vesting account, materialised from the vesting schedule
+	VestingAccountUnlock
+	// Gov proposal deposits refunded or burned by the gov EndBlocker when the deposit or voting period ends
+	GovDepositRefund
+	GovDepositBurn
+	// Unbondings and redelegations completed by the staking EndBlocker, and delegator losses from validator slashes
+	StakingUnbondingComplete
+	StakingRedelegationComplete
+	StakingSlash
 )
 
 // An event does not necessarily need to be part of a Transaction. For example, Osmosis rewards.
 // Events can happen on chain and generate tendermint ABCI events that do not show up in transactions.
 type TaxableEvent struct {
 	ID             uint
-	Source         uint            // This will indicate what type of event occurred on chain. Currently, only used for Osmosis rewards.
+	Source         uint            // This will indicate what type of event occurred on chain, e.g. Osmosis rewards or hook airdrop claims.
 	Amount         decimal.Decimal `gorm:"type:decimal(78,0);"` // 2^256 or 78 digits, cosmos Int can be up to this length
 	DenominationID uint
 	Denomination   Denom   `gorm:"foreignKey:DenominationID"`
@@ -104,6 +120,212 @@ type TaxableEvent struct {
 	EventHash      string  `gorm:"uniqueIndex:idx_teevthash"`
 	BlockID        uint    `gorm:"index:idx_teblkid"`
 	Block          Block   `gorm:"foreignKey:BlockID"`
+	// The gov proposal of deposit refunds and burns, 0 for the other sources
+	ProposalID uint64
+	// The heights of the blocks of the events merged into this one when the exports aggregate rewards per period
+	AggregatedHeights []int64 `gorm:"-"`
+}
+
+// A concentrated liquidity position, keyed by the chain's position ID. The owner is set on creation and follows transfers.
+type CLPosition struct {
+	ID             uint
+	BlockchainID   uint   `gorm:"uniqueIndex:chainclposition"`
+	Chain          Chain  `gorm:"foreignKey:BlockchainID"`
+	PositionID     uint64 `gorm:"uniqueIndex:chainclposition"`
+	PoolID         uint64 `gorm:"index:idx_clpos_pool"`
+	LowerTick      int64
+	UpperTick      int64
+	OwnerAddressID *uint   `gorm:"index:idx_clpos_owner"`
+	OwnerAddress   Address `gorm:"foreignKey:OwnerAddressID"`
+}
+
+func (CLPosition) TableName() string {
+	return "cl_positions"
+}
+
+// A single change to a concentrated liquidity position (create, withdraw, collected rewards or an ownership transfer).
+// Liquidity is the signed liquidity delta of the change, the tokens moved are stored per denom in CLPositionEventAmount.
+type CLPositionEvent struct {
+	ID                uint
+	CLPositionID      uint       `gorm:"uniqueIndex:idx_clposevt_msg_pos_action"`
+	CLPosition        CLPosition `gorm:"foreignKey:CLPositionID"`
+	MessageID         uint       `gorm:"uniqueIndex:idx_clposevt_msg_pos_action"`
+	Message           Message    `gorm:"foreignKey:MessageID"`
+	Action            string     `gorm:"uniqueIndex:idx_clposevt_msg_pos_action"`
+	AddressID         uint       `gorm:"index:idx_clposevt_addr"`
+	Address           Address    `gorm:"foreignKey:AddressID"`
+	NewOwnerAddressID *uint
+	NewOwnerAddress   Address                 `gorm:"foreignKey:NewOwnerAddressID"`
+	Liquidity         decimal.Decimal         `gorm:"type:decimal(78,18);"`
+	Amounts           []CLPositionEventAmount `gorm:"foreignKey:CLPositionEventID"`
+}
+
+type CLPositionEventAmount struct {
+	ID                uint
+	CLPositionEventID uint            `gorm:"uniqueIndex:idx_clposevtamt_denom"`
+	Amount            decimal.Decimal `gorm:"type:decimal(78,0);"`
+	DenominationID    uint            `gorm:"uniqueIndex:idx_clposevtamt_denom"`
+	Denomination      Denom           `gorm:"foreignKey:DenominationID"`
+}
+
+// A non-fungible token, keyed by the class (x/nft class, CW721 collection contract or ICS-721 class trace) and token ID.
+// The owner follows the transfers and is cleared when the NFT is burned or sent to another chain.
+type NFT struct {
+	ID             uint
+	BlockchainID   uint    `gorm:"uniqueIndex:idx_nft_chain_class_token"`
+	Chain          Chain   `gorm:"foreignKey:BlockchainID"`
+	ClassID        string  `gorm:"uniqueIndex:idx_nft_chain_class_token"`
+	TokenID        string  `gorm:"uniqueIndex:idx_nft_chain_class_token"`
+	OwnerAddressID *uint   `gorm:"index:idx_nft_owner"`
+	OwnerAddress   Address `gorm:"foreignKey:OwnerAddressID"`
+}
+
+func (NFT) TableName() string {
+	return "nfts"
+}
+
+// A single movement of an NFT (mint, transfer, burn or IBC transfer). Sales link the fungible payment of the same message.
+// The event index is the position of the movement among the NFT movements of the message, an NFT can move more than once.
+type NFTTransfer struct {
+	ID                 uint
+	NFTID              uint    `gorm:"uniqueIndex:idx_nfttransfer_msg_nft_event"`
+	NFT                NFT     `gorm:"foreignKey:NFTID"`
+	MessageID          uint    `gorm:"uniqueIndex:idx_nfttransfer_msg_nft_event"`
+	Message            Message `gorm:"foreignKey:MessageID"`
+	EventIndex         int     `gorm:"uniqueIndex:idx_nfttransfer_msg_nft_event"`
+	Action             string
+	SenderAddressID    *uint              `gorm:"index:idx_nfttransfer_sender"`
+	SenderAddress      Address            `gorm:"foreignKey:SenderAddressID"`
+	ReceiverAddressID  *uint              `gorm:"index:idx_nfttransfer_receiver"`
+	ReceiverAddress    Address            `gorm:"foreignKey:ReceiverAddressID"`
+	PaymentTaxableTxID *uint              `gorm:"index:idx_nfttransfer_payment"`
+	PaymentTaxableTx   TaxableTransaction `gorm:"foreignKey:PaymentTaxableTxID"`
+}
+
+// A vesting account created on chain (continuous, delayed, periodic or permanently locked) with its vesting schedule.
+// The original vesting amounts are stored per denom in VestingAmount, periodic accounts store their periods in VestingPeriod.
+type VestingAccount struct {
+	ID              uint
+	BlockchainID    uint    `gorm:"index:idx_vesting_chain"`
+	Chain           Chain   `gorm:"foreignKey:BlockchainID"`
+	MessageID       uint    `gorm:"uniqueIndex:idx_vesting_msg"`
+	Message         Message `gorm:"foreignKey:MessageID"`
+	AddressID       uint    `gorm:"index:idx_vesting_addr"`
+	Address         Address `gorm:"foreignKey:AddressID"`
+	FunderAddressID *uint
+	FunderAddress   Address `gorm:"foreignKey:FunderAddressID"`
+	Type            string
+	StartTime       time.Time
+	EndTime         time.Time
+	Amounts         []VestingAmount `gorm:"foreignKey:VestingAccountID"`
+	Periods         []VestingPeriod `gorm:"foreignKey:VestingAccountID"`
+}
+
+type VestingAmount struct {
+	ID               uint
+	VestingAccountID uint            `gorm:"uniqueIndex:idx_vestingamt_denom"`
+	Amount           decimal.Decimal `gorm:"type:decimal(78,0);"`
+	DenominationID   uint            `gorm:"uniqueIndex:idx_vestingamt_denom"`
+	Denomination     Denom           `gorm:"foreignKey:DenominationID"`
+}
+
+// A single period of a periodic vesting account, Length is the duration in seconds since the end of the previous period
+type VestingPeriod struct {
+	ID               uint
+	VestingAccountID uint `gorm:"uniqueIndex:idx_vestingperiod_denom"`
+	PeriodIndex      int  `gorm:"uniqueIndex:idx_vestingperiod_denom"`
+	Length           int64
+	Amount           decimal.Decimal `gorm:"type:decimal(78,0);"`
+	DenominationID   uint            `gorm:"uniqueIndex:idx_vestingperiod_denom"`
+	Denomination     Denom           `gorm:"foreignKey:DenominationID"`
+}
+
+// A deposit made to a gov proposal (initial or additional), used to attribute the burned deposits of the proposal to the depositors
+type GovDeposit struct {
+	ID             uint
+	BlockchainID   uint            `gorm:"index:idx_govdeposit_proposal"`
+	Chain          Chain           `gorm:"foreignKey:BlockchainID"`
+	ProposalID     uint64          `gorm:"index:idx_govdeposit_proposal"`
+	MessageID      uint            `gorm:"uniqueIndex:idx_govdeposit_msg_denom"`
+	Message        Message         `gorm:"foreignKey:MessageID"`
+	AddressID      uint            `gorm:"index:idx_govdeposit_addr"`
+	Address        Address         `gorm:"foreignKey:AddressID"`
+	Amount         decimal.Decimal `gorm:"type:decimal(78,0);"`
+	DenominationID uint            `gorm:"uniqueIndex:idx_govdeposit_msg_denom"`
+	Denomination   Denom           `gorm:"foreignKey:DenominationID"`
+}
+
+// The deposits of a gov proposal burned by the gov EndBlocker. The burn has no depositor, it is split between the indexed depositors
+// of the proposal when the taxable events of an address are searched.
+type GovProposalBurn struct {
+	ID             uint
+	BlockchainID   uint            `gorm:"uniqueIndex:idx_govproposalburn_proposal_denom"`
+	Chain          Chain           `gorm:"foreignKey:BlockchainID"`
+	ProposalID     uint64          `gorm:"uniqueIndex:idx_govproposalburn_proposal_denom"`
+	BlockID        uint            `gorm:"index"`
+	Block          Block           `gorm:"foreignKey:BlockID"`
+	Amount         decimal.Decimal `gorm:"type:decimal(78,0);"`
+	DenominationID uint            `gorm:"uniqueIndex:idx_govproposalburn_proposal_denom"`
+	Denomination   Denom           `gorm:"foreignKey:DenominationID"`
+}
+
+// A validator of the chain. The consensus address is the hex address of the consensus pubkey, used to match slashes.
+// The moniker is the latest one seen for the validator.
+type Validator struct {
+	ID               uint
+	BlockchainID     uint   `gorm:"uniqueIndex:idx_validator_operator"`
+	Chain            Chain  `gorm:"foreignKey:BlockchainID"`
+	OperatorAddress  string `gorm:"uniqueIndex:idx_validator_operator"`
+	ConsensusAddress string `gorm:"index:idx_validator_cons"`
+	Moniker          string
+}
+
+// A slash of a validator by the slashing or evidence BeginBlocker. The slash has no delegator, the losses are attributed to the
+// indexed delegations, unbondings and redelegations of the validator when the taxable events of an address are searched.
+type ValidatorSlash struct {
+	ID               uint
+	BlockchainID     uint   `gorm:"uniqueIndex:idx_validatorslash_block_cons_denom"`
+	Chain            Chain  `gorm:"foreignKey:BlockchainID"`
+	BlockID          uint   `gorm:"uniqueIndex:idx_validatorslash_block_cons_denom"`
+	Block            Block  `gorm:"foreignKey:BlockID"`
+	ConsensusAddress string `gorm:"uniqueIndex:idx_validatorslash_block_cons_denom"`
+	InfractionHeight int64
+	Fraction         decimal.Decimal `gorm:"type:decimal(78,18);"`
+	Amount           decimal.Decimal `gorm:"type:decimal(78,0);"`
+	DenominationID   uint            `gorm:"uniqueIndex:idx_validatorslash_block_cons_denom"`
+	Denomination     Denom           `gorm:"foreignKey:DenominationID"`
+}
+
+// A change of the address the staking rewards of a delegator are paid to. The withdraw address of a delegator at a height
+// is the one of the latest change up to that height, or the delegator itself when there is none.
+type WithdrawAddress struct {
+	ID                 uint
+	BlockchainID       uint    `gorm:"index:idx_withdrawaddr_delegator"`
+	Chain              Chain   `gorm:"foreignKey:BlockchainID"`
+	MessageID          uint    `gorm:"uniqueIndex:idx_withdrawaddr_msg"`
+	Message            Message `gorm:"foreignKey:MessageID"`
+	Height             int64   `gorm:"index:idx_withdrawaddr_delegator"`
+	DelegatorAddressID uint    `gorm:"index:idx_withdrawaddr_delegator"`
+	DelegatorAddress   Address `gorm:"foreignKey:DelegatorAddressID"`
+	WithdrawAddressID  uint
+	WithdrawAddress    Address `gorm:"foreignKey:WithdrawAddressID"`
+}
+
+// A change of the tokens a delegator has bonded to a validator, negative when unbonded or redelegated away. The bonded tokens
+// of a delegator at a height are the sum of the changes up to that height.
+type DelegationChange struct {
+	ID                 uint
+	BlockchainID       uint            `gorm:"index:idx_delegation_validator"`
+	Chain              Chain           `gorm:"foreignKey:BlockchainID"`
+	MessageID          uint            `gorm:"uniqueIndex:idx_delegation_msg_validator"`
+	Message            Message         `gorm:"foreignKey:MessageID"`
+	Height             int64           `gorm:"index:idx_delegation_validator"`
+	DelegatorAddressID uint            `gorm:"index:idx_delegation_delegator"`
+	DelegatorAddress   Address         `gorm:"foreignKey:DelegatorAddressID"`
+	ValidatorAddress   string          `gorm:"uniqueIndex:idx_delegation_msg_validator;index:idx_delegation_validator"`
+	Amount             decimal.Decimal `gorm:"type:decimal(78,0);"`
+	DenominationID     uint            `gorm:"uniqueIndex:idx_delegation_msg_validator"`
+	Denomination       Denom           `gorm:"foreignKey:DenominationID"`
 }
 
 // type SimpleDenom struct {
@@ -116,6 +338,9 @@ func (TaxableEvent) TableName() string {
 	return "taxable_event"
 }
 
+// EarnerAddress is the account that earned a reward leg, it differs from the receiver when rewards are paid to a withdraw address.
+// ValidatorAddress is the operator address of the validator that paid a staking reward leg, its moniker is resolved from the
+// indexed validators when searching.
 type TaxableTransaction struct {
 	ID                     uint
 	MessageID              uint            `gorm:"index:idx_msg"`
@@ -130,6 +355,14 @@ type TaxableTransaction struct {
 	SenderAddress          Address
 	ReceiverAddressID      *uint `gorm:"index:idx_receiver"`
 	ReceiverAddress        Address
+	EarnerAddressID        *uint   `gorm:"index:idx_earner"`
+	EarnerAddress          Address `gorm:"foreignKey:EarnerAddressID"`
+	ValidatorAddress       string  `gorm:"index:idx_validator"`
+	ValidatorMoniker       string  `gorm:"-"`
+	// The heights of the blocks of the rewards merged into this one when the exports aggregate rewards per period
+	AggregatedHeights []int64 `gorm:"-"`
+	// Set by the exports when the sender and the receiver both belong to the queried addresses
+	InternalTransfer bool `gorm:"-"`
 }
 
 func (TaxableTransaction) TableName() string {
@@ -143,6 +376,39 @@ type Denom struct {
 	Symbol string
 }
 
+// The fiat price of one display unit of a denom at a time. Source records where the price came from, e.g. an imported dump.
+// Prices derived from swaps record the denoms they were chained through (e.g. TOKEN/OSMO/USDC), the fiat volume and the
+// number of swaps of the pair they were derived from.
+type Price struct {
+	ID             uint
+	DenominationID uint            `gorm:"uniqueIndex:idx_price_denom_time_fiat_source"`
+	Denomination   Denom           `gorm:"foreignKey:DenominationID"`
+	Timestamp      time.Time       `gorm:"uniqueIndex:idx_price_denom_time_fiat_source"`
+	FiatCurrency   string          `gorm:"uniqueIndex:idx_price_denom_time_fiat_source"`
+	Source         string          `gorm:"uniqueIndex:idx_price_denom_time_fiat_source"`
+	Price          decimal.Decimal `gorm:"type:decimal(78,18);"`
+	Path           string
+	Volume         decimal.Decimal `gorm:"type:decimal(78,18);"`
+	Trades         int
+}
+
+// The rate of a liquid staking token in the native tokens of its host zone, e.g. the Stride stATOM redemption rate, at the start
+// of the epoch it was updated in. The native denom is the denom of the native tokens on the indexed chain.
+type RedemptionRate struct {
+	ID                   uint
+	BlockchainID         uint      `gorm:"uniqueIndex:idx_redemption_rate_chain_zone_height"`
+	Chain                Chain     `gorm:"foreignKey:BlockchainID"`
+	HostZone             string    `gorm:"uniqueIndex:idx_redemption_rate_chain_zone_height"`
+	Height               int64     `gorm:"uniqueIndex:idx_redemption_rate_chain_zone_height"`
+	Timestamp            time.Time `gorm:"index"`
+	EpochNumber          uint
+	DenominationID       uint  `gorm:"index"`
+	Denomination         Denom `gorm:"foreignKey:DenominationID"`
+	NativeDenominationID uint
+	NativeDenomination   Denom           `gorm:"foreignKey:NativeDenominationID"`
+	Rate                 decimal.Decimal `gorm:"type:decimal(78,18);"`
+}
+
 type DenomUnit struct {
 	ID       uint
 	DenomID  uint `gorm:"uniqueIndex:,composite:denom_id_name"`
@@ -156,12 +422,22 @@ type TxDBWrapper struct {
 	Tx            Tx
 	SignerAddress Address
 	Messages      []MessageDBWrapper
+	// Taxable events that happened during the tx outside of the message data, e.g. airdrops claimed through hooks
+	TaxableEvents []TaxableEvent
 }
 
 // Store messages with their taxable events for easy database creation
 type MessageDBWrapper struct {
-	Message    Message
-	TaxableTxs []TaxableTxDBWrapper
+	Message           Message
+	TaxableTxs        []TaxableTxDBWrapper
+	CLPositionEvents  []CLPositionEventDBWrapper
+	BundledTxHashes   []string
+	NFTTransfers      []NFTTransferDBWrapper
+	VestingAccount    *VestingAccountDBWrapper
+	GovDeposit        *GovDepositDBWrapper
+	DelegationChanges []DelegationChangeDBWrapper
+	Validator         *Validator
+	WithdrawAddress   *WithdrawAddressDBWrapper
 }
 
 // Store taxable tx with their sender/receiver address for easy database creation
@@ -169,6 +445,51 @@ type TaxableTxDBWrapper struct {
 	TaxableTx       TaxableTransaction
 	SenderAddress   Address
 	ReceiverAddress Address
+	EarnerAddress   Address
+}
+
+// Store concentrated liquidity position changes with their position and addresses for easy database creation
+type CLPositionEventDBWrapper struct {
+	Position        CLPosition
+	Event           CLPositionEvent
+	OwnerAddress    Address
+	NewOwnerAddress Address
+	Amounts         []CLPositionEventAmount
+}
+
+// Store NFT transfers with their NFT and addresses for easy database creation.
+// PaymentLegIndex is the index of the paired payment in the TaxableTxs of the message, -1 when there is none.
+type NFTTransferDBWrapper struct {
+	NFT             NFT
+	Transfer        NFTTransfer
+	SenderAddress   Address
+	ReceiverAddress Address
+	PaymentLegIndex int
+}
+
+// Store vesting accounts with their addresses for easy database creation, the amounts and periods carry their denoms
+type VestingAccountDBWrapper struct {
+	Account       VestingAccount
+	Address       Address
+	FunderAddress Address
+}
+
+// Store gov deposits with their depositor for easy database creation, there is one deposit per denom
+type GovDepositDBWrapper struct {
+	Deposits  []GovDeposit
+	Depositor Address
+}
+
+// Store delegation changes with their delegator for easy database creation
+type DelegationChangeDBWrapper struct {
+	Change           DelegationChange
+	DelegatorAddress Address
+}
+
+// Store withdraw address changes with the delegator and withdraw addresses for easy database creation
+type WithdrawAddressDBWrapper struct {
+	DelegatorAddress Address
+	WithdrawAddress  Address
 }
 
 type DenomDBWrapper struct {
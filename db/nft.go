@@ -0,0 +1,148 @@
+package db
+
+import (
+	"sort"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+func indexNFTTransfer(db *gorm.DB, dbChainID uint, messageID uint, nftTransfer NFTTransferDBWrapper, taxableTxIDs []uint) error {
+	token := NFT{BlockchainID: dbChainID, ClassID: nftTransfer.NFT.ClassID, TokenID: nftTransfer.NFT.TokenID}
+	if err := db.Where(&token).FirstOrCreate(&token).Error; err != nil {
+		return err
+	}
+
+	transfer := NFTTransfer{NFTID: token.ID, MessageID: messageID, EventIndex: nftTransfer.Transfer.EventIndex}
+	transferUpdates := NFTTransfer{Action: nftTransfer.Transfer.Action}
+
+	if nftTransfer.SenderAddress.Address != "" {
+		if err := db.Where(&nftTransfer.SenderAddress).FirstOrCreate(&nftTransfer.SenderAddress).Error; err != nil {
+			return err
+		}
+		transferUpdates.SenderAddressID = &nftTransfer.SenderAddress.ID
+	}
+
+	if nftTransfer.ReceiverAddress.Address != "" {
+		if err := db.Where(&nftTransfer.ReceiverAddress).FirstOrCreate(&nftTransfer.ReceiverAddress).Error; err != nil {
+			return err
+		}
+		transferUpdates.ReceiverAddressID = &nftTransfer.ReceiverAddress.ID
+	}
+
+	if nftTransfer.PaymentLegIndex >= 0 && nftTransfer.PaymentLegIndex < len(taxableTxIDs) && taxableTxIDs[nftTransfer.PaymentLegIndex] != 0 {
+		transferUpdates.PaymentTaxableTxID = &taxableTxIDs[nftTransfer.PaymentLegIndex]
+	}
+
+	// The first event has index 0, a struct condition would skip it
+	if err := db.Where("nft_id = ? AND message_id = ? AND event_index = ?", transfer.NFTID, transfer.MessageID, transfer.EventIndex).
+		Assign(transferUpdates).FirstOrCreate(&transfer).Error; err != nil {
+		return err
+	}
+
+	// The NFT leaves the chain (or stops existing) on burns and outgoing IBC transfers, every other movement assigns the receiver.
+	// IBC transfers that fail or time out are refunded to the sender.
+	ownerUpdates := map[string]interface{}{}
+	switch nftTransfer.Transfer.Action {
+	case nft.TransferActionBurn, nft.TransferActionIBCSend:
+		ownerUpdates["owner_address_id"] = nil
+	default:
+		if transferUpdates.ReceiverAddressID != nil {
+			ownerUpdates["owner_address_id"] = *transferUpdates.ReceiverAddressID
+		}
+	}
+
+	if len(ownerUpdates) > 0 {
+		if err := db.Model(&token).Updates(ownerUpdates).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetNFTTransfers returns every movement of an NFT sent or received by the address
+func GetNFTTransfers(address string, db *gorm.DB) ([]NFTTransfer, error) {
+	addressIDs := db.Table("addresses").Select("id").Where("address = ?", address)
+
+	var transfers []NFTTransfer
+	result := db.Where("sender_address_id IN (?) OR receiver_address_id IN (?)", addressIDs, addressIDs).
+		Preload("NFT").Preload("NFT.Chain").Preload("SenderAddress").Preload("ReceiverAddress").
+		Preload("PaymentTaxableTx").Preload("PaymentTaxableTx.DenominationSent").Preload("PaymentTaxableTx.DenominationReceived").
+		Preload("Message").Preload("Message.MessageType").Preload("Message.Tx").Preload("Message.Tx.Block").
+		Find(&transfers)
+
+	return transfers, result.Error
+}
+
+// NFTTransferSummary is a single movement of an NFT, with the fungible payment of the message for sales (in base units)
+type NFTTransferSummary struct {
+	Timestamp     time.Time
+	ChainID       string
+	TxHash        string
+	MessageType   string
+	ClassID       string
+	TokenID       string
+	Action        string
+	From          string
+	To            string
+	PaymentDenom  string
+	PaymentAmount decimal.Decimal
+}
+
+// GetNFTTransferSummaries returns the movements of the NFTs of the address in block order
+func GetNFTTransferSummaries(address string, db *gorm.DB) ([]NFTTransferSummary, error) {
+	transfers, err := GetNFTTransfers(address, db)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if transfers[i].Message.Tx.Block.Height != transfers[j].Message.Tx.Block.Height {
+			return transfers[i].Message.Tx.Block.Height < transfers[j].Message.Tx.Block.Height
+		}
+		if transfers[i].MessageID != transfers[j].MessageID {
+			return transfers[i].MessageID < transfers[j].MessageID
+		}
+		return transfers[i].EventIndex < transfers[j].EventIndex
+	})
+
+	summaries := make([]NFTTransferSummary, 0, len(transfers))
+	for _, transfer := range transfers {
+		summaries = append(summaries, SummarizeNFTTransfer(transfer))
+	}
+
+	return summaries, nil
+}
+
+// SummarizeNFTTransfer flattens the movement. The payment is the sent leg of the linked taxable tx, or its received leg when
+// nothing was sent.
+func SummarizeNFTTransfer(transfer NFTTransfer) NFTTransferSummary {
+	summary := NFTTransferSummary{
+		Timestamp:     transfer.Message.Tx.Block.TimeStamp,
+		ChainID:       transfer.NFT.Chain.ChainID,
+		TxHash:        transfer.Message.Tx.Hash,
+		MessageType:   transfer.Message.MessageType.MessageType,
+		ClassID:       transfer.NFT.ClassID,
+		TokenID:       transfer.NFT.TokenID,
+		Action:        transfer.Action,
+		From:          transfer.SenderAddress.Address,
+		To:            transfer.ReceiverAddress.Address,
+		PaymentAmount: decimal.Zero,
+	}
+
+	if transfer.PaymentTaxableTxID == nil {
+		return summary
+	}
+
+	payment := transfer.PaymentTaxableTx
+	if payment.DenominationSentID != nil {
+		summary.PaymentDenom, summary.PaymentAmount = payment.DenominationSent.Base, payment.AmountSent
+	} else if payment.DenominationReceivedID != nil {
+		summary.PaymentDenom, summary.PaymentAmount = payment.DenominationReceived.Base, payment.AmountReceived
+	}
+
+	return summary
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/nft"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeNFTTransfer(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	paymentID := uint(7)
+	denomID := uint(1)
+
+	transfer := NFTTransfer{
+		NFT:             NFT{ClassID: "stars1collection", TokenID: "42", Chain: Chain{ChainID: "stargaze-1"}},
+		Message:         Message{Tx: Tx{Hash: "sale", Block: Block{TimeStamp: at}}},
+		Action:          nft.TransferActionSend,
+		SenderAddress:   Address{Address: "stars1seller"},
+		ReceiverAddress: Address{Address: "stars1buyer"},
+	}
+
+	summary := SummarizeNFTTransfer(transfer)
+	assert.Equal(t, "stars1seller", summary.From)
+	assert.Equal(t, "stars1buyer", summary.To)
+	assert.Empty(t, summary.PaymentDenom)
+	assert.True(t, summary.PaymentAmount.IsZero())
+
+	// A send paid for in the same message links the fungible payment
+	transfer.PaymentTaxableTxID = &paymentID
+	transfer.PaymentTaxableTx = TaxableTransaction{
+		ID: paymentID, AmountSent: decimal.NewFromInt(5000000), DenominationSentID: &denomID, DenominationSent: Denom{ID: denomID, Base: "ustars"},
+	}
+
+	summary = SummarizeNFTTransfer(transfer)
+	assert.Equal(t, at, summary.Timestamp)
+	assert.Equal(t, "stargaze-1", summary.ChainID)
+	assert.Equal(t, "42", summary.TokenID)
+	assert.Equal(t, nft.TransferActionSend, summary.Action)
+	assert.Equal(t, "ustars", summary.PaymentDenom)
+	assert.True(t, decimal.NewFromInt(5000000).Equal(summary.PaymentAmount))
+}
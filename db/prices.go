@@ -0,0 +1,197 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/gamm"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/poolmanager"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultFiatCurrency is the fiat currency the exports are valued in
+const DefaultFiatCurrency = "USD"
+
+// PriceSourceImport marks the prices imported from price dumps
+const PriceSourceImport = "import"
+
+// PriceSourceOsmosisSwaps marks the prices derived from the indexed Osmosis swaps
+const PriceSourceOsmosisSwaps = "osmosis-swaps"
+
+// PriceSources are the price sources in the order they are preferred in, market data before the prices derived from swaps
+var PriceSources = []string{PriceSourceImport, PriceSourceOsmosisSwaps}
+
+// MaxPriceGap is the furthest a price can be from the looked up time, prices further away are neither used nor interpolated with
+const MaxPriceGap = 24 * time.Hour
+
+// UpsertPrices stores the prices, a price of the same denom, time, fiat currency and source is replaced
+func UpsertPrices(db *gorm.DB, prices []Price) error {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "denomination_id"}, {Name: "timestamp"}, {Name: "fiat_currency"}, {Name: "source"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "path", "volume", "trades"}),
+	}).CreateInBatches(&prices, 1000).Error
+}
+
+// GetDenomForCurrency returns the denom for a currency of a price dump or a CSV row, either the base denom or a symbol
+// that only one denom has
+func GetDenomForCurrency(db *gorm.DB, currency string) (Denom, error) {
+	var denoms []Denom
+	result := db.Where("base = ?", currency).Limit(1).Find(&denoms)
+	if result.Error != nil {
+		return Denom{}, result.Error
+	}
+
+	if len(denoms) == 0 {
+		result = db.Where("symbol = ?", currency).Limit(2).Find(&denoms)
+		if result.Error != nil {
+			return Denom{}, result.Error
+		}
+	}
+
+	switch len(denoms) {
+	case 0:
+		return Denom{}, fmt.Errorf("no denom found for currency %s", currency)
+	case 1:
+		return denoms[0], nil
+	default:
+		return Denom{}, fmt.Errorf("currency %s is the symbol of several denoms, use the base denom", currency)
+	}
+}
+
+// GetPriceAt returns the price of the denom at the given time from the first of the PriceSources that has one, linearly
+// interpolated between the closest prices before and after the time when both are within MaxPriceGap of the time. When only
+// one of them is within MaxPriceGap it is used as is.
+func GetPriceAt(db *gorm.DB, denomID uint, fiatCurrency string, at time.Time) (decimal.Decimal, error) {
+	for _, source := range PriceSources {
+		price, found, err := getSourcePriceAt(db, denomID, fiatCurrency, source, at)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if found {
+			return price, nil
+		}
+	}
+
+	return decimal.Zero, fmt.Errorf("no %s price for denom %d around %s", fiatCurrency, denomID, at)
+}
+
+func getSourcePriceAt(db *gorm.DB, denomID uint, fiatCurrency string, source string, at time.Time) (decimal.Decimal, bool, error) {
+	var before, after Price
+	result := db.Where("denomination_id = ? AND fiat_currency = ? AND source = ? AND timestamp <= ?", denomID, fiatCurrency, source, at).
+		Order("timestamp desc").Limit(1).Find(&before)
+	if result.Error != nil {
+		return decimal.Zero, false, result.Error
+	}
+
+	result = db.Where("denomination_id = ? AND fiat_currency = ? AND source = ? AND timestamp >= ?", denomID, fiatCurrency, source, at).
+		Order("timestamp asc").Limit(1).Find(&after)
+	if result.Error != nil {
+		return decimal.Zero, false, result.Error
+	}
+
+	price, found := priceAt(before, after, at)
+	return price, found, nil
+}
+
+// priceAt returns the price at the time from the closest prices before and after it, a price further than MaxPriceGap from
+// the time is ignored so a gap in the prices is not bridged
+func priceAt(before Price, after Price, at time.Time) (decimal.Decimal, bool) {
+	beforeFound := before.ID != 0 && at.Sub(before.Timestamp) <= MaxPriceGap
+	afterFound := after.ID != 0 && after.Timestamp.Sub(at) <= MaxPriceGap
+
+	switch {
+	case beforeFound && afterFound:
+		return interpolatePrice(before, after, at), true
+	case beforeFound:
+		return before.Price, true
+	case afterFound:
+		return after.Price, true
+	}
+
+	return decimal.Zero, false
+}
+
+func interpolatePrice(before Price, after Price, at time.Time) decimal.Decimal {
+	span := after.Timestamp.Sub(before.Timestamp)
+	if span <= 0 {
+		return before.Price
+	}
+
+	elapsed := decimal.NewFromInt(int64(at.Sub(before.Timestamp)))
+	return before.Price.Add(after.Price.Sub(before.Price).Mul(elapsed).Div(decimal.NewFromInt(int64(span))))
+}
+
+// PriceLookup looks up the prices of the CSV row currencies in the prices table, the denoms of the currencies are cached
+type PriceLookup struct {
+	db           *gorm.DB
+	fiatCurrency string
+	denoms       map[string]uint
+}
+
+func NewPriceLookup(db *gorm.DB, fiatCurrency string) *PriceLookup {
+	return &PriceLookup{db: db, fiatCurrency: fiatCurrency, denoms: make(map[string]uint)}
+}
+
+func (l *PriceLookup) GetPrice(currency string, at time.Time) (decimal.Decimal, error) {
+	denomID, ok := l.denoms[currency]
+	if !ok {
+		denom, err := GetDenomForCurrency(l.db, currency)
+		if err != nil {
+			// Unknown currencies are cached as denom 0, which has no prices
+			config.Log.Debugf("No denom for currency %s. Err: %v", currency, err)
+		}
+		denomID = denom.ID
+		l.denoms[currency] = denomID
+	}
+
+	if denomID == 0 {
+		return decimal.Zero, fmt.Errorf("no denom found for currency %s", currency)
+	}
+
+	return GetPriceAt(l.db, denomID, l.fiatCurrency, at)
+}
+
+// SwapLeg is an indexed swap of an amount of one denom for an amount of another, in base units
+type SwapLeg struct {
+	Time                   time.Time
+	DenominationSentID     uint
+	AmountSent             decimal.Decimal
+	DenominationReceivedID uint
+	AmountReceived         decimal.Decimal
+}
+
+// The message types of the Osmosis swaps, their taxable txs send one denom and receive another for the same address
+var osmosisSwapTypes = []string{
+	gamm.MsgSwapExactAmountIn,
+	gamm.MsgSwapExactAmountOut,
+	poolmanager.MsgSwapExactAmountIn,
+	poolmanager.MsgSwapExactAmountOut,
+	poolmanager.MsgSplitRouteSwapExactAmountIn,
+	poolmanager.MsgSplitRouteSwapExactAmountOut,
+}
+
+// GetOsmosisSwapLegs returns the swaps of the chain with a block time in [start, end), ordered by block time
+func GetOsmosisSwapLegs(db *gorm.DB, dbChainID uint, start time.Time, end time.Time) ([]SwapLeg, error) {
+	var legs []SwapLeg
+	result := db.Table("taxable_tx").
+		Select("blocks.time_stamp AS time, taxable_tx.denomination_sent_id, taxable_tx.amount_sent, "+
+			"taxable_tx.denomination_received_id, taxable_tx.amount_received").
+		Joins("JOIN messages ON messages.id = taxable_tx.message_id").
+		Joins("JOIN message_types ON message_types.id = messages.message_type_id").
+		Joins("JOIN txes ON txes.id = messages.tx_id").
+		Joins("JOIN blocks ON blocks.id = txes.block_id").
+		Where("blocks.blockchain_id = ? AND blocks.time_stamp >= ? AND blocks.time_stamp < ? AND message_types.message_type IN ?",
+			dbChainID, start, end, osmosisSwapTypes).
+		Where("taxable_tx.denomination_sent_id IS NOT NULL AND taxable_tx.denomination_received_id IS NOT NULL").
+		Order("blocks.time_stamp asc").
+		Scan(&legs)
+
+	return legs, result.Error
+}
@@ -0,0 +1,35 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceAt(t *testing.T) {
+	at := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	price := func(id uint, timestamp time.Time, value int64) Price {
+		return Price{ID: id, Timestamp: timestamp, Price: decimal.NewFromInt(value)}
+	}
+
+	// Interpolated between the prices on both sides
+	value, found := priceAt(price(1, at.Add(-6*time.Hour), 10), price(2, at.Add(6*time.Hour), 20), at)
+	assert.True(t, found)
+	assert.True(t, decimal.NewFromInt(15).Equal(value))
+
+	// A price on one side within the gap is used as is
+	value, found = priceAt(price(1, at.Add(-6*time.Hour), 10), Price{}, at)
+	assert.True(t, found)
+	assert.True(t, decimal.NewFromInt(10).Equal(value))
+
+	// The side further than the gap is not interpolated with
+	value, found = priceAt(price(1, at.Add(-30*24*time.Hour), 10), price(2, at.Add(time.Hour), 20), at)
+	assert.True(t, found)
+	assert.True(t, decimal.NewFromInt(20).Equal(value))
+
+	// Prices on both sides further than the gap are not bridged
+	_, found = priceAt(price(1, at.Add(-30*24*time.Hour), 10), price(2, at.Add(30*24*time.Hour), 20), at)
+	assert.False(t, found)
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"sort"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// BalanceChange is a change of the bank balance of an address by an indexed leg, fee, event or delegation
+type BalanceChange struct {
+	Height int64
+	Denom  string
+	Amount decimal.Decimal
+}
+
+// The direction each taxable event source moves the bank balance of the event address in. Vesting unlocks, redelegation
+// completions, burned gov deposits and slashes do not move bank balances, the tokens were already in the balance or were
+// taken from a delegation or escrow.
+var taxableEventBalanceSigns = map[uint]int64{
+	OsmosisRewardDistribution:                  1,
+	TendermintLiquidityDepositCoinsToPool:      -1,
+	TendermintLiquidityDepositPoolCoinReceived: 1,
+	TendermintLiquiditySwapTransactedCoinIn:    -1,
+	TendermintLiquiditySwapTransactedCoinOut:   1,
+	TendermintLiquiditySwapTransactedFee:       -1,
+	TendermintLiquidityWithdrawPoolCoinSent:    -1,
+	TendermintLiquidityWithdrawCoinReceived:    1,
+	TendermintLiquidityWithdrawFee:             -1,
+	OsmosisProtorevDeveloperRewardDistribution: 1,
+	ClaimHookAirdrop:                           1,
+	GovDepositRefund:                           1,
+	StakingUnbondingComplete:                   1,
+}
+
+// TaxableEventBalanceSign returns 1 when the taxable event source adds to the bank balance of the event address, -1 when it
+// takes from it and 0 when it does not move it
+func TaxableEventBalanceSign(source uint) int64 {
+	return taxableEventBalanceSigns[source]
+}
+
+// Delegations of these message types are paid from the bank balance of the delegator, the other delegation changes
+// move tokens between delegations or out of an unbonding
+var bankFundedDelegationTypes = []string{staking.MsgDelegate, staking.MsgCreateValidator}
+
+// GetBalanceChanges returns the changes of the bank balance of the address by the indexed taxable txs, fees, taxable events
+// and delegations of the chain up to and including the given height, ordered by height
+func GetBalanceChanges(db *gorm.DB, dbChainID uint, address string, height int64) ([]BalanceChange, error) {
+	var changes []BalanceChange
+
+	var received []BalanceChange
+	result := db.Table("taxable_tx").
+		Select("blocks.height AS height, denoms.base AS denom, taxable_tx.amount_received AS amount").
+		Joins("JOIN messages ON messages.id = taxable_tx.message_id").
+		Joins("JOIN txes ON txes.id = messages.tx_id").
+		Joins("JOIN blocks ON blocks.id = txes.block_id").
+		Joins("JOIN addresses ON addresses.id = taxable_tx.receiver_address_id").
+		Joins("JOIN denoms ON denoms.id = taxable_tx.denomination_received_id").
+		Where("blocks.blockchain_id = ? AND blocks.height <= ? AND addresses.address = ?", dbChainID, height, address).
+		Scan(&received)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	changes = append(changes, received...)
+
+	var sent []BalanceChange
+	result = db.Table("taxable_tx").
+		Select("blocks.height AS height, denoms.base AS denom, taxable_tx.amount_sent AS amount").
+		Joins("JOIN messages ON messages.id = taxable_tx.message_id").
+		Joins("JOIN txes ON txes.id = messages.tx_id").
+		Joins("JOIN blocks ON blocks.id = txes.block_id").
+		Joins("JOIN addresses ON addresses.id = taxable_tx.sender_address_id").
+		Joins("JOIN denoms ON denoms.id = taxable_tx.denomination_sent_id").
+		Where("blocks.blockchain_id = ? AND blocks.height <= ? AND addresses.address = ?", dbChainID, height, address).
+		Scan(&sent)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	changes = append(changes, negateBalanceChanges(sent)...)
+
+	var fees []BalanceChange
+	result = db.Table("fees").
+		Select("blocks.height AS height, denoms.base AS denom, fees.amount AS amount").
+		Joins("JOIN txes ON txes.id = fees.tx_id").
+		Joins("JOIN blocks ON blocks.id = txes.block_id").
+		Joins("JOIN addresses ON addresses.id = fees.payer_address_id").
+		Joins("JOIN denoms ON denoms.id = fees.denomination_id").
+		Where("blocks.blockchain_id = ? AND blocks.height <= ? AND addresses.address = ?", dbChainID, height, address).
+		Scan(&fees)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	changes = append(changes, negateBalanceChanges(fees)...)
+
+	var delegations []BalanceChange
+	result = db.Table("delegation_changes").
+		Select("delegation_changes.height AS height, denoms.base AS denom, delegation_changes.amount AS amount").
+		Joins("JOIN messages ON messages.id = delegation_changes.message_id").
+		Joins("JOIN message_types ON message_types.id = messages.message_type_id").
+		Joins("JOIN addresses ON addresses.id = delegation_changes.delegator_address_id").
+		Joins("JOIN denoms ON denoms.id = delegation_changes.denomination_id").
+		Where("delegation_changes.blockchain_id = ? AND delegation_changes.height <= ? AND addresses.address = ? AND message_types.message_type IN ?",
+			dbChainID, height, address, bankFundedDelegationTypes).
+		Scan(&delegations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	changes = append(changes, negateBalanceChanges(delegations)...)
+
+	var taxableEvents []struct {
+		BalanceChange
+		Source uint
+	}
+	result = db.Table("taxable_event").
+		Select("blocks.height AS height, denoms.base AS denom, taxable_event.amount AS amount, taxable_event.source AS source").
+		Joins("JOIN blocks ON blocks.id = taxable_event.block_id").
+		Joins("JOIN addresses ON addresses.id = taxable_event.address_id").
+		Joins("JOIN denoms ON denoms.id = taxable_event.denomination_id").
+		Where("blocks.blockchain_id = ? AND blocks.height <= ? AND addresses.address = ?", dbChainID, height, address).
+		Scan(&taxableEvents)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, taxableEvent := range taxableEvents {
+		sign, ok := taxableEventBalanceSigns[taxableEvent.Source]
+		if !ok {
+			continue
+		}
+		change := taxableEvent.BalanceChange
+		change.Amount = change.Amount.Mul(decimal.NewFromInt(sign))
+		changes = append(changes, change)
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Height < changes[j].Height
+	})
+
+	return changes, nil
+}
+
+func negateBalanceChanges(changes []BalanceChange) []BalanceChange {
+	for i := range changes {
+		changes[i].Amount = changes[i].Amount.Neg()
+	}
+	return changes
+}
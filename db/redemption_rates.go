@@ -0,0 +1,51 @@
+package db
+
+import (
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IndexRedemptionRates stores the redemption rates queried at the start height of an epoch, a rate of the same host zone and
+// height is replaced
+func IndexRedemptionRates(db *gorm.DB, dryRun bool, blockHeight int64, blockTime time.Time, epochNumber uint, rates []RedemptionRate, dbChainID string, dbChainName string) error {
+	if dryRun || len(rates) == 0 {
+		return nil
+	}
+
+	chain := Chain{ChainID: dbChainID, Name: dbChainName}
+	if err := db.Where("chain_id = ?", dbChainID).FirstOrCreate(&chain).Error; err != nil {
+		return err
+	}
+
+	for i := range rates {
+		denom, err := GetOrAddDenom(db, rates[i].Denomination.Base)
+		if err != nil {
+			return err
+		}
+
+		nativeDenom, err := GetOrAddDenom(db, rates[i].NativeDenomination.Base)
+		if err != nil {
+			return err
+		}
+
+		rates[i].BlockchainID = chain.ID
+		rates[i].Chain = Chain{}
+		rates[i].Height = blockHeight
+		rates[i].Timestamp = blockTime
+		rates[i].EpochNumber = epochNumber
+		rates[i].DenominationID = denom.ID
+		rates[i].Denomination = Denom{}
+		rates[i].NativeDenominationID = nativeDenom.ID
+		rates[i].NativeDenomination = Denom{}
+	}
+
+	config.Log.Infof("Sending %d redemption rates to DB for block %d", len(rates), blockHeight)
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "blockchain_id"}, {Name: "host_zone"}, {Name: "height"}},
+		DoUpdates: clause.AssignmentColumns([]string{"timestamp", "epoch_number", "denomination_id", "native_denomination_id", "rate"}),
+	}).Create(&rates).Error
+}
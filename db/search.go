@@ -8,23 +8,52 @@ func GetTaxableTransactions(address string, db *gorm.DB) ([]TaxableTransaction,
 	// Look up all Transactions, and Messages for the addresses
 	var taxableTransactions []TaxableTransaction
 
-	result := db.Joins("JOIN addresses ON addresses.id = taxable_tx.sender_address_id OR addresses.id = taxable_tx.receiver_address_id").
+	result := db.Joins("JOIN addresses ON addresses.id = taxable_tx.sender_address_id OR addresses.id = taxable_tx.receiver_address_id OR addresses.id = taxable_tx.earner_address_id").
 		Where("addresses.address = ?", address).
 		Preload("Message").Preload("Message.MessageType").Preload("Message.Tx").
-		Preload("Message.Tx.Block").
+		Preload("Message.Tx.Block").Preload("Message.Tx.Block.Chain").
 		Preload("Message.Tx.SignerAddress").Preload("Message.Tx.Fees").
 		Preload("Message.Tx.Fees.Denomination").Preload("Message.Tx.Fees.PayerAddress").
 		Preload("Message.Tx.Fees.Tx").Preload("Message.Tx.Fees.Tx.Block").
-		Preload("SenderAddress").Preload("ReceiverAddress").Preload("DenominationSent").
+		Preload("SenderAddress").Preload("ReceiverAddress").Preload("EarnerAddress").Preload("DenominationSent").
 		Preload("DenominationReceived").Find(&taxableTransactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
 
-	return taxableTransactions, result.Error
+	err := resolveValidatorMonikers(db, taxableTransactions)
+
+	return taxableTransactions, err
+}
+
+// resolveValidatorMonikers sets the monikers of the validators that paid the reward legs
+func resolveValidatorMonikers(db *gorm.DB, taxableTransactions []TaxableTransaction) error {
+	var operatorAddresses []string
+	seen := make(map[string]bool)
+	for _, taxableTransaction := range taxableTransactions {
+		if taxableTransaction.ValidatorAddress != "" && !seen[taxableTransaction.ValidatorAddress] {
+			seen[taxableTransaction.ValidatorAddress] = true
+			operatorAddresses = append(operatorAddresses, taxableTransaction.ValidatorAddress)
+		}
+	}
+
+	monikers, err := GetValidatorMonikers(db, operatorAddresses)
+	if err != nil {
+		return err
+	}
+
+	for i := range taxableTransactions {
+		taxableTransactions[i].ValidatorMoniker = monikers[taxableTransactions[i].ValidatorAddress]
+	}
+
+	return nil
 }
 
 func GetTaxableFees(address string, db *gorm.DB) ([]Fee, error) {
 	var fees []Fee
 	result := db.Joins("JOIN addresses ON addresses.id = fees.payer_address_id").
-		Where("addresses.address = ?", address).Preload("PayerAddress").Preload("Denomination").Preload("Tx").Preload("Tx.Block").Find(&fees)
+		Where("addresses.address = ?", address).Preload("PayerAddress").Preload("Denomination").Preload("Tx").Preload("Tx.Block").
+		Preload("Tx.Block.Chain").Find(&fees)
 	return fees, result.Error
 }
 
@@ -35,6 +64,22 @@ func GetTaxableEvents(address string, db *gorm.DB) ([]TaxableEvent, error) {
 	result := db.Joins("JOIN addresses ON addresses.id = taxable_event.address_id").
 		Where("addresses.address = ?", address).Preload("EventAddress").Preload("Denomination").
 		Preload("Block").Preload("Block.Chain").Find(&taxableEvents)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// The gov deposit burns are attributed to the depositors from the indexed deposits
+	burns, err := GetGovDepositBurns(db, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// The slashes are attributed to the delegators from the indexed staking messages
+	slashLosses, err := GetSlashLosses(db, address)
+	if err != nil {
+		return nil, err
+	}
 
-	return taxableEvents, result.Error
+	taxableEvents = append(taxableEvents, burns...)
+	return append(taxableEvents, slashLosses...), nil
 }
@@ -0,0 +1,322 @@
+package db
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/events"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func indexDelegationChange(db *gorm.DB, dbChainID uint, messageID uint, height int64, delegationChange DelegationChangeDBWrapper) error {
+	if err := db.Where(&delegationChange.DelegatorAddress).FirstOrCreate(&delegationChange.DelegatorAddress).Error; err != nil {
+		return err
+	}
+
+	change := DelegationChange{
+		MessageID:        messageID,
+		ValidatorAddress: delegationChange.Change.ValidatorAddress,
+		DenominationID:   delegationChange.Change.Denomination.ID,
+	}
+	changeUpdates := DelegationChange{
+		BlockchainID:       dbChainID,
+		Height:             height,
+		DelegatorAddressID: delegationChange.DelegatorAddress.ID,
+		Amount:             delegationChange.Change.Amount,
+	}
+
+	return db.Where(change).Assign(changeUpdates).FirstOrCreate(&change).Error
+}
+
+func indexValidator(db *gorm.DB, dbChainID uint, validatorL Validator) error {
+	validator := Validator{BlockchainID: dbChainID, OperatorAddress: validatorL.OperatorAddress}
+	validatorUpdates := Validator{}
+	if validatorL.ConsensusAddress != "" {
+		validatorUpdates.ConsensusAddress = validatorL.ConsensusAddress
+	}
+	if validatorL.Moniker != "" {
+		validatorUpdates.Moniker = validatorL.Moniker
+	}
+
+	return db.Where(validator).Assign(validatorUpdates).FirstOrCreate(&validator).Error
+}
+
+// UpsertValidators stores the validators of the chain, keeping the known consensus addresses and monikers when they are empty
+func UpsertValidators(db *gorm.DB, dbChainID uint, validators []Validator) error {
+	return db.Transaction(func(dbTransaction *gorm.DB) error {
+		for _, validator := range validators {
+			if err := indexValidator(dbTransaction, dbChainID, validator); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetValidatorMonikers returns the monikers of the given validator operator addresses, validators without an indexed
+// moniker are left out
+func GetValidatorMonikers(db *gorm.DB, operatorAddresses []string) (map[string]string, error) {
+	monikers := make(map[string]string)
+	if len(operatorAddresses) == 0 {
+		return monikers, nil
+	}
+
+	var validators []Validator
+	result := db.Where("operator_address IN ? AND moniker <> ''", operatorAddresses).Find(&validators)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, validator := range validators {
+		monikers[validator.OperatorAddress] = validator.Moniker
+	}
+
+	return monikers, nil
+}
+
+// indexValidatorSlashes stores the validator slashes of the block with their infraction height and slash fraction
+func indexValidatorSlashes(db *gorm.DB, blockHeight int64, blockTime time.Time, slashes []events.EventRelevantInformation, dbChainID string, dbChainName string) error {
+	chain := Chain{ChainID: dbChainID, Name: dbChainName}
+	if err := db.Where("chain_id = ?", dbChainID).FirstOrCreate(&chain).Error; err != nil {
+		return err
+	}
+
+	block := Block{Height: blockHeight, BlockchainID: chain.ID}
+	if err := db.Where(block).Attrs(Block{TimeStamp: blockTime}).FirstOrCreate(&block).Error; err != nil {
+		return err
+	}
+
+	validatorSlashes := make([]ValidatorSlash, 0, len(slashes))
+	for _, slash := range slashes {
+		denom, err := GetOrAddDenom(db, slash.Denomination)
+		if err != nil {
+			return err
+		}
+
+		validatorSlashes = append(validatorSlashes, ValidatorSlash{
+			BlockchainID:     chain.ID,
+			BlockID:          block.ID,
+			ConsensusAddress: slash.Slash.ConsensusAddress,
+			InfractionHeight: slash.Slash.InfractionHeight,
+			Fraction:         slash.Slash.Fraction,
+			Amount:           util.ToNumeric(slash.Amount),
+			DenominationID:   denom.ID,
+		})
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "blockchain_id"}, {Name: "block_id"}, {Name: "consensus_address"}, {Name: "denomination_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"infraction_height", "fraction", "amount"}),
+	}).Create(&validatorSlashes).Error
+}
+
+// A delegation change of the address with the type of the message that made it
+type stakingEntry struct {
+	BlockchainID     uint
+	MessageID        uint
+	MessageType      string
+	Height           int64
+	ValidatorAddress string
+	Denom            string
+	Amount           decimal.Decimal
+}
+
+// A validator slash with the operator address of the slashed validator
+type operatorSlash struct {
+	Slash           ValidatorSlash
+	OperatorAddress string
+}
+
+// GetSlashLosses returns the losses of the address from the slashes of the validators it delegated to. The delegations,
+// unbondings and redelegations of the address are replayed from its indexed staking messages.
+func GetSlashLosses(db *gorm.DB, address string) ([]TaxableEvent, error) {
+	var entries []stakingEntry
+	result := db.Table("delegation_changes").
+		Select("delegation_changes.blockchain_id, delegation_changes.message_id, message_types.message_type, delegation_changes.height, "+
+			"delegation_changes.validator_address, denoms.base AS denom, delegation_changes.amount").
+		Joins("JOIN addresses ON addresses.id = delegation_changes.delegator_address_id").
+		Joins("JOIN denoms ON denoms.id = delegation_changes.denomination_id").
+		Joins("JOIN messages ON messages.id = delegation_changes.message_id").
+		Joins("JOIN message_types ON message_types.id = messages.message_type_id").
+		Where("addresses.address = ?", address).
+		Order("delegation_changes.height, delegation_changes.message_id, delegation_changes.amount").
+		Scan(&entries)
+	if result.Error != nil || len(entries) == 0 {
+		return nil, result.Error
+	}
+
+	operatorsByChain := make(map[uint][]string)
+	for _, entry := range entries {
+		operatorsByChain[entry.BlockchainID] = append(operatorsByChain[entry.BlockchainID], entry.ValidatorAddress)
+	}
+
+	var slashes []operatorSlash
+	for blockchainID, operators := range operatorsByChain {
+		var validators []Validator
+		result := db.Where("blockchain_id = ? AND operator_address IN ? AND consensus_address <> ''", blockchainID, operators).Find(&validators)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		operatorByConsensus := make(map[string]string, len(validators))
+		consensusAddresses := make([]string, 0, len(validators))
+		for _, validator := range validators {
+			operatorByConsensus[validator.ConsensusAddress] = validator.OperatorAddress
+			consensusAddresses = append(consensusAddresses, validator.ConsensusAddress)
+		}
+		if len(consensusAddresses) == 0 {
+			continue
+		}
+
+		var validatorSlashes []ValidatorSlash
+		result = db.Where("blockchain_id = ? AND consensus_address IN ?", blockchainID, consensusAddresses).
+			Preload("Denomination").Preload("Block").Preload("Block.Chain").
+			Find(&validatorSlashes)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		for _, slash := range validatorSlashes {
+			slashes = append(slashes, operatorSlash{Slash: slash, OperatorAddress: operatorByConsensus[slash.ConsensusAddress]})
+		}
+	}
+
+	var losses []TaxableEvent
+	for _, loss := range computeSlashLosses(entries, slashes) {
+		slash := loss.Slash
+		hash := sha256.Sum256([]byte(fmt.Sprint(address, slash.Block.Height, " ", loss.Amount, slash.Denomination.Base, " ", StakingSlash, " ", slash.ConsensusAddress)))
+		losses = append(losses, TaxableEvent{
+			Source:         StakingSlash,
+			Amount:         loss.Amount,
+			DenominationID: slash.DenominationID,
+			Denomination:   slash.Denomination,
+			EventAddress:   Address{Address: address},
+			EventHash:      fmt.Sprintf("%x", hash),
+			BlockID:        slash.BlockID,
+			Block:          slash.Block,
+		})
+	}
+
+	return losses, nil
+}
+
+type slashLoss struct {
+	Slash  ValidatorSlash
+	Amount decimal.Decimal
+}
+
+// An unbonding or redelegation entry, slashed when it was created at or after the infraction height. The tokens of a
+// redelegation entry are bonded to the destination validator.
+type unbondingEntry struct {
+	Height               int64
+	Amount               decimal.Decimal
+	DestinationValidator string
+}
+
+type delegationKey struct {
+	BlockchainID uint
+	Validator    string
+	Denom        string
+}
+
+// computeSlashLosses replays the delegation changes and the slashes in height order. A slash applies before the changes of its
+// block, as it happens in the BeginBlocker. Each slash takes its fraction of the bonded tokens, earlier slashes included, and of
+// the unbonding and redelegation entries created since the infraction.
+func computeSlashLosses(entries []stakingEntry, slashes []operatorSlash) []slashLoss {
+	sort.SliceStable(slashes, func(i, j int) bool {
+		return slashes[i].Slash.Block.Height < slashes[j].Slash.Block.Height
+	})
+
+	bonded := make(map[delegationKey]decimal.Decimal)
+	unbondings := make(map[delegationKey][]unbondingEntry)
+	redelegations := make(map[delegationKey][]unbondingEntry)
+
+	// The destination of the redelegations, from the positive change of the message
+	redelegationDestinations := make(map[uint]string)
+	for _, entry := range entries {
+		if entry.MessageType == staking.MsgBeginRedelegate && entry.Amount.IsPositive() {
+			redelegationDestinations[entry.MessageID] = entry.ValidatorAddress
+		}
+	}
+
+	var losses []slashLoss
+	slashIndex := 0
+	applySlashesBefore := func(height int64) {
+		for ; slashIndex < len(slashes) && slashes[slashIndex].Slash.Block.Height <= height; slashIndex++ {
+			slash := slashes[slashIndex]
+			key := delegationKey{BlockchainID: slash.Slash.BlockchainID, Validator: slash.OperatorAddress, Denom: slash.Slash.Denomination.Base}
+
+			loss := slashTokens(bonded[key], slash.Slash.Fraction)
+			bonded[key] = bonded[key].Sub(loss)
+
+			for i, unbonding := range unbondings[key] {
+				if unbonding.Height < slash.Slash.InfractionHeight {
+					continue
+				}
+				unbondingLoss := slashTokens(unbonding.Amount, slash.Slash.Fraction)
+				unbondings[key][i].Amount = unbonding.Amount.Sub(unbondingLoss)
+				loss = loss.Add(unbondingLoss)
+			}
+
+			for i, redelegation := range redelegations[key] {
+				if redelegation.Height < slash.Slash.InfractionHeight {
+					continue
+				}
+				redelegationLoss := slashTokens(redelegation.Amount, slash.Slash.Fraction)
+				redelegations[key][i].Amount = redelegation.Amount.Sub(redelegationLoss)
+				destination := delegationKey{BlockchainID: key.BlockchainID, Validator: redelegation.DestinationValidator, Denom: key.Denom}
+				bonded[destination] = decimal.Max(bonded[destination].Sub(redelegationLoss), decimal.Zero)
+				loss = loss.Add(redelegationLoss)
+			}
+
+			if loss.IsPositive() {
+				losses = append(losses, slashLoss{Slash: slash.Slash, Amount: loss})
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		// Changes at the height of a slash happen after it
+		applySlashesBefore(entry.Height)
+
+		key := delegationKey{BlockchainID: entry.BlockchainID, Validator: entry.ValidatorAddress, Denom: entry.Denom}
+		bonded[key] = decimal.Max(bonded[key].Add(entry.Amount), decimal.Zero)
+
+		switch {
+		case entry.MessageType == staking.MsgUndelegate:
+			unbondings[key] = append(unbondings[key], unbondingEntry{Height: entry.Height, Amount: entry.Amount.Neg()})
+		case entry.MessageType == staking.MsgCancelUnbondingDelegation:
+			// The cancelled tokens are taken from the latest unbonding entries
+			cancelled := entry.Amount
+			for i := len(unbondings[key]) - 1; i >= 0 && cancelled.IsPositive(); i-- {
+				taken := decimal.Min(unbondings[key][i].Amount, cancelled)
+				unbondings[key][i].Amount = unbondings[key][i].Amount.Sub(taken)
+				cancelled = cancelled.Sub(taken)
+			}
+		case entry.MessageType == staking.MsgBeginRedelegate && entry.Amount.IsNegative():
+			redelegations[key] = append(redelegations[key], unbondingEntry{
+				Height:               entry.Height,
+				Amount:               entry.Amount.Neg(),
+				DestinationValidator: redelegationDestinations[entry.MessageID],
+			})
+		}
+	}
+	applySlashesBefore(math.MaxInt64)
+
+	return losses
+}
+
+// slashTokens returns the slashed share of the tokens, truncated like the staking module does
+func slashTokens(tokens decimal.Decimal, fraction decimal.Decimal) decimal.Decimal {
+	if !tokens.IsPositive() {
+		return decimal.Zero
+	}
+	return tokens.Mul(fraction).Floor()
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/staking"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeSlashLosses(t *testing.T) {
+	entries := []stakingEntry{
+		{BlockchainID: 1, MessageID: 1, MessageType: "/cosmos.staking.v1beta1.MsgDelegate", Height: 10, ValidatorAddress: "valA", Denom: "aevmos", Amount: decimal.NewFromInt(1000)},
+		{BlockchainID: 1, MessageID: 2, MessageType: staking.MsgUndelegate, Height: 20, ValidatorAddress: "valA", Denom: "aevmos", Amount: decimal.NewFromInt(-200)},
+		{BlockchainID: 1, MessageID: 3, MessageType: staking.MsgBeginRedelegate, Height: 30, ValidatorAddress: "valA", Denom: "aevmos", Amount: decimal.NewFromInt(-300)},
+		{BlockchainID: 1, MessageID: 3, MessageType: staking.MsgBeginRedelegate, Height: 30, ValidatorAddress: "valB", Denom: "aevmos", Amount: decimal.NewFromInt(300)},
+		// Made in the block of the second slash, after it
+		{BlockchainID: 1, MessageID: 4, MessageType: "/cosmos.staking.v1beta1.MsgDelegate", Height: 50, ValidatorAddress: "valA", Denom: "aevmos", Amount: decimal.NewFromInt(1000)},
+	}
+
+	denom := Denom{ID: 1, Base: "aevmos"}
+	slashes := []operatorSlash{
+		// Double signing at height 25, after the unbonding and before the redelegation
+		{OperatorAddress: "valA", Slash: ValidatorSlash{BlockchainID: 1, Block: Block{Height: 50}, InfractionHeight: 25, Fraction: decimal.RequireFromString("0.1"), Denomination: denom}},
+		// Downtime, takes its fraction of the tokens left after the first slash
+		{OperatorAddress: "valA", Slash: ValidatorSlash{BlockchainID: 1, Block: Block{Height: 40}, InfractionHeight: 38, Fraction: decimal.RequireFromString("0.5"), Denomination: denom}},
+	}
+
+	losses := computeSlashLosses(entries, slashes)
+	assert.Len(t, losses, 2)
+
+	// 500 bonded and nothing unbonding since the infraction
+	assert.Equal(t, int64(40), losses[0].Slash.Block.Height)
+	assert.True(t, decimal.NewFromInt(250).Equal(losses[0].Amount), losses[0].Amount.String())
+
+	// 250 bonded and the 300 redelegated after the infraction, the unbonding was created before it
+	assert.Equal(t, int64(50), losses[1].Slash.Block.Height)
+	assert.True(t, decimal.NewFromInt(55).Equal(losses[1].Amount), losses[1].Amount.String())
+}
@@ -0,0 +1,362 @@
+package db
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// Vesting account types stored on VestingAccount, these match the vesting module account types
+const (
+	VestingAccountTypeContinuous      = "continuous"
+	VestingAccountTypeDelayed         = "delayed"
+	VestingAccountTypePeriodic        = "periodic"
+	VestingAccountTypePermanentLocked = "permanent_locked"
+)
+
+// Report periods of the vesting schedule
+const (
+	VestingReportPeriodDay   = "day"
+	VestingReportPeriodWeek  = "week"
+	VestingReportPeriodMonth = "month"
+	VestingReportPeriodYear  = "year"
+)
+
+var VestingReportPeriods = []string{VestingReportPeriodDay, VestingReportPeriodWeek, VestingReportPeriodMonth, VestingReportPeriodYear}
+
+func indexVestingAccount(db *gorm.DB, dbChainID uint, messageID uint, vestingAccount VestingAccountDBWrapper) error {
+	if err := db.Where(&vestingAccount.Address).FirstOrCreate(&vestingAccount.Address).Error; err != nil {
+		return err
+	}
+
+	account := VestingAccount{MessageID: messageID}
+	accountUpdates := VestingAccount{
+		BlockchainID: dbChainID,
+		AddressID:    vestingAccount.Address.ID,
+		Type:         vestingAccount.Account.Type,
+		StartTime:    vestingAccount.Account.StartTime,
+		EndTime:      vestingAccount.Account.EndTime,
+	}
+
+	if vestingAccount.FunderAddress.Address != "" {
+		if err := db.Where(&vestingAccount.FunderAddress).FirstOrCreate(&vestingAccount.FunderAddress).Error; err != nil {
+			return err
+		}
+		accountUpdates.FunderAddressID = &vestingAccount.FunderAddress.ID
+	}
+
+	if err := db.Where(account).Assign(accountUpdates).FirstOrCreate(&account).Error; err != nil {
+		return err
+	}
+
+	for _, amountL := range vestingAccount.Account.Amounts {
+		amount := VestingAmount{VestingAccountID: account.ID, DenominationID: amountL.Denomination.ID}
+		if err := db.Where(amount).Assign(VestingAmount{Amount: amountL.Amount}).FirstOrCreate(&amount).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, periodL := range vestingAccount.Account.Periods {
+		period := VestingPeriod{VestingAccountID: account.ID, PeriodIndex: periodL.PeriodIndex, DenominationID: periodL.Denomination.ID}
+		if err := db.Where(period).Assign(VestingPeriod{Length: periodL.Length, Amount: periodL.Amount}).FirstOrCreate(&period).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetVestingAccounts returns the vesting accounts of the addresses with their schedules
+func GetVestingAccounts(addresses []string, db *gorm.DB) ([]VestingAccount, error) {
+	var accounts []VestingAccount
+
+	result := db.Joins("JOIN addresses ON addresses.id = vesting_accounts.address_id").
+		Where("addresses.address IN ?", addresses).
+		Preload("Address").Preload("FunderAddress").Preload("Chain").
+		Preload("Amounts").Preload("Amounts.Denomination").
+		Preload("Periods").Preload("Periods.Denomination").
+		Preload("Message").Preload("Message.Tx").
+		Order("vesting_accounts.start_time").
+		Find(&accounts)
+
+	return accounts, result.Error
+}
+
+// VestingUnlock is the amount of a denom unlocked by a vesting account during a report period. UnlockTime is the last time the
+// account unlocked tokens in the period.
+type VestingUnlock struct {
+	Account     VestingAccount
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	UnlockTime  time.Time
+	Denom       Denom
+	Amount      decimal.Decimal
+}
+
+// VestedAmounts returns the amounts vested by the account at the given time per denom ID. This follows the vesting account
+// implementations of the Cosmos SDK, which only consider the unix second of the block time.
+func VestedAmounts(account VestingAccount, t time.Time) map[uint]decimal.Decimal {
+	vested := map[uint]decimal.Decimal{}
+	now := t.Unix()
+	start := account.StartTime.Unix()
+	end := account.EndTime.Unix()
+
+	switch account.Type {
+	case VestingAccountTypeContinuous:
+		if now <= start {
+			return vested
+		}
+		if now >= end {
+			return originalVesting(account)
+		}
+
+		share := decimal.NewFromInt(now-start).DivRound(decimal.NewFromInt(end-start), 18)
+		for _, amount := range account.Amounts {
+			vested[amount.DenominationID] = amount.Amount.Mul(share).RoundBank(0)
+		}
+	case VestingAccountTypeDelayed:
+		if now >= end {
+			return originalVesting(account)
+		}
+	case VestingAccountTypePeriodic:
+		if now <= start {
+			return vested
+		}
+
+		periodEnd := start
+		for _, periodAmounts := range groupVestingPeriods(account.Periods) {
+			periodEnd += periodAmounts[0].Length
+			if now < periodEnd {
+				break
+			}
+			for _, amount := range periodAmounts {
+				vested[amount.DenominationID] = vested[amount.DenominationID].Add(amount.Amount)
+			}
+		}
+	}
+
+	// Permanently locked accounts never vest
+	return vested
+}
+
+func originalVesting(account VestingAccount) map[uint]decimal.Decimal {
+	vested := map[uint]decimal.Decimal{}
+	for _, amount := range account.Amounts {
+		vested[amount.DenominationID] = vested[amount.DenominationID].Add(amount.Amount)
+	}
+	return vested
+}
+
+// groupVestingPeriods returns the per denom amounts of each period in period order
+func groupVestingPeriods(periods []VestingPeriod) [][]VestingPeriod {
+	sorted := make([]VestingPeriod, len(periods))
+	copy(sorted, periods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PeriodIndex < sorted[j].PeriodIndex
+	})
+
+	var grouped [][]VestingPeriod
+	for i, period := range sorted {
+		if i == 0 || period.PeriodIndex != sorted[i-1].PeriodIndex {
+			grouped = append(grouped, nil)
+		}
+		grouped[len(grouped)-1] = append(grouped[len(grouped)-1], period)
+	}
+
+	return grouped
+}
+
+// lastUnlockTime returns the last time the account unlocked tokens before the end of the report period
+func lastUnlockTime(account VestingAccount, periodStart time.Time, periodEnd time.Time) time.Time {
+	switch account.Type {
+	case VestingAccountTypePeriodic:
+		last := periodStart
+		unlock := account.StartTime
+		for _, periodAmounts := range groupVestingPeriods(account.Periods) {
+			unlock = unlock.Add(time.Duration(periodAmounts[0].Length) * time.Second)
+			if !unlock.Before(periodEnd) {
+				break
+			}
+			last = unlock
+		}
+		return last
+	case VestingAccountTypeDelayed:
+		return account.EndTime
+	default:
+		if account.EndTime.Before(periodEnd) {
+			return account.EndTime
+		}
+		return periodEnd.Add(-time.Second)
+	}
+}
+
+// TruncateToVestingReportPeriod returns the start of the report period the time is in, weeks start on Monday (UTC)
+func TruncateToVestingReportPeriod(t time.Time, period string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case VestingReportPeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case VestingReportPeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case VestingReportPeriodYear:
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func nextVestingReportPeriod(t time.Time, period string) time.Time {
+	switch period {
+	case VestingReportPeriodWeek:
+		return t.AddDate(0, 0, 7)
+	case VestingReportPeriodMonth:
+		return t.AddDate(0, 1, 0)
+	case VestingReportPeriodYear:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// ComputeVestingUnlocks returns the amounts unlocked by the account in each report period between start and end.
+// Periods without unlocks are left out.
+func ComputeVestingUnlocks(account VestingAccount, start time.Time, end time.Time, period string) []VestingUnlock {
+	var unlocks []VestingUnlock
+	if account.Type == VestingAccountTypePermanentLocked {
+		return unlocks
+	}
+
+	denoms := map[uint]Denom{}
+	for _, amount := range account.Amounts {
+		denoms[amount.DenominationID] = amount.Denomination
+	}
+	for _, period := range account.Periods {
+		denoms[period.DenominationID] = period.Denomination
+	}
+
+	denomIDs := make([]uint, 0, len(denoms))
+	for denomID := range denoms {
+		denomIDs = append(denomIDs, denomID)
+	}
+	sort.Slice(denomIDs, func(i, j int) bool { return denomIDs[i] < denomIDs[j] })
+
+	for periodStart := TruncateToVestingReportPeriod(start, period); periodStart.Before(end); periodStart = nextVestingReportPeriod(periodStart, period) {
+		periodEnd := nextVestingReportPeriod(periodStart, period)
+
+		// Tokens unlocked in [periodStart, periodEnd)
+		vestedBefore := VestedAmounts(account, periodStart.Add(-time.Second))
+		vestedAfter := VestedAmounts(account, periodEnd.Add(-time.Second))
+
+		for _, denomID := range denomIDs {
+			unlocked := vestedAfter[denomID].Sub(vestedBefore[denomID])
+			if !unlocked.IsPositive() {
+				continue
+			}
+
+			unlocks = append(unlocks, VestingUnlock{
+				Account:     account,
+				PeriodStart: periodStart,
+				PeriodEnd:   periodEnd,
+				UnlockTime:  lastUnlockTime(account, periodStart, periodEnd),
+				Denom:       denoms[denomID],
+				Amount:      unlocked,
+			})
+		}
+	}
+
+	return unlocks
+}
+
+// MaterializeVestingUnlocks stores the unlocks of the accounts between start and end as taxable events with the
+// VestingAccountUnlock source, one per account, denom and unlock boundary (see materializedVestingUnlocks) whatever the
+// report period, so the events of a boundary are replaced when materialized again. The events are stored in the first indexed
+// block at or after the unlock, unlocks after the last indexed block are skipped and the number skipped is returned.
+func MaterializeVestingUnlocks(db *gorm.DB, accounts []VestingAccount, start time.Time, end time.Time) (int, error) {
+	var unlocks []VestingUnlock
+	for _, account := range accounts {
+		unlocks = append(unlocks, materializedVestingUnlocks(account, start, end)...)
+	}
+
+	skipped := 0
+
+	err := db.Transaction(func(dbTransaction *gorm.DB) error {
+		for _, unlock := range unlocks {
+			var block Block
+			result := dbTransaction.Where("blockchain_id = ? AND time_stamp >= ?", unlock.Account.BlockchainID, unlock.UnlockTime).
+				Order("time_stamp asc").Limit(1).Find(&block)
+			if result.Error != nil {
+				return result.Error
+			}
+			if block.ID == 0 {
+				skipped++
+				continue
+			}
+
+			event := TaxableEvent{
+				Source:         VestingAccountUnlock,
+				Amount:         unlock.Amount,
+				DenominationID: unlock.Denom.ID,
+				AddressID:      unlock.Account.AddressID,
+				EventHash:      vestingUnlockHash(unlock),
+				BlockID:        block.ID,
+			}
+
+			if err := dbTransaction.Where(TaxableEvent{EventHash: event.EventHash}).
+				Assign(TaxableEvent{Amount: event.Amount, BlockID: event.BlockID}).
+				FirstOrCreate(&event).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return skipped, err
+}
+
+// materializedVestingUnlocks returns the unlocks of the account that are stored as taxable events. Periodic and delayed
+// accounts unlock at the end of their vesting periods, which are grouped per day. Continuous accounts unlock every second and
+// are grouped per month, a daily event would be stored for every day of their schedule.
+func materializedVestingUnlocks(account VestingAccount, start time.Time, end time.Time) []VestingUnlock {
+	if account.Type == VestingAccountTypeContinuous {
+		return ComputeVestingUnlocks(account, start, end, VestingReportPeriodMonth)
+	}
+	return ComputeVestingUnlocks(account, start, end, VestingReportPeriodDay)
+}
+
+// vestingUnlockHash identifies the unlock of a denom by the account by the start of its boundary, the boundaries do not depend
+// on the range materialized
+func vestingUnlockHash(unlock VestingUnlock) string {
+	hash := sha256.Sum256([]byte(fmt.Sprint("vesting", unlock.Account.ID, unlock.PeriodStart.Unix(), unlock.Denom.Base)))
+	return fmt.Sprintf("%x", hash)
+}
+
+// GetVestingReportRange returns the range of the vesting schedules of the accounts, used when the report dates are not set
+func GetVestingReportRange(accounts []VestingAccount) (time.Time, time.Time, error) {
+	var start, end time.Time
+	for _, account := range accounts {
+		if account.Type == VestingAccountTypePermanentLocked {
+			continue
+		}
+		if start.IsZero() || account.StartTime.Before(start) {
+			start = account.StartTime
+		}
+		if account.EndTime.After(end) {
+			end = account.EndTime
+		}
+	}
+
+	if start.IsZero() {
+		return start, end, errors.New("no vesting accounts with a vesting schedule")
+	}
+
+	// The end of the last vesting is included in the report
+	return start, end.Add(time.Second), nil
+}
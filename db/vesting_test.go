@@ -0,0 +1,96 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeVestingUnlocksPeriodic(t *testing.T) {
+	start := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+	denom := Denom{ID: 1, Base: "uatom"}
+	month := int64(30 * 24 * 60 * 60)
+
+	account := VestingAccount{
+		Type:      VestingAccountTypePeriodic,
+		StartTime: start,
+		EndTime:   start.Add(time.Duration(2*month) * time.Second),
+		Amounts:   []VestingAmount{{DenominationID: 1, Denomination: denom, Amount: decimal.NewFromInt(300)}},
+		Periods: []VestingPeriod{
+			{PeriodIndex: 0, DenominationID: 1, Denomination: denom, Length: month, Amount: decimal.NewFromInt(100)},
+			{PeriodIndex: 1, DenominationID: 1, Denomination: denom, Length: month, Amount: decimal.NewFromInt(200)},
+		},
+	}
+
+	unlocks := ComputeVestingUnlocks(account, start, account.EndTime.Add(time.Second), VestingReportPeriodMonth)
+	assert.Len(t, unlocks, 2)
+	assert.Equal(t, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), unlocks[0].PeriodStart)
+	assert.True(t, decimal.NewFromInt(100).Equal(unlocks[0].Amount))
+	assert.Equal(t, start.Add(time.Duration(month)*time.Second), unlocks[0].UnlockTime)
+	assert.Equal(t, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), unlocks[1].PeriodStart)
+	assert.True(t, decimal.NewFromInt(200).Equal(unlocks[1].Amount))
+}
+
+func TestComputeVestingUnlocksContinuous(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	denom := Denom{ID: 1, Base: "uatom"}
+
+	account := VestingAccount{
+		Type:      VestingAccountTypeContinuous,
+		StartTime: start,
+		EndTime:   start.AddDate(1, 0, 0),
+		Amounts:   []VestingAmount{{DenominationID: 1, Denomination: denom, Amount: decimal.NewFromInt(365000)}},
+	}
+
+	unlocks := ComputeVestingUnlocks(account, start, account.EndTime.Add(time.Second), VestingReportPeriodMonth)
+	assert.Len(t, unlocks, 12)
+
+	total := decimal.Zero
+	for _, unlock := range unlocks {
+		total = total.Add(unlock.Amount)
+	}
+	assert.True(t, decimal.NewFromInt(365000).Equal(total))
+	assert.True(t, decimal.NewFromInt(31000).Equal(unlocks[0].Amount))
+}
+
+func TestVestingUnlockHashIndependentOfRange(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	denom := Denom{ID: 1, Base: "uatom"}
+
+	account := VestingAccount{
+		ID:        7,
+		Type:      VestingAccountTypeContinuous,
+		StartTime: start,
+		EndTime:   start.AddDate(1, 0, 0),
+		Amounts:   []VestingAmount{{DenominationID: 1, Denomination: denom, Amount: decimal.NewFromInt(365000)}},
+	}
+
+	// A continuous account is materialized once per month, the unlocks of March by a yearly and a mid-day ranged run match
+	yearly := materializedVestingUnlocks(account, start, account.EndTime)
+	assert.Len(t, yearly, 12)
+	ranged := materializedVestingUnlocks(account, time.Date(2023, 3, 10, 15, 0, 0, 0, time.UTC), time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, ranged, 1)
+	assert.True(t, decimal.NewFromInt(31000).Equal(ranged[0].Amount))
+
+	var matched bool
+	for _, unlock := range yearly {
+		if vestingUnlockHash(unlock) == vestingUnlockHash(ranged[0]) {
+			matched = true
+			assert.True(t, unlock.Amount.Equal(ranged[0].Amount))
+		}
+	}
+	assert.True(t, matched)
+
+	// Periodic accounts are materialized at the end of each vesting period
+	account.Type = VestingAccountTypePeriodic
+	account.Periods = []VestingPeriod{
+		{PeriodIndex: 0, Length: 90 * 24 * 3600, DenominationID: 1, Denomination: denom, Amount: decimal.NewFromInt(100000)},
+		{PeriodIndex: 1, Length: 90 * 24 * 3600, DenominationID: 1, Denomination: denom, Amount: decimal.NewFromInt(265000)},
+	}
+	periodic := materializedVestingUnlocks(account, start, account.EndTime)
+	assert.Len(t, periodic, 2)
+	assert.Equal(t, start.AddDate(0, 0, 90), periodic[0].UnlockTime)
+	assert.True(t, decimal.NewFromInt(265000).Equal(periodic[1].Amount))
+}
@@ -0,0 +1,87 @@
+package ethcrypto
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	hexAddressPrefix = "0x"
+	addressLength    = 20
+)
+
+// Keccak256 returns the legacy Keccak256 hash used by Ethereum
+func Keccak256(data ...[]byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		hasher.Write(b)
+	}
+	return hasher.Sum(nil)
+}
+
+// IsHexAddress returns true for 0x prefixed 20 byte hex addresses
+func IsHexAddress(address string) bool {
+	_, err := HexToAddressBytes(address)
+	return err == nil
+}
+
+// HexToAddressBytes decodes a 0x prefixed 20 byte hex address
+func HexToAddressBytes(address string) ([]byte, error) {
+	if !strings.HasPrefix(strings.ToLower(address), hexAddressPrefix) {
+		return nil, fmt.Errorf("address %s is missing the 0x prefix", address)
+	}
+
+	bz, err := hex.DecodeString(address[len(hexAddressPrefix):])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) != addressLength {
+		return nil, fmt.Errorf("address %s is not %d bytes", address, addressLength)
+	}
+
+	return bz, nil
+}
+
+// TopicToAddressBytes returns the address in a 32 byte EVM log topic (left padded with zeros)
+func TopicToAddressBytes(topic string) ([]byte, error) {
+	bz, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(topic), hexAddressPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) != 32 {
+		return nil, fmt.Errorf("topic %s is not 32 bytes", topic)
+	}
+
+	return bz[32-addressLength:], nil
+}
+
+// ToChecksumHex returns the EIP-55 mixed case hex form of the address bytes
+func ToChecksumHex(address []byte) string {
+	lowerHex := hex.EncodeToString(address)
+	hash := hex.EncodeToString(Keccak256([]byte(lowerHex)))
+
+	checksummed := []byte(lowerHex)
+	for i, c := range checksummed {
+		if c >= 'a' && c <= 'f' && hash[i] >= '8' {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+
+	return hexAddressPrefix + string(checksummed)
+}
+
+// Bech32ToHex returns the EIP-55 hex form of a 20 byte Bech32 account address with any prefix
+func Bech32ToHex(address string) (string, bool) {
+	_, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil || len(bz) != addressLength {
+		return "", false
+	}
+
+	return ToChecksumHex(bz), true
+}
@@ -0,0 +1,80 @@
+package ethcrypto
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+const (
+	// KeyType is the key type of Ethermint ethsecp256k1 keys
+	KeyType = "eth_secp256k1"
+	// PubKeySize is the size of a compressed ethsecp256k1 public key, the same as a Cosmos secp256k1 public key
+	PubKeySize = 33
+	// signatureSize is the size of an Ethereum [R || S || V] signature
+	signatureSize = 65
+)
+
+var _ cryptotypes.PubKey = &PubKey{}
+
+// PubKey is an Ethermint ethsecp256k1 public key. The key is stored compressed like a Cosmos secp256k1 key,
+// but the address is derived the Ethereum way from the Keccak256 hash of the uncompressed key.
+type PubKey struct {
+	Key []byte
+}
+
+// Address returns the last 20 bytes of the Keccak256 hash of the uncompressed public key (without the 0x04 prefix)
+func (pubKey *PubKey) Address() crypto.Address {
+	pk, err := secp256k1.ParsePubKey(pubKey.Key)
+	if err != nil {
+		panic(fmt.Sprintf("invalid ethsecp256k1 public key: %v", err))
+	}
+
+	hash := Keccak256(pk.SerializeUncompressed()[1:])
+	return crypto.Address(hash[12:])
+}
+
+func (pubKey *PubKey) Bytes() []byte {
+	return pubKey.Key
+}
+
+// VerifySignature verifies an Ethereum [R || S || V] signature over the Keccak256 hash of the message
+func (pubKey *PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	if len(sig) != signatureSize && len(sig) != signatureSize-1 {
+		return false
+	}
+
+	pk, err := secp256k1.ParsePubKey(pubKey.Key)
+	if err != nil {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:64]) {
+		return false
+	}
+
+	return ecdsa.NewSignature(&r, &s).Verify(Keccak256(msg), pk)
+}
+
+func (pubKey *PubKey) Equals(other cryptotypes.PubKey) bool {
+	return pubKey.Type() == other.Type() && bytes.Equal(pubKey.Bytes(), other.Bytes())
+}
+
+func (pubKey *PubKey) Type() string {
+	return KeyType
+}
+
+func (pubKey *PubKey) Reset() {
+	*pubKey = PubKey{}
+}
+
+func (pubKey *PubKey) String() string {
+	return fmt.Sprintf("EthPubKeySecp256k1{%X}", pubKey.Key)
+}
+
+func (*PubKey) ProtoMessage() {}
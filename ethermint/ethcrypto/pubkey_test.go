@@ -0,0 +1,28 @@
+package ethcrypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubKeyAddress(t *testing.T) {
+	// The public key of the private key 1, the address is the well known Ethereum address of that key
+	key, err := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	assert.Nil(t, err)
+
+	pubKey := &PubKey{Key: key}
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", ToChecksumHex(pubKey.Address()))
+}
+
+func TestHexAddressRoundTrip(t *testing.T) {
+	address := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+	bz, err := HexToAddressBytes(address)
+	assert.Nil(t, err)
+	assert.Equal(t, address, ToChecksumHex(bz))
+
+	assert.False(t, IsHexAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA"))
+	assert.False(t, IsHexAddress("evmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq"))
+}
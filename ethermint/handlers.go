@@ -0,0 +1,13 @@
+package ethermint
+
+import (
+	txTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/modules/evm"
+)
+
+// MessageTypeHandler is used to unmarshal JSON to a particular type.
+var MessageTypeHandler = map[string][]func() txTypes.CosmosMessage{
+	evm.MsgEthereumTx:          {func() txTypes.CosmosMessage { return &evm.WrapperMsgEthereumTx{} }},
+	evm.InjectiveMsgEthereumTx: {func() txTypes.CosmosMessage { return &evm.WrapperMsgEthereumTx{} }},
+	evm.CosmosEVMMsgEthereumTx: {func() txTypes.CosmosMessage { return &evm.WrapperMsgEthereumTx{} }},
+}
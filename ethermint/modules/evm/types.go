@@ -0,0 +1,278 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/ethcrypto"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Ethermint x/evm MsgEthereumTx messages of the Ethermint forks.
+// The Ethermint protobuf types are vendored in ethermint/types and registered with the chain client. The wrapper reads the
+// EVM execution from the message log, the messages only carry the signed Ethereum tx.
+const (
+	MsgEthereumTx          = "/ethermint.evm.v1.MsgEthereumTx"
+	InjectiveMsgEthereumTx = "/injective.evm.v1.MsgEthereumTx"
+	CosmosEVMMsgEthereumTx = "/cosmos.evm.vm.v1.MsgEthereumTx"
+)
+
+// Events and attributes emitted by the x/evm module for an EVM execution
+const (
+	EventTypeEthereumTx            = "ethereum_tx"
+	EventTypeTxLog                 = "tx_log"
+	AttributeKeyRecipient          = "recipient"
+	AttributeKeyEthereumTxHash     = "ethereumTxHash"
+	AttributeKeyEthereumTxFailed   = "ethereumTxFailed"
+	AttributeKeyTxLog              = "txLog"
+	erc20TransferEventSignature    = "Transfer(address,address,uint256)"
+	erc20TransferTopicCount        = 3
+	erc20DenomPrefix               = "erc20/"
+	messageEventAttributeKeySender = "sender"
+)
+
+// evmDenom is the denom of the native EVM value (the x/evm evm_denom param).
+// This will need to be prepopulated by the indexer before messages are processed
+var evmDenom string
+
+func SetEVMDenom(denom string) {
+	evmDenom = denom
+}
+
+var erc20TransferTopic = "0x" + fmt.Sprintf("%x", ethcrypto.Keccak256([]byte(erc20TransferEventSignature)))
+
+// IsMsgEthereumTx returns true for the MsgEthereumTx type URLs of the Ethermint forks
+func IsMsgEthereumTx(typeURL string) bool {
+	return typeURL == MsgEthereumTx || typeURL == InjectiveMsgEthereumTx || typeURL == CosmosEVMMsgEthereumTx
+}
+
+// The sender set in the decoded MsgEthereumTx, the newer versions set the address bytes and the older versions the hex address
+type senderBytesMsg interface {
+	GetFrom() []byte
+}
+
+type hexSenderMsg interface {
+	GetDeprecatedFrom() string
+}
+
+// GetMsgSender returns the sender address set in a decoded MsgEthereumTx. Ethereum txs have no Cosmos signers, the sender
+// is nil when the message does not set it and has to be read from the message log instead.
+func GetMsgSender(msg sdk.Msg) sdk.AccAddress {
+	if senderMsg, ok := msg.(senderBytesMsg); ok && len(senderMsg.GetFrom()) != 0 {
+		return senderMsg.GetFrom()
+	}
+
+	if senderMsg, ok := msg.(hexSenderMsg); ok {
+		if senderBytes, err := ethcrypto.HexToAddressBytes(senderMsg.GetDeprecatedFrom()); err == nil {
+			return senderBytes
+		}
+	}
+
+	return nil
+}
+
+// FeePayerMessage is implemented by messages that pay the tx fees without a Cosmos signer info to derive the payer from
+type FeePayerMessage interface {
+	GetFeePayer() string
+}
+
+// Log is an EVM log as emitted (JSON encoded) in the txLog attribute of the tx_log event
+type Log struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    []byte   `json:"data"`
+}
+
+// Transfer is a single movement of the native EVM value or an ERC-20 token, the addresses are Bech32 and empty for mints and burns
+type Transfer struct {
+	Sender   string
+	Receiver string
+	Amount   sdk.Coin
+}
+
+type WrapperMsgEthereumTx struct {
+	txModule.Message
+	Sender         string
+	Recipient      string
+	EthereumTxHash string
+	Failed         bool
+	Transfers      []Transfer
+}
+
+// GetERC20Denom returns the denom used for the ERC-20 token contract, in the same erc20/{contract} format as the Evmos x/erc20 module
+func GetERC20Denom(contract []byte) string {
+	return erc20DenomPrefix + ethcrypto.ToChecksumHex(contract)
+}
+
+// hexToBech32 converts a hex EVM address to the Bech32 account address of the chain, the zero address (mints and burns) is empty
+func hexToBech32(address []byte) string {
+	if bytes.Equal(address, make([]byte, len(address))) {
+		return ""
+	}
+	return sdk.AccAddress(address).String()
+}
+
+func (sf *WrapperMsgEthereumTx) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.Sender = getEVMSender(log)
+	if sf.Sender == "" {
+		return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+	}
+
+	ethereumTxEvent := txModule.GetEventWithType(EventTypeEthereumTx, log)
+	if ethereumTxEvent == nil {
+		return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+	}
+
+	sf.EthereumTxHash = txModule.GetLastValueForAttribute(AttributeKeyEthereumTxHash, ethereumTxEvent)
+
+	// Reverted EVM executions are still successful Cosmos txs, only the fees were paid
+	sf.Failed = txModule.GetLastValueForAttribute(AttributeKeyEthereumTxFailed, ethereumTxEvent) != ""
+	if sf.Failed {
+		return nil
+	}
+
+	// Contract creations have no recipient
+	if recipient := txModule.GetLastValueForAttribute(AttributeKeyRecipient, ethereumTxEvent); recipient != "" {
+		recipientBytes, err := ethcrypto.HexToAddressBytes(recipient)
+		if err != nil {
+			return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		}
+		sf.Recipient = hexToBech32(recipientBytes)
+	}
+
+	value, ok := math.NewIntFromString(txModule.GetLastValueForAttribute(txModule.EventAttributeAmount, ethereumTxEvent))
+	if ok && value.IsPositive() {
+		if evmDenom == "" {
+			return errors.New("the EVM denom is not set for this chain, cannot parse the MsgEthereumTx value")
+		}
+		sf.Transfers = append(sf.Transfers, Transfer{Sender: sf.Sender, Receiver: sf.Recipient, Amount: sdk.NewCoin(evmDenom, value)})
+	}
+
+	for _, txLogEvent := range txModule.GetEventsWithType(EventTypeTxLog, log) {
+		for _, attr := range txLogEvent.Attributes {
+			if attr.Key != AttributeKeyTxLog {
+				continue
+			}
+
+			var evmLog Log
+			if err := json.Unmarshal([]byte(attr.Value), &evmLog); err != nil {
+				return fmt.Errorf("error parsing EVM log for MsgEthereumTx: %w", err)
+			}
+
+			transfer, ok, err := parseERC20Transfer(evmLog)
+			if err != nil {
+				return fmt.Errorf("error parsing ERC-20 transfer for MsgEthereumTx: %w", err)
+			}
+			if ok {
+				sf.Transfers = append(sf.Transfers, transfer)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getEVMSender returns the Bech32 address of the EVM sender. The x/evm module emits the hex address of the sender in the message event,
+// newer SDK versions also emit the Bech32 signer in the same event.
+func getEVMSender(log *txModule.LogMessage) string {
+	for _, messageEvent := range txModule.GetEventsWithType("message", log) {
+		for _, attr := range messageEvent.Attributes {
+			if attr.Key != messageEventAttributeKeySender {
+				continue
+			}
+
+			if senderBytes, err := ethcrypto.HexToAddressBytes(attr.Value); err == nil {
+				return sdk.AccAddress(senderBytes).String()
+			}
+
+			if senderAddress, err := sdk.AccAddressFromBech32(attr.Value); err == nil {
+				return senderAddress.String()
+			}
+		}
+	}
+
+	return ""
+}
+
+// parseERC20Transfer parses ERC-20 Transfer logs. ERC-721 tokens emit the same event with the token ID as a 4th topic, those are skipped.
+func parseERC20Transfer(evmLog Log) (Transfer, bool, error) {
+	if len(evmLog.Topics) != erc20TransferTopicCount || !strings.EqualFold(evmLog.Topics[0], erc20TransferTopic) {
+		return Transfer{}, false, nil
+	}
+
+	contract, err := ethcrypto.HexToAddressBytes(evmLog.Address)
+	if err != nil {
+		return Transfer{}, false, err
+	}
+
+	sender, err := ethcrypto.TopicToAddressBytes(evmLog.Topics[1])
+	if err != nil {
+		return Transfer{}, false, err
+	}
+
+	receiver, err := ethcrypto.TopicToAddressBytes(evmLog.Topics[2])
+	if err != nil {
+		return Transfer{}, false, err
+	}
+
+	amount := math.NewIntFromBigInt(new(big.Int).SetBytes(evmLog.Data))
+	if !amount.IsPositive() {
+		return Transfer{}, false, nil
+	}
+
+	return Transfer{
+		Sender:   hexToBech32(sender),
+		Receiver: hexToBech32(receiver),
+		Amount:   sdk.NewCoin(GetERC20Denom(contract), amount),
+	}, true, nil
+}
+
+func (sf *WrapperMsgEthereumTx) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	relevantData := make([]parsingTypes.MessageRelevantInformation, 0, len(sf.Transfers))
+	for _, transfer := range sf.Transfers {
+		relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+			AmountSent:           transfer.Amount.Amount.BigInt(),
+			DenominationSent:     transfer.Amount.Denom,
+			AmountReceived:       transfer.Amount.Amount.BigInt(),
+			DenominationReceived: transfer.Amount.Denom,
+			SenderAddress:        transfer.Sender,
+			ReceiverAddress:      transfer.Receiver,
+		})
+	}
+	return relevantData
+}
+
+// GetFeePayer returns the EVM sender, Ethereum txs have no Cosmos signer infos to derive the fee payer from
+func (sf *WrapperMsgEthereumTx) GetFeePayer() string {
+	return sf.Sender
+}
+
+func (sf *WrapperMsgEthereumTx) String() string {
+	if sf.Failed {
+		return fmt.Sprintf("MsgEthereumTx: %s failed EVM tx %s", sf.Sender, sf.EthereumTxHash)
+	}
+
+	var transfers []string
+	for _, transfer := range sf.Transfers {
+		transfers = append(transfers, fmt.Sprintf("%s from %s to %s", transfer.Amount, transfer.Sender, transfer.Receiver))
+	}
+
+	return fmt.Sprintf("MsgEthereumTx: %s executed EVM tx %s to %s, transfers: %s", sf.Sender, sf.EthereumTxHash, sf.Recipient, strings.Join(transfers, ", "))
+}
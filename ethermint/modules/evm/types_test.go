@@ -0,0 +1,94 @@
+package evm
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/ethcrypto"
+	ethermintTypes "github.com/DefiantLabs/cosmos-tax-cli/ethermint/types/ethermint"
+	lensClient "github.com/DefiantLabs/lens/client"
+
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptoTypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// The public key of the private key 1 and its well known Ethereum address
+const (
+	testPubKey  = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	testAddress = "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"
+)
+
+func newTestClient() *lensClient.ChainClient {
+	cl := &lensClient.ChainClient{Codec: lensClient.MakeCodec(lensClient.ModuleBasics, "evmos", "evmosvaloper")}
+	config.RegisterAdditionalTypes(cl)
+	return cl
+}
+
+func TestDecodeEthereumTx(t *testing.T) {
+	cl := newTestClient()
+
+	txData, err := codecTypes.NewAnyWithValue(&ethermintTypes.DynamicFeeTx{ChainId: "9001", Nonce: 1, Gas: 21000, To: testAddress, Value: "1000"})
+	assert.Nil(t, err)
+	extension, err := codecTypes.NewAnyWithValue(&ethermintTypes.ExtensionOptionsEthereumTx{})
+	assert.Nil(t, err)
+
+	txBuilder := cl.Codec.TxConfig.NewTxBuilder()
+	assert.Nil(t, txBuilder.SetMsgs(&ethermintTypes.MsgEthereumTx{Data: txData, DeprecatedFrom: testAddress}))
+	txBuilder.(authTx.ExtensionOptionsTxBuilder).SetExtensionOptions(extension)
+	txBytes, err := cl.Codec.TxConfig.TxEncoder()(txBuilder.GetTx())
+	assert.Nil(t, err)
+
+	decodedTx, err := cl.Codec.TxConfig.TxDecoder()(txBytes)
+	assert.Nil(t, err)
+	assert.Len(t, decodedTx.GetMsgs(), 1)
+
+	msg := decodedTx.GetMsgs()[0]
+	assert.True(t, IsMsgEthereumTx(sdk.MsgTypeURL(msg)))
+	ethereumTx, ok := msg.(*ethermintTypes.MsgEthereumTx)
+	assert.True(t, ok)
+	_, ok = ethereumTx.Data.GetCachedValue().(*ethermintTypes.DynamicFeeTx)
+	assert.True(t, ok)
+
+	// Ethereum txs have no Cosmos signers, the sender is read from the message
+	_, _, err = cl.Codec.Marshaler.GetMsgV1Signers(msg)
+	assert.NotNil(t, err)
+	assert.Equal(t, testAddress, ethcrypto.ToChecksumHex(GetMsgSender(msg)))
+	assert.Nil(t, GetMsgSender(&ethermintTypes.MsgEthereumTx{}))
+}
+
+func TestDecodeEthSecp256k1SignedTx(t *testing.T) {
+	cl := newTestClient()
+
+	key, err := hex.DecodeString(testPubKey)
+	assert.Nil(t, err)
+	pubKey := &ethermintTypes.PubKey{Key: key}
+	sender := sdk.AccAddress(pubKey.Address())
+
+	extension, err := codecTypes.NewAnyWithValue(&ethermintTypes.ExtensionOptionsWeb3Tx{TypedDataChainId: 9001, FeePayer: sender.String()})
+	assert.Nil(t, err)
+
+	txBuilder := cl.Codec.TxConfig.NewTxBuilder()
+	assert.Nil(t, txBuilder.SetMsgs(bankTypes.NewMsgSend(sender, sender, sdk.NewCoins(sdk.NewInt64Coin("aevmos", 1)))))
+	txBuilder.(authTx.ExtensionOptionsTxBuilder).SetExtensionOptions(extension)
+	assert.Nil(t, txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey: pubKey,
+		Data:   &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, Signature: make([]byte, 65)},
+	}))
+	txBytes, err := cl.Codec.TxConfig.TxEncoder()(txBuilder.GetTx())
+	assert.Nil(t, err)
+
+	decodedTx, err := cl.Codec.TxConfig.TxDecoder()(txBytes)
+	assert.Nil(t, err)
+
+	pubKeys, err := decodedTx.(authTx.ExtensionOptionsTxBuilder).GetTx().GetPubKeys()
+	assert.Nil(t, err)
+	assert.Len(t, pubKeys, 1)
+	assert.Equal(t, ethcrypto.KeyType, pubKeys[0].Type())
+	assert.Equal(t, testAddress, ethcrypto.ToChecksumHex(pubKeys[0].(cryptoTypes.PubKey).Address()))
+}
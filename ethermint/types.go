@@ -0,0 +1,43 @@
+package ethermint
+
+import "regexp"
+
+// EVM chains built on Ethermint, keyed by chain ID with the denom of the native EVM value (the x/evm evm_denom param)
+const (
+	EvmosChainID     = "evmos_9001-2"
+	CronosChainID    = "cronosmainnet_25-1"
+	InjectiveChainID = "injective-1"
+)
+
+var evmDenoms = map[string]string{
+	EvmosChainID:     "aevmos",
+	CronosChainID:    "basecro",
+	InjectiveChainID: "inj",
+}
+
+// Ethermint chain IDs have the {identifier}_{EIP155 chain ID}-{version} format
+var ethermintChainIDRegex = regexp.MustCompile(`^[a-z]+_[1-9][0-9]*-[1-9][0-9]*$`)
+
+// The ethsecp256k1 public key types of the Ethermint forks, the keys share the protobuf layout of the Cosmos secp256k1 keys
+var ethSecp256k1PubKeyTypeURLs = map[string]bool{
+	"/ethermint.crypto.v1.ethsecp256k1.PubKey":      true,
+	"/injective.crypto.v1beta1.ethsecp256k1.PubKey": true,
+	"/cosmos.evm.crypto.v1.ethsecp256k1.PubKey":     true,
+}
+
+// IsEVMChain returns true for the known Ethermint chains and chain IDs in the Ethermint format
+func IsEVMChain(chainID string) bool {
+	_, ok := evmDenoms[chainID]
+	return ok || ethermintChainIDRegex.MatchString(chainID)
+}
+
+// GetEVMDenom returns the denom of the native EVM value for the chain
+func GetEVMDenom(chainID string) (string, bool) {
+	denom, ok := evmDenoms[chainID]
+	return denom, ok
+}
+
+// IsEthSecp256k1PubKeyType returns true if the Any type URL is an ethsecp256k1 public key
+func IsEthSecp256k1PubKeyType(typeURL string) bool {
+	return ethSecp256k1PubKeyTypeURLs[typeURL]
+}
@@ -0,0 +1,79 @@
+package ethermint
+
+import (
+	"github.com/DefiantLabs/cosmos-tax-cli/ethermint/ethcrypto"
+	"github.com/cometbft/cometbft/crypto"
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptoTypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// TxData is the inner tx data of a MsgEthereumTx
+type TxData interface {
+	proto.Message
+}
+
+// RegisterInterfaces registers the x/evm messages, the tx extension options and the ethsecp256k1 public key with the
+// interface registry, so the Ethereum txs and the Cosmos txs signed with Ethereum keys can be decoded
+func RegisterInterfaces(registry codecTypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgEthereumTx{},
+	)
+
+	registry.RegisterInterface("ethermint.evm.v1.TxData", (*TxData)(nil),
+		&LegacyTx{},
+		&AccessListTx{},
+		&DynamicFeeTx{},
+	)
+
+	registry.RegisterImplementations((*tx.TxExtensionOptionI)(nil),
+		&ExtensionOptionsEthereumTx{},
+		&ExtensionOptionsWeb3Tx{},
+		&ExtensionOptionDynamicFeeTx{},
+	)
+
+	registry.RegisterImplementations((*cryptoTypes.PubKey)(nil),
+		&PubKey{},
+	)
+}
+
+var _ codecTypes.UnpackInterfacesMessage = &MsgEthereumTx{}
+
+// UnpackInterfaces unpacks the inner tx data of the message
+func (msg *MsgEthereumTx) UnpackInterfaces(unpacker codecTypes.AnyUnpacker) error {
+	var data TxData
+	return unpacker.UnpackAny(msg.Data, &data)
+}
+
+var _ cryptoTypes.PubKey = &PubKey{}
+
+// Address returns the Ethereum address of the key, see ethcrypto.PubKey
+func (pubKey *PubKey) Address() crypto.Address {
+	return pubKey.ethPubKey().Address()
+}
+
+func (pubKey *PubKey) Bytes() []byte {
+	return pubKey.Key
+}
+
+func (pubKey *PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	return pubKey.ethPubKey().VerifySignature(msg, sig)
+}
+
+func (pubKey *PubKey) Equals(other cryptoTypes.PubKey) bool {
+	return pubKey.ethPubKey().Equals(other)
+}
+
+func (pubKey *PubKey) Type() string {
+	return ethcrypto.KeyType
+}
+
+func (pubKey *PubKey) String() string {
+	return pubKey.ethPubKey().String()
+}
+
+func (pubKey *PubKey) ethPubKey() *ethcrypto.PubKey {
+	return &ethcrypto.PubKey{Key: pubKey.Key}
+}
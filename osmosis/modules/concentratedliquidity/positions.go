@@ -0,0 +1,242 @@
+package concentratedliquidity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdkMath "cosmossdk.io/math"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Actions recorded in the position ledger for a single concentrated liquidity position
+const (
+	PositionActionCreate        = "create"
+	PositionActionWithdraw      = "withdraw"
+	PositionActionSpreadRewards = "spread_rewards"
+	PositionActionIncentives    = "incentives"
+	PositionActionTransfer      = "transfer"
+)
+
+const (
+	createPositionEvent           = "create_position"
+	withdrawPositionEvent         = "withdraw_position"
+	collectSpreadRewardsEvent     = "collect_spread_rewards"
+	collectIncentivesEvent        = "collect_incentives"
+	transferPositionsEvent        = "transfer_positions"
+	positionIDAttribute           = "position_id"
+	inputPositionIDsAttribute     = "input_position_ids"
+	newOwnerAttribute             = "new_owner"
+	moduleAttribute               = "module"
+	liquidityChangeAmount0        = "amount0"
+	liquidityChangeAmount1        = "amount1"
+	liquidityChangeLiquidityDelta = "liquidity"
+)
+
+// PositionLedgerEntry is a single change to a concentrated liquidity position as parsed from a message log.
+// Position metadata (pool, ticks) is only present on create and withdraw entries, the events for rewards and
+// transfers do not include it.
+type PositionLedgerEntry struct {
+	PositionID uint64
+	PoolID     uint64
+	LowerTick  int64
+	UpperTick  int64
+	Action     string
+	Owner      string
+	NewOwner   string
+	Liquidity  sdkMath.LegacyDec
+	Tokens     sdk.Coins
+}
+
+// PositionLedgerMessage is implemented by message wrappers that change the state of concentrated liquidity positions.
+type PositionLedgerMessage interface {
+	ParsePositionLedger() []PositionLedgerEntry
+}
+
+// parsePositionLedger builds the ledger entries for every position touched in the message log.
+// Legacy ABCI logs merge all events of the same type into one, so each event is split on its leading module attribute.
+func parsePositionLedger(owner string, log *txModule.LogMessage) ([]PositionLedgerEntry, error) {
+	var entries []PositionLedgerEntry
+	usedCoinEvents := map[string]map[int]bool{"coin_spent": {}, "coin_received": {}}
+
+	// Withdrawals must be parsed first, AddToPosition withdraws the old position before creating the new one
+	for _, eventType := range []string{withdrawPositionEvent, createPositionEvent} {
+		for _, attributes := range txModule.SplitEventsOnAttribute(eventType, moduleAttribute, log) {
+			entry, err := parseLiquidityChange(eventType, attributes)
+			if err != nil {
+				return nil, err
+			}
+
+			coinEventType, addressKey := "coin_spent", "spender"
+			if eventType == withdrawPositionEvent {
+				coinEventType, addressKey = "coin_received", "receiver"
+			}
+
+			entry.Tokens = matchLiquidityChangeTokens(entry.Owner, coinEventType, addressKey, attributes[liquidityChangeAmount0], attributes[liquidityChangeAmount1], log, usedCoinEvents[coinEventType])
+			entries = append(entries, entry)
+		}
+	}
+
+	rewardEvents := map[string]string{
+		collectSpreadRewardsEvent: PositionActionSpreadRewards,
+		collectIncentivesEvent:    PositionActionIncentives,
+	}
+
+	for _, eventType := range []string{collectSpreadRewardsEvent, collectIncentivesEvent} {
+		for _, attributes := range txModule.SplitEventsOnAttribute(eventType, moduleAttribute, log) {
+			positionID, err := strconv.ParseUint(attributes[positionIDAttribute], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing position ID from %s event: %w", eventType, err)
+			}
+
+			poolID, err := strconv.ParseUint(attributes["pool_id"], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing pool ID from %s event: %w", eventType, err)
+			}
+
+			var tokens sdk.Coins
+			if attributes[tokensOutEvent] != "" {
+				tokens, err = sdk.ParseCoinsNormalized(attributes[tokensOutEvent])
+				if err != nil {
+					return nil, fmt.Errorf("error parsing tokens out from %s event: %w", eventType, err)
+				}
+			}
+
+			entries = append(entries, PositionLedgerEntry{
+				PositionID: positionID,
+				PoolID:     poolID,
+				Action:     rewardEvents[eventType],
+				Owner:      owner,
+				Tokens:     tokens,
+			})
+		}
+	}
+
+	for _, attributes := range txModule.SplitEventsOnAttribute(transferPositionsEvent, moduleAttribute, log) {
+		positionIDs, err := parsePositionIDList(attributes[inputPositionIDsAttribute])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, positionID := range positionIDs {
+			entries = append(entries, PositionLedgerEntry{
+				PositionID: positionID,
+				Action:     PositionActionTransfer,
+				Owner:      owner,
+				NewOwner:   attributes[newOwnerAttribute],
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+func parseLiquidityChange(eventType string, attributes map[string]string) (PositionLedgerEntry, error) {
+	entry := PositionLedgerEntry{
+		Action: PositionActionCreate,
+		Owner:  attributes["sender"],
+	}
+
+	if eventType == withdrawPositionEvent {
+		entry.Action = PositionActionWithdraw
+	}
+
+	var err error
+	entry.PositionID, err = strconv.ParseUint(attributes[positionIDAttribute], 10, 64)
+	if err != nil {
+		return entry, fmt.Errorf("error parsing position ID from %s event: %w", eventType, err)
+	}
+
+	entry.PoolID, err = strconv.ParseUint(attributes["pool_id"], 10, 64)
+	if err != nil {
+		return entry, fmt.Errorf("error parsing pool ID from %s event: %w", eventType, err)
+	}
+
+	entry.LowerTick, err = strconv.ParseInt(attributes["lower_tick"], 10, 64)
+	if err != nil {
+		return entry, fmt.Errorf("error parsing lower tick from %s event: %w", eventType, err)
+	}
+
+	entry.UpperTick, err = strconv.ParseInt(attributes["upper_tick"], 10, 64)
+	if err != nil {
+		return entry, fmt.Errorf("error parsing upper tick from %s event: %w", eventType, err)
+	}
+
+	entry.Liquidity, err = sdkMath.LegacyNewDecFromStr(attributes[liquidityChangeLiquidityDelta])
+	if err != nil {
+		return entry, fmt.Errorf("error parsing liquidity from %s event: %w", eventType, err)
+	}
+
+	return entry, nil
+}
+
+// The liquidity change events only carry the raw amount0 and amount1 of the pool assets.
+// The denoms are recovered by finding the single bank send between the owner and the pool that moved exactly those amounts.
+func matchLiquidityChangeTokens(owner string, coinEventType string, addressKey string, amount0 string, amount1 string, log *txModule.LogMessage, used map[int]bool) sdk.Coins {
+	var expected []string
+	for _, amount := range []string{amount0, amount1} {
+		if amount != "" && amount != "0" {
+			expected = append(expected, amount)
+		}
+	}
+
+	if len(expected) == 0 {
+		return nil
+	}
+
+	for i, attributes := range txModule.SplitEventsOnAttribute(coinEventType, addressKey, log) {
+		if used[i] || attributes[addressKey] != owner {
+			continue
+		}
+
+		coins, err := sdk.ParseCoinsNormalized(attributes[txModule.EventAttributeAmount])
+		if err != nil || len(coins) != len(expected) {
+			continue
+		}
+
+		remaining := append([]string{}, expected...)
+		matched := true
+		for _, coin := range coins {
+			found := false
+			for j, amount := range remaining {
+				if coin.Amount.String() == amount {
+					remaining = append(remaining[:j], remaining[j+1:]...)
+					found = true
+					break
+				}
+			}
+			if !found {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			used[i] = true
+			return coins
+		}
+	}
+
+	return nil
+}
+
+// Osmosis formats the transferred position IDs as a comma separated list (e.g. 1, 2, 3)
+func parsePositionIDList(value string) ([]uint64, error) {
+	var positionIDs []uint64
+
+	for _, positionIDString := range strings.Split(value, ",") {
+		positionIDString = strings.TrimSpace(positionIDString)
+		if positionIDString == "" {
+			continue
+		}
+
+		positionID, err := strconv.ParseUint(positionIDString, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing transferred position ID %s: %w", positionIDString, err)
+		}
+		positionIDs = append(positionIDs, positionID)
+	}
+
+	return positionIDs, nil
+}
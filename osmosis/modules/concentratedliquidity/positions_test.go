@@ -0,0 +1,74 @@
+package concentratedliquidity
+
+import (
+	"testing"
+
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func liquidityChangeEvent(eventType, positionID, liquidity, amount0, amount1 string) txModule.LogMessageEvent {
+	return txModule.LogMessageEvent{
+		Type: eventType,
+		Attributes: []txModule.Attribute{
+			{Key: "module", Value: "concentratedliquidity"},
+			{Key: "position_id", Value: positionID},
+			{Key: "sender", Value: "osmo1owner"},
+			{Key: "pool_id", Value: "1066"},
+			{Key: "lower_tick", Value: "-100"},
+			{Key: "upper_tick", Value: "100"},
+			{Key: "join_time", Value: "2023-08-01 00:00:00 +0000 UTC"},
+			{Key: "liquidity", Value: liquidity},
+			{Key: "amount0", Value: amount0},
+			{Key: "amount1", Value: amount1},
+		},
+	}
+}
+
+func TestParsePositionLedgerAddToPosition(t *testing.T) {
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "coin_received", Attributes: []txModule.Attribute{
+				{Key: "receiver", Value: "osmo1owner"},
+				{Key: "amount", Value: "5uion"},
+				{Key: "receiver", Value: "osmo1owner"},
+				{Key: "amount", Value: "100uatom,200uosmo"},
+			}},
+			{Type: "coin_spent", Attributes: []txModule.Attribute{
+				{Key: "spender", Value: "osmo1owner"},
+				{Key: "amount", Value: "150uatom,300uosmo"},
+			}},
+			liquidityChangeEvent("withdraw_position", "1", "-1000.000000000000000000", "200", "100"),
+			liquidityChangeEvent("create_position", "2", "1500.000000000000000000", "300", "150"),
+			{Type: "collect_spread_rewards", Attributes: []txModule.Attribute{
+				{Key: "module", Value: "concentratedliquidity"},
+				{Key: "pool_id", Value: "1066"},
+				{Key: "position_id", Value: "1"},
+				{Key: "tokens_out", Value: "5uion"},
+			}},
+		},
+	}
+
+	entries, err := parsePositionLedger("osmo1owner", log)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+
+	assert.Equal(t, PositionActionWithdraw, entries[0].Action)
+	assert.Equal(t, uint64(1), entries[0].PositionID)
+	assert.Equal(t, int64(-100), entries[0].LowerTick)
+	assert.Equal(t, "100uatom,200uosmo", entries[0].Tokens.String(), "withdrawal denoms are matched from the bank receive")
+
+	assert.Equal(t, PositionActionCreate, entries[1].Action)
+	assert.Equal(t, uint64(2), entries[1].PositionID)
+	assert.Equal(t, "150uatom,300uosmo", entries[1].Tokens.String(), "deposit denoms are matched from the bank spend")
+
+	assert.Equal(t, PositionActionSpreadRewards, entries[2].Action)
+	assert.Equal(t, uint64(1), entries[2].PositionID)
+	assert.Equal(t, "5uion", entries[2].Tokens.String())
+}
+
+func TestParsePositionIDList(t *testing.T) {
+	positionIDs, err := parsePositionIDList("1, 22, 333")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 22, 333}, positionIDs)
+}
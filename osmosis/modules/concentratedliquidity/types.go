@@ -29,6 +29,7 @@ type WrapperMsgCreatePosition struct {
 	OsmosisMsgCreatePosition *clTypes.MsgCreatePosition
 	TokensSent               sdk.Coins
 	Address                  string
+	PositionLedger           []PositionLedgerEntry
 }
 
 func (sf *WrapperMsgCreatePosition) String() string {
@@ -69,9 +70,19 @@ func (sf *WrapperMsgCreatePosition) HandleMsg(msgType string, msg sdk.Msg, log *
 
 	sf.Address = sf.OsmosisMsgCreatePosition.Sender
 
+	positionLedger, err := parsePositionLedger(sf.Address, log)
+	if err != nil {
+		return err
+	}
+	sf.PositionLedger = positionLedger
+
 	return nil
 }
 
+func (sf *WrapperMsgCreatePosition) ParsePositionLedger() []PositionLedgerEntry {
+	return sf.PositionLedger
+}
+
 func (sf *WrapperMsgCreatePosition) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
 	relevantData := make([]parsingTypes.MessageRelevantInformation, 0)
 
@@ -92,6 +103,7 @@ type WrapperMsgWithdrawPosition struct {
 	OsmosisMsgWithdrawPosition *clTypes.MsgWithdrawPosition
 	TokensRecieved             sdk.Coins
 	Address                    string
+	PositionLedger             []PositionLedgerEntry
 }
 
 func (sf *WrapperMsgWithdrawPosition) String() string {
@@ -132,9 +144,19 @@ func (sf *WrapperMsgWithdrawPosition) HandleMsg(msgType string, msg sdk.Msg, log
 
 	sf.Address = sf.OsmosisMsgWithdrawPosition.Sender
 
+	positionLedger, err := parsePositionLedger(sf.Address, log)
+	if err != nil {
+		return err
+	}
+	sf.PositionLedger = positionLedger
+
 	return nil
 }
 
+func (sf *WrapperMsgWithdrawPosition) ParsePositionLedger() []PositionLedgerEntry {
+	return sf.PositionLedger
+}
+
 func (sf *WrapperMsgWithdrawPosition) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
 	relevantData := make([]parsingTypes.MessageRelevantInformation, 0)
 	for _, token := range sf.TokensRecieved {
@@ -154,6 +176,7 @@ type WrapperMsgCollectSpreadRewards struct {
 	OsmosisMsgCollectSpreadRewards *clTypes.MsgCollectSpreadRewards
 	TokensRecieved                 sdk.Coins
 	Address                        string
+	PositionLedger                 []PositionLedgerEntry
 }
 
 func (sf *WrapperMsgCollectSpreadRewards) String() string {
@@ -208,9 +231,19 @@ func (sf *WrapperMsgCollectSpreadRewards) HandleMsg(msgType string, msg sdk.Msg,
 
 	sf.Address = sf.OsmosisMsgCollectSpreadRewards.Sender
 
+	positionLedger, err := parsePositionLedger(sf.Address, log)
+	if err != nil {
+		return err
+	}
+	sf.PositionLedger = positionLedger
+
 	return nil
 }
 
+func (sf *WrapperMsgCollectSpreadRewards) ParsePositionLedger() []PositionLedgerEntry {
+	return sf.PositionLedger
+}
+
 func (sf *WrapperMsgCollectSpreadRewards) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
 	relevantData := make([]parsingTypes.MessageRelevantInformation, 0)
 	for _, token := range sf.TokensRecieved {
@@ -291,6 +324,7 @@ type WrapperMsgCollectIncentives struct {
 	OsmosisMsgCollectIncentives *clTypes.MsgCollectIncentives
 	TokensRecv                  sdk.Coins
 	Address                     string
+	PositionLedger              []PositionLedgerEntry
 }
 
 func (sf *WrapperMsgCollectIncentives) String() string {
@@ -328,9 +362,19 @@ func (sf *WrapperMsgCollectIncentives) HandleMsg(msgType string, msg sdk.Msg, lo
 
 	sf.Address = sf.OsmosisMsgCollectIncentives.Sender
 
+	positionLedger, err := parsePositionLedger(sf.Address, log)
+	if err != nil {
+		return err
+	}
+	sf.PositionLedger = positionLedger
+
 	return nil
 }
 
+func (sf *WrapperMsgCollectIncentives) ParsePositionLedger() []PositionLedgerEntry {
+	return sf.PositionLedger
+}
+
 func (sf *WrapperMsgCollectIncentives) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
 	relevantData := make([]parsingTypes.MessageRelevantInformation, 0)
 
@@ -353,6 +397,7 @@ type WrapperMsgAddToPosition struct {
 	TokensRecv              sdk.Coins
 	TokensSent              sdk.Coins
 	Address                 string
+	PositionLedger          []PositionLedgerEntry
 }
 
 func (sf *WrapperMsgAddToPosition) String() string {
@@ -429,9 +474,19 @@ func (sf *WrapperMsgAddToPosition) HandleMsg(msgType string, msg sdk.Msg, log *t
 
 	sf.Address = sf.OsmosisMsgAddToPosition.Sender
 
+	positionLedger, err := parsePositionLedger(sf.Address, log)
+	if err != nil {
+		return err
+	}
+	sf.PositionLedger = positionLedger
+
 	return nil
 }
 
+func (sf *WrapperMsgAddToPosition) ParsePositionLedger() []PositionLedgerEntry {
+	return sf.PositionLedger
+}
+
 func (sf *WrapperMsgAddToPosition) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
 	relevantData := make([]parsingTypes.MessageRelevantInformation, 0)
 
@@ -463,6 +518,7 @@ type WrapperMsgTransferPositions struct {
 	OsmosisMsgTransferPositions *clTypes.MsgTransferPositions
 	TokensRecv                  sdk.Coins
 	Address                     string
+	PositionLedger              []PositionLedgerEntry
 }
 
 func (sf *WrapperMsgTransferPositions) String() string {
@@ -519,9 +575,19 @@ func (sf *WrapperMsgTransferPositions) HandleMsg(msgType string, msg sdk.Msg, lo
 
 	sf.Address = sf.OsmosisMsgTransferPositions.Sender
 
+	positionLedger, err := parsePositionLedger(sf.Address, log)
+	if err != nil {
+		return err
+	}
+	sf.PositionLedger = positionLedger
+
 	return nil
 }
 
+func (sf *WrapperMsgTransferPositions) ParsePositionLedger() []PositionLedgerEntry {
+	return sf.PositionLedger
+}
+
 func (sf *WrapperMsgTransferPositions) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
 	relevantData := make([]parsingTypes.MessageRelevantInformation, 0)
 
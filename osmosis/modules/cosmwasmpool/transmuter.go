@@ -0,0 +1,247 @@
+package cosmwasmpool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	wasmTypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmwasm/modules/wasm"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	cosmwasmPoolModelTypes "github.com/osmosis-labs/osmosis/v26/x/cosmwasmpool/model"
+)
+
+// Execute messages users send directly to transmuter pool contracts. Swaps are not executed on the contract directly,
+// they are routed by the poolmanager (through sudo) and parsed by the poolmanager swap messages.
+const (
+	TransmuterJoinPool = "join_pool"
+	TransmuterExitPool = "exit_pool"
+)
+
+// Transmuter contracts are instantiated with the pool asset denoms (v1) or the pool asset configs (v2+)
+var transmuterInstantiateFields = []string{"pool_asset_denoms", "pool_asset_configs"}
+
+var _ wasm.ContractExecutionMessageHandlerByContractAddress = &WrapperMsgExecuteTransmuter{}
+
+// WrapperMsgExecuteTransmuter handles MsgExecuteContract executions on a single transmuter pool contract
+type WrapperMsgExecuteTransmuter struct {
+	txModule.Message
+	CosmWasmMsgExecuteContract *wasmTypes.MsgExecuteContract
+	PoolID                     uint64
+	PoolContractAddress        string
+	Action                     string
+	Address                    string
+	TokensIn                   sdk.Coins
+	TokensOut                  sdk.Coins
+}
+
+// IsTransmuterPool checks the pool instantiate message for the fields only transmuter contracts are instantiated with
+func IsTransmuterPool(pool cosmwasmPoolModelTypes.CosmWasmPool) bool {
+	var instantiateMsg map[string]json.RawMessage
+	if err := json.Unmarshal(pool.InstantiateMsg, &instantiateMsg); err != nil {
+		return false
+	}
+
+	for _, field := range transmuterInstantiateFields {
+		if _, ok := instantiateMsg[field]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+var _ wasm.ContractExecutionMessageHandlerResolver = &TransmuterPoolResolver{}
+
+// TransmuterPoolResolver resolves transmuter pool handlers from the CosmWasm pools that exist at the height being indexed,
+// so executions from before a pool was created or reconfigured are parsed with the pool configuration of their block.
+type TransmuterPoolResolver struct {
+	// GetPools queries the CosmWasm pools at a height
+	GetPools func(height int64) ([]cosmwasmPoolModelTypes.CosmWasmPool, error)
+
+	mu sync.Mutex
+	// The pool contracts at the known height. Pools are never removed, so only executions on these contracts need a historical
+	// query up to that height. Executions past it query the pools at their height, which refreshes the known pool contracts.
+	knownPoolContracts map[string]bool
+	knownHeight        int64
+	// The pools at the last height queried, most blocks execute several pool contracts
+	cachedHeight int64
+	cachedPools  map[string]cosmwasmPoolModelTypes.CosmWasmPool
+}
+
+// NewTransmuterPoolResolver loads the pool contracts known at the latest height
+func NewTransmuterPoolResolver(getPools func(height int64) ([]cosmwasmPoolModelTypes.CosmWasmPool, error), latestHeight int64) (*TransmuterPoolResolver, error) {
+	resolver := &TransmuterPoolResolver{GetPools: getPools, knownPoolContracts: map[string]bool{}}
+	if _, err := resolver.poolsAt(latestHeight); err != nil {
+		return nil, err
+	}
+
+	return resolver, nil
+}
+
+// KnownPoolCount is the number of CosmWasm pool contracts that exist at the known height
+func (r *TransmuterPoolResolver) KnownPoolCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.knownPoolContracts)
+}
+
+// poolsAt returns the pools at the height by contract address, and adds them to the known pool contracts when the height is
+// past the known height. The caller must hold the lock.
+func (r *TransmuterPoolResolver) poolsAt(height int64) (map[string]cosmwasmPoolModelTypes.CosmWasmPool, error) {
+	if r.cachedPools == nil || r.cachedHeight != height {
+		pools, err := r.GetPools(height)
+		if err != nil {
+			return nil, fmt.Errorf("error getting CosmWasm pools at height %d: %w", height, err)
+		}
+
+		r.cachedHeight = height
+		r.cachedPools = make(map[string]cosmwasmPoolModelTypes.CosmWasmPool, len(pools))
+		for _, pool := range pools {
+			r.cachedPools[pool.ContractAddress] = pool
+		}
+	}
+
+	if height > r.knownHeight {
+		for contractAddress := range r.cachedPools {
+			r.knownPoolContracts[contractAddress] = true
+		}
+		r.knownHeight = height
+	}
+
+	return r.cachedPools, nil
+}
+
+func (r *TransmuterPoolResolver) ResolveContractHandler(contractAddress string, height int64) (wasm.ContractExecutionMessageHandler, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A contract that is not a pool at the known height was not a pool at any earlier height
+	if !r.knownPoolContracts[contractAddress] && height <= r.knownHeight {
+		return nil, nil
+	}
+
+	pools, err := r.poolsAt(height)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, ok := pools[contractAddress]
+	if !ok || !IsTransmuterPool(pool) {
+		return nil, nil
+	}
+
+	return &WrapperMsgExecuteTransmuter{PoolID: pool.PoolId, PoolContractAddress: pool.ContractAddress}, nil
+}
+
+func (sf *WrapperMsgExecuteTransmuter) ContractFriendlyName() string {
+	return fmt.Sprintf("Transmuter Pool %d", sf.PoolID)
+}
+
+func (sf *WrapperMsgExecuteTransmuter) TopLevelFieldIdentifiers() []string {
+	return []string{TransmuterJoinPool, TransmuterExitPool}
+}
+
+func (sf *WrapperMsgExecuteTransmuter) TopLevelIdentifierType() any {
+	return map[string]json.RawMessage{}
+}
+
+func (sf *WrapperMsgExecuteTransmuter) CosmosMessageType() txModule.CosmosMessage {
+	return &WrapperMsgExecuteTransmuter{PoolID: sf.PoolID, PoolContractAddress: sf.PoolContractAddress}
+}
+
+func (sf *WrapperMsgExecuteTransmuter) ContractAddress() string {
+	return sf.PoolContractAddress
+}
+
+func (sf *WrapperMsgExecuteTransmuter) String() string {
+	var tokensIn []string
+	for _, v := range sf.TokensIn {
+		tokensIn = append(tokensIn, v.String())
+	}
+
+	var tokensOut []string
+	for _, v := range sf.TokensOut {
+		tokensOut = append(tokensOut, v.String())
+	}
+
+	return fmt.Sprintf("MsgExecuteContract (%s %s): %s sent %s and received %s",
+		sf.ContractFriendlyName(), sf.Action, sf.Address, strings.Join(tokensIn, ", "), strings.Join(tokensOut, ", "))
+}
+
+func (sf *WrapperMsgExecuteTransmuter) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+	sf.CosmWasmMsgExecuteContract = msg.(*wasmTypes.MsgExecuteContract)
+
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	var executeMsg map[string]json.RawMessage
+	if err := json.Unmarshal(sf.CosmWasmMsgExecuteContract.Msg, &executeMsg); err != nil {
+		return fmt.Errorf("error parsing transmuter execute message: %w", err)
+	}
+
+	for _, action := range sf.TopLevelFieldIdentifiers() {
+		if _, ok := executeMsg[action]; ok {
+			sf.Action = action
+			break
+		}
+	}
+
+	if sf.Action == "" {
+		return errors.New("unhandled transmuter execute message")
+	}
+
+	sf.Address = sf.CosmWasmMsgExecuteContract.Sender
+
+	// Joins are funded with the message funds, the contract pays out exits (and any share tokens minted on join) to the sender
+	sf.TokensIn = sf.CosmWasmMsgExecuteContract.Funds
+
+	for _, coinsReceivedString := range txModule.GetCoinsReceived(sf.Address, txModule.GetEventsWithType("coin_received", log)) {
+		coinsReceived, err := sdk.ParseCoinsNormalized(coinsReceivedString)
+		if err != nil {
+			return errors.New("error parsing coins received from event")
+		}
+
+		sf.TokensOut = sf.TokensOut.Add(coinsReceived...)
+	}
+
+	if sf.Action == TransmuterExitPool && sf.TokensOut.Empty() {
+		return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+	}
+
+	return nil
+}
+
+func (sf *WrapperMsgExecuteTransmuter) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	relevantData := make([]parsingTypes.MessageRelevantInformation, 0)
+	for _, token := range sf.TokensIn {
+		if token.Amount.IsPositive() {
+			relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+				AmountSent:       token.Amount.BigInt(),
+				DenominationSent: token.Denom,
+				SenderAddress:    sf.Address,
+			})
+		}
+	}
+
+	for _, token := range sf.TokensOut {
+		if token.Amount.IsPositive() {
+			relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+				AmountReceived:       token.Amount.BigInt(),
+				DenominationReceived: token.Denom,
+				ReceiverAddress:      sf.Address,
+			})
+		}
+	}
+
+	return relevantData
+}
@@ -0,0 +1,75 @@
+package cosmwasmpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cosmwasmPoolModelTypes "github.com/osmosis-labs/osmosis/v26/x/cosmwasmpool/model"
+)
+
+func TestTransmuterPoolResolverUsesPoolsAtHeight(t *testing.T) {
+	transmuter := cosmwasmPoolModelTypes.CosmWasmPool{
+		PoolId:          1212,
+		ContractAddress: "osmo1transmuter",
+		InstantiateMsg:  []byte(`{"pool_asset_denoms":["uosmo","uion"]}`),
+	}
+	orderbook := cosmwasmPoolModelTypes.CosmWasmPool{
+		PoolId:          1213,
+		ContractAddress: "osmo1orderbook",
+		InstantiateMsg:  []byte(`{"base_denom":"uosmo","quote_denom":"uion"}`),
+	}
+
+	newTransmuter := cosmwasmPoolModelTypes.CosmWasmPool{
+		PoolId:          1214,
+		ContractAddress: "osmo1newtransmuter",
+		InstantiateMsg:  []byte(`{"pool_asset_configs":[{"denom":"uosmo"},{"denom":"uion"}]}`),
+	}
+
+	// The transmuter pool is created at height 100, the orderbook pool exists from the start. The resolver is created at
+	// height 200, the new transmuter pool is created after it at height 250.
+	var queriedHeights []int64
+	resolver, err := NewTransmuterPoolResolver(func(height int64) ([]cosmwasmPoolModelTypes.CosmWasmPool, error) {
+		queriedHeights = append(queriedHeights, height)
+		switch {
+		case height >= 250:
+			return []cosmwasmPoolModelTypes.CosmWasmPool{orderbook, transmuter, newTransmuter}, nil
+		case height >= 100:
+			return []cosmwasmPoolModelTypes.CosmWasmPool{orderbook, transmuter}, nil
+		}
+		return []cosmwasmPoolModelTypes.CosmWasmPool{orderbook}, nil
+	}, 200)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, resolver.KnownPoolCount())
+
+	handler, err := resolver.ResolveContractHandler("osmo1transmuter", 50)
+	assert.Nil(t, err)
+	assert.Nil(t, handler, "the pool does not exist yet at this height")
+
+	handler, err = resolver.ResolveContractHandler("osmo1transmuter", 150)
+	assert.Nil(t, err)
+	assert.NotNil(t, handler)
+	assert.Equal(t, uint64(1212), handler.(*WrapperMsgExecuteTransmuter).PoolID)
+
+	handler, err = resolver.ResolveContractHandler("osmo1orderbook", 150)
+	assert.Nil(t, err)
+	assert.Nil(t, handler, "orderbook pools are not transmuter pools")
+
+	handler, err = resolver.ResolveContractHandler("osmo1notapool", 150)
+	assert.Nil(t, err)
+	assert.Nil(t, handler)
+
+	// Executions past the known height refresh the known pools from the pools at their height
+	handler, err = resolver.ResolveContractHandler("osmo1newtransmuter", 300)
+	assert.Nil(t, err)
+	assert.NotNil(t, handler)
+	assert.Equal(t, uint64(1214), handler.(*WrapperMsgExecuteTransmuter).PoolID)
+	assert.Equal(t, 3, resolver.KnownPoolCount())
+
+	handler, err = resolver.ResolveContractHandler("osmo1notapool", 300)
+	assert.Nil(t, err)
+	assert.Nil(t, handler)
+
+	// The pools at a height are queried once, contracts that are not pools are only queried past the known height
+	assert.Equal(t, []int64{200, 50, 150, 300}, queriedHeights)
+}
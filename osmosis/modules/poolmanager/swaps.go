@@ -0,0 +1,110 @@
+package poolmanager
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	tokenSwappedEvent = "token_swapped"
+	moduleAttribute   = "module"
+)
+
+// poolSwap is a single pool hop as reported by the token_swapped event. Every pool type, including CosmWasm pools,
+// emits this event when the poolmanager routes a swap through it.
+type poolSwap struct {
+	PoolID    uint64
+	Sender    string
+	TokensIn  sdk.Coin
+	TokensOut sdk.Coin
+}
+
+// routeSwap is the resolved input and output of a single route of pools
+type routeSwap struct {
+	TokenIn  sdk.Coin
+	TokenOut sdk.Coin
+}
+
+func parseTokenSwappedEvents(sender string, log *txModule.LogMessage) ([]poolSwap, error) {
+	var swaps []poolSwap
+
+	for _, attributes := range txModule.SplitEventsOnAttribute(tokenSwappedEvent, moduleAttribute, log) {
+		// Swaps executed by other modules in the same message (e.g. protorev) are not part of the user's routes
+		if attributes["sender"] != sender {
+			continue
+		}
+
+		poolID, err := strconv.ParseUint(attributes["pool_id"], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing pool ID from token_swapped event: %w", err)
+		}
+
+		tokensIn, err := sdk.ParseCoinNormalized(attributes["tokens_in"])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing tokens in from token_swapped event: %w", err)
+		}
+
+		tokensOut, err := sdk.ParseCoinNormalized(attributes["tokens_out"])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing tokens out from token_swapped event: %w", err)
+		}
+
+		swaps = append(swaps, poolSwap{
+			PoolID:    poolID,
+			Sender:    attributes["sender"],
+			TokensIn:  tokensIn,
+			TokensOut: tokensOut,
+		})
+	}
+
+	return swaps, nil
+}
+
+// resolveRouteSwaps walks the token_swapped events in execution order and matches each route to the consecutive hops
+// through its pools. The token in of a route is the first hop's input and the token out is the last hop's output.
+func resolveRouteSwaps(sender string, routes [][]uint64, log *txModule.LogMessage) ([]routeSwap, error) {
+	swaps, err := parseTokenSwappedEvents(sender, log)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]routeSwap, 0, len(routes))
+	next := 0
+
+	for _, poolIDs := range routes {
+		if len(poolIDs) == 0 {
+			continue
+		}
+
+		// Find the first hop of the route, then require the remaining hops to follow it
+		for next < len(swaps) && swaps[next].PoolID != poolIDs[0] {
+			next++
+		}
+
+		if next+len(poolIDs) > len(swaps) {
+			return nil, errors.New("no token_swapped events found for route")
+		}
+
+		for i, poolID := range poolIDs {
+			if swaps[next+i].PoolID != poolID {
+				return nil, fmt.Errorf("token_swapped event for pool %d does not match route pool %d", swaps[next+i].PoolID, poolID)
+			}
+		}
+
+		resolved = append(resolved, routeSwap{
+			TokenIn:  swaps[next].TokensIn,
+			TokenOut: swaps[next+len(poolIDs)-1].TokensOut,
+		})
+		next += len(poolIDs)
+	}
+
+	if len(resolved) == 0 {
+		return nil, errors.New("no routes resolved from token_swapped events")
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,44 @@
+package poolmanager
+
+import (
+	"testing"
+
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenSwappedAttributes(module, sender, poolID, tokensIn, tokensOut string) []txModule.Attribute {
+	return []txModule.Attribute{
+		{Key: "module", Value: module},
+		{Key: "sender", Value: sender},
+		{Key: "pool_id", Value: poolID},
+		{Key: "tokens_in", Value: tokensIn},
+		{Key: "tokens_out", Value: tokensOut},
+	}
+}
+
+func TestResolveRouteSwapsSplitRoute(t *testing.T) {
+	// Legacy logs merge every token_swapped event into one, the second route goes through a CosmWasm pool
+	var attributes []txModule.Attribute
+	attributes = append(attributes, tokenSwappedAttributes("gamm", "osmo1sender", "1", "100uosmo", "10uion")...)
+	attributes = append(attributes, tokenSwappedAttributes("gamm", "osmo1sender", "2", "10uion", "20uatom")...)
+	attributes = append(attributes, tokenSwappedAttributes("gamm", "osmo1protorev", "3", "5uatom", "6uatom")...)
+	attributes = append(attributes, tokenSwappedAttributes("cosmwasmpool", "osmo1sender", "1212", "50uosmo", "11uatom")...)
+
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "token_swapped", Attributes: attributes},
+		},
+	}
+
+	routeSwaps, err := resolveRouteSwaps("osmo1sender", [][]uint64{{1, 2}, {1212}}, log)
+	assert.Nil(t, err)
+	assert.Len(t, routeSwaps, 2)
+	assert.Equal(t, "100uosmo", routeSwaps[0].TokenIn.String())
+	assert.Equal(t, "20uatom", routeSwaps[0].TokenOut.String())
+	assert.Equal(t, "50uosmo", routeSwaps[1].TokenIn.String())
+	assert.Equal(t, "11uatom", routeSwaps[1].TokenOut.String())
+
+	_, err = resolveRouteSwaps("osmo1sender", [][]uint64{{1, 3}}, log)
+	assert.NotNil(t, err, "route hops must match the pools in order")
+}
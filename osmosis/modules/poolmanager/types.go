@@ -3,7 +3,6 @@ package poolmanager
 import (
 	"errors"
 	"fmt"
-	"strconv"
 
 	sdkMath "cosmossdk.io/math"
 	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
@@ -118,36 +117,25 @@ func (sf *WrapperMsgSwapExactAmountIn) HandleMsg(msgType string, msg sdk.Msg, lo
 	sf.TokenIn = sf.OsmosisMsgSwapExactAmountIn.TokenIn
 	sf.Address = sf.OsmosisMsgSwapExactAmountIn.Sender
 
-	// The attribute in the log message that shows you the tokens swapped
-	tokensSwappedEvents := txModule.GetEventsWithType("token_swapped", log)
-
-	var tokensSwappedEvt *txModule.LogMessageEvent
-	if len(tokensSwappedEvents) == 0 {
-		tokensSwappedEvt = nil
-	} else {
-		tokensSwappedEvt = &tokensSwappedEvents[len(tokensSwappedEvents)-1]
-	}
-
-	// We prefer the tokensSwappedEvt if it exists, but it is prone to error
-	// If it does exist, attempt a parse. If parsing fails, try other methods.
-	// If it does not exist, we will use the transfer event.
+	// We prefer the token_swapped events for each pool in the route if they exist, but they are prone to error
+	// If they do exist, attempt a parse. If parsing fails, try other methods.
+	// If they do not exist, we will use the transfer event.
 
 	parsed := false
 
-	if tokensSwappedEvt != nil {
+	var routePoolIDs []uint64
+	for _, route := range sf.OsmosisMsgSwapExactAmountIn.Routes {
+		routePoolIDs = append(routePoolIDs, route.PoolId)
+	}
 
-		// The last route in the hops gives the token out denom and pool ID for the final output
+	routeSwaps, err := resolveRouteSwaps(sf.Address, [][]uint64{routePoolIDs}, log)
+	if err == nil {
+		// The last route in the hops gives the token out denom for the final output
 		lastRoute := sf.OsmosisMsgSwapExactAmountIn.Routes[len(sf.OsmosisMsgSwapExactAmountIn.Routes)-1]
-		lastRouteDenom := lastRoute.TokenOutDenom
-		lastRoutePoolID := lastRoute.PoolId
-
-		tokenOutStr := txModule.GetLastValueForAttribute("tokens_out", tokensSwappedEvt)
-		tokenOutPoolID := txModule.GetLastValueForAttribute("pool_id", tokensSwappedEvt)
 
-		tokenOut, err := sdk.ParseCoinNormalized(tokenOutStr)
 		// Sanity check last route swap
-		if err == nil && tokenOut.Denom == lastRouteDenom && strconv.FormatUint(lastRoutePoolID, 10) == tokenOutPoolID {
-			sf.TokenOut = tokenOut
+		if routeSwaps[0].TokenOut.Denom == lastRoute.TokenOutDenom {
+			sf.TokenOut = routeSwaps[0].TokenOut
 			parsed = true
 		}
 	}
@@ -219,16 +207,28 @@ func (sf *WrapperMsgSwapExactAmountOut) HandleMsg(msgType string, msg sdk.Msg, l
 	sf.Type = msgType
 	sf.OsmosisMsgSwapExactAmountOut = msg.(*poolManagerTypes.MsgSwapExactAmountOut)
 
+	var routePoolIDs []uint64
+	for _, route := range sf.OsmosisMsgSwapExactAmountOut.Routes {
+		routePoolIDs = append(routePoolIDs, route.PoolId)
+	}
+
+	// The token_swapped events of every pool in the route, CosmWasm pools emit these as well when routed through the poolmanager
+	routeSwaps, routeErr := resolveRouteSwaps(sf.OsmosisMsgSwapExactAmountOut.Sender, [][]uint64{routePoolIDs}, log)
 	// The attribute in the log message that shows you the tokens swapped
 	tokensSwappedEvt := txModule.GetEventWithType("token_swapped", log)
 	// Hallmark of a cosmwasm pool swap execution
 	wasmEvt := txModule.GetEventWithType("wasm", log)
 
-	if tokensSwappedEvt == nil && wasmEvt == nil {
+	if routeErr != nil && tokensSwappedEvt == nil && wasmEvt == nil {
 		return errors.New("no processable events for poolmanager MsgSwapExactAmountOut")
 	}
 
 	switch {
+	case routeErr == nil:
+		sf.Parser = "tokens_swapped"
+
+		// This gets the first token swapped in (if there are multiple pools we do not care about intermediates)
+		sf.TokenIn = routeSwaps[0].TokenIn
 	case tokensSwappedEvt != nil:
 		sf.Parser = "tokens_swapped"
 
@@ -390,21 +390,46 @@ func (sf *WrapperMsgSplitRouteSwapExactAmountIn) HandleMsg(msgType string, msg s
 		return nil
 	}
 
-	// Get the final out amount from the split_route_swap_exact_amount_in event
-	splitRouteFinalEvent := txModule.GetEventWithType("split_route_swap_exact_amount_in", log)
+	var routes [][]uint64
+	for _, route := range sf.OsmosisMsgSplitRouteSwapExactAmountIn.Routes {
+		var poolIDs []uint64
+		for _, pool := range route.Pools {
+			poolIDs = append(poolIDs, pool.PoolId)
+		}
+		routes = append(routes, poolIDs)
+	}
 
-	if splitRouteFinalEvent == nil {
-		return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+	// Sum the final hop of every route from the token_swapped events, these are emitted by every pool type (including CosmWasm pools)
+	tokenOutAmount := sdkMath.ZeroInt()
+	routeSwaps, err := resolveRouteSwaps(sf.OsmosisMsgSplitRouteSwapExactAmountIn.Sender, routes, log)
+	if err == nil {
+		for _, routeSwap := range routeSwaps {
+			if routeSwap.TokenOut.Denom != denomOut {
+				err = errors.New("route token out denom does not match final route denom")
+				break
+			}
+			tokenOutAmount = tokenOutAmount.Add(routeSwap.TokenOut.Amount)
+		}
 	}
 
-	tokensOutString, err := txModule.GetValueForAttribute("tokens_out", splitRouteFinalEvent)
 	if err != nil {
-		return err
-	}
+		// Get the final out amount from the split_route_swap_exact_amount_in event
+		splitRouteFinalEvent := txModule.GetEventWithType("split_route_swap_exact_amount_in", log)
+
+		if splitRouteFinalEvent == nil {
+			return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		}
 
-	tokenOutAmount, ok := sdkMath.NewIntFromString(tokensOutString)
-	if !ok {
-		return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		tokensOutString, err := txModule.GetValueForAttribute("tokens_out", splitRouteFinalEvent)
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		tokenOutAmount, ok = sdkMath.NewIntFromString(tokensOutString)
+		if !ok {
+			return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		}
 	}
 
 	finalTokensOut := sdk.NewCoin(denomOut, tokenOutAmount)
@@ -424,41 +449,63 @@ func (sf *WrapperMsgSplitRouteSwapExactAmountOut) HandleMsg(msgType string, msg
 
 	denomOut := sf.OsmosisMsgSplitRouteSwapExactAmountOut.TokenOutDenom
 
-	// Contains the addition of all tokens swapped in by the user
-	splitRouteFinalEvent := txModule.GetEventWithType("split_route_swap_exact_amount_out", log)
-
-	if splitRouteFinalEvent == nil {
-		return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
-	}
-
-	// Mislabled event
-	tokensOutString, err := txModule.GetValueForAttribute("tokens_out", splitRouteFinalEvent)
-	if err != nil {
-		return err
-	}
-
-	tokenInAmount, ok := sdkMath.NewIntFromString(tokensOutString)
-	if !ok {
-		return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
-	}
-
 	tokenInDenom := ""
 	tokenOutAmount := sdkMath.NewInt(0)
 
-	for _, routes := range sf.OsmosisMsgSplitRouteSwapExactAmountOut.Routes {
-		if len(routes.Pools) == 0 {
+	var routes [][]uint64
+	for _, route := range sf.OsmosisMsgSplitRouteSwapExactAmountOut.Routes {
+		if len(route.Pools) == 0 {
 			continue
 		}
 
-		firstPool := routes.Pools[0]
+		firstPool := route.Pools[0]
 		if tokenInDenom == "" {
 			tokenInDenom = firstPool.TokenInDenom
 		} else if tokenInDenom != firstPool.TokenInDenom {
 			return errors.New("token in denom does not match across routes first pool")
 		}
 
-		tokenOutAmount = tokenOutAmount.Add(routes.TokenOutAmount)
+		tokenOutAmount = tokenOutAmount.Add(route.TokenOutAmount)
+
+		var poolIDs []uint64
+		for _, pool := range route.Pools {
+			poolIDs = append(poolIDs, pool.PoolId)
+		}
+		routes = append(routes, poolIDs)
+	}
+
+	// Sum the first hop of every route from the token_swapped events, these are emitted by every pool type (including CosmWasm pools)
+	tokenInAmount := sdkMath.ZeroInt()
+	routeSwaps, err := resolveRouteSwaps(sf.Address, routes, log)
+	if err == nil {
+		for _, routeSwap := range routeSwaps {
+			if routeSwap.TokenIn.Denom != tokenInDenom {
+				err = errors.New("route token in denom does not match first route denom")
+				break
+			}
+			tokenInAmount = tokenInAmount.Add(routeSwap.TokenIn.Amount)
+		}
+	}
+
+	if err != nil {
+		// Contains the addition of all tokens swapped in by the user
+		splitRouteFinalEvent := txModule.GetEventWithType("split_route_swap_exact_amount_out", log)
+
+		if splitRouteFinalEvent == nil {
+			return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		}
 
+		// Mislabled event
+		tokensOutString, err := txModule.GetValueForAttribute("tokens_out", splitRouteFinalEvent)
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		tokenInAmount, ok = sdkMath.NewIntFromString(tokensOutString)
+		if !ok {
+			return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		}
 	}
 
 	finalTokensIn := sdk.NewCoin(tokenInDenom, tokenInAmount)
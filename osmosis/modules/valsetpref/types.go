@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/distribution"
 	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
 	"github.com/DefiantLabs/cosmos-tax-cli/util"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -43,9 +44,26 @@ func getRewardsReceived(log *txModule.LogMessage, address string) (sdk.Coins, er
 	return rewardCoins, nil
 }
 
-func getRelevantData(rewards sdk.Coins, address string) []parsingTypes.MessageRelevantInformation {
+// getRelevantData returns a leg per reward, split between the validators that paid them when they are known
+func getRelevantData(rewards sdk.Coins, validatorRewards []distribution.ValidatorRewards, address string) []parsingTypes.MessageRelevantInformation {
 	relevantData := make([]parsingTypes.MessageRelevantInformation, 0)
 
+	if len(validatorRewards) > 0 {
+		for _, validatorReward := range validatorRewards {
+			for _, token := range validatorReward.Rewards {
+				if token.Amount.IsPositive() {
+					relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
+						AmountReceived:       token.Amount.BigInt(),
+						DenominationReceived: token.Denom,
+						SenderAddress:        address,
+						ValidatorAddress:     validatorReward.Validator,
+					})
+				}
+			}
+		}
+		return relevantData
+	}
+
 	for _, token := range rewards {
 		if token.Amount.IsPositive() {
 			relevantData = append(relevantData, parsingTypes.MessageRelevantInformation{
@@ -76,6 +94,7 @@ type WrapperMsgDelegateToValidatorSet struct {
 	OsmosisMsgDelegateToValidatorSet *valsetPrefTypes.MsgDelegateToValidatorSet
 	DelegatorAddress                 string
 	RewardsOut                       sdk.Coins
+	ValidatorRewards                 []distribution.ValidatorRewards
 }
 
 func (sf *WrapperMsgDelegateToValidatorSet) String() string {
@@ -99,12 +118,13 @@ func (sf *WrapperMsgDelegateToValidatorSet) HandleMsg(msgType string, msg sdk.Ms
 	}
 
 	sf.RewardsOut = coins
+	sf.ValidatorRewards = distribution.GetValidatorRewards(log, coins)
 
 	return nil
 }
 
 func (sf *WrapperMsgDelegateToValidatorSet) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
-	return getRelevantData(sf.RewardsOut, sf.DelegatorAddress)
+	return getRelevantData(sf.RewardsOut, sf.ValidatorRewards, sf.DelegatorAddress)
 }
 
 type WrapperMsgUndelegateFromValidatorSet struct {
@@ -112,6 +132,7 @@ type WrapperMsgUndelegateFromValidatorSet struct {
 	OsmosisMsgUndelegateFromValidatorSet *valsetPrefTypes.MsgUndelegateFromValidatorSet
 	DelegatorAddress                     string
 	RewardsOut                           sdk.Coins
+	ValidatorRewards                     []distribution.ValidatorRewards
 }
 
 func (sf *WrapperMsgUndelegateFromValidatorSet) String() string {
@@ -136,12 +157,13 @@ func (sf *WrapperMsgUndelegateFromValidatorSet) HandleMsg(msgType string, msg sd
 	}
 
 	sf.RewardsOut = coins
+	sf.ValidatorRewards = distribution.GetValidatorRewards(log, coins)
 
 	return nil
 }
 
 func (sf *WrapperMsgUndelegateFromValidatorSet) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
-	return getRelevantData(sf.RewardsOut, sf.DelegatorAddress)
+	return getRelevantData(sf.RewardsOut, sf.ValidatorRewards, sf.DelegatorAddress)
 }
 
 type WrapperMsgRedelegateValidatorSet struct {
@@ -149,6 +171,7 @@ type WrapperMsgRedelegateValidatorSet struct {
 	OsmosisMsgRedelegateValidatorSet *valsetPrefTypes.MsgRedelegateValidatorSet
 	DelegatorAddress                 string
 	RewardsOut                       sdk.Coins
+	ValidatorRewards                 []distribution.ValidatorRewards
 }
 
 func (sf *WrapperMsgRedelegateValidatorSet) String() string {
@@ -173,12 +196,13 @@ func (sf *WrapperMsgRedelegateValidatorSet) HandleMsg(msgType string, msg sdk.Ms
 	}
 
 	sf.RewardsOut = coins
+	sf.ValidatorRewards = distribution.GetValidatorRewards(log, coins)
 
 	return nil
 }
 
 func (sf *WrapperMsgRedelegateValidatorSet) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
-	return getRelevantData(sf.RewardsOut, sf.DelegatorAddress)
+	return getRelevantData(sf.RewardsOut, sf.ValidatorRewards, sf.DelegatorAddress)
 }
 
 type WrapperMsgWithdrawDelegationRewards struct {
@@ -186,6 +210,7 @@ type WrapperMsgWithdrawDelegationRewards struct {
 	OsmosisMsgWithdrawDelegationRewards *valsetPrefTypes.MsgWithdrawDelegationRewards
 	DelegatorAddress                    string
 	RewardsOut                          sdk.Coins
+	ValidatorRewards                    []distribution.ValidatorRewards
 }
 
 func (sf *WrapperMsgWithdrawDelegationRewards) String() string {
@@ -210,12 +235,13 @@ func (sf *WrapperMsgWithdrawDelegationRewards) HandleMsg(msgType string, msg sdk
 	}
 
 	sf.RewardsOut = coins
+	sf.ValidatorRewards = distribution.GetValidatorRewards(log, coins)
 
 	return nil
 }
 
 func (sf *WrapperMsgWithdrawDelegationRewards) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
-	return getRelevantData(sf.RewardsOut, sf.DelegatorAddress)
+	return getRelevantData(sf.RewardsOut, sf.ValidatorRewards, sf.DelegatorAddress)
 }
 
 type WrapperMsgDelegateBondedTokens struct {
@@ -223,6 +249,7 @@ type WrapperMsgDelegateBondedTokens struct {
 	OsmosisMsgDelegateBondedTokens *valsetPrefTypes.MsgDelegateBondedTokens
 	DelegatorAddress               string
 	RewardsOut                     sdk.Coins
+	ValidatorRewards               []distribution.ValidatorRewards
 }
 
 func (sf *WrapperMsgDelegateBondedTokens) String() string {
@@ -247,12 +274,13 @@ func (sf *WrapperMsgDelegateBondedTokens) HandleMsg(msgType string, msg sdk.Msg,
 	}
 
 	sf.RewardsOut = coins
+	sf.ValidatorRewards = distribution.GetValidatorRewards(log, coins)
 
 	return nil
 }
 
 func (sf *WrapperMsgDelegateBondedTokens) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
-	return getRelevantData(sf.RewardsOut, sf.DelegatorAddress)
+	return getRelevantData(sf.RewardsOut, sf.ValidatorRewards, sf.DelegatorAddress)
 }
 
 type WrapperMsgUndelegateFromRebalancedValidatorSet struct {
@@ -260,6 +288,7 @@ type WrapperMsgUndelegateFromRebalancedValidatorSet struct {
 	OsmosisMsgUndelegateFromRebalancedValidatorSet *valsetPrefTypes.MsgUndelegateFromRebalancedValidatorSet
 	DelegatorAddress                               string
 	RewardsOut                                     sdk.Coins
+	ValidatorRewards                               []distribution.ValidatorRewards
 }
 
 func (sf *WrapperMsgUndelegateFromRebalancedValidatorSet) String() string {
@@ -283,10 +312,11 @@ func (sf *WrapperMsgUndelegateFromRebalancedValidatorSet) HandleMsg(msgType stri
 	}
 
 	sf.RewardsOut = coins
+	sf.ValidatorRewards = distribution.GetValidatorRewards(log, coins)
 
 	return nil
 }
 
 func (sf *WrapperMsgUndelegateFromRebalancedValidatorSet) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
-	return getRelevantData(sf.RewardsOut, sf.DelegatorAddress)
+	return getRelevantData(sf.RewardsOut, sf.ValidatorRewards, sf.DelegatorAddress)
 }
@@ -2,10 +2,13 @@ package osmosis
 
 import (
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	"github.com/DefiantLabs/cosmos-tax-cli/cosmwasm/modules/wasm"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/epochs/protorev"
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/cosmwasmpool"
 	"github.com/DefiantLabs/cosmos-tax-cli/osmosis/modules/epochs"
 	"github.com/DefiantLabs/cosmos-tax-cli/rpc"
 	"github.com/DefiantLabs/lens/client"
+	cosmwasmPoolModelTypes "github.com/osmosis-labs/osmosis/v26/x/cosmwasmpool/model"
 )
 
 // SetupOsmosisEpochIndexer sets up the indexer for the osmosis epoch indexing process
@@ -30,3 +33,25 @@ func SetupOsmosisEpochIndexer(cl *client.ChainClient, epochIdentifier string) er
 
 	return nil
 }
+
+// GetCosmWasmPoolContractResolver returns a resolver that finds the transmuter pool handlers for pool contract executions
+// from the CosmWasm pools registered in the poolmanager at the height of the execution
+func GetCosmWasmPoolContractResolver(cl *client.ChainClient) (wasm.ContractExecutionMessageHandlerResolver, error) {
+	config.Log.Info("Gathering Osmosis CosmWasm pool contracts")
+
+	latestHeight, err := rpc.GetLatestBlockHeight(cl)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := cosmwasmpool.NewTransmuterPoolResolver(func(height int64) ([]cosmwasmPoolModelTypes.CosmWasmPool, error) {
+		return rpc.GetCosmWasmPools(cl, height)
+	}, latestHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Log.Debugf("Found %d CosmWasm pools", resolver.KnownPoolCount())
+
+	return resolver, nil
+}
@@ -0,0 +1,204 @@
+// Package parquet writes flat tables as Parquet files with github.com/xitongsys/parquet-go. The values are the same strings
+// as in the CSV, they are converted to the type of their column when written.
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xitongsys/parquet-go/marshal"
+	goparquet "github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// The types of the columns
+const (
+	TypeString = iota
+	TypeInt64
+	TypeBoolean
+	TypeDecimal
+	TypeTimestamp
+)
+
+// Column is a column of the file. Optional columns are null for empty values, the empty values of required columns other
+// than strings are an error. Timestamps are RFC3339 strings written as UTC milliseconds.
+type Column struct {
+	Name      string
+	Type      int
+	Optional  bool
+	Precision int32 // Only used for decimals
+	Scale     int32 // Only used for decimals
+}
+
+// StringColumns returns a required UTF-8 column per header
+func StringColumns(headers []string) []Column {
+	columns := make([]Column, len(headers))
+	for i, header := range headers {
+		columns[i] = Column{Name: header, Type: TypeString}
+	}
+	return columns
+}
+
+// Write writes the rows as a Parquet file with a single row group, every row must have a value per column
+func Write(w io.Writer, columns []Column, rows [][]string) error {
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(columns))
+		}
+	}
+
+	schema, err := schemaElements(columns)
+	if err != nil {
+		return err
+	}
+
+	parquetWriter, err := writer.NewParquetWriterFromWriter(w, schema, 1)
+	if err != nil {
+		return err
+	}
+	parquetWriter.MarshalFunc = marshal.MarshalCSV
+
+	for i, row := range rows {
+		values := make([]interface{}, len(columns))
+		for j, column := range columns {
+			values[j], err = parseValue(column, row[j])
+			if err != nil {
+				return fmt.Errorf("row %d column %s: %w", i, column.Name, err)
+			}
+		}
+
+		if err := parquetWriter.Write(values); err != nil {
+			return err
+		}
+	}
+
+	return parquetWriter.WriteStop()
+}
+
+// schemaElements returns the schema of the file, a root element with the columns as its children
+func schemaElements(columns []Column) ([]*goparquet.SchemaElement, error) {
+	numChildren := int32(len(columns))
+	root := goparquet.NewSchemaElement()
+	root.Name = "schema"
+	root.NumChildren = &numChildren
+	root.RepetitionType = goparquet.FieldRepetitionTypePtr(goparquet.FieldRepetitionType_REQUIRED)
+
+	elements := []*goparquet.SchemaElement{root}
+	for _, column := range columns {
+		element := goparquet.NewSchemaElement()
+		element.Name = column.Name
+		element.RepetitionType = goparquet.FieldRepetitionTypePtr(goparquet.FieldRepetitionType_REQUIRED)
+		if column.Optional {
+			element.RepetitionType = goparquet.FieldRepetitionTypePtr(goparquet.FieldRepetitionType_OPTIONAL)
+		}
+
+		logicalType := goparquet.NewLogicalType()
+		switch column.Type {
+		case TypeString:
+			element.Type = goparquet.TypePtr(goparquet.Type_BYTE_ARRAY)
+			element.ConvertedType = goparquet.ConvertedTypePtr(goparquet.ConvertedType_UTF8)
+			logicalType.STRING = goparquet.NewStringType()
+		case TypeInt64:
+			element.Type = goparquet.TypePtr(goparquet.Type_INT64)
+			element.ConvertedType = goparquet.ConvertedTypePtr(goparquet.ConvertedType_INT_64)
+			logicalType.INTEGER = &goparquet.IntType{BitWidth: 64, IsSigned: true}
+		case TypeBoolean:
+			element.Type = goparquet.TypePtr(goparquet.Type_BOOLEAN)
+			logicalType = nil
+		case TypeDecimal:
+			element.Type = goparquet.TypePtr(goparquet.Type_BYTE_ARRAY)
+			element.ConvertedType = goparquet.ConvertedTypePtr(goparquet.ConvertedType_DECIMAL)
+			element.Precision = &column.Precision
+			element.Scale = &column.Scale
+			logicalType.DECIMAL = &goparquet.DecimalType{Precision: column.Precision, Scale: column.Scale}
+		case TypeTimestamp:
+			element.Type = goparquet.TypePtr(goparquet.Type_INT64)
+			element.ConvertedType = goparquet.ConvertedTypePtr(goparquet.ConvertedType_TIMESTAMP_MILLIS)
+			logicalType.TIMESTAMP = &goparquet.TimestampType{IsAdjustedToUTC: true, Unit: &goparquet.TimeUnit{MILLIS: goparquet.NewMilliSeconds()}}
+		default:
+			return nil, fmt.Errorf("column %s has an unknown type %d", column.Name, column.Type)
+		}
+		element.LogicalType = logicalType
+
+		elements = append(elements, element)
+	}
+
+	return elements, nil
+}
+
+// parseValue converts the value to the type of the column, empty values of optional columns are null
+func parseValue(column Column, value string) (interface{}, error) {
+	if column.Type == TypeString {
+		return value, nil
+	}
+
+	if value == "" {
+		if column.Optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("empty value for a required column")
+	}
+
+	switch column.Type {
+	case TypeInt64:
+		return strconv.ParseInt(value, 10, 64)
+	case TypeBoolean:
+		return strconv.ParseBool(value)
+	case TypeDecimal:
+		return decimalBytes(value, column.Precision, column.Scale)
+	case TypeTimestamp:
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, err
+		}
+		return at.UnixMilli(), nil
+	}
+
+	return nil, fmt.Errorf("unknown column type %d", column.Type)
+}
+
+// decimalBytes returns the unscaled value of the decimal as a big-endian two's complement integer, the value must fit the
+// precision and scale without rounding
+func decimalBytes(value string, precision int32, scale int32) (string, error) {
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return "", err
+	}
+
+	unscaled := amount.Shift(scale)
+	if !unscaled.Equal(unscaled.Truncate(0)) {
+		return "", fmt.Errorf("%s has more than %d decimals", value, scale)
+	}
+
+	integer := unscaled.BigInt()
+	if len(new(big.Int).Abs(integer).String()) > int(precision) {
+		return "", fmt.Errorf("%s has more than %d digits", value, precision)
+	}
+
+	return string(twosComplement(integer)), nil
+}
+
+// twosComplement returns the shortest big-endian two's complement encoding of the integer
+func twosComplement(integer *big.Int) []byte {
+	if integer.Sign() >= 0 {
+		bytes := integer.Bytes()
+		if len(bytes) == 0 || bytes[0]&0x80 != 0 {
+			bytes = append([]byte{0}, bytes...)
+		}
+		return bytes
+	}
+
+	// -x in n bytes is 2^(8n) - x, n is large enough for the sign bit to be set
+	length := len(integer.Bytes()) + 1
+	complement := new(big.Int).Lsh(big.NewInt(1), uint(8*length))
+	complement.Add(complement, integer)
+	bytes := complement.Bytes()
+	for len(bytes) > 1 && bytes[0] == 0xff && bytes[1]&0x80 != 0 {
+		bytes = bytes[1:]
+	}
+	return bytes
+}
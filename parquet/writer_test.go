@@ -0,0 +1,159 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	goparquet "github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+)
+
+// bufferFile is a read only Parquet source over the bytes of a written file
+type bufferFile struct {
+	*bytes.Reader
+}
+
+func (f bufferFile) Write([]byte) (int, error) {
+	return 0, errors.New("read only")
+}
+
+func (f bufferFile) Close() error {
+	return nil
+}
+
+func (f bufferFile) Open(string) (source.ParquetFile, error) {
+	return f, nil
+}
+
+func (f bufferFile) Create(string) (source.ParquetFile, error) {
+	return nil, errors.New("read only")
+}
+
+// decodeDecimal decodes a big-endian two's complement decimal, the decoder of parquet-go ignores the sign
+func decodeDecimal(value interface{}, scale int32) string {
+	bytes := []byte(value.(string))
+	integer := new(big.Int).SetBytes(bytes)
+	if len(bytes) > 0 && bytes[0]&0x80 != 0 {
+		integer.Sub(integer, new(big.Int).Lsh(big.NewInt(1), uint(8*len(bytes))))
+	}
+	return decimal.NewFromBigInt(integer, -scale).String()
+}
+
+// readColumns reads the written file back and returns the values of each column
+func readColumns(t *testing.T, file []byte, columns []Column, numRows int) (*reader.ParquetReader, [][]interface{}) {
+	parquetReader, err := reader.NewParquetColumnReader(bufferFile{bytes.NewReader(file)}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rows := parquetReader.GetNumRows(); rows != int64(numRows) {
+		t.Fatalf("read %d rows, expected %d", rows, numRows)
+	}
+
+	values := make([][]interface{}, len(columns))
+	for i, column := range columns {
+		if name := parquetReader.SchemaHandler.Infos[i+1].ExName; name != column.Name {
+			t.Fatalf("column %d is %s, expected %s", i, name, column.Name)
+		}
+
+		values[i], _, _, err = parquetReader.ReadColumnByIndex(int64(i), int64(numRows))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(values[i]) != numRows {
+			t.Fatalf("column %s has %d values, expected %d", column.Name, len(values[i]), numRows)
+		}
+	}
+
+	return parquetReader, values
+}
+
+func TestWriteStrings(t *testing.T) {
+	headers := []string{"tx_hash", "Sent Amount", "memo"}
+	rows := [][]string{{"ABC", "1000000", "gm"}, {"DEF", "0.000001", ""}, {"", "-5", "ünïcode"}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, StringColumns(headers), rows); err != nil {
+		t.Fatal(err)
+	}
+
+	parquetReader, values := readColumns(t, buf.Bytes(), StringColumns(headers), len(rows))
+	defer parquetReader.ReadStop()
+
+	for i, header := range headers {
+		for j, row := range rows {
+			if values[i][j] != row[i] {
+				t.Fatalf("column %s value %d is '%v', expected '%s'", header, j, values[i][j], row[i])
+			}
+		}
+	}
+}
+
+func TestWriteTyped(t *testing.T) {
+	columns := []Column{
+		{Name: "time", Type: TypeTimestamp},
+		{Name: "block_height", Type: TypeInt64},
+		{Name: "message_index", Type: TypeInt64, Optional: true},
+		{Name: "amount", Type: TypeDecimal, Precision: 38, Scale: 0},
+		{Name: "amount_converted", Type: TypeDecimal, Optional: true, Precision: 38, Scale: 18},
+		{Name: "internal_transfer", Type: TypeBoolean},
+	}
+	rows := [][]string{
+		{"2024-03-01T10:00:00Z", "12345678", "0", "1000000000000000000000000", "1000000.000000000000000001", "false"},
+		{"2024-03-01T10:00:01Z", "12345679", "", "-128", "", "true"},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, columns, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	parquetReader, values := readColumns(t, buf.Bytes(), columns, len(rows))
+	defer parquetReader.ReadStop()
+
+	schema := parquetReader.SchemaHandler.SchemaElements
+	if schema[2].GetType() != goparquet.Type_INT64 || schema[4].GetConvertedType() != goparquet.ConvertedType_DECIMAL ||
+		schema[1].GetConvertedType() != goparquet.ConvertedType_TIMESTAMP_MILLIS || schema[6].GetType() != goparquet.Type_BOOLEAN {
+		t.Fatalf("unexpected schema %v", schema)
+	}
+	if schema[3].GetRepetitionType() != goparquet.FieldRepetitionType_OPTIONAL || schema[2].GetRepetitionType() != goparquet.FieldRepetitionType_REQUIRED {
+		t.Fatal("unexpected repetition types")
+	}
+
+	if values[0][0] != int64(1709287200000) || values[1][1] != int64(12345679) {
+		t.Fatalf("unexpected integer values %v %v", values[0], values[1])
+	}
+	if values[2][0] != int64(0) || values[2][1] != nil {
+		t.Fatalf("unexpected optional values %v", values[2])
+	}
+	if amount := decodeDecimal(values[3][0], 0); amount != "1000000000000000000000000" {
+		t.Fatalf("amount is %s", amount)
+	}
+	if amount := decodeDecimal(values[3][1], 0); amount != "-128" {
+		t.Fatalf("negative amount is %s", amount)
+	}
+	if amount := decodeDecimal(values[4][0], 18); amount != "1000000.000000000000000001" {
+		t.Fatalf("converted amount is %s", amount)
+	}
+	if values[4][1] != nil || values[5][1] != true {
+		t.Fatalf("unexpected values %v %v", values[4], values[5])
+	}
+}
+
+func TestWriteInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, StringColumns([]string{"a", "b"}), [][]string{{"1"}}); err == nil {
+		t.Fatal("expected an error for a row with a missing value")
+	}
+
+	columns := []Column{{Name: "amount", Type: TypeDecimal, Precision: 38, Scale: 0}}
+	for _, value := range []string{"", "1.5", "not a number"} {
+		if err := Write(&buf, columns, [][]string{{value}}); err == nil {
+			t.Fatalf("expected an error for the amount '%s'", value)
+		}
+	}
+}
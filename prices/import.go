@@ -0,0 +1,184 @@
+package prices
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Record is a price of a price dump, the currency is a base denom or a symbol and the price is for one display unit
+type Record struct {
+	Currency     string
+	Timestamp    time.Time
+	Price        decimal.Decimal
+	FiatCurrency string
+}
+
+// The column names accepted for each field of the CSV dumps and the JSON objects
+var (
+	currencyColumns  = []string{"currency", "denom", "symbol"}
+	timestampColumns = []string{"timestamp", "time", "date"}
+	priceColumns     = []string{"price", "close"}
+	fiatColumns      = []string{"fiat", "fiat_currency", "vs_currency"}
+)
+
+var timestampLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02:15:04:05", "2006-01-02"}
+
+// ParseCSV parses a CSV dump with a header row. The currency and fiat columns are optional when the defaults are set.
+func ParseCSV(r io.Reader, defaultCurrency string, defaultFiat string) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading the header row: %v", err)
+	}
+
+	columns := make(map[string]int)
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	var records []Record
+	for line := 2; ; line++ {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading line %d: %v", line, err)
+		}
+
+		value := func(names []string) string {
+			for _, name := range names {
+				if i, ok := columns[name]; ok && i < len(fields) {
+					return strings.TrimSpace(fields[i])
+				}
+			}
+			return ""
+		}
+
+		record, err := newRecord(value(currencyColumns), value(timestampColumns), value(priceColumns), value(fiatColumns), defaultCurrency, defaultFiat)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing line %d: %v", line, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ParseJSON parses a JSON dump, either an array of price objects or a CoinGecko market chart ({"prices": [[ms, price], ...]})
+// of the default currency
+func ParseJSON(r io.Reader, defaultCurrency string, defaultFiat string) ([]Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var marketChart struct {
+		Prices [][2]json.Number `json:"prices"`
+	}
+	if err := json.Unmarshal(data, &marketChart); err == nil && marketChart.Prices != nil {
+		var records []Record
+		for i, point := range marketChart.Prices {
+			record, err := newRecord("", point[0].String(), point[1].String(), "", defaultCurrency, defaultFiat)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing price %d: %v", i, err)
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	}
+
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, errors.New("the JSON dump must be an array of price objects or a market chart with a prices array")
+	}
+
+	var records []Record
+	for i, object := range objects {
+		value := func(names []string) string {
+			for _, name := range names {
+				if raw, ok := object[name]; ok {
+					var s string
+					if json.Unmarshal(raw, &s) == nil {
+						return strings.TrimSpace(s)
+					}
+					return strings.TrimSpace(string(raw))
+				}
+			}
+			return ""
+		}
+
+		record, err := newRecord(value(currencyColumns), value(timestampColumns), value(priceColumns), value(fiatColumns), defaultCurrency, defaultFiat)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing price %d: %v", i, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func newRecord(currency string, timestamp string, price string, fiat string, defaultCurrency string, defaultFiat string) (Record, error) {
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	if currency == "" {
+		return Record{}, errors.New("no currency set")
+	}
+
+	if fiat == "" {
+		fiat = defaultFiat
+	}
+	if fiat == "" {
+		return Record{}, errors.New("no fiat currency set")
+	}
+
+	parsedTimestamp, err := ParseTimestamp(timestamp)
+	if err != nil {
+		return Record{}, err
+	}
+
+	parsedPrice, err := decimal.NewFromString(price)
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid price '%s'", price)
+	}
+	if parsedPrice.IsNegative() {
+		return Record{}, fmt.Errorf("negative price '%s'", price)
+	}
+
+	return Record{
+		Currency:     currency,
+		Timestamp:    parsedTimestamp,
+		Price:        parsedPrice,
+		FiatCurrency: strings.ToUpper(fiat),
+	}, nil
+}
+
+// ParseTimestamp parses a Unix timestamp in seconds or milliseconds, or a date in one of the supported layouts, in UTC
+func ParseTimestamp(timestamp string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(timestamp, 10, 64); err == nil {
+		// Timestamps past the year 33658 in seconds are in milliseconds
+		if unix > 1e12 {
+			return time.UnixMilli(unix).UTC(), nil
+		}
+		return time.Unix(unix, 0).UTC(), nil
+	}
+
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, timestamp); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp '%s'", timestamp)
+}
@@ -0,0 +1,44 @@
+package prices
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	dump := "Symbol,Date,Close\nATOM,2022-01-01,30.5\nOSMO,1640995200,5.25\n"
+
+	records, err := ParseCSV(strings.NewReader(dump), "", "usd")
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "ATOM", records[0].Currency)
+	assert.Equal(t, "USD", records[0].FiatCurrency)
+	assert.True(t, records[0].Timestamp.Equal(records[1].Timestamp))
+	assert.Equal(t, "5.25", records[1].Price.String())
+}
+
+func TestParseJSONMarketChart(t *testing.T) {
+	dump := `{"prices": [[1640995200000, 30.5], [1641081600000, 31]]}`
+
+	records, err := ParseJSON(strings.NewReader(dump), "uatom", "USD")
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "uatom", records[1].Currency)
+	assert.Equal(t, time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC), records[1].Timestamp)
+	assert.Equal(t, "31", records[1].Price.String())
+}
+
+func TestParseJSONObjects(t *testing.T) {
+	dump := `[{"denom": "uosmo", "timestamp": "2022-01-01T00:00:00Z", "price": "5.25", "fiat": "EUR"}]`
+
+	records, err := ParseJSON(strings.NewReader(dump), "", "USD")
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "EUR", records[0].FiatCurrency)
+
+	_, err = ParseJSON(strings.NewReader(`[{"timestamp": "2022-01-01", "price": 1}]`), "", "USD")
+	assert.Error(t, err)
+}
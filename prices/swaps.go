@@ -0,0 +1,254 @@
+package prices
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+)
+
+// Swap is a swap of an amount of one denom for an amount of another, in display units
+type Swap struct {
+	Time      time.Time
+	DenomIn   uint
+	AmountIn  decimal.Decimal
+	DenomOut  uint
+	AmountOut decimal.Decimal
+}
+
+// SwapDeriver derives volume-weighted prices per time bucket from swaps. The reference denoms (e.g. USDC) are worth one unit of
+// the fiat currency, the liquid denoms (e.g. OSMO and ATOM) are priced through their pairs with the reference denoms and with
+// each other, and every other denom is priced through its pair with a priced reference or liquid denom that has the highest
+// volume. Illiquid denoms are never chained through, so a thin pool cannot move the prices of other denoms.
+type SwapDeriver struct {
+	Bucket       time.Duration
+	FiatCurrency string
+	References   map[uint]bool
+	Liquid       map[uint]bool
+	// Names are the names of the denoms in the price paths
+	Names map[uint]string
+
+	// The last prices of the liquid denoms, used as anchors in buckets without swaps of the liquid denoms
+	lastLiquid map[uint]quote
+}
+
+type quote struct {
+	price  decimal.Decimal
+	path   []uint
+	volume decimal.Decimal
+	trades int
+	time   time.Time
+}
+
+type pairKey struct {
+	a, b uint
+}
+
+type pairVolume struct {
+	amountA, amountB decimal.Decimal
+	trades           int
+}
+
+func NewSwapDeriver(bucket time.Duration, fiatCurrency string, references []uint, liquid []uint, names map[uint]string) *SwapDeriver {
+	deriver := &SwapDeriver{
+		Bucket:       bucket,
+		FiatCurrency: fiatCurrency,
+		References:   make(map[uint]bool),
+		Liquid:       make(map[uint]bool),
+		Names:        names,
+		lastLiquid:   make(map[uint]quote),
+	}
+
+	for _, denomID := range references {
+		deriver.References[denomID] = true
+	}
+	for _, denomID := range liquid {
+		deriver.Liquid[denomID] = true
+	}
+
+	return deriver
+}
+
+// Derive returns the prices of the buckets of the swaps, timestamped at the middle of the buckets. The swaps must be ordered by
+// time and later calls must pass later swaps, the last liquid prices carry over between the calls.
+func (d *SwapDeriver) Derive(swaps []Swap) []db.Price {
+	var prices []db.Price
+
+	for start := 0; start < len(swaps); {
+		bucket := swaps[start].Time.Truncate(d.Bucket)
+		end := start
+		for end < len(swaps) && swaps[end].Time.Truncate(d.Bucket).Equal(bucket) {
+			end++
+		}
+
+		prices = append(prices, d.deriveBucket(bucket, swaps[start:end])...)
+		start = end
+	}
+
+	return prices
+}
+
+func (d *SwapDeriver) deriveBucket(bucket time.Time, swaps []Swap) []db.Price {
+	pairs := make(map[pairKey]*pairVolume)
+	for _, swap := range swaps {
+		if swap.DenomIn == swap.DenomOut || !swap.AmountIn.IsPositive() || !swap.AmountOut.IsPositive() {
+			continue
+		}
+
+		key, amountA, amountB := pairKey{swap.DenomIn, swap.DenomOut}, swap.AmountIn, swap.AmountOut
+		if key.a > key.b {
+			key, amountA, amountB = pairKey{key.b, key.a}, amountB, amountA
+		}
+
+		volume, ok := pairs[key]
+		if !ok {
+			volume = &pairVolume{}
+			pairs[key] = volume
+		}
+		volume.amountA = volume.amountA.Add(amountA)
+		volume.amountB = volume.amountB.Add(amountB)
+		volume.trades++
+	}
+
+	priced := make(map[uint]quote)
+	for denomID := range d.References {
+		priced[denomID] = quote{price: decimal.NewFromInt(1), path: []uint{denomID}}
+	}
+
+	// The liquid denoms are priced in rounds so the shortest paths to the reference denoms win
+	for {
+		found := d.bestQuotes(pairs, priced, func(denomID uint) bool { return d.Liquid[denomID] })
+		if len(found) == 0 {
+			break
+		}
+		for denomID, q := range found {
+			priced[denomID] = q
+		}
+	}
+
+	for denomID := range d.Liquid {
+		if q, ok := priced[denomID]; ok {
+			q.time = bucket
+			d.lastLiquid[denomID] = q
+			continue
+		}
+		if last, ok := d.lastLiquid[denomID]; ok && bucket.Sub(last.time) <= db.MaxPriceGap {
+			// Anchor on the last price, it has no swaps of its own in this bucket
+			priced[denomID] = quote{price: last.price, path: last.path}
+		}
+	}
+
+	others := d.bestQuotes(pairs, priced, func(denomID uint) bool { return !d.Liquid[denomID] })
+	for denomID, q := range others {
+		priced[denomID] = q
+	}
+
+	timestamp := bucket.Add(d.Bucket / 2)
+	var prices []db.Price
+	for denomID, q := range priced {
+		if q.trades == 0 {
+			continue
+		}
+		prices = append(prices, db.Price{
+			DenominationID: denomID,
+			Timestamp:      timestamp,
+			FiatCurrency:   d.FiatCurrency,
+			Source:         db.PriceSourceOsmosisSwaps,
+			Price:          q.price,
+			Path:           d.pathName(q.path),
+			Volume:         q.volume,
+			Trades:         q.trades,
+		})
+	}
+
+	sort.Slice(prices, func(i, j int) bool { return prices[i].DenominationID < prices[j].DenominationID })
+	return prices
+}
+
+// bestQuotes returns a quote for every unpriced denom accepted by the filter that has a pair with a priced denom, through the
+// pair with the highest fiat volume
+func (d *SwapDeriver) bestQuotes(pairs map[pairKey]*pairVolume, priced map[uint]quote, accept func(uint) bool) map[uint]quote {
+	found := make(map[uint]quote)
+
+	for key, volume := range pairs {
+		for _, side := range []struct {
+			denomID, anchorID    uint
+			amount, anchorAmount decimal.Decimal
+		}{
+			{key.a, key.b, volume.amountA, volume.amountB},
+			{key.b, key.a, volume.amountB, volume.amountA},
+		} {
+			if _, ok := priced[side.denomID]; ok || !accept(side.denomID) {
+				continue
+			}
+			anchor, ok := priced[side.anchorID]
+			if !ok {
+				continue
+			}
+
+			fiatVolume := side.anchorAmount.Mul(anchor.price)
+			if best, ok := found[side.denomID]; ok && best.volume.GreaterThanOrEqual(fiatVolume) {
+				continue
+			}
+
+			found[side.denomID] = quote{
+				price:  fiatVolume.Div(side.amount),
+				path:   append([]uint{side.denomID}, anchor.path...),
+				volume: fiatVolume,
+				trades: volume.trades,
+			}
+		}
+	}
+
+	return found
+}
+
+func (d *SwapDeriver) pathName(path []uint) string {
+	names := make([]string, len(path))
+	for i, denomID := range path {
+		names[i] = d.Names[denomID]
+	}
+	return strings.Join(names, "/")
+}
+
+// NewSwap converts the amounts of the swap leg to the display units of the denoms, it returns false when a denom has no
+// known units
+func NewSwap(leg db.SwapLeg, denomsByID map[uint]db.Denom) (Swap, bool) {
+	amountIn, ok := displayAmount(leg.AmountSent, denomsByID[leg.DenominationSentID])
+	if !ok {
+		return Swap{}, false
+	}
+
+	amountOut, ok := displayAmount(leg.AmountReceived, denomsByID[leg.DenominationReceivedID])
+	if !ok {
+		return Swap{}, false
+	}
+
+	return Swap{
+		Time:      leg.Time,
+		DenomIn:   leg.DenominationSentID,
+		AmountIn:  amountIn,
+		DenomOut:  leg.DenominationReceivedID,
+		AmountOut: amountOut,
+	}, true
+}
+
+func displayAmount(amount decimal.Decimal, denom db.Denom) (decimal.Decimal, bool) {
+	if denom.ID == 0 {
+		return decimal.Zero, false
+	}
+
+	conversionAmount, _, err := db.ConvertUnits(amount.BigInt(), denom)
+	if err != nil {
+		return decimal.Zero, false
+	}
+
+	convertedAmount, err := decimal.NewFromString(conversionAmount.Text('f', -1))
+	if err != nil {
+		return decimal.Zero, false
+	}
+
+	return convertedAmount, true
+}
@@ -0,0 +1,75 @@
+package prices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	usdc uint = iota + 1
+	osmo
+	atom
+	foo
+	bar
+)
+
+var swapsStart = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestSwap(minutes int, denomIn uint, amountIn int64, denomOut uint, amountOut int64) Swap {
+	return Swap{
+		Time:      swapsStart.Add(time.Duration(minutes) * time.Minute),
+		DenomIn:   denomIn,
+		AmountIn:  decimal.NewFromInt(amountIn),
+		DenomOut:  denomOut,
+		AmountOut: decimal.NewFromInt(amountOut),
+	}
+}
+
+func TestSwapDeriverChainsThroughLiquidDenoms(t *testing.T) {
+	names := map[uint]string{usdc: "USDC", osmo: "OSMO", atom: "ATOM", foo: "FOO", bar: "BAR"}
+	deriver := NewSwapDeriver(time.Hour, "USD", []uint{usdc}, []uint{osmo, atom}, names)
+
+	prices := deriver.Derive([]Swap{
+		// OSMO at $0.50 and $1.50, volume weighted to $1
+		newTestSwap(1, osmo, 100, usdc, 50),
+		newTestSwap(2, usdc, 150, osmo, 100),
+		// ATOM at 10 OSMO
+		newTestSwap(3, atom, 10, osmo, 100),
+		// FOO at 2 ATOM through a large pool and at 1 OSMO through a small one
+		newTestSwap(4, foo, 100, atom, 200),
+		newTestSwap(5, foo, 1, osmo, 1),
+		// BAR only trades against FOO, which is not chained through
+		newTestSwap(6, bar, 1, foo, 1),
+	})
+
+	assert.Len(t, prices, 3)
+	byDenom := make(map[uint]int)
+	for i, price := range prices {
+		byDenom[price.DenominationID] = i
+		assert.Equal(t, swapsStart.Add(30*time.Minute), price.Timestamp)
+	}
+
+	assert.Equal(t, "1", prices[byDenom[osmo]].Price.String())
+	assert.Equal(t, "OSMO/USDC", prices[byDenom[osmo]].Path)
+	assert.Equal(t, 2, prices[byDenom[osmo]].Trades)
+	assert.Equal(t, "10", prices[byDenom[atom]].Price.String())
+	assert.Equal(t, "ATOM/OSMO/USDC", prices[byDenom[atom]].Path)
+	assert.Equal(t, "20", prices[byDenom[foo]].Price.String())
+	assert.Equal(t, "FOO/ATOM/OSMO/USDC", prices[byDenom[foo]].Path)
+	assert.Equal(t, "2000", prices[byDenom[foo]].Volume.String())
+}
+
+func TestSwapDeriverCarriesLiquidPrices(t *testing.T) {
+	deriver := NewSwapDeriver(time.Hour, "USD", []uint{usdc}, []uint{osmo}, map[uint]string{})
+
+	deriver.Derive([]Swap{newTestSwap(1, osmo, 10, usdc, 20)})
+	prices := deriver.Derive([]Swap{newTestSwap(90, foo, 4, osmo, 1)})
+
+	assert.Len(t, prices, 1)
+	assert.Equal(t, foo, prices[0].DenominationID)
+	assert.Equal(t, "0.5", prices[0].Price.String())
+	assert.Equal(t, swapsStart.Add(90*time.Minute), prices[0].Timestamp)
+}
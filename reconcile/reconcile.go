@@ -0,0 +1,148 @@
+package reconcile
+
+import (
+	"sort"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/rpc"
+	"github.com/DefiantLabs/lens/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// Discrepancy is a denom whose balance replayed from the indexed data does not match the bank balance of the address on chain.
+// FirstDivergenceHeight is the first height the balances differ at, 0 when it could not be searched.
+type Discrepancy struct {
+	Address               string `json:"address"`
+	Height                int64  `json:"height"`
+	Denom                 string `json:"denom"`
+	Indexed               string `json:"indexed"`
+	OnChain               string `json:"on_chain"`
+	Difference            string `json:"difference"`
+	FirstDivergenceHeight int64  `json:"first_divergence_height,omitempty"`
+}
+
+// Address replays the indexed balance changes of the address up to the height and compares the net balance of each denom
+// with the bank balance queried from the node at the height. The replay starts from the bank balance before startHeight, the
+// lowest indexed height, unless indexing started at genesis. For the mismatched denoms the first divergence is searched
+// between startHeight and the height, this requires an archive node keeping the state of those heights.
+func Address(cl *client.ChainClient, db *gorm.DB, dbChainID uint, address string, startHeight int64, height int64) ([]Discrepancy, error) {
+	changes, err := dbTypes.GetBalanceChanges(db, dbChainID, address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	opening := make(map[string]decimal.Decimal)
+	if startHeight > 1 {
+		openingBalances, err := rpc.GetAllBalancesAtHeight(cl, address, startHeight-1)
+		if err != nil {
+			return nil, err
+		}
+		opening = coinBalances(openingBalances)
+	}
+
+	balances, err := rpc.GetAllBalancesAtHeight(cl, address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	discrepancies := compareBalances(address, height, indexedBalances(opening, changes, height), coinBalances(balances))
+	for i, discrepancy := range discrepancies {
+		denom := discrepancy.Denom
+		balanceAt := func(height int64) (decimal.Decimal, error) {
+			balance, err := rpc.GetBalanceAtHeight(cl, address, denom, height)
+			if err != nil {
+				return decimal.Zero, err
+			}
+			return decimal.NewFromBigInt(balance.Amount.BigInt(), 0), nil
+		}
+
+		discrepancies[i].FirstDivergenceHeight, err = firstDivergence(balanceAt, opening, changes, denom, startHeight, height)
+		if err != nil {
+			config.Log.Warnf("Could not search the first divergence of %s for address %s. Err: %v", denom, address, err)
+		}
+	}
+
+	return discrepancies, nil
+}
+
+// compareBalances returns the denoms whose indexed and on-chain balances differ, sorted by denom
+func compareBalances(address string, height int64, indexed map[string]decimal.Decimal, onChain map[string]decimal.Decimal) []Discrepancy {
+	denoms := make([]string, 0, len(indexed)+len(onChain))
+	for denom := range indexed {
+		denoms = append(denoms, denom)
+	}
+	for denom := range onChain {
+		if _, ok := indexed[denom]; !ok {
+			denoms = append(denoms, denom)
+		}
+	}
+	sort.Strings(denoms)
+
+	var discrepancies []Discrepancy
+	for _, denom := range denoms {
+		if indexed[denom].Equal(onChain[denom]) {
+			continue
+		}
+
+		discrepancies = append(discrepancies, Discrepancy{
+			Address:    address,
+			Height:     height,
+			Denom:      denom,
+			Indexed:    indexed[denom].String(),
+			OnChain:    onChain[denom].String(),
+			Difference: onChain[denom].Sub(indexed[denom]).String(),
+		})
+	}
+
+	return discrepancies
+}
+
+// firstDivergence binary searches the first height between start and end where the indexed and on-chain balance of the denom
+// differ, the balances must differ at end. Divergences that cancel out before end are not found.
+func firstDivergence(balanceAt func(height int64) (decimal.Decimal, error), opening map[string]decimal.Decimal, changes []dbTypes.BalanceChange,
+	denom string, start int64, end int64,
+) (int64, error) {
+	for start < end {
+		mid := start + (end-start)/2
+
+		balance, err := balanceAt(mid)
+		if err != nil {
+			return 0, err
+		}
+
+		if indexedBalances(opening, changes, mid)[denom].Equal(balance) {
+			start = mid + 1
+		} else {
+			end = mid
+		}
+	}
+
+	return start, nil
+}
+
+// indexedBalances adds the changes up to the height to the opening balances
+func indexedBalances(opening map[string]decimal.Decimal, changes []dbTypes.BalanceChange, height int64) map[string]decimal.Decimal {
+	balances := make(map[string]decimal.Decimal, len(opening))
+	for denom, amount := range opening {
+		balances[denom] = amount
+	}
+
+	for _, change := range changes {
+		if change.Height > height {
+			break
+		}
+		balances[change.Denom] = balances[change.Denom].Add(change.Amount)
+	}
+	return balances
+}
+
+func coinBalances(coins sdk.Coins) map[string]decimal.Decimal {
+	balances := make(map[string]decimal.Decimal, len(coins))
+	for _, coin := range coins {
+		balances[coin.Denom] = decimal.NewFromBigInt(coin.Amount.BigInt(), 0)
+	}
+	return balances
+}
@@ -0,0 +1,54 @@
+package reconcile
+
+import (
+	"testing"
+
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedBalancesFromOpeningBalance(t *testing.T) {
+	opening := map[string]decimal.Decimal{"uatom": decimal.NewFromInt(100)}
+	changes := []dbTypes.BalanceChange{
+		{Height: 10, Denom: "uatom", Amount: decimal.NewFromInt(-30)},
+		{Height: 12, Denom: "uosmo", Amount: decimal.NewFromInt(5)},
+		{Height: 15, Denom: "uatom", Amount: decimal.NewFromInt(10)},
+	}
+
+	balances := indexedBalances(opening, changes, 12)
+	assert.True(t, decimal.NewFromInt(70).Equal(balances["uatom"]))
+	assert.True(t, decimal.NewFromInt(5).Equal(balances["uosmo"]))
+	assert.True(t, decimal.NewFromInt(100).Equal(opening["uatom"]), "the opening balances are not modified")
+
+	onChain := map[string]decimal.Decimal{"uatom": decimal.NewFromInt(70), "uosmo": decimal.NewFromInt(6), "uion": decimal.NewFromInt(1)}
+	discrepancies := compareBalances("cosmos1address", 12, balances, onChain)
+	assert.Len(t, discrepancies, 2, "the opening balance is not a discrepancy")
+	assert.Equal(t, "uion", discrepancies[0].Denom)
+	assert.Equal(t, "0", discrepancies[0].Indexed)
+	assert.Equal(t, "uosmo", discrepancies[1].Denom)
+	assert.Equal(t, "1", discrepancies[1].Difference)
+}
+
+func TestFirstDivergence(t *testing.T) {
+	opening := map[string]decimal.Decimal{"uatom": decimal.NewFromInt(100)}
+	changes := []dbTypes.BalanceChange{
+		{Height: 10, Denom: "uatom", Amount: decimal.NewFromInt(-30)},
+	}
+
+	// An unindexed receive of 1uatom at height 14
+	balanceAt := func(height int64) (decimal.Decimal, error) {
+		balance := decimal.NewFromInt(100)
+		if height >= 10 {
+			balance = balance.Sub(decimal.NewFromInt(30))
+		}
+		if height >= 14 {
+			balance = balance.Add(decimal.NewFromInt(1))
+		}
+		return balance, nil
+	}
+
+	divergence, err := firstDivergence(balanceAt, opening, changes, "uatom", 5, 20)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(14), divergence)
+}
@@ -4,16 +4,27 @@ import (
 	"fmt"
 	"time"
 
+	sdkMath "cosmossdk.io/math"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	osmosisCosmWasmPool "github.com/osmosis-labs/osmosis/v26/x/cosmwasmpool/model"
+	osmosisLockup "github.com/osmosis-labs/osmosis/v26/x/lockup/types"
+	osmosisPoolManager "github.com/osmosis-labs/osmosis/v26/x/poolmanager/client/queryproto"
 	osmosisProtorev "github.com/osmosis-labs/osmosis/v26/x/protorev/types"
 	osmosisEpochs "github.com/osmosis-labs/osmosis/x/epochs/types"
 
+	evidenceTypes "cosmossdk.io/x/evidence/types"
 	wasmTypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	strideStakeibc "github.com/DefiantLabs/cosmos-tax-cli/stride/types/stakeibc"
 	lensClient "github.com/DefiantLabs/lens/client"
 	lensQuery "github.com/DefiantLabs/lens/client/query"
+	sdkTypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
 	txTypes "github.com/cosmos/cosmos-sdk/types/tx"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributionTypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	slashingTypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 var apiEndpoints = map[string]string{
@@ -194,6 +205,52 @@ func GetEpochsAtHeight(cl *lensClient.ChainClient, height int64) (*osmosisEpochs
 	return resp, err
 }
 
+// strideEpochInfosMethod is the Stride epochs query, the Stride epochs module is a fork of the Osmosis epochs module and the
+// EpochInfos request and response are wire compatible with the Osmosis types
+const strideEpochInfosMethod = "/stride.epochs.Query/EpochInfos"
+
+// GetStrideEpochsAtHeight makes a request to the Cosmos RPC API and returns the Stride Epochs at a specific height
+func GetStrideEpochsAtHeight(cl *lensClient.ChainClient, height int64) (*osmosisEpochs.QueryEpochsInfoResponse, error) {
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+	ctx, cancel := query.GetQueryContext()
+	defer cancel()
+
+	resp := &osmosisEpochs.QueryEpochsInfoResponse{}
+	err := cl.Invoke(ctx, strideEpochInfosMethod, &osmosisEpochs.QueryEpochsInfoRequest{}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStrideHostZones makes a request to the Cosmos RPC API and returns every Stride host zone at a specific height
+func GetStrideHostZones(cl *lensClient.ChainClient, height int64) ([]strideStakeibc.HostZone, error) {
+	var hostZones []strideStakeibc.HostZone
+	queryClient := strideStakeibc.NewQueryClient(cl)
+
+	pg := query.PageRequest{Limit: 100}
+	for {
+		options := lensQuery.QueryOptions{Height: height}
+		query := lensQuery.Query{Client: cl, Options: &options}
+		ctx, cancel := query.GetQueryContext()
+
+		resp, err := queryClient.HostZoneAll(ctx, &strideStakeibc.QueryAllHostZoneRequest{Pagination: &pg})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		hostZones = append(hostZones, resp.HostZone...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			break
+		}
+		pg.Key = resp.Pagination.NextKey
+	}
+
+	return hostZones, nil
+}
+
 // GetEpochsAtHeight makes a request to the Cosmos RPC API and returns the Epoch at a specific height
 func GetProtorevDeveloperAccount(cl *lensClient.ChainClient) (*osmosisProtorev.QueryGetProtoRevDeveloperAccountResponse, error) {
 	options := lensQuery.QueryOptions{}
@@ -212,3 +269,277 @@ func GetContractsByCodeIDAtHeight(cl *lensClient.ChainClient, codeID uint64, hei
 	}
 	return resp, nil
 }
+
+const cosmWasmPoolTypeURL = "/osmosis.cosmwasmpool.v1beta1.CosmWasmPool"
+
+// GetCosmWasmPools returns every CosmWasm pool (e.g. transmuter and orderbook pools) registered in the Osmosis poolmanager
+func GetCosmWasmPools(cl *lensClient.ChainClient, height int64) ([]osmosisCosmWasmPool.CosmWasmPool, error) {
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+	ctx, cancel := query.GetQueryContext()
+	defer cancel()
+
+	queryClient := osmosisPoolManager.NewQueryClient(cl)
+	resp, err := queryClient.AllPools(ctx, &osmosisPoolManager.AllPoolsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []osmosisCosmWasmPool.CosmWasmPool
+	for _, anyPool := range resp.Pools {
+		if anyPool.TypeUrl != cosmWasmPoolTypeURL {
+			continue
+		}
+
+		var pool osmosisCosmWasmPool.CosmWasmPool
+		if err := pool.Unmarshal(anyPool.Value); err != nil {
+			return nil, fmt.Errorf("error unmarshaling CosmWasm pool: %w", err)
+		}
+		pools = append(pools, pool)
+	}
+
+	return pools, nil
+}
+
+// GetValidators returns every validator of the chain, bonded or not, with their consensus pubkeys unpacked
+func GetValidators(cl *lensClient.ChainClient) ([]stakingTypes.Validator, error) {
+	pg := query.PageRequest{Limit: 100}
+	options := lensQuery.QueryOptions{}
+	query := lensQuery.Query{Client: cl, Options: &options}
+
+	queryClient := stakingTypes.NewQueryClient(cl)
+
+	var validators []stakingTypes.Validator
+	for {
+		ctx, cancel := query.GetQueryContext()
+		resp, err := queryClient.Validators(ctx, &stakingTypes.QueryValidatorsRequest{Pagination: &pg})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, validator := range resp.Validators {
+			if err := validator.UnpackInterfaces(cl.Codec.InterfaceRegistry); err != nil {
+				return nil, fmt.Errorf("error unpacking the consensus pubkey of validator %s: %w", validator.OperatorAddress, err)
+			}
+			validators = append(validators, validator)
+		}
+
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			break
+		}
+		pg.Key = resp.Pagination.NextKey
+	}
+
+	return validators, nil
+}
+
+// GetSlashingParamsAtHeight returns the slashing params, with the slash fractions of downtime and double signing, at a specific height
+func GetSlashingParamsAtHeight(cl *lensClient.ChainClient, height int64) (slashingTypes.Params, error) {
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+	ctx, cancel := query.GetQueryContext()
+	defer cancel()
+
+	queryClient := slashingTypes.NewQueryClient(cl)
+	resp, err := queryClient.Params(ctx, &slashingTypes.QueryParamsRequest{})
+	if err != nil {
+		return slashingTypes.Params{}, err
+	}
+
+	return resp.Params, nil
+}
+
+const equivocationTypeURL = "/cosmos.evidence.v1beta1.Equivocation"
+
+// GetEquivocationsAtHeight returns the double signing evidence handled by the evidence module until a specific height
+func GetEquivocationsAtHeight(cl *lensClient.ChainClient, height int64) ([]evidenceTypes.Equivocation, error) {
+	pg := query.PageRequest{Limit: 100}
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+
+	queryClient := evidenceTypes.NewQueryClient(cl)
+
+	var equivocations []evidenceTypes.Equivocation
+	for {
+		ctx, cancel := query.GetQueryContext()
+		resp, err := queryClient.AllEvidence(ctx, &evidenceTypes.QueryAllEvidenceRequest{Pagination: &pg})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, anyEvidence := range resp.Evidence {
+			if anyEvidence.TypeUrl != equivocationTypeURL {
+				continue
+			}
+
+			var equivocation evidenceTypes.Equivocation
+			if err := equivocation.Unmarshal(anyEvidence.Value); err != nil {
+				return nil, fmt.Errorf("error unmarshaling equivocation evidence: %w", err)
+			}
+			equivocations = append(equivocations, equivocation)
+		}
+
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			break
+		}
+		pg.Key = resp.Pagination.NextKey
+	}
+
+	return equivocations, nil
+}
+
+// GetStakingParamsAtHeight returns the staking params, with the bond denom, at a specific height
+func GetStakingParamsAtHeight(cl *lensClient.ChainClient, height int64) (stakingTypes.Params, error) {
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+	ctx, cancel := query.GetQueryContext()
+	defer cancel()
+
+	queryClient := stakingTypes.NewQueryClient(cl)
+	resp, err := queryClient.Params(ctx, &stakingTypes.QueryParamsRequest{})
+	if err != nil {
+		return stakingTypes.Params{}, err
+	}
+
+	return resp.Params, nil
+}
+
+// GetDelegatorDelegationsAtHeight returns the delegations of the delegator at a specific height
+func GetDelegatorDelegationsAtHeight(cl *lensClient.ChainClient, delegator string, height int64) (stakingTypes.DelegationResponses, error) {
+	pg := query.PageRequest{Limit: 100}
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+
+	queryClient := stakingTypes.NewQueryClient(cl)
+
+	var delegations stakingTypes.DelegationResponses
+	for {
+		ctx, cancel := query.GetQueryContext()
+		resp, err := queryClient.DelegatorDelegations(ctx, &stakingTypes.QueryDelegatorDelegationsRequest{DelegatorAddr: delegator, Pagination: &pg})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		delegations = append(delegations, resp.DelegationResponses...)
+
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			break
+		}
+		pg.Key = resp.Pagination.NextKey
+	}
+
+	return delegations, nil
+}
+
+// GetDelegatorUnbondingDelegationsAtHeight returns the unbonding delegations of the delegator at a specific height
+func GetDelegatorUnbondingDelegationsAtHeight(cl *lensClient.ChainClient, delegator string, height int64) ([]stakingTypes.UnbondingDelegation, error) {
+	pg := query.PageRequest{Limit: 100}
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+
+	queryClient := stakingTypes.NewQueryClient(cl)
+
+	var unbondingDelegations []stakingTypes.UnbondingDelegation
+	for {
+		ctx, cancel := query.GetQueryContext()
+		resp, err := queryClient.DelegatorUnbondingDelegations(ctx, &stakingTypes.QueryDelegatorUnbondingDelegationsRequest{DelegatorAddr: delegator, Pagination: &pg})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		unbondingDelegations = append(unbondingDelegations, resp.UnbondingResponses...)
+
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			break
+		}
+		pg.Key = resp.Pagination.NextKey
+	}
+
+	return unbondingDelegations, nil
+}
+
+// GetDelegationTotalRewardsAtHeight returns the unclaimed rewards of the delegator per validator at a specific height
+func GetDelegationTotalRewardsAtHeight(cl *lensClient.ChainClient, delegator string, height int64) (*distributionTypes.QueryDelegationTotalRewardsResponse, error) {
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+	ctx, cancel := query.GetQueryContext()
+	defer cancel()
+
+	queryClient := distributionTypes.NewQueryClient(cl)
+	return queryClient.DelegationTotalRewards(ctx, &distributionTypes.QueryDelegationTotalRewardsRequest{DelegatorAddress: delegator})
+}
+
+// GetAllBalancesAtHeight returns the bank balances of the address at a specific height
+func GetAllBalancesAtHeight(cl *lensClient.ChainClient, address string, height int64) (sdkTypes.Coins, error) {
+	pg := query.PageRequest{Limit: 100}
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+
+	queryClient := bankTypes.NewQueryClient(cl)
+
+	var balances sdkTypes.Coins
+	for {
+		ctx, cancel := query.GetQueryContext()
+		resp, err := queryClient.AllBalances(ctx, &bankTypes.QueryAllBalancesRequest{Address: address, Pagination: &pg})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+
+		balances = append(balances, resp.Balances...)
+
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			break
+		}
+		pg.Key = resp.Pagination.NextKey
+	}
+
+	return balances, nil
+}
+
+// GetBalanceAtHeight returns the bank balance of a single denom of the address at a specific height
+func GetBalanceAtHeight(cl *lensClient.ChainClient, address string, denom string, height int64) (sdkTypes.Coin, error) {
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+	ctx, cancel := query.GetQueryContext()
+	defer cancel()
+
+	queryClient := bankTypes.NewQueryClient(cl)
+	resp, err := queryClient.Balance(ctx, &bankTypes.QueryBalanceRequest{Address: address, Denom: denom})
+	if err != nil {
+		return sdkTypes.Coin{}, err
+	}
+
+	if resp.Balance == nil {
+		return sdkTypes.NewCoin(denom, sdkMath.ZeroInt()), nil
+	}
+
+	return *resp.Balance, nil
+}
+
+// GetOsmosisLockedCoinsAtHeight returns the coins the address has locked and unlocking in the Osmosis lockup module (e.g.
+// bonded LP shares) at a specific height
+func GetOsmosisLockedCoinsAtHeight(cl *lensClient.ChainClient, address string, height int64) (locked sdkTypes.Coins, unlocking sdkTypes.Coins, err error) {
+	options := lensQuery.QueryOptions{Height: height}
+	query := lensQuery.Query{Client: cl, Options: &options}
+	ctx, cancel := query.GetQueryContext()
+	defer cancel()
+
+	queryClient := osmosisLockup.NewQueryClient(cl)
+
+	lockedResp, err := queryClient.AccountLockedCoins(ctx, &osmosisLockup.AccountLockedCoinsRequest{Owner: address})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unlockingResp, err := queryClient.AccountUnlockingCoins(ctx, &osmosisLockup.AccountUnlockingCoinsRequest{Owner: address})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return lockedResp.Coins, unlockingResp.Coins, nil
+}
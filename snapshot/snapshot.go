@@ -0,0 +1,178 @@
+package snapshot
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/osmosis"
+	"github.com/DefiantLabs/cosmos-tax-cli/rpc"
+	"github.com/DefiantLabs/lens/client"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// The categories of the positions in a snapshot
+const (
+	CategoryBonded           = "bonded"
+	CategoryUnbonding        = "unbonding"
+	CategoryUnclaimedRewards = "unclaimed_rewards"
+	CategoryBalance          = "balance"
+	CategoryLPShares         = "lp_shares"
+	CategoryLocked           = "locked"
+	CategoryUnlocking        = "unlocking"
+)
+
+// Osmosis GAMM pool shares are held as bank balances with this denom prefix
+const gammSharesPrefix = "gamm/pool/"
+
+// Position is an amount of a denom held by an address at the snapshot height. The amount is in the base unit of the denom,
+// unclaimed rewards can have decimals.
+type Position struct {
+	Address        string     `json:"address"`
+	Category       string     `json:"category"`
+	Validator      string     `json:"validator,omitempty"`
+	Denom          string     `json:"denom"`
+	Amount         string     `json:"amount"`
+	CompletionTime *time.Time `json:"completion_time,omitempty"`
+}
+
+// Snapshot is the state of the addresses at the last indexed block at or before the snapshot date
+type Snapshot struct {
+	ChainID   string     `json:"chain_id"`
+	Date      time.Time  `json:"date"`
+	Height    int64      `json:"height"`
+	BlockTime time.Time  `json:"block_time"`
+	Positions []Position `json:"positions"`
+}
+
+// Take queries the archive node for the delegations, unbonding delegations, unclaimed rewards, balances and LP shares of
+// the addresses at the given height. The node must keep the state of that height.
+func Take(cl *client.ChainClient, date time.Time, height int64, blockTime time.Time, addresses []string) (Snapshot, error) {
+	snapshot := Snapshot{
+		ChainID:   cl.Config.ChainID,
+		Date:      date,
+		Height:    height,
+		BlockTime: blockTime,
+	}
+
+	// The unbonding entries do not carry a denom, they are in the bond denom of the chain
+	stakingParams, err := rpc.GetStakingParamsAtHeight(cl, height)
+	if err != nil {
+		return snapshot, err
+	}
+
+	for _, address := range addresses {
+		positions, err := takeAddress(cl, address, height, stakingParams.BondDenom)
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot.Positions = append(snapshot.Positions, positions...)
+	}
+
+	return snapshot, nil
+}
+
+func takeAddress(cl *client.ChainClient, address string, height int64, bondDenom string) ([]Position, error) {
+	delegations, err := rpc.GetDelegatorDelegationsAtHeight(cl, address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	unbondingDelegations, err := rpc.GetDelegatorUnbondingDelegationsAtHeight(cl, address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := delegationPositions(address, delegations)
+	positions = append(positions, unbondingPositions(address, bondDenom, unbondingDelegations)...)
+
+	rewards, err := rpc.GetDelegationTotalRewardsAtHeight(cl, address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, validatorRewards := range rewards.Rewards {
+		for _, reward := range validatorRewards.Reward {
+			if reward.IsZero() {
+				continue
+			}
+			positions = append(positions, Position{
+				Address:   address,
+				Category:  CategoryUnclaimedRewards,
+				Validator: validatorRewards.ValidatorAddress,
+				Denom:     reward.Denom,
+				Amount:    reward.Amount.String(),
+			})
+		}
+	}
+
+	balances, err := rpc.GetAllBalancesAtHeight(cl, address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, balance := range balances {
+		category := CategoryBalance
+		if strings.HasPrefix(balance.Denom, gammSharesPrefix) {
+			category = CategoryLPShares
+		}
+		positions = append(positions, Position{
+			Address:  address,
+			Category: category,
+			Denom:    balance.Denom,
+			Amount:   balance.Amount.String(),
+		})
+	}
+
+	// LP shares bonded in the Osmosis lockup module are not bank balances of the address
+	if cl.Config.ChainID == osmosis.ChainID {
+		locked, unlocking, err := rpc.GetOsmosisLockedCoinsAtHeight(cl, address, height)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, coin := range locked {
+			positions = append(positions, Position{Address: address, Category: CategoryLocked, Denom: coin.Denom, Amount: coin.Amount.String()})
+		}
+
+		for _, coin := range unlocking {
+			positions = append(positions, Position{Address: address, Category: CategoryUnlocking, Denom: coin.Denom, Amount: coin.Amount.String()})
+		}
+	}
+
+	return positions, nil
+}
+
+func delegationPositions(address string, delegations stakingTypes.DelegationResponses) []Position {
+	var positions []Position
+	for _, delegation := range delegations {
+		if delegation.Balance.IsZero() {
+			continue
+		}
+		positions = append(positions, Position{
+			Address:   address,
+			Category:  CategoryBonded,
+			Validator: delegation.Delegation.ValidatorAddress,
+			Denom:     delegation.Balance.Denom,
+			Amount:    delegation.Balance.Amount.String(),
+		})
+	}
+	return positions
+}
+
+func unbondingPositions(address string, bondDenom string, unbondingDelegations []stakingTypes.UnbondingDelegation) []Position {
+	var positions []Position
+	for _, unbondingDelegation := range unbondingDelegations {
+		for _, entry := range unbondingDelegation.Entries {
+			completionTime := entry.CompletionTime
+			positions = append(positions, Position{
+				Address:        address,
+				Category:       CategoryUnbonding,
+				Validator:      unbondingDelegation.ValidatorAddress,
+				Denom:          bondDenom,
+				Amount:         entry.Balance.String(),
+				CompletionTime: &completionTime,
+			})
+		}
+	}
+	return positions
+}
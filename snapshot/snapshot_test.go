@@ -0,0 +1,46 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+
+	sdkMath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingTypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnbondingPositionsOfFullyUnbondedAddress(t *testing.T) {
+	completionTime := time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)
+	unbondingDelegations := []stakingTypes.UnbondingDelegation{{
+		DelegatorAddress: "cosmos1delegator",
+		ValidatorAddress: "cosmosvaloper1validator",
+		Entries: []stakingTypes.UnbondingDelegationEntry{
+			{CompletionTime: completionTime, Balance: sdkMath.NewInt(1000)},
+		},
+	}}
+
+	// The address has no delegation left to take the denom from
+	assert.Empty(t, delegationPositions("cosmos1delegator", nil))
+
+	positions := unbondingPositions("cosmos1delegator", "uatom", unbondingDelegations)
+	assert.Len(t, positions, 1)
+	assert.Equal(t, CategoryUnbonding, positions[0].Category)
+	assert.Equal(t, "uatom", positions[0].Denom)
+	assert.Equal(t, "1000", positions[0].Amount)
+	assert.Equal(t, "cosmosvaloper1validator", positions[0].Validator)
+	assert.Equal(t, completionTime, *positions[0].CompletionTime)
+}
+
+func TestDelegationPositions(t *testing.T) {
+	delegations := stakingTypes.DelegationResponses{
+		{Delegation: stakingTypes.Delegation{ValidatorAddress: "cosmosvaloper1a"}, Balance: sdk.NewCoin("uatom", sdkMath.NewInt(500))},
+		{Delegation: stakingTypes.Delegation{ValidatorAddress: "cosmosvaloper1b"}, Balance: sdk.NewCoin("uatom", sdkMath.ZeroInt())},
+	}
+
+	positions := delegationPositions("cosmos1delegator", delegations)
+	assert.Len(t, positions, 1, "empty delegations are left out")
+	assert.Equal(t, CategoryBonded, positions[0].Category)
+	assert.Equal(t, "cosmosvaloper1a", positions[0].Validator)
+	assert.Equal(t, "500", positions[0].Amount)
+}
@@ -0,0 +1,65 @@
+package epochs
+
+import (
+	eventTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/events"
+	dbTypes "github.com/DefiantLabs/cosmos-tax-cli/db"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/epochs"
+	stakeibcTypes "github.com/DefiantLabs/cosmos-tax-cli/stride/types/stakeibc"
+	"github.com/shopspring/decimal"
+)
+
+// The Stride epoch hooks (reinvestment, unbonding and the transfers of deposits to the host zones) move funds between module
+// and host zone accounts, none of them are taxable for accounts on Stride itself, so there are no block event handlers.
+var dayEventTypeHandlers = map[string]map[string][]func() eventTypes.CosmosEvent{
+	"begin_block": nil,
+	"end_block":   nil,
+}
+
+var strideEpochEventTypeHandlers = map[string]map[string][]func() eventTypes.CosmosEvent{
+	"begin_block": nil,
+	"end_block":   nil,
+}
+
+// EpochIdentifierBlockEventHandlers is a mapping of epoch identifiers to event types and their associated event handlers
+// It is used to get a list of event handlers for:
+// 1. A particular Epoch Identifier
+// 2. Stride begin blocker or end blocker events for
+// 3. A particular Event Type
+var EpochIdentifierBlockEventHandlers = map[string]map[string]map[string][]func() eventTypes.CosmosEvent{
+	epochs.DayEpochIdentifier:    dayEventTypeHandlers,
+	epochs.StrideEpochIdentifier: strideEpochEventTypeHandlers,
+}
+
+// RedemptionRateEpochIdentifiers are the epochs stakeibc updates the redemption rates of the host zones in. The update emits
+// no event, the rates are read from the host zones at the start height of the epoch instead.
+var RedemptionRateEpochIdentifiers = map[string]bool{
+	epochs.StrideEpochIdentifier: true,
+}
+
+// stTokenPrefix is prepended to the host denom to get the denom of the liquid staking token, e.g. stuatom
+const stTokenPrefix = "st"
+
+// ParseRedemptionRates returns the redemption rate of the stToken of each host zone in the native tokens on Stride
+func ParseRedemptionRates(hostZones []stakeibcTypes.HostZone) ([]dbTypes.RedemptionRate, error) {
+	var rates []dbTypes.RedemptionRate
+	for _, hostZone := range hostZones {
+		// Host zones that are registered but not liquid staked to yet have no rate
+		if hostZone.HostDenom == "" || hostZone.IbcDenom == "" || hostZone.RedemptionRate.IsNil() || !hostZone.RedemptionRate.IsPositive() {
+			continue
+		}
+
+		rate, err := decimal.NewFromString(hostZone.RedemptionRate.String())
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, dbTypes.RedemptionRate{
+			HostZone:           hostZone.ChainId,
+			Denomination:       dbTypes.Denom{Base: stTokenPrefix + hostZone.HostDenom},
+			NativeDenomination: dbTypes.Denom{Base: hostZone.IbcDenom},
+			Rate:               rate,
+		})
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,38 @@
+package epochs
+
+import (
+	"testing"
+
+	stakeibcTypes "github.com/DefiantLabs/cosmos-tax-cli/stride/types/stakeibc"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRedemptionRates(t *testing.T) {
+	hostZones := []stakeibcTypes.HostZone{
+		{
+			ChainId:            "cosmoshub-4",
+			HostDenom:          "uatom",
+			IbcDenom:           "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+			LastRedemptionRate: math.LegacyMustNewDecFromStr("1.25"),
+			RedemptionRate:     math.LegacyMustNewDecFromStr("1.250345"),
+		},
+		// Registered but never liquid staked to
+		{
+			ChainId:        "dydx-mainnet-1",
+			HostDenom:      "adydx",
+			IbcDenom:       "ibc/561C70B20188A047BFDE6F9946BDDC5D8AC172B9BE04FF868DFABF819E5A9CCE",
+			RedemptionRate: math.LegacyZeroDec(),
+		},
+	}
+
+	rates, err := ParseRedemptionRates(hostZones)
+	assert.Nil(t, err)
+	assert.Len(t, rates, 1)
+
+	assert.Equal(t, "cosmoshub-4", rates[0].HostZone)
+	assert.Equal(t, "stuatom", rates[0].Denomination.Base)
+	assert.Equal(t, hostZones[0].IbcDenom, rates[0].NativeDenomination.Base)
+	assert.Equal(t, "1.250345", rates[0].Rate.String())
+}
@@ -0,0 +1,15 @@
+package stride
+
+import (
+	txTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/claim"
+	"github.com/DefiantLabs/cosmos-tax-cli/stride/modules/stakeibc"
+)
+
+// MessageTypeHandler is used to unmarshal JSON to a particular type.
+var MessageTypeHandler = map[string][]func() txTypes.CosmosMessage{
+	stakeibc.MsgLiquidStake:    {func() txTypes.CosmosMessage { return &stakeibc.WrapperMsgLiquidStake{} }},
+	stakeibc.MsgLSMLiquidStake: {func() txTypes.CosmosMessage { return &stakeibc.WrapperMsgLSMLiquidStake{} }},
+	stakeibc.MsgRedeemStake:    {func() txTypes.CosmosMessage { return &stakeibc.WrapperMsgRedeemStake{} }},
+	claim.MsgClaimFreeAmount:   {func() txTypes.CosmosMessage { return &claim.WrapperMsgClaimFreeAmount{} }},
+}
@@ -0,0 +1,66 @@
+package claim
+
+import (
+	"fmt"
+
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	airdropClaim "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/claim"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Stride claim module messages. The airdrop is paid out of the airdrop distributor account to the claiming user.
+// The message type is vendored in stride/types and registered with the chain client.
+const (
+	MsgClaimFreeAmount = "/stride.claim.MsgClaimFreeAmount"
+)
+
+type claimFreeAmountMsg interface {
+	GetUser() string
+}
+
+type WrapperMsgClaimFreeAmount struct {
+	txModule.Message
+	User   string
+	Claims []airdropClaim.Claim
+}
+
+func (sf *WrapperMsgClaimFreeAmount) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+
+	claimMsg, ok := msg.(claimFreeAmountMsg)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T for %s", msg, msgType)
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.User = claimMsg.GetUser()
+
+	var err error
+	sf.Claims, err = airdropClaim.ParseClaims(msgType, sf.User, log)
+	return err
+}
+
+func (sf *WrapperMsgClaimFreeAmount) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	return airdropClaim.ClaimsToRelevantData(sf.Claims)
+}
+
+func (sf *WrapperMsgClaimFreeAmount) IsAirdropClaim() bool {
+	return true
+}
+
+func (sf *WrapperMsgClaimFreeAmount) String() string {
+	var claimed sdk.Coins
+	for _, claim := range sf.Claims {
+		claimed = claimed.Add(claim.Amount...)
+	}
+
+	return fmt.Sprintf("MsgClaimFreeAmount: %s claimed %s", sf.User, claimed)
+}
@@ -0,0 +1,55 @@
+package claim
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	claimTypes "github.com/DefiantLabs/cosmos-tax-cli/stride/types/claim"
+	lensClient "github.com/DefiantLabs/lens/client"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimFreeAmount(t *testing.T) {
+	cl := &lensClient.ChainClient{Codec: lensClient.MakeCodec(lensClient.ModuleBasics, "stride", "stridevaloper")}
+	config.RegisterAdditionalTypes(cl)
+
+	user, err := bech32.ConvertAndEncode("stride", []byte("stride-test-claimer1"))
+	assert.Nil(t, err)
+	distributor, err := bech32.ConvertAndEncode("stride", []byte("stride-distributor01"))
+	assert.Nil(t, err)
+
+	txBuilder := cl.Codec.TxConfig.NewTxBuilder()
+	assert.Nil(t, txBuilder.SetMsgs(&claimTypes.MsgClaimFreeAmount{User: user}))
+	txBytes, err := cl.Codec.TxConfig.TxEncoder()(txBuilder.GetTx())
+	assert.Nil(t, err)
+
+	decodedTx, err := cl.Codec.TxConfig.TxDecoder()(txBytes)
+	assert.Nil(t, err)
+	assert.Len(t, decodedTx.GetMsgs(), 1)
+
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: MsgClaimFreeAmount}}},
+			{Type: "transfer", Attributes: []txModule.Attribute{
+				{Key: "recipient", Value: user},
+				{Key: "sender", Value: distributor},
+				{Key: "amount", Value: "5000000ustrd"},
+			}},
+		},
+	}
+
+	wrapper := &WrapperMsgClaimFreeAmount{}
+	err = wrapper.HandleMsg(MsgClaimFreeAmount, decodedTx.GetMsgs()[0], log)
+	assert.Nil(t, err)
+	assert.Equal(t, user, wrapper.User)
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 1)
+	assert.Equal(t, distributor, relevantData[0].SenderAddress)
+	assert.Equal(t, user, relevantData[0].ReceiverAddress)
+	assert.Equal(t, int64(5000000), relevantData[0].AmountReceived.Int64())
+	assert.Equal(t, "ustrd", relevantData[0].DenominationReceived)
+}
@@ -0,0 +1,11 @@
+package epochs
+
+const (
+	DayEpochIdentifier    = "day"
+	StrideEpochIdentifier = "stride_epoch"
+)
+
+var StrideIndexableEpochs = map[string]bool{
+	DayEpochIdentifier:    true,
+	StrideEpochIdentifier: true,
+}
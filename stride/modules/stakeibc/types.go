@@ -0,0 +1,190 @@
+package stakeibc
+
+import (
+	"fmt"
+
+	parsingTypes "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	"github.com/DefiantLabs/cosmos-tax-cli/util"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// Stride stakeibc module messages. Liquid staking mints stTokens for the native tokens of a host zone and redeeming
+// burns the stTokens for the native tokens, both are exchanges at the current redemption rate.
+// The Stride protobuf types are vendored in stride/types and registered with the chain client, the wrappers read the decoded
+// messages through their generated getters and the message logs.
+const (
+	MsgLiquidStake    = "/stride.stakeibc.MsgLiquidStake"
+	MsgLSMLiquidStake = "/stride.stakeibc.MsgLSMLiquidStake"
+	MsgRedeemStake    = "/stride.stakeibc.MsgRedeemStake"
+)
+
+// Attributes of the redeem_stake event, the native tokens are sent to the receiver on the host zone once the unbonding completes
+const (
+	EventTypeRedeemStake        = "redeem_stake"
+	AttributeKeyNativeBaseDenom = "native_base_denom"
+	AttributeKeyNativeAmount    = "native_amount"
+)
+
+type creatorMsg interface {
+	GetCreator() string
+}
+
+type redeemStakeMsg interface {
+	creatorMsg
+	GetHostZone() string
+	GetReceiver() string
+}
+
+type WrapperMsgLiquidStake struct {
+	txModule.Message
+	Creator  string
+	TokenIn  sdk.Coin
+	TokenOut sdk.Coin
+}
+
+// WrapperMsgLSMLiquidStake liquid stakes LSM tokenized delegation shares (IBC transferred from the host zone) instead of native tokens
+type WrapperMsgLSMLiquidStake struct {
+	WrapperMsgLiquidStake
+}
+
+type WrapperMsgRedeemStake struct {
+	txModule.Message
+	Creator  string
+	HostZone string
+	Receiver string
+	TokenIn  sdk.Coin
+	TokenOut sdk.Coin
+}
+
+// getSingleCoin sums the coins in the list of coin strings and expects a single denom
+func getSingleCoin(msgType string, coinStrings []string, log *txModule.LogMessage) (sdk.Coin, error) {
+	var total sdk.Coins
+	for _, coinString := range coinStrings {
+		coins, err := sdk.ParseCoinsNormalized(coinString)
+		if err != nil {
+			return sdk.Coin{}, &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+		}
+		total = total.Add(coins...)
+	}
+
+	if len(total) != 1 {
+		return sdk.Coin{}, &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+	}
+
+	return total[0], nil
+}
+
+func (sf *WrapperMsgLiquidStake) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+
+	liquidStakeMsg, ok := msg.(creatorMsg)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T for %s", msg, msgType)
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.Creator = liquidStakeMsg.GetCreator()
+
+	// The staked tokens are spent to the host zone deposit address and the stTokens are minted to the staker
+	var err error
+	sf.TokenIn, err = getSingleCoin(msgType, txModule.GetCoinsSpent(sf.Creator, txModule.GetEventsWithType(bankTypes.EventTypeCoinSpent, log)), log)
+	if err != nil {
+		return err
+	}
+
+	sf.TokenOut, err = getSingleCoin(msgType, txModule.GetCoinsReceived(sf.Creator, txModule.GetEventsWithType(bankTypes.EventTypeCoinReceived, log)), log)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (sf *WrapperMsgRedeemStake) HandleMsg(msgType string, msg sdk.Msg, log *txModule.LogMessage) error {
+	sf.Type = msgType
+
+	redeemMsg, ok := msg.(redeemStakeMsg)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T for %s", msg, msgType)
+	}
+
+	// Confirm that the action listed in the message log matches the Message type
+	validLog := txModule.IsMessageActionEquals(sf.GetType(), log)
+	if !validLog {
+		return util.ReturnInvalidLog(msgType, log)
+	}
+
+	sf.Creator = redeemMsg.GetCreator()
+	sf.HostZone = redeemMsg.GetHostZone()
+	sf.Receiver = redeemMsg.GetReceiver()
+
+	// The stTokens are escrowed by the module and burned when the unbonding starts
+	var err error
+	sf.TokenIn, err = getSingleCoin(msgType, txModule.GetCoinsSpent(sf.Creator, txModule.GetEventsWithType(bankTypes.EventTypeCoinSpent, log)), log)
+	if err != nil {
+		return err
+	}
+
+	// The native tokens are received on the host zone, the amount is fixed at the redemption rate when the message is executed.
+	// The denom is the base denom of the host zone, which does not exist on Stride.
+	redeemEvent := txModule.GetEventWithType(EventTypeRedeemStake, log)
+	nativeDenom := txModule.GetLastValueForAttribute(AttributeKeyNativeBaseDenom, redeemEvent)
+	nativeAmount, ok := math.NewIntFromString(txModule.GetLastValueForAttribute(AttributeKeyNativeAmount, redeemEvent))
+	if nativeDenom == "" || !ok {
+		return &txModule.MessageLogFormatError{MessageType: msgType, Log: fmt.Sprintf("%+v", log)}
+	}
+
+	sf.TokenOut = sdk.NewCoin(nativeDenom, nativeAmount)
+
+	return nil
+}
+
+func (sf *WrapperMsgLiquidStake) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	relevantData := make([]parsingTypes.MessageRelevantInformation, 1)
+	relevantData[0] = parsingTypes.MessageRelevantInformation{
+		AmountSent:           sf.TokenIn.Amount.BigInt(),
+		DenominationSent:     sf.TokenIn.Denom,
+		AmountReceived:       sf.TokenOut.Amount.BigInt(),
+		DenominationReceived: sf.TokenOut.Denom,
+		SenderAddress:        sf.Creator,
+		ReceiverAddress:      sf.Creator,
+	}
+	return relevantData
+}
+
+// ParseRelevantData records the redemption as a swap of the stTokens for the native tokens by the redeemer, like a liquid
+// stake. The native tokens (in the base denom of the host zone) are paid out to the receiver on the host zone once the
+// unbonding completes.
+func (sf *WrapperMsgRedeemStake) ParseRelevantData() []parsingTypes.MessageRelevantInformation {
+	relevantData := make([]parsingTypes.MessageRelevantInformation, 1)
+	relevantData[0] = parsingTypes.MessageRelevantInformation{
+		AmountSent:           sf.TokenIn.Amount.BigInt(),
+		DenominationSent:     sf.TokenIn.Denom,
+		AmountReceived:       sf.TokenOut.Amount.BigInt(),
+		DenominationReceived: sf.TokenOut.Denom,
+		SenderAddress:        sf.Creator,
+		ReceiverAddress:      sf.Creator,
+	}
+	return relevantData
+}
+
+func (sf *WrapperMsgLiquidStake) String() string {
+	return fmt.Sprintf("MsgLiquidStake: %s liquid staked %s for %s", sf.Creator, sf.TokenIn, sf.TokenOut)
+}
+
+func (sf *WrapperMsgLSMLiquidStake) String() string {
+	return fmt.Sprintf("MsgLSMLiquidStake: %s liquid staked %s for %s", sf.Creator, sf.TokenIn, sf.TokenOut)
+}
+
+func (sf *WrapperMsgRedeemStake) String() string {
+	return fmt.Sprintf("MsgRedeemStake: %s redeemed %s for %s on %s (receiver %s)", sf.Creator, sf.TokenIn, sf.TokenOut, sf.HostZone, sf.Receiver)
+}
@@ -0,0 +1,109 @@
+package stakeibc
+
+import (
+	"testing"
+
+	"github.com/DefiantLabs/cosmos-tax-cli/config"
+	txModule "github.com/DefiantLabs/cosmos-tax-cli/cosmos/modules/tx"
+	stakeibcTypes "github.com/DefiantLabs/cosmos-tax-cli/stride/types/stakeibc"
+	lensClient "github.com/DefiantLabs/lens/client"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeMsg(t *testing.T, msg sdk.Msg) sdk.Msg {
+	cl := &lensClient.ChainClient{Codec: lensClient.MakeCodec(lensClient.ModuleBasics, "stride", "stridevaloper")}
+	config.RegisterAdditionalTypes(cl)
+
+	txBuilder := cl.Codec.TxConfig.NewTxBuilder()
+	assert.Nil(t, txBuilder.SetMsgs(msg))
+	txBytes, err := cl.Codec.TxConfig.TxEncoder()(txBuilder.GetTx())
+	assert.Nil(t, err)
+
+	decodedTx, err := cl.Codec.TxConfig.TxDecoder()(txBytes)
+	assert.Nil(t, err)
+	assert.Len(t, decodedTx.GetMsgs(), 1)
+
+	signers, _, err := cl.Codec.Marshaler.GetMsgV1Signers(decodedTx.GetMsgs()[0])
+	assert.Nil(t, err)
+	assert.Len(t, signers, 1)
+
+	return decodedTx.GetMsgs()[0]
+}
+
+func TestLiquidStake(t *testing.T) {
+	staker, err := bech32.ConvertAndEncode("stride", []byte("stride-test-staker01"))
+	assert.Nil(t, err)
+
+	msg := decodeMsg(t, &stakeibcTypes.MsgLiquidStake{Creator: staker, Amount: math.NewInt(1000000), HostDenom: "uatom"})
+	_, ok := msg.(*stakeibcTypes.MsgLiquidStake)
+	assert.True(t, ok)
+
+	// The native tokens are sent to the deposit address and the stTokens are minted to the staker
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: MsgLiquidStake}}},
+			{Type: "coin_spent", Attributes: []txModule.Attribute{
+				{Key: "spender", Value: staker},
+				{Key: "amount", Value: "1000000ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"},
+			}},
+			{Type: "coin_received", Attributes: []txModule.Attribute{
+				{Key: "receiver", Value: staker},
+				{Key: "amount", Value: "800000stuatom"},
+			}},
+		},
+	}
+
+	wrapper := &WrapperMsgLiquidStake{}
+	err = wrapper.HandleMsg(MsgLiquidStake, msg, log)
+	assert.Nil(t, err)
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 1)
+	assert.Equal(t, int64(1000000), relevantData[0].AmountSent.Int64())
+	assert.Equal(t, "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", relevantData[0].DenominationSent)
+	assert.Equal(t, int64(800000), relevantData[0].AmountReceived.Int64())
+	assert.Equal(t, "stuatom", relevantData[0].DenominationReceived)
+}
+
+func TestRedeemStake(t *testing.T) {
+	redeemer, err := bech32.ConvertAndEncode("stride", []byte("stride-test-redeemer"))
+	assert.Nil(t, err)
+	receiver, err := bech32.ConvertAndEncode("cosmos", []byte("stride-test-redeemer"))
+	assert.Nil(t, err)
+
+	msg := decodeMsg(t, &stakeibcTypes.MsgRedeemStake{Creator: redeemer, Amount: math.NewInt(800000), HostZone: "cosmoshub-4", Receiver: receiver})
+
+	log := &txModule.LogMessage{
+		Events: []txModule.LogMessageEvent{
+			{Type: "message", Attributes: []txModule.Attribute{{Key: "action", Value: MsgRedeemStake}}},
+			{Type: "coin_spent", Attributes: []txModule.Attribute{
+				{Key: "spender", Value: redeemer},
+				{Key: "amount", Value: "800000stuatom"},
+			}},
+			{Type: EventTypeRedeemStake, Attributes: []txModule.Attribute{
+				{Key: AttributeKeyNativeBaseDenom, Value: "uatom"},
+				{Key: AttributeKeyNativeAmount, Value: "1000276"},
+			}},
+		},
+	}
+
+	wrapper := &WrapperMsgRedeemStake{}
+	err = wrapper.HandleMsg(MsgRedeemStake, msg, log)
+	assert.Nil(t, err)
+	assert.Equal(t, "cosmoshub-4", wrapper.HostZone)
+	assert.Equal(t, receiver, wrapper.Receiver)
+
+	relevantData := wrapper.ParseRelevantData()
+	assert.Len(t, relevantData, 1)
+	assert.Equal(t, int64(800000), relevantData[0].AmountSent.Int64())
+	assert.Equal(t, "stuatom", relevantData[0].DenominationSent)
+	assert.Equal(t, redeemer, relevantData[0].SenderAddress)
+	assert.Equal(t, redeemer, relevantData[0].ReceiverAddress)
+	// The swap is booked to the redeemer, the native tokens are paid out to the receiver on the host zone
+	assert.Equal(t, int64(1000276), relevantData[0].AmountReceived.Int64())
+	assert.Equal(t, "uatom", relevantData[0].DenominationReceived)
+}
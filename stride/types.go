@@ -0,0 +1,6 @@
+package stride
+
+const (
+	ChainID = "stride-1"
+	Name    = "Stride"
+)
@@ -0,0 +1,15 @@
+package stakeibc
+
+import (
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInterfaces registers the stakeibc messages with the interface registry, so txs containing them can be decoded
+func RegisterInterfaces(registry codecTypes.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgLiquidStake{},
+		&MsgLSMLiquidStake{},
+		&MsgRedeemStake{},
+	)
+}